@@ -12,6 +12,7 @@ import (
 	"time"
 
 	"github.com/oapi-codegen/runtime"
+	openapi_types "github.com/oapi-codegen/runtime/types"
 )
 
 const (
@@ -23,11 +24,141 @@ const (
 	Password AuthRequestGrantType = "password"
 )
 
+// Defines values for BatchTaskOperationKind.
+const (
+	BatchTaskOperationKindArchive BatchTaskOperationKind = "archive"
+	BatchTaskOperationKindCreate  BatchTaskOperationKind = "create"
+	BatchTaskOperationKindDelete  BatchTaskOperationKind = "delete"
+	BatchTaskOperationKindUpdate  BatchTaskOperationKind = "update"
+)
+
+// Defines values for CustomFieldType.
+const (
+	CustomFieldTypeDate   CustomFieldType = "date"
+	CustomFieldTypeEnum   CustomFieldType = "enum"
+	CustomFieldTypeNumber CustomFieldType = "number"
+	CustomFieldTypeText   CustomFieldType = "text"
+)
+
+// Defines values for InvoiceResponseStatus.
+const (
+	InvoiceResponseStatusDraft  InvoiceResponseStatus = "draft"
+	InvoiceResponseStatusIssued InvoiceResponseStatus = "issued"
+)
+
+// Defines values for OvertimeGroupBy.
+const (
+	OvertimeGroupByDay  OvertimeGroupBy = "day"
+	OvertimeGroupByWeek OvertimeGroupBy = "week"
+)
+
+// Defines values for ReportCompareTo.
+const (
+	PreviousMonth  ReportCompareTo = "previous_month"
+	PreviousPeriod ReportCompareTo = "previous_period"
+	PreviousYear   ReportCompareTo = "previous_year"
+)
+
+// Defines values for ReportGroupBy.
+const (
+	ReportGroupByClient  ReportGroupBy = "client"
+	ReportGroupByDay     ReportGroupBy = "day"
+	ReportGroupByField   ReportGroupBy = "field"
+	ReportGroupByProject ReportGroupBy = "project"
+	ReportGroupByTag     ReportGroupBy = "tag"
+	ReportGroupByTask    ReportGroupBy = "task"
+)
+
+// Defines values for ReportRoundingMode.
+const (
+	Nearest ReportRoundingMode = "nearest"
+	None    ReportRoundingMode = "none"
+	Up      ReportRoundingMode = "up"
+)
+
+// Defines values for ReportRoundingScope.
+const (
+	Group ReportRoundingScope = "group"
+	Total ReportRoundingScope = "total"
+)
+
+// Defines values for ReportSubscriptionFormat.
+const (
+	ReportSubscriptionFormatCsv  ReportSubscriptionFormat = "csv"
+	ReportSubscriptionFormatText ReportSubscriptionFormat = "text"
+)
+
+// Defines values for ReportSubscriptionPeriod.
+const (
+	Day   ReportSubscriptionPeriod = "day"
+	Month ReportSubscriptionPeriod = "month"
+	Week  ReportSubscriptionPeriod = "week"
+)
+
+// Defines values for ReportSubscriptionScope.
+const (
+	Team ReportSubscriptionScope = "team"
+	User ReportSubscriptionScope = "user"
+)
+
+// Defines values for TaskActivityKind.
+const (
+	DescriptionChanged TaskActivityKind = "description_changed"
+	StatusChanged      TaskActivityKind = "status_changed"
+	WorkStarted        TaskActivityKind = "work_started"
+	WorkStopped        TaskActivityKind = "work_stopped"
+)
+
+// Defines values for TaskChildrenMode.
+const (
+	Cascade  TaskChildrenMode = "cascade"
+	Detach   TaskChildrenMode = "detach"
+	Restrict TaskChildrenMode = "restrict"
+)
+
+// Defines values for TaskStatus.
+const (
+	Archived   TaskStatus = "archived"
+	Done       TaskStatus = "done"
+	InProgress TaskStatus = "in_progress"
+	Open       TaskStatus = "open"
+)
+
+// Defines values for TaskTemplateMode.
+const (
+	TaskTemplateModeCreate TaskTemplateMode = "create"
+	TaskTemplateModeReopen TaskTemplateMode = "reopen"
+)
+
+// Defines values for TaskVisibility.
+const (
+	Everyone TaskVisibility = "everyone"
+	Private  TaskVisibility = "private"
+	Project  TaskVisibility = "project"
+)
+
+// Defines values for TimesheetResponseStatus.
+const (
+	TimesheetResponseStatusApproved  TimesheetResponseStatus = "approved"
+	TimesheetResponseStatusDraft     TimesheetResponseStatus = "draft"
+	TimesheetResponseStatusRejected  TimesheetResponseStatus = "rejected"
+	TimesheetResponseStatusSubmitted TimesheetResponseStatus = "submitted"
+)
+
 // Defines values for TokenResponseTokenType.
 const (
 	Bearer TokenResponseTokenType = "Bearer"
 )
 
+// AmountResponse defines model for AmountResponse.
+type AmountResponse struct {
+	// Amount Decimal number, e.g. "42.50".
+	Amount string `json:"amount"`
+
+	// Currency ISO 4217 currency code.
+	Currency string `json:"currency"`
+}
+
 // AuthRequest Password grant (https://datatracker.ietf.org/doc/html/rfc6749#section-4.3).
 type AuthRequest struct {
 	GrantType AuthRequestGrantType `json:"grant_type"`
@@ -38,9 +169,219 @@ type AuthRequest struct {
 // AuthRequestGrantType defines model for AuthRequest.GrantType.
 type AuthRequestGrantType string
 
+// BatchTaskOperation defines model for BatchTaskOperation.
+type BatchTaskOperation struct {
+	// Children What happens to the subtasks of a task when it is archived or deleted, defaults to "restrict". "restrict" refuses to archive a task with subtasks that aren't archived or to delete a task with any subtasks, "cascade" archives or deletes all the descendants of the task with it, and "detach" moves the subtasks to the parent of the task.
+	Children *TaskChildrenMode  `json:"children,omitempty"`
+	Create   *CreateTaskRequest `json:"create,omitempty"`
+
+	// Force Delete the task even if it has works. Administrators only, with the delete operation only.
+	Force *bool `json:"force,omitempty"`
+
+	// Id Task to update, archive, or delete. Omitted for create operations.
+	Id *int `json:"id,omitempty"`
+
+	// Op "create" requires create, "update" requires id and update, "archive" and "delete" require id and accept children. "delete" moves the task to the trash and accepts force.
+	Op     BatchTaskOperationKind `json:"op"`
+	Update *UpdateTaskRequest     `json:"update,omitempty"`
+
+	// Version Version of the task the operation is based on, like If-Match of the single endpoints. The operation fails with 412 if the task has changed since. Omitted for create operations.
+	Version *int `json:"version,omitempty"`
+}
+
+// BatchTaskOperationKind "create" requires create, "update" requires id and update, "archive" and "delete" require id and accept children. "delete" moves the task to the trash and accepts force.
+type BatchTaskOperationKind string
+
+// BatchTaskResult defines model for BatchTaskResult.
+type BatchTaskResult struct {
+	Error *string `json:"error,omitempty"`
+
+	// Status HTTP status code the single endpoint would respond with.
+	Status int           `json:"status"`
+	Task   *TaskResponse `json:"task,omitempty"`
+}
+
+// BatchTasksRequest defines model for BatchTasksRequest.
+type BatchTasksRequest struct {
+	Operations []BatchTaskOperation `json:"operations"`
+
+	// Partial Whether failed operations are skipped instead of failing the whole batch. Defaults to false.
+	Partial *bool `json:"partial,omitempty"`
+}
+
+// BatchTasksResponse defines model for BatchTasksResponse.
+type BatchTasksResponse struct {
+	// Results Results of the operations in the order of the request.
+	Results []BatchTaskResult `json:"results"`
+}
+
+// BudgetEventResponse defines model for BudgetEventResponse.
+type BudgetEventResponse struct {
+	BudgetHours string    `json:"budgetHours"`
+	CreatedAt   time.Time `json:"createdAt"`
+	Id          int       `json:"id"`
+	TaskId      int       `json:"taskId"`
+
+	// Threshold Percentage of the budget that was reached, 80 or 100.
+	Threshold    int    `json:"threshold"`
+	TrackedHours string `json:"trackedHours"`
+}
+
+// ClientResponse defines model for ClientResponse.
+type ClientResponse struct {
+	Id   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+// CommentResponse defines model for CommentResponse.
+type CommentResponse struct {
+	Body      string    `json:"body"`
+	CreatedAt time.Time `json:"createdAt"`
+	Id        int       `json:"id"`
+	TaskId    int       `json:"taskId"`
+
+	// UpdatedAt Time the comment was last edited, absent unless it was edited.
+	UpdatedAt *time.Time `json:"updatedAt,omitempty"`
+
+	// UserId Author of the comment, absent once the author is deleted.
+	UserId *int `json:"userId,omitempty"`
+}
+
+// CreateClientRequest defines model for CreateClientRequest.
+type CreateClientRequest struct {
+	Name string `json:"name"`
+}
+
+// CreateCommentRequest defines model for CreateCommentRequest.
+type CreateCommentRequest struct {
+	Body string `json:"body"`
+}
+
+// CreateCustomFieldRequest defines model for CreateCustomFieldRequest.
+type CreateCustomFieldRequest struct {
+	// Name Name of the custom field, unique regardless of case.
+	Name string `json:"name"`
+
+	// Options Values of an enum field, required for enum fields only.
+	Options *[]string       `json:"options,omitempty"`
+	Type    CustomFieldType `json:"type"`
+}
+
+// CreateHolidayRequest defines model for CreateHolidayRequest.
+type CreateHolidayRequest struct {
+	Date openapi_types.Date `json:"date"`
+	Name string             `json:"name"`
+}
+
+// CreateInvoiceRequest defines model for CreateInvoiceRequest.
+type CreateInvoiceRequest struct {
+	ClientId int `json:"clientId"`
+
+	// Currency ISO 4217 currency code.
+	Currency string    `json:"currency"`
+	From     time.Time `json:"from"`
+	To       time.Time `json:"to"`
+}
+
+// CreateProjectRequest defines model for CreateProjectRequest.
+type CreateProjectRequest struct {
+	ClientId *int   `json:"clientId,omitempty"`
+	Name     string `json:"name"`
+}
+
+// CreateRateRequest Rate for a user, for a task, or for a user working on a task. At least one of userId and taskId is required.
+type CreateRateRequest struct {
+	// Currency ISO 4217 currency code.
+	Currency      string    `json:"currency"`
+	EffectiveFrom time.Time `json:"effectiveFrom"`
+
+	// HourlyRate Decimal number, e.g. "42.50".
+	HourlyRate string `json:"hourlyRate"`
+	TaskId     *int   `json:"taskId,omitempty"`
+	UserId     *int   `json:"userId,omitempty"`
+}
+
+// CreateReportSubscriptionRequest defines model for CreateReportSubscriptionRequest.
+type CreateReportSubscriptionRequest struct {
+	// Format Text in the body or a CSV attachment.
+	Format ReportSubscriptionFormat `json:"format"`
+
+	// Period Previous complete period reported on each delivery.
+	Period    ReportSubscriptionPeriod `json:"period"`
+	Recipient openapi_types.Email      `json:"recipient"`
+
+	// Schedule Cron expression with five fields or a descriptor such as "@weekly", e.g. "0 8 * * 1".
+	Schedule string                  `json:"schedule"`
+	Scope    ReportSubscriptionScope `json:"scope"`
+
+	// UserId User to report on, required for the user scope.
+	UserId *int `json:"userId,omitempty"`
+}
+
+// CreateScheduleRequest defines model for CreateScheduleRequest.
+type CreateScheduleRequest struct {
+	EffectiveFrom openapi_types.Date `json:"effectiveFrom"`
+
+	// Hours Decimal number of hours expected on each weekday, e.g. "8.00", between 0 and 24.
+	Hours  ScheduleHours `json:"hours"`
+	UserId int           `json:"userId"`
+}
+
+// CreateTagRequest defines model for CreateTagRequest.
+type CreateTagRequest struct {
+	// Name Name of the tag, unique regardless of case.
+	Name string `json:"name"`
+}
+
 // CreateTaskRequest defines model for CreateTaskRequest.
 type CreateTaskRequest struct {
+	AssigneeIds *[]int `json:"assigneeIds,omitempty"`
+
+	// Billable Whether works on the task are billable by default. Defaults to true.
+	Billable *bool `json:"billable,omitempty"`
+
+	// BudgetHours Decimal number of hours budgeted for the task, e.g. "40", greater than 0.
+	BudgetHours  *string                  `json:"budgetHours,omitempty"`
+	CustomFields *[]TaskFieldValueRequest `json:"customFields,omitempty"`
+	Description  string                   `json:"description"`
+
+	// ParentId Task the task is a subtask of, it must be visible to the current user.
+	ParentId *int `json:"parentId,omitempty"`
+
+	// ProjectId Project of the task, defaults to the default project.
+	ProjectId *int   `json:"projectId,omitempty"`
+	TagIds    *[]int `json:"tagIds,omitempty"`
+
+	// Visibility Who can see the task and track time on it besides its creator, its assignees, and administrators. Project members are the creators and the assignees of the tasks of the project. Present in task endpoints only.
+	Visibility *TaskVisibility `json:"visibility,omitempty"`
+}
+
+// CreateTaskTemplateRequest defines model for CreateTaskTemplateRequest.
+type CreateTaskTemplateRequest struct {
+	// Billable Whether works on the tasks are billable by default. Defaults to true.
+	Billable    *bool  `json:"billable,omitempty"`
 	Description string `json:"description"`
+
+	// Mode Whether each occurrence creates a new task or reopens the task created on the first occurrence if it is done or archived. Defaults to create.
+	Mode *TaskTemplateMode `json:"mode,omitempty"`
+
+	// ProjectId Project of the tasks, defaults to the default project.
+	ProjectId *int `json:"projectId,omitempty"`
+
+	// Rule RFC 5545 recurrence rule with FREQ of DAILY, WEEKLY, MONTHLY, or YEARLY and optional INTERVAL, COUNT, UNTIL, BYDAY, BYMONTHDAY, BYMONTH, and WKST, e.g. "FREQ=WEEKLY;BYDAY=MO" or "FREQ=MONTHLY;BYMONTHDAY=-1".
+	Rule string `json:"rule"`
+
+	// StartsAt Start of the rule, occurrences are at its time of day in UTC.
+	StartsAt time.Time `json:"startsAt"`
+
+	// Visibility Who can see the task and track time on it besides its creator, its assignees, and administrators. Project members are the creators and the assignees of the tasks of the project. Present in task endpoints only.
+	Visibility *TaskVisibility `json:"visibility,omitempty"`
+}
+
+// CreateTimesheetRequest defines model for CreateTimesheetRequest.
+type CreateTimesheetRequest struct {
+	// Week Monday of the week.
+	Week openapi_types.Date `json:"week"`
 }
 
 // CreateUserRequest defines model for CreateUserRequest.
@@ -48,6 +389,24 @@ type CreateUserRequest struct {
 	PassportNumber string `json:"passportNumber"`
 }
 
+// CustomFieldResponse defines model for CustomFieldResponse.
+type CustomFieldResponse struct {
+	Id   int    `json:"id"`
+	Name string `json:"name"`
+
+	// Options Values of an enum field, empty for other types.
+	Options []string        `json:"options"`
+	Type    CustomFieldType `json:"type"`
+}
+
+// CustomFieldType defines model for CustomFieldType.
+type CustomFieldType string
+
+// DecideTimesheetRequest defines model for DecideTimesheetRequest.
+type DecideTimesheetRequest struct {
+	Comment *string `json:"comment,omitempty"`
+}
+
 // ErrorResponse defines model for ErrorResponse.
 type ErrorResponse struct {
 	Message string `json:"message"`
@@ -58,179 +417,3349 @@ type HealthResponse struct {
 	Status string `json:"status"`
 }
 
-// ReportDurationResponse defines model for ReportDurationResponse.
-type ReportDurationResponse struct {
-	Hours   int `json:"hours"`
-	Minutes int `json:"minutes"`
-	Seconds int `json:"seconds"`
+// HolidayResponse defines model for HolidayResponse.
+type HolidayResponse struct {
+	Date openapi_types.Date `json:"date"`
+	Id   int                `json:"id"`
+	Name string             `json:"name"`
 }
 
-// ReportRequest defines model for ReportRequest.
-type ReportRequest struct {
-	From time.Time `json:"from"`
-	To   time.Time `json:"to"`
+// InvoiceLineResponse defines model for InvoiceLineResponse.
+type InvoiceLineResponse struct {
+	// Amount Decimal number, e.g. "42.50".
+	Amount string `json:"amount"`
+
+	// Hours Decimal number of hours, e.g. "1.50".
+	Hours string       `json:"hours"`
+	Task  TaskResponse `json:"task"`
 }
 
-// ReportTaskResponse defines model for ReportTaskResponse.
-type ReportTaskResponse struct {
-	Duration *ReportDurationResponse `json:"duration,omitempty"`
-	Task     *TaskResponse           `json:"task,omitempty"`
+// InvoiceResponse defines model for InvoiceResponse.
+type InvoiceResponse struct {
+	Client    ClientResponse        `json:"client"`
+	CreatedAt time.Time             `json:"createdAt"`
+	Currency  string                `json:"currency"`
+	From      time.Time             `json:"from"`
+	Id        int                   `json:"id"`
+	IssuedAt  *time.Time            `json:"issuedAt,omitempty"`
+	Lines     []InvoiceLineResponse `json:"lines"`
+
+	// Number Sequential number, assigned when the invoice is issued.
+	Number *int                  `json:"number,omitempty"`
+	Status InvoiceResponseStatus `json:"status"`
+	To     time.Time             `json:"to"`
+
+	// Total Decimal number, e.g. "42.50".
+	Total string `json:"total"`
 }
 
-// TaskResponse defines model for TaskResponse.
-type TaskResponse struct {
-	Description string `json:"description"`
-	Id          int    `json:"id"`
+// InvoiceResponseStatus defines model for InvoiceResponse.Status.
+type InvoiceResponseStatus string
+
+// OvertimeEntryResponse defines model for OvertimeEntryResponse.
+type OvertimeEntryResponse struct {
+	// BalanceHours Running sum of overtime hours from the start of the time frame, e.g. "-1.25".
+	BalanceHours string `json:"balanceHours"`
+
+	// Date First day of the entry in the time frame.
+	Date openapi_types.Date `json:"date"`
+
+	// ExpectedHours Decimal number of hours, e.g. "8.00".
+	ExpectedHours string `json:"expectedHours"`
+
+	// OvertimeHours Tracked minus expected hours, negative for undertime, e.g. "0.50".
+	OvertimeHours string `json:"overtimeHours"`
+
+	// TrackedHours Decimal number of hours, e.g. "8.50".
+	TrackedHours string `json:"trackedHours"`
 }
 
-// TokenResponse Token (https://datatracker.ietf.org/doc/html/rfc6749#section-5.1).
-type TokenResponse struct {
-	AccessToken string                 `json:"access_token"`
-	TokenType   TokenResponseTokenType `json:"token_type"`
+// OvertimeGroupBy What overtime entries are grouped by, defaults to "day".
+type OvertimeGroupBy string
+
+// OvertimeResponse defines model for OvertimeResponse.
+type OvertimeResponse struct {
+	Entries []OvertimeEntryResponse `json:"entries"`
+	Total   OvertimeTotalResponse   `json:"total"`
 }
 
-// TokenResponseTokenType defines model for TokenResponse.TokenType.
-type TokenResponseTokenType string
+// OvertimeTotalResponse defines model for OvertimeTotalResponse.
+type OvertimeTotalResponse struct {
+	ExpectedHours string `json:"expectedHours"`
+	OvertimeHours string `json:"overtimeHours"`
+	TrackedHours  string `json:"trackedHours"`
+}
 
-// UpdateTaskRequest defines model for UpdateTaskRequest.
-type UpdateTaskRequest struct {
-	Description *string `json:"description,omitempty"`
+// ProjectResponse defines model for ProjectResponse.
+type ProjectResponse struct {
+	ClientId *int `json:"clientId,omitempty"`
+	Id       int  `json:"id"`
+
+	// IsDefault Whether tasks created without a project belong to the project.
+	IsDefault bool   `json:"isDefault"`
+	Name      string `json:"name"`
 }
 
-// UpdateUserRequest defines model for UpdateUserRequest.
-type UpdateUserRequest struct {
-	Address        *string `json:"address,omitempty"`
-	Name           *string `json:"name,omitempty"`
-	PassportNumber *string `json:"passportNumber,omitempty"`
-	Patronymic     *string `json:"patronymic,omitempty"`
-	PatronymicNull *bool   `json:"patronymicNull,omitempty"`
-	Surname        *string `json:"surname,omitempty"`
+// RateResponse defines model for RateResponse.
+type RateResponse struct {
+	// Currency ISO 4217 currency code.
+	Currency      string    `json:"currency"`
+	EffectiveFrom time.Time `json:"effectiveFrom"`
+
+	// HourlyRate Decimal number, e.g. "42.50".
+	HourlyRate string `json:"hourlyRate"`
+	Id         int    `json:"id"`
+	TaskId     *int   `json:"taskId,omitempty"`
+	UserId     *int   `json:"userId,omitempty"`
 }
 
-// UserResponse defines model for UserResponse.
-type UserResponse struct {
-	Address        string  `json:"address"`
-	Id             int     `json:"id"`
-	Name           string  `json:"name"`
-	PassportNumber string  `json:"passportNumber"`
-	Patronymic     *string `json:"patronymic,omitempty"`
-	Surname        string  `json:"surname"`
+// ReportChangeResponse defines model for ReportChangeResponse.
+type ReportChangeResponse struct {
+	// Milliseconds Absolute change in milliseconds, negative if less time was spent.
+	Milliseconds int64 `json:"milliseconds"`
+
+	// Percent Change relative to the previous duration in percent, e.g. "-12.50". Omitted if no time was spent in the previous time frame.
+	Percent *string `json:"percent,omitempty"`
 }
 
-// GetTasksParams defines parameters for GetTasks.
-type GetTasksParams struct {
-	Offset *int `form:"offset,omitempty" json:"offset,omitempty"`
-	Limit  *int `form:"limit,omitempty" json:"limit,omitempty"`
+// ReportClientResponse defines model for ReportClientResponse.
+type ReportClientResponse struct {
+	Amounts []AmountResponse `json:"amounts"`
+
+	// BillableDuration Duration with millisecond precision. Hours, minutes, and seconds are components of the duration truncated to seconds.
+	BillableDuration ReportDurationResponse `json:"billableDuration"`
+
+	// Client Absent for time on tasks without a client.
+	Client *ClientResponse `json:"client,omitempty"`
+
+	// Duration Duration with millisecond precision. Hours, minutes, and seconds are components of the duration truncated to seconds.
+	Duration ReportDurationResponse `json:"duration"`
 }
 
-// GetUsersParams defines parameters for GetUsers.
-type GetUsersParams struct {
-	// Filter Filter by user fields. Can be used multiple times.
-	Filter *[]string `form:"filter,omitempty" json:"filter,omitempty"`
-	Offset *int      `form:"offset,omitempty" json:"offset,omitempty"`
-	Limit  *int      `form:"limit,omitempty" json:"limit,omitempty"`
+// ReportCompareTo Period to compare the report to. The previous period has the same length and ends at the start of the time frame, the previous month and year are the time frame shifted by a calendar month or year.
+type ReportCompareTo string
+
+// ReportComparisonResponse Comparison of the time spent on tasks with the time frame the report is compared to. Tasks present in only one of the time frames are included. Durations are rounded the same way as in the report, trends are exact.
+type ReportComparisonResponse struct {
+	// From Start of the time frame the report is compared to.
+	From  time.Time                      `json:"from"`
+	Tasks []ReportComparisonTaskResponse `json:"tasks"`
+
+	// To End of the time frame the report is compared to.
+	To    time.Time                     `json:"to"`
+	Total ReportComparisonTotalResponse `json:"total"`
 }
 
-// PostAuthFormdataRequestBody defines body for PostAuth for application/x-www-form-urlencoded ContentType.
-type PostAuthFormdataRequestBody = AuthRequest
+// ReportComparisonTaskResponse defines model for ReportComparisonTaskResponse.
+type ReportComparisonTaskResponse struct {
+	Change ReportChangeResponse `json:"change"`
 
-// PostTasksJSONRequestBody defines body for PostTasks for application/json ContentType.
-type PostTasksJSONRequestBody = CreateTaskRequest
+	// Duration Duration with millisecond precision. Hours, minutes, and seconds are components of the duration truncated to seconds.
+	Duration ReportDurationResponse `json:"duration"`
 
-// PatchTasksIdJSONRequestBody defines body for PatchTasksId for application/json ContentType.
-type PatchTasksIdJSONRequestBody = UpdateTaskRequest
+	// PreviousDuration Duration with millisecond precision. Hours, minutes, and seconds are components of the duration truncated to seconds.
+	PreviousDuration ReportDurationResponse `json:"previousDuration"`
+	Task             TaskResponse           `json:"task"`
 
-// PostUsersJSONRequestBody defines body for PostUsers for application/json ContentType.
-type PostUsersJSONRequestBody = CreateUserRequest
+	// Trend Milliseconds spent on each calendar day of the time frame in the time zone of its start, suitable for sparklines.
+	Trend ReportTrend `json:"trend"`
+}
 
-// PatchUsersIdJSONRequestBody defines body for PatchUsersId for application/json ContentType.
-type PatchUsersIdJSONRequestBody = UpdateUserRequest
+// ReportComparisonTotalResponse defines model for ReportComparisonTotalResponse.
+type ReportComparisonTotalResponse struct {
+	Change ReportChangeResponse `json:"change"`
 
-// PostUsersIdReportJSONRequestBody defines body for PostUsersIdReport for application/json ContentType.
-type PostUsersIdReportJSONRequestBody = ReportRequest
+	// Duration Duration with millisecond precision. Hours, minutes, and seconds are components of the duration truncated to seconds.
+	Duration ReportDurationResponse `json:"duration"`
 
-// ServerInterface represents all server handlers.
-type ServerInterface interface {
+	// PreviousDuration Duration with millisecond precision. Hours, minutes, and seconds are components of the duration truncated to seconds.
+	PreviousDuration ReportDurationResponse `json:"previousDuration"`
 
-	// (POST /auth)
-	PostAuth(w http.ResponseWriter, r *http.Request)
+	// PreviousTrend Milliseconds spent on each calendar day of the time frame in the time zone of its start, suitable for sparklines.
+	PreviousTrend ReportTrend `json:"previousTrend"`
 
-	// (GET /health)
-	GetHealth(w http.ResponseWriter, r *http.Request)
+	// Trend Milliseconds spent on each calendar day of the time frame in the time zone of its start, suitable for sparklines.
+	Trend ReportTrend `json:"trend"`
+}
 
-	// (GET /tasks/)
-	GetTasks(w http.ResponseWriter, r *http.Request, params GetTasksParams)
+// ReportDayResponse defines model for ReportDayResponse.
+type ReportDayResponse struct {
+	// Amounts Amounts for billable time, one for each currency of the rates that apply.
+	Amounts []AmountResponse `json:"amounts"`
 
-	// (POST /tasks/)
-	PostTasks(w http.ResponseWriter, r *http.Request)
+	// BillableDuration Duration with millisecond precision. Hours, minutes, and seconds are components of the duration truncated to seconds.
+	BillableDuration ReportDurationResponse `json:"billableDuration"`
+	Date             openapi_types.Date     `json:"date"`
 
-	// (DELETE /tasks/{id})
-	DeleteTasksId(w http.ResponseWriter, r *http.Request, id int)
+	// Duration Duration with millisecond precision. Hours, minutes, and seconds are components of the duration truncated to seconds.
+	Duration ReportDurationResponse `json:"duration"`
+}
 
-	// (GET /tasks/{id})
-	GetTasksId(w http.ResponseWriter, r *http.Request, id int)
+// ReportDurationResponse Duration with millisecond precision. Hours, minutes, and seconds are components of the duration truncated to seconds.
+type ReportDurationResponse struct {
+	Hours int `json:"hours"`
 
-	// (PATCH /tasks/{id})
-	PatchTasksId(w http.ResponseWriter, r *http.Request, id int)
+	// Iso8601 ISO 8601 duration, e.g. "PT1H30M15.25S".
+	Iso8601 string `json:"iso8601"`
 
-	// (POST /tasks/{id}/start)
-	PostTasksIdStart(w http.ResponseWriter, r *http.Request, id int)
+	// Milliseconds Total number of milliseconds.
+	Milliseconds int64 `json:"milliseconds"`
+	Minutes      int   `json:"minutes"`
+	Seconds      int   `json:"seconds"`
+}
 
-	// (POST /tasks/{id}/stop)
-	PostTasksIdStop(w http.ResponseWriter, r *http.Request, id int)
+// ReportFieldValueResponse defines model for ReportFieldValueResponse.
+type ReportFieldValueResponse struct {
+	Amounts []AmountResponse `json:"amounts"`
 
-	// (GET /users/)
-	GetUsers(w http.ResponseWriter, r *http.Request, params GetUsersParams)
+	// BillableDuration Duration with millisecond precision. Hours, minutes, and seconds are components of the duration truncated to seconds.
+	BillableDuration ReportDurationResponse `json:"billableDuration"`
 
-	// (POST /users/)
-	PostUsers(w http.ResponseWriter, r *http.Request)
+	// Duration Duration with millisecond precision. Hours, minutes, and seconds are components of the duration truncated to seconds.
+	Duration ReportDurationResponse `json:"duration"`
 
-	// (GET /users/current)
-	GetUsersCurrent(w http.ResponseWriter, r *http.Request)
+	// Value Absent for time on tasks without a value of the field.
+	Value *string `json:"value,omitempty"`
+}
 
-	// (DELETE /users/{id})
-	DeleteUsersId(w http.ResponseWriter, r *http.Request, id int)
+// ReportGroupBy What report entries are grouped by, defaults to "task". Days are calendar days in the time zone of the start of the time frame. Projects and clients are those of the tasks, time on tasks without a client is reported without a client. Tags are those of the works and of their tasks. Custom field values are those of the tasks for the enum field given by fieldId.
+type ReportGroupBy string
 
-	// (GET /users/{id})
-	GetUsersId(w http.ResponseWriter, r *http.Request, id int)
+// ReportProjectResponse defines model for ReportProjectResponse.
+type ReportProjectResponse struct {
+	Amounts []AmountResponse `json:"amounts"`
 
-	// (PATCH /users/{id})
-	PatchUsersId(w http.ResponseWriter, r *http.Request, id int)
+	// BillableDuration Duration with millisecond precision. Hours, minutes, and seconds are components of the duration truncated to seconds.
+	BillableDuration ReportDurationResponse `json:"billableDuration"`
 
-	// (POST /users/{id}/report)
-	PostUsersIdReport(w http.ResponseWriter, r *http.Request, id int)
+	// Duration Duration with millisecond precision. Hours, minutes, and seconds are components of the duration truncated to seconds.
+	Duration ReportDurationResponse `json:"duration"`
+	Project  ProjectResponse        `json:"project"`
 }
 
-// ServerInterfaceWrapper converts contexts to parameters.
-type ServerInterfaceWrapper struct {
-	Handler            ServerInterface
-	HandlerMiddlewares []MiddlewareFunc
-	ErrorHandlerFunc   func(w http.ResponseWriter, r *http.Request, err error)
+// ReportRequest defines model for ReportRequest.
+type ReportRequest struct {
+	// CompareTo Period to compare the report to. The previous period has the same length and ends at the start of the time frame, the previous month and year are the time frame shifted by a calendar month or year.
+	CompareTo *ReportCompareTo `json:"compareTo,omitempty"`
+
+	// FieldId Enum custom field to group by, required for reports by field only.
+	FieldId *int      `json:"fieldId,omitempty"`
+	From    time.Time `json:"from"`
+
+	// GroupBy What report entries are grouped by, defaults to "task". Days are calendar days in the time zone of the start of the time frame. Projects and clients are those of the tasks, time on tasks without a client is reported without a client. Tags are those of the works and of their tasks. Custom field values are those of the tasks for the enum field given by fieldId.
+	GroupBy *ReportGroupBy `json:"groupBy,omitempty"`
+
+	// IncludeSubtasks Whether the time of each task includes the time spent on its subtasks, for reports by task. Ancestors of tasks with time are reported too. The total and comparisons are of the time spent on each task itself.
+	IncludeSubtasks *bool `json:"includeSubtasks,omitempty"`
+
+	// Rounding Rounding of reported durations. With the "group" scope the duration of each group of the report, e.g. each task or each day, is rounded after the exact durations of its works are summed, and the total is the sum of the rounded durations. Works are not rounded one by one. With the "total" scope group durations are exact and only the total is rounded. Amounts are always calculated from exact durations.
+	Rounding *ReportRoundingRequest `json:"rounding,omitempty"`
+	To       time.Time              `json:"to"`
 }
 
-type MiddlewareFunc func(http.Handler) http.Handler
+// ReportResponse Report with tasks, projects, clients, tags, custom field values, or days, depending on what the entries are grouped by. A work with several tags is reported under each of them, so the total is the time of the works and not the sum of the tags.
+type ReportResponse struct {
+	Clients *[]ReportClientResponse `json:"clients,omitempty"`
 
-// PostAuth operation middleware
-func (siw *ServerInterfaceWrapper) PostAuth(w http.ResponseWriter, r *http.Request) {
-	ctx := r.Context()
+	// Comparison Comparison of the time spent on tasks with the time frame the report is compared to. Tasks present in only one of the time frames are included. Durations are rounded the same way as in the report, trends are exact.
+	Comparison  *ReportComparisonResponse   `json:"comparison,omitempty"`
+	Days        *[]ReportDayResponse        `json:"days,omitempty"`
+	FieldValues *[]ReportFieldValueResponse `json:"fieldValues,omitempty"`
+	Projects    *[]ReportProjectResponse    `json:"projects,omitempty"`
+	Tags        *[]ReportTagResponse        `json:"tags,omitempty"`
+	Tasks       *[]ReportTaskResponse       `json:"tasks,omitempty"`
 
-	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.PostAuth(w, r)
-	}))
+	// Total Grand total of the report. Amounts are sums of the tasks' amounts.
+	Total ReportTotalResponse `json:"total"`
+}
 
-	for _, middleware := range siw.HandlerMiddlewares {
-		handler = middleware(handler)
-	}
+// ReportRoundingMode "none" keeps durations exact, "nearest" rounds them to the nearest multiple of minutes, and "up" rounds them up to a multiple of minutes.
+type ReportRoundingMode string
 
-	handler.ServeHTTP(w, r.WithContext(ctx))
+// ReportRoundingRequest Rounding of reported durations. With the "group" scope the duration of each group of the report, e.g. each task or each day, is rounded after the exact durations of its works are summed, and the total is the sum of the rounded durations. Works are not rounded one by one. With the "total" scope group durations are exact and only the total is rounded. Amounts are always calculated from exact durations.
+type ReportRoundingRequest struct {
+	// Minutes Rounding step, required unless the mode is "none".
+	Minutes *int `json:"minutes,omitempty"`
+
+	// Mode "none" keeps durations exact, "nearest" rounds them to the nearest multiple of minutes, and "up" rounds them up to a multiple of minutes.
+	Mode ReportRoundingMode `json:"mode"`
+
+	// Scope What is rounded, defaults to "group".
+	Scope *ReportRoundingScope `json:"scope,omitempty"`
+}
+
+// ReportRoundingScope What is rounded, defaults to "group".
+type ReportRoundingScope string
+
+// ReportSubscriptionFormat Text in the body or a CSV attachment.
+type ReportSubscriptionFormat string
+
+// ReportSubscriptionPeriod Previous complete period reported on each delivery.
+type ReportSubscriptionPeriod string
+
+// ReportSubscriptionResponse defines model for ReportSubscriptionResponse.
+type ReportSubscriptionResponse struct {
+	// Format Text in the body or a CSV attachment.
+	Format    ReportSubscriptionFormat `json:"format"`
+	Id        int                      `json:"id"`
+	LastRunAt *time.Time               `json:"lastRunAt,omitempty"`
+	NextRunAt time.Time                `json:"nextRunAt"`
+
+	// Period Previous complete period reported on each delivery.
+	Period    ReportSubscriptionPeriod `json:"period"`
+	Recipient openapi_types.Email      `json:"recipient"`
+	Schedule  string                   `json:"schedule"`
+	Scope     ReportSubscriptionScope  `json:"scope"`
+	UserId    *int                     `json:"userId,omitempty"`
+}
+
+// ReportSubscriptionScope defines model for ReportSubscriptionScope.
+type ReportSubscriptionScope string
+
+// ReportTagResponse defines model for ReportTagResponse.
+type ReportTagResponse struct {
+	Amounts []AmountResponse `json:"amounts"`
+
+	// BillableDuration Duration with millisecond precision. Hours, minutes, and seconds are components of the duration truncated to seconds.
+	BillableDuration ReportDurationResponse `json:"billableDuration"`
+
+	// Duration Duration with millisecond precision. Hours, minutes, and seconds are components of the duration truncated to seconds.
+	Duration ReportDurationResponse `json:"duration"`
+
+	// Tag Absent for time in works without tags.
+	Tag *TagResponse `json:"tag,omitempty"`
 }
 
-// GetHealth operation middleware
-func (siw *ServerInterfaceWrapper) GetHealth(w http.ResponseWriter, r *http.Request) {
-	ctx := r.Context()
+// ReportTaskResponse defines model for ReportTaskResponse.
+type ReportTaskResponse struct {
+	// Amounts Amounts for billable time, one for each currency of the rates that apply.
+	Amounts []AmountResponse `json:"amounts"`
+
+	// BillableDuration Duration with millisecond precision. Hours, minutes, and seconds are components of the duration truncated to seconds.
+	BillableDuration ReportDurationResponse `json:"billableDuration"`
+
+	// Duration Duration with millisecond precision. Hours, minutes, and seconds are components of the duration truncated to seconds.
+	Duration ReportDurationResponse `json:"duration"`
+	Task     TaskResponse           `json:"task"`
+}
+
+// ReportTotalResponse Grand total of the report. Amounts are sums of the tasks' amounts.
+type ReportTotalResponse struct {
+	Amounts []AmountResponse `json:"amounts"`
+
+	// BillableDuration Duration with millisecond precision. Hours, minutes, and seconds are components of the duration truncated to seconds.
+	BillableDuration ReportDurationResponse `json:"billableDuration"`
+
+	// Duration Duration with millisecond precision. Hours, minutes, and seconds are components of the duration truncated to seconds.
+	Duration ReportDurationResponse `json:"duration"`
+}
+
+// ReportTrend Milliseconds spent on each calendar day of the time frame in the time zone of its start, suitable for sparklines.
+type ReportTrend = []int64
+
+// ScheduleHours Decimal number of hours expected on each weekday, e.g. "8.00", between 0 and 24.
+type ScheduleHours struct {
+	Friday    string `json:"friday"`
+	Monday    string `json:"monday"`
+	Saturday  string `json:"saturday"`
+	Sunday    string `json:"sunday"`
+	Thursday  string `json:"thursday"`
+	Tuesday   string `json:"tuesday"`
+	Wednesday string `json:"wednesday"`
+}
+
+// ScheduleResponse defines model for ScheduleResponse.
+type ScheduleResponse struct {
+	EffectiveFrom openapi_types.Date `json:"effectiveFrom"`
+
+	// Hours Decimal number of hours expected on each weekday, e.g. "8.00", between 0 and 24.
+	Hours  ScheduleHours `json:"hours"`
+	Id     int           `json:"id"`
+	UserId int           `json:"userId"`
+}
+
+// TagResponse defines model for TagResponse.
+type TagResponse struct {
+	Id   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+// TaskActivityKind defines model for TaskActivityKind.
+type TaskActivityKind string
+
+// TaskActivityResponse defines model for TaskActivityResponse.
+type TaskActivityResponse struct {
+	CreatedAt time.Time        `json:"createdAt"`
+	Id        int              `json:"id"`
+	Kind      TaskActivityKind `json:"kind"`
+
+	// NewValue Description or status after the change. Present for changes only.
+	NewValue *string `json:"newValue,omitempty"`
+
+	// OldValue Description or status before the change. Present for changes only.
+	OldValue *string `json:"oldValue,omitempty"`
+	TaskId   int     `json:"taskId"`
+
+	// UserId User who did it, absent once the user is deleted.
+	UserId *int `json:"userId,omitempty"`
+
+	// WorkId Work that was started or stopped, absent once the work is deleted.
+	WorkId *int `json:"workId,omitempty"`
+}
+
+// TaskChildrenMode What happens to the subtasks of a task when it is archived or deleted, defaults to "restrict". "restrict" refuses to archive a task with subtasks that aren't archived or to delete a task with any subtasks, "cascade" archives or deletes all the descendants of the task with it, and "detach" moves the subtasks to the parent of the task.
+type TaskChildrenMode string
+
+// TaskFieldValueRequest defines model for TaskFieldValueRequest.
+type TaskFieldValueRequest struct {
+	FieldId int `json:"fieldId"`
+
+	// Value Value of the custom field, removed if absent. Text is trimmed and must not be empty, numbers are decimal numbers, e.g. "12.5", enum values must be one of the options, and dates are formatted as YYYY-MM-DD.
+	Value *string `json:"value,omitempty"`
+}
+
+// TaskFieldValueResponse defines model for TaskFieldValueResponse.
+type TaskFieldValueResponse struct {
+	FieldId int `json:"fieldId"`
+
+	// Value Value normalized to the field type, see TaskFieldValueRequest.
+	Value string `json:"value"`
+}
+
+// TaskHighlightResponse defines model for TaskHighlightResponse.
+type TaskHighlightResponse struct {
+	// End Offset after the last matched character in Unicode code points.
+	End int `json:"end"`
+
+	// Start Offset of the first matched character in Unicode code points.
+	Start int `json:"start"`
+}
+
+// TaskMatchResponse How the task matches a search query. Present in task searches only.
+type TaskMatchResponse struct {
+	// Highlights Matched parts of the description, ordered by start.
+	Highlights []TaskHighlightResponse `json:"highlights"`
+
+	// Score Similarity of the query to the description from 0 to 1, 1 for exact matches.
+	Score float64 `json:"score"`
+}
+
+// TaskProgressResponse defines model for TaskProgressResponse.
+type TaskProgressResponse struct {
+	BudgetHours *string `json:"budgetHours,omitempty"`
+	OverBudget  bool    `json:"overBudget"`
+
+	// Percent Tracked hours in percent of the budget, e.g. "82.50". Present if the task has a budget.
+	Percent *string `json:"percent,omitempty"`
+
+	// RemainingHours Budgeted minus tracked hours, negative when over budget. Present if the task has a budget.
+	RemainingHours *string                    `json:"remainingHours,omitempty"`
+	Task           TaskResponse               `json:"task"`
+	TrackedHours   string                     `json:"trackedHours"`
+	Users          []TaskUserProgressResponse `json:"users"`
+}
+
+// TaskResponse defines model for TaskResponse.
+type TaskResponse struct {
+	// AssigneeIds Users assigned to the task. Present in task endpoints only.
+	AssigneeIds *[]int `json:"assigneeIds,omitempty"`
+	Billable    bool   `json:"billable"`
+
+	// BudgetHours Decimal number of hours budgeted for the task, e.g. "40.00". Present in task endpoints only.
+	BudgetHours *string `json:"budgetHours,omitempty"`
+
+	// ClientId Client of the project of the task. Present in task lists, searches, batches, and progress only, as it isn't covered by the ETag of a task.
+	ClientId *int `json:"clientId,omitempty"`
+
+	// CreatedBy User who created the task, absent for tasks created before tasks had creators. Present in task endpoints only.
+	CreatedBy *int `json:"createdBy,omitempty"`
+
+	// CustomFields Values of the custom fields of the task, ordered by field. Present in task endpoints only.
+	CustomFields *[]TaskFieldValueResponse `json:"customFields,omitempty"`
+
+	// DeletedAt When the task was moved to the trash. Present in task endpoints only.
+	DeletedAt   *time.Time `json:"deletedAt,omitempty"`
+	Description string     `json:"description"`
+	Id          int        `json:"id"`
+
+	// Match How the task matches a search query. Present in task searches only.
+	Match *TaskMatchResponse `json:"match,omitempty"`
+
+	// OverBudget Whether more hours than budgeted were tracked. Present in task lists, searches, batches, and progress only, as it isn't covered by the ETag of a task.
+	OverBudget *bool `json:"overBudget,omitempty"`
+
+	// ParentId Task the task is a subtask of. Present in task endpoints only.
+	ParentId *int `json:"parentId,omitempty"`
+
+	// ProjectId Present in task endpoints only.
+	ProjectId *int `json:"projectId,omitempty"`
+
+	// Status Status of the task. Open and in progress tasks can move to any status, done tasks can be reopened or archived, and archived tasks can be reopened. Tasks are archived with DELETE /tasks/{id} or the archive batch operation, not by updating their status, so that their subtasks are handled. Time can't be tracked on done or archived tasks. Present in task endpoints only.
+	Status *TaskStatus `json:"status,omitempty"`
+
+	// TagIds Tags of the task. Present in task endpoints only.
+	TagIds *[]int `json:"tagIds,omitempty"`
+
+	// TrackedHours Decimal number of hours in stopped works on the task. Present in task lists, searches, batches, and progress only, as it isn't covered by the ETag of a task.
+	TrackedHours *string `json:"trackedHours,omitempty"`
+
+	// Version Version of the task, incremented on every update. Sent as the ETag of the task. Present in task endpoints only.
+	Version *int `json:"version,omitempty"`
+
+	// Visibility Who can see the task and track time on it besides its creator, its assignees, and administrators. Project members are the creators and the assignees of the tasks of the project. Present in task endpoints only.
+	Visibility *TaskVisibility `json:"visibility,omitempty"`
+}
+
+// TaskStatus Status of the task. Open and in progress tasks can move to any status, done tasks can be reopened or archived, and archived tasks can be reopened. Tasks are archived with DELETE /tasks/{id} or the archive batch operation, not by updating their status, so that their subtasks are handled. Time can't be tracked on done or archived tasks. Present in task endpoints only.
+type TaskStatus string
+
+// TaskTemplateMode Whether each occurrence creates a new task or reopens the task created on the first occurrence if it is done or archived. Defaults to create.
+type TaskTemplateMode string
+
+// TaskTemplateResponse defines model for TaskTemplateResponse.
+type TaskTemplateResponse struct {
+	Billable    bool       `json:"billable"`
+	Description string     `json:"description"`
+	Id          int        `json:"id"`
+	LastRunAt   *time.Time `json:"lastRunAt,omitempty"`
+
+	// Mode Whether each occurrence creates a new task or reopens the task created on the first occurrence if it is done or archived. Defaults to create.
+	Mode TaskTemplateMode `json:"mode"`
+
+	// NextRunAt Next occurrence of the rule, omitted once the rule has no more occurrences.
+	NextRunAt *time.Time `json:"nextRunAt,omitempty"`
+
+	// ProjectId Project of the tasks, the default project if omitted.
+	ProjectId *int      `json:"projectId,omitempty"`
+	Rule      string    `json:"rule"`
+	StartsAt  time.Time `json:"startsAt"`
+
+	// TaskId Task created or reopened on the last run.
+	TaskId *int `json:"taskId,omitempty"`
+
+	// Visibility Who can see the task and track time on it besides its creator, its assignees, and administrators. Project members are the creators and the assignees of the tasks of the project. Present in task endpoints only.
+	Visibility TaskVisibility `json:"visibility"`
+}
+
+// TaskUserProgressResponse defines model for TaskUserProgressResponse.
+type TaskUserProgressResponse struct {
+	TrackedHours string `json:"trackedHours"`
+	UserId       int    `json:"userId"`
+}
+
+// TaskVisibility Who can see the task and track time on it besides its creator, its assignees, and administrators. Project members are the creators and the assignees of the tasks of the project. Present in task endpoints only.
+type TaskVisibility string
+
+// TimesheetResponse defines model for TimesheetResponse.
+type TimesheetResponse struct {
+	// Comment Comment of the manager who approved or rejected the timesheet.
+	Comment   *string    `json:"comment,omitempty"`
+	DecidedAt *time.Time `json:"decidedAt,omitempty"`
+	DecidedBy *int       `json:"decidedBy,omitempty"`
+	From      time.Time  `json:"from"`
+
+	// Hours Decimal number of hours, e.g. "1.50".
+	Hours       string                  `json:"hours"`
+	Id          int                     `json:"id"`
+	Status      TimesheetResponseStatus `json:"status"`
+	SubmittedAt *time.Time              `json:"submittedAt,omitempty"`
+
+	// Tasks Time spent on tasks in stopped works started in the week.
+	Tasks  []TimesheetTaskResponse `json:"tasks"`
+	To     time.Time               `json:"to"`
+	UserId int                     `json:"userId"`
+}
+
+// TimesheetResponseStatus defines model for TimesheetResponse.Status.
+type TimesheetResponseStatus string
+
+// TimesheetTaskResponse defines model for TimesheetTaskResponse.
+type TimesheetTaskResponse struct {
+	// Hours Decimal number of hours, e.g. "1.50".
+	Hours string       `json:"hours"`
+	Task  TaskResponse `json:"task"`
+}
+
+// TokenResponse Token (https://datatracker.ietf.org/doc/html/rfc6749#section-5.1).
+type TokenResponse struct {
+	AccessToken string                 `json:"access_token"`
+	TokenType   TokenResponseTokenType `json:"token_type"`
+}
+
+// TokenResponseTokenType defines model for TokenResponse.TokenType.
+type TokenResponseTokenType string
+
+// UpdateClientRequest defines model for UpdateClientRequest.
+type UpdateClientRequest struct {
+	Name *string `json:"name,omitempty"`
+}
+
+// UpdateCommentRequest defines model for UpdateCommentRequest.
+type UpdateCommentRequest struct {
+	Body string `json:"body"`
+}
+
+// UpdateCustomFieldRequest defines model for UpdateCustomFieldRequest.
+type UpdateCustomFieldRequest struct {
+	Name *string `json:"name,omitempty"`
+
+	// Options Replaces the options of an enum field.
+	Options *[]string `json:"options,omitempty"`
+}
+
+// UpdateProjectRequest defines model for UpdateProjectRequest.
+type UpdateProjectRequest struct {
+	ClientId     *int    `json:"clientId,omitempty"`
+	ClientIdNull *bool   `json:"clientIdNull,omitempty"`
+	Name         *string `json:"name,omitempty"`
+}
+
+// UpdateTagRequest defines model for UpdateTagRequest.
+type UpdateTagRequest struct {
+	Name *string `json:"name,omitempty"`
+}
+
+// UpdateTaskRequest defines model for UpdateTaskRequest.
+type UpdateTaskRequest struct {
+	// AssigneeIds Replaces the assignees of the task.
+	AssigneeIds     *[]int  `json:"assigneeIds,omitempty"`
+	Billable        *bool   `json:"billable,omitempty"`
+	BudgetHours     *string `json:"budgetHours,omitempty"`
+	BudgetHoursNull *bool   `json:"budgetHoursNull,omitempty"`
+
+	// CustomFields Sets or removes the values of the given custom fields, other values are kept.
+	CustomFields *[]TaskFieldValueRequest `json:"customFields,omitempty"`
+	Description  *string                  `json:"description,omitempty"`
+
+	// ParentId Task the task is a subtask of. It can't be the task itself or one of its descendants.
+	ParentId     *int  `json:"parentId,omitempty"`
+	ParentIdNull *bool `json:"parentIdNull,omitempty"`
+	ProjectId    *int  `json:"projectId,omitempty"`
+
+	// Status Status of the task. Open and in progress tasks can move to any status, done tasks can be reopened or archived, and archived tasks can be reopened. Tasks are archived with DELETE /tasks/{id} or the archive batch operation, not by updating their status, so that their subtasks are handled. Time can't be tracked on done or archived tasks. Present in task endpoints only.
+	Status *TaskStatus `json:"status,omitempty"`
+
+	// TagIds Replaces the tags of the task.
+	TagIds *[]int `json:"tagIds,omitempty"`
+
+	// Visibility Who can see the task and track time on it besides its creator, its assignees, and administrators. Project members are the creators and the assignees of the tasks of the project. Present in task endpoints only.
+	Visibility *TaskVisibility `json:"visibility,omitempty"`
+}
+
+// UpdateUserRequest defines model for UpdateUserRequest.
+type UpdateUserRequest struct {
+	Address        *string `json:"address,omitempty"`
+	Name           *string `json:"name,omitempty"`
+	PassportNumber *string `json:"passportNumber,omitempty"`
+	Patronymic     *string `json:"patronymic,omitempty"`
+	PatronymicNull *bool   `json:"patronymicNull,omitempty"`
+	Surname        *string `json:"surname,omitempty"`
+}
+
+// UpdateWorkRequest defines model for UpdateWorkRequest.
+type UpdateWorkRequest struct {
+	Billable *bool `json:"billable,omitempty"`
+
+	// TagIds Replaces the tags of the work.
+	TagIds *[]int `json:"tagIds,omitempty"`
+}
+
+// UserResponse defines model for UserResponse.
+type UserResponse struct {
+	Address        string     `json:"address"`
+	CreatedAt      *time.Time `json:"createdAt,omitempty"`
+	Id             int        `json:"id"`
+	Name           string     `json:"name"`
+	PassportNumber string     `json:"passportNumber"`
+	Patronymic     *string    `json:"patronymic,omitempty"`
+	Surname        string     `json:"surname"`
+
+	// Version Version of the user, incremented on every update. Sent as the ETag of the user.
+	Version *int `json:"version,omitempty"`
+}
+
+// WorkResponse defines model for WorkResponse.
+type WorkResponse struct {
+	Billable  bool       `json:"billable"`
+	Id        int        `json:"id"`
+	StartedAt time.Time  `json:"startedAt"`
+	StoppedAt *time.Time `json:"stoppedAt,omitempty"`
+
+	// TagIds Tags of the work itself, without the tags of its task.
+	TagIds *[]int `json:"tagIds,omitempty"`
+	TaskId int    `json:"taskId"`
+	UserId int    `json:"userId"`
+}
+
+// GetBudgetEventsParams defines parameters for GetBudgetEvents.
+type GetBudgetEventsParams struct {
+	AfterId *int `form:"afterId,omitempty" json:"afterId,omitempty"`
+	Limit   *int `form:"limit,omitempty" json:"limit,omitempty"`
+}
+
+// GetClientsParams defines parameters for GetClients.
+type GetClientsParams struct {
+	// Cursor Cursor of a page from a link in the Link header of the previous response.
+	Cursor *string `form:"cursor,omitempty" json:"cursor,omitempty"`
+
+	// Offset Number of items to skip. Deprecated in favour of cursor.
+	Offset *int `form:"offset,omitempty" json:"offset,omitempty"`
+	Limit  *int `form:"limit,omitempty" json:"limit,omitempty"`
+}
+
+// GetCustomFieldsParams defines parameters for GetCustomFields.
+type GetCustomFieldsParams struct {
+	// Cursor Cursor of a page from a link in the Link header of the previous response.
+	Cursor *string `form:"cursor,omitempty" json:"cursor,omitempty"`
+
+	// Offset Number of items to skip. Deprecated in favour of cursor.
+	Offset *int `form:"offset,omitempty" json:"offset,omitempty"`
+	Limit  *int `form:"limit,omitempty" json:"limit,omitempty"`
+}
+
+// GetHolidaysParams defines parameters for GetHolidays.
+type GetHolidaysParams struct {
+	From *openapi_types.Date `form:"from,omitempty" json:"from,omitempty"`
+
+	// To Exclusive end of the time frame.
+	To *openapi_types.Date `form:"to,omitempty" json:"to,omitempty"`
+}
+
+// GetInvoicesParams defines parameters for GetInvoices.
+type GetInvoicesParams struct {
+	ClientId *int `form:"clientId,omitempty" json:"clientId,omitempty"`
+
+	// Cursor Cursor of a page from a link in the Link header of the previous response.
+	Cursor *string `form:"cursor,omitempty" json:"cursor,omitempty"`
+
+	// Offset Number of items to skip. Deprecated in favour of cursor.
+	Offset *int `form:"offset,omitempty" json:"offset,omitempty"`
+	Limit  *int `form:"limit,omitempty" json:"limit,omitempty"`
+}
+
+// GetProjectsParams defines parameters for GetProjects.
+type GetProjectsParams struct {
+	ClientId *int `form:"clientId,omitempty" json:"clientId,omitempty"`
+
+	// Cursor Cursor of a page from a link in the Link header of the previous response.
+	Cursor *string `form:"cursor,omitempty" json:"cursor,omitempty"`
+
+	// Offset Number of items to skip. Deprecated in favour of cursor.
+	Offset *int `form:"offset,omitempty" json:"offset,omitempty"`
+	Limit  *int `form:"limit,omitempty" json:"limit,omitempty"`
+}
+
+// GetRatesParams defines parameters for GetRates.
+type GetRatesParams struct {
+	UserId *int `form:"userId,omitempty" json:"userId,omitempty"`
+	TaskId *int `form:"taskId,omitempty" json:"taskId,omitempty"`
+}
+
+// GetSchedulesParams defines parameters for GetSchedules.
+type GetSchedulesParams struct {
+	UserId *int `form:"userId,omitempty" json:"userId,omitempty"`
+}
+
+// GetTagsParams defines parameters for GetTags.
+type GetTagsParams struct {
+	// Cursor Cursor of a page from a link in the Link header of the previous response.
+	Cursor *string `form:"cursor,omitempty" json:"cursor,omitempty"`
+
+	// Offset Number of items to skip. Deprecated in favour of cursor.
+	Offset *int `form:"offset,omitempty" json:"offset,omitempty"`
+	Limit  *int `form:"limit,omitempty" json:"limit,omitempty"`
+}
+
+// GetTasksParams defines parameters for GetTasks.
+type GetTasksParams struct {
+	// Q Search query, matched fuzzily against task descriptions.
+	Q          *string `form:"q,omitempty" json:"q,omitempty"`
+	ProjectId  *int    `form:"projectId,omitempty" json:"projectId,omitempty"`
+	AssigneeId *int    `form:"assigneeId,omitempty" json:"assigneeId,omitempty"`
+	TagId      *int    `form:"tagId,omitempty" json:"tagId,omitempty"`
+	ParentId   *int    `form:"parentId,omitempty" json:"parentId,omitempty"`
+
+	// FieldId Custom field that must have the value given by fieldValue.
+	FieldId *int `form:"fieldId,omitempty" json:"fieldId,omitempty"`
+
+	// FieldValue Value of the custom field given by fieldId, compared after normalization to the field type.
+	FieldValue *string `form:"fieldValue,omitempty" json:"fieldValue,omitempty"`
+
+	// Status Statuses of the tasks, defaults to all statuses except archived.
+	Status *[]TaskStatus `form:"status,omitempty" json:"status,omitempty"`
+
+	// Cursor Cursor of a page from a link in the Link header of the previous response.
+	Cursor *string `form:"cursor,omitempty" json:"cursor,omitempty"`
+
+	// Offset Number of items to skip. Deprecated in favour of cursor.
+	Offset *int `form:"offset,omitempty" json:"offset,omitempty"`
+	Limit  *int `form:"limit,omitempty" json:"limit,omitempty"`
+}
+
+// DeleteTasksIdParams defines parameters for DeleteTasksId.
+type DeleteTasksIdParams struct {
+	Permanent *bool `form:"permanent,omitempty" json:"permanent,omitempty"`
+
+	// Force Delete the task even if it has works. Administrators only, with permanent=true only.
+	Force    *bool             `form:"force,omitempty" json:"force,omitempty"`
+	Children *TaskChildrenMode `form:"children,omitempty" json:"children,omitempty"`
+
+	// IfMatch ETag of the version the request is based on. The request fails with 412 if the resource has changed since. Required if the server requires it.
+	IfMatch *string `json:"If-Match,omitempty"`
+}
+
+// PatchTasksIdParams defines parameters for PatchTasksId.
+type PatchTasksIdParams struct {
+	// IfMatch ETag of the version the request is based on. The request fails with 412 if the resource has changed since. Required if the server requires it.
+	IfMatch *string `json:"If-Match,omitempty"`
+}
+
+// GetTasksIdActivityParams defines parameters for GetTasksIdActivity.
+type GetTasksIdActivityParams struct {
+	// Cursor Cursor of a page from a link in the Link header of the previous response.
+	Cursor *string `form:"cursor,omitempty" json:"cursor,omitempty"`
+
+	// Offset Number of items to skip. Deprecated in favour of cursor.
+	Offset *int `form:"offset,omitempty" json:"offset,omitempty"`
+	Limit  *int `form:"limit,omitempty" json:"limit,omitempty"`
+}
+
+// GetTasksIdChildrenParams defines parameters for GetTasksIdChildren.
+type GetTasksIdChildrenParams struct {
+	// Status Statuses of the subtasks, defaults to all statuses except archived.
+	Status *[]TaskStatus `form:"status,omitempty" json:"status,omitempty"`
+
+	// Cursor Cursor of a page from a link in the Link header of the previous response.
+	Cursor *string `form:"cursor,omitempty" json:"cursor,omitempty"`
+
+	// Offset Number of items to skip. Deprecated in favour of cursor.
+	Offset *int `form:"offset,omitempty" json:"offset,omitempty"`
+	Limit  *int `form:"limit,omitempty" json:"limit,omitempty"`
+}
+
+// GetTasksIdCommentsParams defines parameters for GetTasksIdComments.
+type GetTasksIdCommentsParams struct {
+	// Cursor Cursor of a page from a link in the Link header of the previous response.
+	Cursor *string `form:"cursor,omitempty" json:"cursor,omitempty"`
+
+	// Offset Number of items to skip. Deprecated in favour of cursor.
+	Offset *int `form:"offset,omitempty" json:"offset,omitempty"`
+	Limit  *int `form:"limit,omitempty" json:"limit,omitempty"`
+}
+
+// GetTimesheetsParams defines parameters for GetTimesheets.
+type GetTimesheetsParams struct {
+	// Cursor Cursor of a page from a link in the Link header of the previous response.
+	Cursor *string `form:"cursor,omitempty" json:"cursor,omitempty"`
+
+	// Offset Number of items to skip. Deprecated in favour of cursor.
+	Offset *int `form:"offset,omitempty" json:"offset,omitempty"`
+	Limit  *int `form:"limit,omitempty" json:"limit,omitempty"`
+}
+
+// GetTimesheetsQueueParams defines parameters for GetTimesheetsQueue.
+type GetTimesheetsQueueParams struct {
+	// Cursor Cursor of a page from a link in the Link header of the previous response.
+	Cursor *string `form:"cursor,omitempty" json:"cursor,omitempty"`
+
+	// Offset Number of items to skip. Deprecated in favour of cursor.
+	Offset *int `form:"offset,omitempty" json:"offset,omitempty"`
+	Limit  *int `form:"limit,omitempty" json:"limit,omitempty"`
+}
+
+// GetUsersParams defines parameters for GetUsers.
+type GetUsersParams struct {
+	// Filter Filter by user fields, one of passport_number, surname, name, patronymic, and address, in the form of field=value or field:mode=value. The mode is contains (default) to match fields that contain the value ignoring case, exact to match fields equal to the value, or fuzzy to match fields similar to the value by trigrams and rank users by similarity. patronymic_null=true or false matches users without or with a patronymic. Alternatives separated by | match users that match any of them. Can be used multiple times, users must match all filters.
+	Filter *[]string `form:"filter,omitempty" json:"filter,omitempty"`
+
+	// Sort Comma-separated fields to sort by, one of id, surname, name, and created_at, each prefixed with - for descending order. Defaults to the similarity for fuzzy filters and to id otherwise.
+	Sort *string `form:"sort,omitempty" json:"sort,omitempty"`
+
+	// Cursor Cursor of a page from a link in the Link header of the previous response.
+	Cursor *string `form:"cursor,omitempty" json:"cursor,omitempty"`
+
+	// Offset Number of items to skip. Deprecated in favour of cursor.
+	Offset *int `form:"offset,omitempty" json:"offset,omitempty"`
+	Limit  *int `form:"limit,omitempty" json:"limit,omitempty"`
+}
+
+// DeleteUsersIdParams defines parameters for DeleteUsersId.
+type DeleteUsersIdParams struct {
+	// Force Delete the user even if they have works. Administrators only.
+	Force *bool `form:"force,omitempty" json:"force,omitempty"`
+
+	// IfMatch ETag of the version the request is based on. The request fails with 412 if the resource has changed since. Required if the server requires it.
+	IfMatch *string `json:"If-Match,omitempty"`
+}
+
+// PatchUsersIdParams defines parameters for PatchUsersId.
+type PatchUsersIdParams struct {
+	// IfMatch ETag of the version the request is based on. The request fails with 412 if the resource has changed since. Required if the server requires it.
+	IfMatch *string `json:"If-Match,omitempty"`
+}
+
+// GetUsersIdOvertimeParams defines parameters for GetUsersIdOvertime.
+type GetUsersIdOvertimeParams struct {
+	From    time.Time        `form:"from" json:"from"`
+	To      time.Time        `form:"to" json:"to"`
+	GroupBy *OvertimeGroupBy `form:"group_by,omitempty" json:"group_by,omitempty"`
+}
+
+// GetUsersIdReportParams defines parameters for GetUsersIdReport.
+type GetUsersIdReportParams struct {
+	From            time.Time            `form:"from" json:"from"`
+	To              time.Time            `form:"to" json:"to"`
+	GroupBy         *ReportGroupBy       `form:"group_by,omitempty" json:"group_by,omitempty"`
+	Rounding        *ReportRoundingMode  `form:"rounding,omitempty" json:"rounding,omitempty"`
+	RoundingMinutes *int                 `form:"rounding_minutes,omitempty" json:"rounding_minutes,omitempty"`
+	RoundingScope   *ReportRoundingScope `form:"rounding_scope,omitempty" json:"rounding_scope,omitempty"`
+	CompareTo       *ReportCompareTo     `form:"compare_to,omitempty" json:"compare_to,omitempty"`
+	IncludeSubtasks *bool                `form:"include_subtasks,omitempty" json:"include_subtasks,omitempty"`
+	FieldId         *int                 `form:"field_id,omitempty" json:"field_id,omitempty"`
+}
+
+// GetWorksParams defines parameters for GetWorks.
+type GetWorksParams struct {
+	From   *time.Time `form:"from,omitempty" json:"from,omitempty"`
+	To     *time.Time `form:"to,omitempty" json:"to,omitempty"`
+	TaskId *int       `form:"taskId,omitempty" json:"taskId,omitempty"`
+	TagId  *int       `form:"tagId,omitempty" json:"tagId,omitempty"`
+
+	// Cursor Cursor of a page from a link in the Link header of the previous response.
+	Cursor *string `form:"cursor,omitempty" json:"cursor,omitempty"`
+
+	// Offset Number of items to skip. Deprecated in favour of cursor.
+	Offset *int `form:"offset,omitempty" json:"offset,omitempty"`
+	Limit  *int `form:"limit,omitempty" json:"limit,omitempty"`
+}
+
+// PostAuthFormdataRequestBody defines body for PostAuth for application/x-www-form-urlencoded ContentType.
+type PostAuthFormdataRequestBody = AuthRequest
+
+// PostClientsJSONRequestBody defines body for PostClients for application/json ContentType.
+type PostClientsJSONRequestBody = CreateClientRequest
+
+// PatchClientsIdJSONRequestBody defines body for PatchClientsId for application/json ContentType.
+type PatchClientsIdJSONRequestBody = UpdateClientRequest
+
+// PatchCommentsIdJSONRequestBody defines body for PatchCommentsId for application/json ContentType.
+type PatchCommentsIdJSONRequestBody = UpdateCommentRequest
+
+// PostCustomFieldsJSONRequestBody defines body for PostCustomFields for application/json ContentType.
+type PostCustomFieldsJSONRequestBody = CreateCustomFieldRequest
+
+// PatchCustomFieldsIdJSONRequestBody defines body for PatchCustomFieldsId for application/json ContentType.
+type PatchCustomFieldsIdJSONRequestBody = UpdateCustomFieldRequest
+
+// PostHolidaysJSONRequestBody defines body for PostHolidays for application/json ContentType.
+type PostHolidaysJSONRequestBody = CreateHolidayRequest
+
+// PostInvoicesJSONRequestBody defines body for PostInvoices for application/json ContentType.
+type PostInvoicesJSONRequestBody = CreateInvoiceRequest
+
+// PostProjectsJSONRequestBody defines body for PostProjects for application/json ContentType.
+type PostProjectsJSONRequestBody = CreateProjectRequest
+
+// PatchProjectsIdJSONRequestBody defines body for PatchProjectsId for application/json ContentType.
+type PatchProjectsIdJSONRequestBody = UpdateProjectRequest
+
+// PostRatesJSONRequestBody defines body for PostRates for application/json ContentType.
+type PostRatesJSONRequestBody = CreateRateRequest
+
+// PostSchedulesJSONRequestBody defines body for PostSchedules for application/json ContentType.
+type PostSchedulesJSONRequestBody = CreateScheduleRequest
+
+// PostSubscriptionsJSONRequestBody defines body for PostSubscriptions for application/json ContentType.
+type PostSubscriptionsJSONRequestBody = CreateReportSubscriptionRequest
+
+// PostTagsJSONRequestBody defines body for PostTags for application/json ContentType.
+type PostTagsJSONRequestBody = CreateTagRequest
+
+// PatchTagsIdJSONRequestBody defines body for PatchTagsId for application/json ContentType.
+type PatchTagsIdJSONRequestBody = UpdateTagRequest
+
+// PostTaskTemplatesJSONRequestBody defines body for PostTaskTemplates for application/json ContentType.
+type PostTaskTemplatesJSONRequestBody = CreateTaskTemplateRequest
+
+// PostTasksJSONRequestBody defines body for PostTasks for application/json ContentType.
+type PostTasksJSONRequestBody = CreateTaskRequest
+
+// PostTasksBatchJSONRequestBody defines body for PostTasksBatch for application/json ContentType.
+type PostTasksBatchJSONRequestBody = BatchTasksRequest
+
+// PatchTasksIdJSONRequestBody defines body for PatchTasksId for application/json ContentType.
+type PatchTasksIdJSONRequestBody = UpdateTaskRequest
+
+// PostTasksIdCommentsJSONRequestBody defines body for PostTasksIdComments for application/json ContentType.
+type PostTasksIdCommentsJSONRequestBody = CreateCommentRequest
+
+// PostTimesheetsJSONRequestBody defines body for PostTimesheets for application/json ContentType.
+type PostTimesheetsJSONRequestBody = CreateTimesheetRequest
+
+// PostTimesheetsIdApproveJSONRequestBody defines body for PostTimesheetsIdApprove for application/json ContentType.
+type PostTimesheetsIdApproveJSONRequestBody = DecideTimesheetRequest
+
+// PostTimesheetsIdRejectJSONRequestBody defines body for PostTimesheetsIdReject for application/json ContentType.
+type PostTimesheetsIdRejectJSONRequestBody = DecideTimesheetRequest
+
+// PostUsersJSONRequestBody defines body for PostUsers for application/json ContentType.
+type PostUsersJSONRequestBody = CreateUserRequest
+
+// PatchUsersIdJSONRequestBody defines body for PatchUsersId for application/json ContentType.
+type PatchUsersIdJSONRequestBody = UpdateUserRequest
+
+// PostUsersIdReportJSONRequestBody defines body for PostUsersIdReport for application/json ContentType.
+type PostUsersIdReportJSONRequestBody = ReportRequest
+
+// PatchWorksIdJSONRequestBody defines body for PatchWorksId for application/json ContentType.
+type PatchWorksIdJSONRequestBody = UpdateWorkRequest
+
+// ServerInterface represents all server handlers.
+type ServerInterface interface {
+
+	// (POST /auth)
+	PostAuth(w http.ResponseWriter, r *http.Request)
+
+	// (GET /budget-events/)
+	GetBudgetEvents(w http.ResponseWriter, r *http.Request, params GetBudgetEventsParams)
+
+	// (GET /clients/)
+	GetClients(w http.ResponseWriter, r *http.Request, params GetClientsParams)
+
+	// (POST /clients/)
+	PostClients(w http.ResponseWriter, r *http.Request)
+
+	// (DELETE /clients/{id})
+	DeleteClientsId(w http.ResponseWriter, r *http.Request, id int)
+
+	// (GET /clients/{id})
+	GetClientsId(w http.ResponseWriter, r *http.Request, id int)
+
+	// (PATCH /clients/{id})
+	PatchClientsId(w http.ResponseWriter, r *http.Request, id int)
+
+	// (DELETE /comments/{id})
+	DeleteCommentsId(w http.ResponseWriter, r *http.Request, id int)
+
+	// (PATCH /comments/{id})
+	PatchCommentsId(w http.ResponseWriter, r *http.Request, id int)
+
+	// (GET /custom-fields/)
+	GetCustomFields(w http.ResponseWriter, r *http.Request, params GetCustomFieldsParams)
+
+	// (POST /custom-fields/)
+	PostCustomFields(w http.ResponseWriter, r *http.Request)
+
+	// (DELETE /custom-fields/{id})
+	DeleteCustomFieldsId(w http.ResponseWriter, r *http.Request, id int)
+
+	// (GET /custom-fields/{id})
+	GetCustomFieldsId(w http.ResponseWriter, r *http.Request, id int)
+
+	// (PATCH /custom-fields/{id})
+	PatchCustomFieldsId(w http.ResponseWriter, r *http.Request, id int)
+
+	// (GET /health)
+	GetHealth(w http.ResponseWriter, r *http.Request)
+
+	// (GET /holidays/)
+	GetHolidays(w http.ResponseWriter, r *http.Request, params GetHolidaysParams)
+
+	// (POST /holidays/)
+	PostHolidays(w http.ResponseWriter, r *http.Request)
+
+	// (DELETE /holidays/{id})
+	DeleteHolidaysId(w http.ResponseWriter, r *http.Request, id int)
+
+	// (GET /invoices/)
+	GetInvoices(w http.ResponseWriter, r *http.Request, params GetInvoicesParams)
+
+	// (POST /invoices/)
+	PostInvoices(w http.ResponseWriter, r *http.Request)
+
+	// (DELETE /invoices/{id})
+	DeleteInvoicesId(w http.ResponseWriter, r *http.Request, id int)
+
+	// (GET /invoices/{id})
+	GetInvoicesId(w http.ResponseWriter, r *http.Request, id int)
+
+	// (POST /invoices/{id}/issue)
+	PostInvoicesIdIssue(w http.ResponseWriter, r *http.Request, id int)
+
+	// (GET /projects/)
+	GetProjects(w http.ResponseWriter, r *http.Request, params GetProjectsParams)
+
+	// (POST /projects/)
+	PostProjects(w http.ResponseWriter, r *http.Request)
+
+	// (DELETE /projects/{id})
+	DeleteProjectsId(w http.ResponseWriter, r *http.Request, id int)
+
+	// (GET /projects/{id})
+	GetProjectsId(w http.ResponseWriter, r *http.Request, id int)
+
+	// (PATCH /projects/{id})
+	PatchProjectsId(w http.ResponseWriter, r *http.Request, id int)
+
+	// (GET /rates/)
+	GetRates(w http.ResponseWriter, r *http.Request, params GetRatesParams)
+
+	// (POST /rates/)
+	PostRates(w http.ResponseWriter, r *http.Request)
+
+	// (DELETE /rates/{id})
+	DeleteRatesId(w http.ResponseWriter, r *http.Request, id int)
+
+	// (GET /schedules/)
+	GetSchedules(w http.ResponseWriter, r *http.Request, params GetSchedulesParams)
+
+	// (POST /schedules/)
+	PostSchedules(w http.ResponseWriter, r *http.Request)
+
+	// (DELETE /schedules/{id})
+	DeleteSchedulesId(w http.ResponseWriter, r *http.Request, id int)
+
+	// (GET /subscriptions/)
+	GetSubscriptions(w http.ResponseWriter, r *http.Request)
+
+	// (POST /subscriptions/)
+	PostSubscriptions(w http.ResponseWriter, r *http.Request)
+
+	// (DELETE /subscriptions/{id})
+	DeleteSubscriptionsId(w http.ResponseWriter, r *http.Request, id int)
+
+	// (GET /tags/)
+	GetTags(w http.ResponseWriter, r *http.Request, params GetTagsParams)
+
+	// (POST /tags/)
+	PostTags(w http.ResponseWriter, r *http.Request)
+
+	// (DELETE /tags/{id})
+	DeleteTagsId(w http.ResponseWriter, r *http.Request, id int)
+
+	// (GET /tags/{id})
+	GetTagsId(w http.ResponseWriter, r *http.Request, id int)
+
+	// (PATCH /tags/{id})
+	PatchTagsId(w http.ResponseWriter, r *http.Request, id int)
+
+	// (GET /task-templates/)
+	GetTaskTemplates(w http.ResponseWriter, r *http.Request)
+
+	// (POST /task-templates/)
+	PostTaskTemplates(w http.ResponseWriter, r *http.Request)
+
+	// (DELETE /task-templates/{id})
+	DeleteTaskTemplatesId(w http.ResponseWriter, r *http.Request, id int)
+
+	// (GET /task-templates/{id})
+	GetTaskTemplatesId(w http.ResponseWriter, r *http.Request, id int)
+
+	// (GET /tasks/)
+	GetTasks(w http.ResponseWriter, r *http.Request, params GetTasksParams)
+
+	// (POST /tasks/)
+	PostTasks(w http.ResponseWriter, r *http.Request)
+
+	// (POST /tasks/batch)
+	PostTasksBatch(w http.ResponseWriter, r *http.Request)
+
+	// (DELETE /tasks/{id})
+	DeleteTasksId(w http.ResponseWriter, r *http.Request, id int, params DeleteTasksIdParams)
+
+	// (GET /tasks/{id})
+	GetTasksId(w http.ResponseWriter, r *http.Request, id int)
+
+	// (PATCH /tasks/{id})
+	PatchTasksId(w http.ResponseWriter, r *http.Request, id int, params PatchTasksIdParams)
+
+	// (GET /tasks/{id}/activity)
+	GetTasksIdActivity(w http.ResponseWriter, r *http.Request, id int, params GetTasksIdActivityParams)
+
+	// (GET /tasks/{id}/children)
+	GetTasksIdChildren(w http.ResponseWriter, r *http.Request, id int, params GetTasksIdChildrenParams)
+
+	// (GET /tasks/{id}/comments)
+	GetTasksIdComments(w http.ResponseWriter, r *http.Request, id int, params GetTasksIdCommentsParams)
+
+	// (POST /tasks/{id}/comments)
+	PostTasksIdComments(w http.ResponseWriter, r *http.Request, id int)
+
+	// (GET /tasks/{id}/progress)
+	GetTasksIdProgress(w http.ResponseWriter, r *http.Request, id int)
+
+	// (POST /tasks/{id}/restore)
+	PostTasksIdRestore(w http.ResponseWriter, r *http.Request, id int)
+
+	// (POST /tasks/{id}/start)
+	PostTasksIdStart(w http.ResponseWriter, r *http.Request, id int)
+
+	// (POST /tasks/{id}/stop)
+	PostTasksIdStop(w http.ResponseWriter, r *http.Request, id int)
+
+	// (GET /timesheets/)
+	GetTimesheets(w http.ResponseWriter, r *http.Request, params GetTimesheetsParams)
+
+	// (POST /timesheets/)
+	PostTimesheets(w http.ResponseWriter, r *http.Request)
+
+	// (GET /timesheets/queue)
+	GetTimesheetsQueue(w http.ResponseWriter, r *http.Request, params GetTimesheetsQueueParams)
+
+	// (GET /timesheets/{id})
+	GetTimesheetsId(w http.ResponseWriter, r *http.Request, id int)
+
+	// (POST /timesheets/{id}/approve)
+	PostTimesheetsIdApprove(w http.ResponseWriter, r *http.Request, id int)
+
+	// (POST /timesheets/{id}/reject)
+	PostTimesheetsIdReject(w http.ResponseWriter, r *http.Request, id int)
+
+	// (POST /timesheets/{id}/submit)
+	PostTimesheetsIdSubmit(w http.ResponseWriter, r *http.Request, id int)
+
+	// (GET /users/)
+	GetUsers(w http.ResponseWriter, r *http.Request, params GetUsersParams)
+
+	// (POST /users/)
+	PostUsers(w http.ResponseWriter, r *http.Request)
+
+	// (GET /users/current)
+	GetUsersCurrent(w http.ResponseWriter, r *http.Request)
+
+	// (DELETE /users/{id})
+	DeleteUsersId(w http.ResponseWriter, r *http.Request, id int, params DeleteUsersIdParams)
+
+	// (GET /users/{id})
+	GetUsersId(w http.ResponseWriter, r *http.Request, id int)
+
+	// (PATCH /users/{id})
+	PatchUsersId(w http.ResponseWriter, r *http.Request, id int, params PatchUsersIdParams)
+
+	// (GET /users/{id}/overtime)
+	GetUsersIdOvertime(w http.ResponseWriter, r *http.Request, id int, params GetUsersIdOvertimeParams)
+
+	// (GET /users/{id}/report)
+	GetUsersIdReport(w http.ResponseWriter, r *http.Request, id int, params GetUsersIdReportParams)
+
+	// (POST /users/{id}/report)
+	PostUsersIdReport(w http.ResponseWriter, r *http.Request, id int)
+
+	// (POST /users/{id}/restore)
+	PostUsersIdRestore(w http.ResponseWriter, r *http.Request, id int)
+
+	// (GET /works/)
+	GetWorks(w http.ResponseWriter, r *http.Request, params GetWorksParams)
+
+	// (DELETE /works/{id})
+	DeleteWorksId(w http.ResponseWriter, r *http.Request, id int)
+
+	// (PATCH /works/{id})
+	PatchWorksId(w http.ResponseWriter, r *http.Request, id int)
+}
+
+// ServerInterfaceWrapper converts contexts to parameters.
+type ServerInterfaceWrapper struct {
+	Handler            ServerInterface
+	HandlerMiddlewares []MiddlewareFunc
+	ErrorHandlerFunc   func(w http.ResponseWriter, r *http.Request, err error)
+}
+
+type MiddlewareFunc func(http.Handler) http.Handler
+
+// PostAuth operation middleware
+func (siw *ServerInterfaceWrapper) PostAuth(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostAuth(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// GetBudgetEvents operation middleware
+func (siw *ServerInterfaceWrapper) GetBudgetEvents(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetBudgetEventsParams
+
+	// ------------- Optional query parameter "afterId" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "afterId", r.URL.Query(), &params.AfterId)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "afterId", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetBudgetEvents(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// GetClients operation middleware
+func (siw *ServerInterfaceWrapper) GetClients(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetClientsParams
+
+	// ------------- Optional query parameter "cursor" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "cursor", r.URL.Query(), &params.Cursor)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "cursor", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "offset" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "offset", r.URL.Query(), &params.Offset)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "offset", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetClients(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// PostClients operation middleware
+func (siw *ServerInterfaceWrapper) PostClients(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostClients(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// DeleteClientsId operation middleware
+func (siw *ServerInterfaceWrapper) DeleteClientsId(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id int
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", r.PathValue("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.DeleteClientsId(w, r, id)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// GetClientsId operation middleware
+func (siw *ServerInterfaceWrapper) GetClientsId(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id int
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", r.PathValue("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetClientsId(w, r, id)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// PatchClientsId operation middleware
+func (siw *ServerInterfaceWrapper) PatchClientsId(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id int
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", r.PathValue("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PatchClientsId(w, r, id)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// DeleteCommentsId operation middleware
+func (siw *ServerInterfaceWrapper) DeleteCommentsId(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id int
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", r.PathValue("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.DeleteCommentsId(w, r, id)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// PatchCommentsId operation middleware
+func (siw *ServerInterfaceWrapper) PatchCommentsId(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id int
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", r.PathValue("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PatchCommentsId(w, r, id)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// GetCustomFields operation middleware
+func (siw *ServerInterfaceWrapper) GetCustomFields(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetCustomFieldsParams
+
+	// ------------- Optional query parameter "cursor" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "cursor", r.URL.Query(), &params.Cursor)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "cursor", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "offset" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "offset", r.URL.Query(), &params.Offset)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "offset", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetCustomFields(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// PostCustomFields operation middleware
+func (siw *ServerInterfaceWrapper) PostCustomFields(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostCustomFields(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// DeleteCustomFieldsId operation middleware
+func (siw *ServerInterfaceWrapper) DeleteCustomFieldsId(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id int
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", r.PathValue("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.DeleteCustomFieldsId(w, r, id)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// GetCustomFieldsId operation middleware
+func (siw *ServerInterfaceWrapper) GetCustomFieldsId(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id int
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", r.PathValue("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetCustomFieldsId(w, r, id)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// PatchCustomFieldsId operation middleware
+func (siw *ServerInterfaceWrapper) PatchCustomFieldsId(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id int
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", r.PathValue("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PatchCustomFieldsId(w, r, id)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// GetHealth operation middleware
+func (siw *ServerInterfaceWrapper) GetHealth(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetHealth(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// GetHolidays operation middleware
+func (siw *ServerInterfaceWrapper) GetHolidays(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetHolidaysParams
+
+	// ------------- Optional query parameter "from" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "from", r.URL.Query(), &params.From)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "from", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "to" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "to", r.URL.Query(), &params.To)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "to", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetHolidays(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// PostHolidays operation middleware
+func (siw *ServerInterfaceWrapper) PostHolidays(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostHolidays(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// DeleteHolidaysId operation middleware
+func (siw *ServerInterfaceWrapper) DeleteHolidaysId(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id int
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", r.PathValue("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.DeleteHolidaysId(w, r, id)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// GetInvoices operation middleware
+func (siw *ServerInterfaceWrapper) GetInvoices(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetInvoicesParams
+
+	// ------------- Optional query parameter "clientId" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "clientId", r.URL.Query(), &params.ClientId)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "clientId", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "cursor" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "cursor", r.URL.Query(), &params.Cursor)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "cursor", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "offset" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "offset", r.URL.Query(), &params.Offset)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "offset", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetInvoices(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// PostInvoices operation middleware
+func (siw *ServerInterfaceWrapper) PostInvoices(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostInvoices(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// DeleteInvoicesId operation middleware
+func (siw *ServerInterfaceWrapper) DeleteInvoicesId(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id int
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", r.PathValue("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.DeleteInvoicesId(w, r, id)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// GetInvoicesId operation middleware
+func (siw *ServerInterfaceWrapper) GetInvoicesId(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id int
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", r.PathValue("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetInvoicesId(w, r, id)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// PostInvoicesIdIssue operation middleware
+func (siw *ServerInterfaceWrapper) PostInvoicesIdIssue(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id int
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", r.PathValue("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostInvoicesIdIssue(w, r, id)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// GetProjects operation middleware
+func (siw *ServerInterfaceWrapper) GetProjects(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetProjectsParams
+
+	// ------------- Optional query parameter "clientId" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "clientId", r.URL.Query(), &params.ClientId)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "clientId", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "cursor" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "cursor", r.URL.Query(), &params.Cursor)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "cursor", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "offset" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "offset", r.URL.Query(), &params.Offset)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "offset", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetProjects(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// PostProjects operation middleware
+func (siw *ServerInterfaceWrapper) PostProjects(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostProjects(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// DeleteProjectsId operation middleware
+func (siw *ServerInterfaceWrapper) DeleteProjectsId(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id int
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", r.PathValue("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.DeleteProjectsId(w, r, id)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// GetProjectsId operation middleware
+func (siw *ServerInterfaceWrapper) GetProjectsId(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id int
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", r.PathValue("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetProjectsId(w, r, id)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// PatchProjectsId operation middleware
+func (siw *ServerInterfaceWrapper) PatchProjectsId(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id int
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", r.PathValue("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PatchProjectsId(w, r, id)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// GetRates operation middleware
+func (siw *ServerInterfaceWrapper) GetRates(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetRatesParams
+
+	// ------------- Optional query parameter "userId" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "userId", r.URL.Query(), &params.UserId)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "userId", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "taskId" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "taskId", r.URL.Query(), &params.TaskId)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "taskId", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetRates(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// PostRates operation middleware
+func (siw *ServerInterfaceWrapper) PostRates(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostRates(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// DeleteRatesId operation middleware
+func (siw *ServerInterfaceWrapper) DeleteRatesId(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id int
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", r.PathValue("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.DeleteRatesId(w, r, id)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// GetSchedules operation middleware
+func (siw *ServerInterfaceWrapper) GetSchedules(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetSchedulesParams
+
+	// ------------- Optional query parameter "userId" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "userId", r.URL.Query(), &params.UserId)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "userId", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetSchedules(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// PostSchedules operation middleware
+func (siw *ServerInterfaceWrapper) PostSchedules(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostSchedules(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// DeleteSchedulesId operation middleware
+func (siw *ServerInterfaceWrapper) DeleteSchedulesId(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id int
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", r.PathValue("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.DeleteSchedulesId(w, r, id)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// GetSubscriptions operation middleware
+func (siw *ServerInterfaceWrapper) GetSubscriptions(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetSubscriptions(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// PostSubscriptions operation middleware
+func (siw *ServerInterfaceWrapper) PostSubscriptions(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostSubscriptions(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// DeleteSubscriptionsId operation middleware
+func (siw *ServerInterfaceWrapper) DeleteSubscriptionsId(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id int
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", r.PathValue("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.DeleteSubscriptionsId(w, r, id)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// GetTags operation middleware
+func (siw *ServerInterfaceWrapper) GetTags(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetTagsParams
+
+	// ------------- Optional query parameter "cursor" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "cursor", r.URL.Query(), &params.Cursor)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "cursor", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "offset" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "offset", r.URL.Query(), &params.Offset)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "offset", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetTags(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// PostTags operation middleware
+func (siw *ServerInterfaceWrapper) PostTags(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostTags(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// DeleteTagsId operation middleware
+func (siw *ServerInterfaceWrapper) DeleteTagsId(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id int
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", r.PathValue("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.DeleteTagsId(w, r, id)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// GetTagsId operation middleware
+func (siw *ServerInterfaceWrapper) GetTagsId(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id int
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", r.PathValue("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetTagsId(w, r, id)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// PatchTagsId operation middleware
+func (siw *ServerInterfaceWrapper) PatchTagsId(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id int
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", r.PathValue("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PatchTagsId(w, r, id)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// GetTaskTemplates operation middleware
+func (siw *ServerInterfaceWrapper) GetTaskTemplates(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetTaskTemplates(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// PostTaskTemplates operation middleware
+func (siw *ServerInterfaceWrapper) PostTaskTemplates(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostTaskTemplates(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// DeleteTaskTemplatesId operation middleware
+func (siw *ServerInterfaceWrapper) DeleteTaskTemplatesId(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id int
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", r.PathValue("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.DeleteTaskTemplatesId(w, r, id)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// GetTaskTemplatesId operation middleware
+func (siw *ServerInterfaceWrapper) GetTaskTemplatesId(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id int
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", r.PathValue("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetTaskTemplatesId(w, r, id)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// GetTasks operation middleware
+func (siw *ServerInterfaceWrapper) GetTasks(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetTasksParams
+
+	// ------------- Optional query parameter "q" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "q", r.URL.Query(), &params.Q)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "q", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "projectId" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "projectId", r.URL.Query(), &params.ProjectId)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "projectId", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "assigneeId" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "assigneeId", r.URL.Query(), &params.AssigneeId)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "assigneeId", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "tagId" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "tagId", r.URL.Query(), &params.TagId)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "tagId", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "parentId" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "parentId", r.URL.Query(), &params.ParentId)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "parentId", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "fieldId" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "fieldId", r.URL.Query(), &params.FieldId)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "fieldId", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "fieldValue" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "fieldValue", r.URL.Query(), &params.FieldValue)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "fieldValue", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "status" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "status", r.URL.Query(), &params.Status)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "status", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "cursor" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "cursor", r.URL.Query(), &params.Cursor)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "cursor", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "offset" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "offset", r.URL.Query(), &params.Offset)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "offset", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetTasks(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// PostTasks operation middleware
+func (siw *ServerInterfaceWrapper) PostTasks(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostTasks(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// PostTasksBatch operation middleware
+func (siw *ServerInterfaceWrapper) PostTasksBatch(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostTasksBatch(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// DeleteTasksId operation middleware
+func (siw *ServerInterfaceWrapper) DeleteTasksId(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id int
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", r.PathValue("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params DeleteTasksIdParams
+
+	// ------------- Optional query parameter "permanent" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "permanent", r.URL.Query(), &params.Permanent)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "permanent", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "force" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "force", r.URL.Query(), &params.Force)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "force", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "children" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "children", r.URL.Query(), &params.Children)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "children", Err: err})
+		return
+	}
+
+	headers := r.Header
+
+	// ------------- Optional header parameter "If-Match" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("If-Match")]; found {
+		var IfMatch string
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "If-Match", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "If-Match", valueList[0], &IfMatch, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "If-Match", Err: err})
+			return
+		}
+
+		params.IfMatch = &IfMatch
+
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.DeleteTasksId(w, r, id, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// GetTasksId operation middleware
+func (siw *ServerInterfaceWrapper) GetTasksId(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id int
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", r.PathValue("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetTasksId(w, r, id)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// PatchTasksId operation middleware
+func (siw *ServerInterfaceWrapper) PatchTasksId(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id int
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", r.PathValue("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params PatchTasksIdParams
+
+	headers := r.Header
+
+	// ------------- Optional header parameter "If-Match" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("If-Match")]; found {
+		var IfMatch string
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "If-Match", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "If-Match", valueList[0], &IfMatch, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "If-Match", Err: err})
+			return
+		}
+
+		params.IfMatch = &IfMatch
+
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PatchTasksId(w, r, id, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// GetTasksIdActivity operation middleware
+func (siw *ServerInterfaceWrapper) GetTasksIdActivity(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id int
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", r.PathValue("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetTasksIdActivityParams
+
+	// ------------- Optional query parameter "cursor" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "cursor", r.URL.Query(), &params.Cursor)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "cursor", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "offset" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "offset", r.URL.Query(), &params.Offset)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "offset", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetTasksIdActivity(w, r, id, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// GetTasksIdChildren operation middleware
+func (siw *ServerInterfaceWrapper) GetTasksIdChildren(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id int
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", r.PathValue("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetTasksIdChildrenParams
+
+	// ------------- Optional query parameter "status" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "status", r.URL.Query(), &params.Status)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "status", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "cursor" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "cursor", r.URL.Query(), &params.Cursor)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "cursor", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "offset" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "offset", r.URL.Query(), &params.Offset)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "offset", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetTasksIdChildren(w, r, id, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// GetTasksIdComments operation middleware
+func (siw *ServerInterfaceWrapper) GetTasksIdComments(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id int
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", r.PathValue("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetTasksIdCommentsParams
+
+	// ------------- Optional query parameter "cursor" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "cursor", r.URL.Query(), &params.Cursor)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "cursor", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "offset" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "offset", r.URL.Query(), &params.Offset)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "offset", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetTasksIdComments(w, r, id, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// PostTasksIdComments operation middleware
+func (siw *ServerInterfaceWrapper) PostTasksIdComments(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id int
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", r.PathValue("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostTasksIdComments(w, r, id)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// GetTasksIdProgress operation middleware
+func (siw *ServerInterfaceWrapper) GetTasksIdProgress(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id int
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", r.PathValue("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetTasksIdProgress(w, r, id)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// PostTasksIdRestore operation middleware
+func (siw *ServerInterfaceWrapper) PostTasksIdRestore(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id int
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", r.PathValue("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostTasksIdRestore(w, r, id)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// PostTasksIdStart operation middleware
+func (siw *ServerInterfaceWrapper) PostTasksIdStart(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id int
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", r.PathValue("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostTasksIdStart(w, r, id)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// PostTasksIdStop operation middleware
+func (siw *ServerInterfaceWrapper) PostTasksIdStop(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id int
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", r.PathValue("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostTasksIdStop(w, r, id)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// GetTimesheets operation middleware
+func (siw *ServerInterfaceWrapper) GetTimesheets(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetTimesheetsParams
+
+	// ------------- Optional query parameter "cursor" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "cursor", r.URL.Query(), &params.Cursor)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "cursor", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "offset" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "offset", r.URL.Query(), &params.Offset)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "offset", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetTimesheets(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// PostTimesheets operation middleware
+func (siw *ServerInterfaceWrapper) PostTimesheets(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostTimesheets(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// GetTimesheetsQueue operation middleware
+func (siw *ServerInterfaceWrapper) GetTimesheetsQueue(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetTimesheetsQueueParams
+
+	// ------------- Optional query parameter "cursor" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "cursor", r.URL.Query(), &params.Cursor)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "cursor", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "offset" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "offset", r.URL.Query(), &params.Offset)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "offset", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetTimesheetsQueue(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// GetTimesheetsId operation middleware
+func (siw *ServerInterfaceWrapper) GetTimesheetsId(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id int
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", r.PathValue("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetTimesheetsId(w, r, id)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// PostTimesheetsIdApprove operation middleware
+func (siw *ServerInterfaceWrapper) PostTimesheetsIdApprove(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id int
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", r.PathValue("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostTimesheetsIdApprove(w, r, id)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// PostTimesheetsIdReject operation middleware
+func (siw *ServerInterfaceWrapper) PostTimesheetsIdReject(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id int
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", r.PathValue("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostTimesheetsIdReject(w, r, id)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// PostTimesheetsIdSubmit operation middleware
+func (siw *ServerInterfaceWrapper) PostTimesheetsIdSubmit(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id int
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", r.PathValue("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.GetHealth(w, r)
+		siw.Handler.PostTimesheetsIdSubmit(w, r, id)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -240,8 +3769,8 @@ func (siw *ServerInterfaceWrapper) GetHealth(w http.ResponseWriter, r *http.Requ
 	handler.ServeHTTP(w, r.WithContext(ctx))
 }
 
-// GetTasks operation middleware
-func (siw *ServerInterfaceWrapper) GetTasks(w http.ResponseWriter, r *http.Request) {
+// GetUsers operation middleware
+func (siw *ServerInterfaceWrapper) GetUsers(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
 	var err error
@@ -249,7 +3778,31 @@ func (siw *ServerInterfaceWrapper) GetTasks(w http.ResponseWriter, r *http.Reque
 	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
 
 	// Parameter object where we will unmarshal all parameters from the context
-	var params GetTasksParams
+	var params GetUsersParams
+
+	// ------------- Optional query parameter "filter" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "filter", r.URL.Query(), &params.Filter)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "filter", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "sort" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "sort", r.URL.Query(), &params.Sort)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "sort", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "cursor" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "cursor", r.URL.Query(), &params.Cursor)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "cursor", Err: err})
+		return
+	}
 
 	// ------------- Optional query parameter "offset" -------------
 
@@ -268,7 +3821,7 @@ func (siw *ServerInterfaceWrapper) GetTasks(w http.ResponseWriter, r *http.Reque
 	}
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.GetTasks(w, r, params)
+		siw.Handler.GetUsers(w, r, params)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -278,12 +3831,12 @@ func (siw *ServerInterfaceWrapper) GetTasks(w http.ResponseWriter, r *http.Reque
 	handler.ServeHTTP(w, r.WithContext(ctx))
 }
 
-// PostTasks operation middleware
-func (siw *ServerInterfaceWrapper) PostTasks(w http.ResponseWriter, r *http.Request) {
+// PostUsers operation middleware
+func (siw *ServerInterfaceWrapper) PostUsers(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.PostTasks(w, r)
+		siw.Handler.PostUsers(w, r)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -293,8 +3846,25 @@ func (siw *ServerInterfaceWrapper) PostTasks(w http.ResponseWriter, r *http.Requ
 	handler.ServeHTTP(w, r.WithContext(ctx))
 }
 
-// DeleteTasksId operation middleware
-func (siw *ServerInterfaceWrapper) DeleteTasksId(w http.ResponseWriter, r *http.Request) {
+// GetUsersCurrent operation middleware
+func (siw *ServerInterfaceWrapper) GetUsersCurrent(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetUsersCurrent(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// DeleteUsersId operation middleware
+func (siw *ServerInterfaceWrapper) DeleteUsersId(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
 	var err error
@@ -310,8 +3880,40 @@ func (siw *ServerInterfaceWrapper) DeleteTasksId(w http.ResponseWriter, r *http.
 
 	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
 
+	// Parameter object where we will unmarshal all parameters from the context
+	var params DeleteUsersIdParams
+
+	// ------------- Optional query parameter "force" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "force", r.URL.Query(), &params.Force)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "force", Err: err})
+		return
+	}
+
+	headers := r.Header
+
+	// ------------- Optional header parameter "If-Match" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("If-Match")]; found {
+		var IfMatch string
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "If-Match", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "If-Match", valueList[0], &IfMatch, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "If-Match", Err: err})
+			return
+		}
+
+		params.IfMatch = &IfMatch
+
+	}
+
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.DeleteTasksId(w, r, id)
+		siw.Handler.DeleteUsersId(w, r, id, params)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -321,8 +3923,8 @@ func (siw *ServerInterfaceWrapper) DeleteTasksId(w http.ResponseWriter, r *http.
 	handler.ServeHTTP(w, r.WithContext(ctx))
 }
 
-// GetTasksId operation middleware
-func (siw *ServerInterfaceWrapper) GetTasksId(w http.ResponseWriter, r *http.Request) {
+// GetUsersId operation middleware
+func (siw *ServerInterfaceWrapper) GetUsersId(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
 	var err error
@@ -339,7 +3941,7 @@ func (siw *ServerInterfaceWrapper) GetTasksId(w http.ResponseWriter, r *http.Req
 	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.GetTasksId(w, r, id)
+		siw.Handler.GetUsersId(w, r, id)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -349,8 +3951,8 @@ func (siw *ServerInterfaceWrapper) GetTasksId(w http.ResponseWriter, r *http.Req
 	handler.ServeHTTP(w, r.WithContext(ctx))
 }
 
-// PatchTasksId operation middleware
-func (siw *ServerInterfaceWrapper) PatchTasksId(w http.ResponseWriter, r *http.Request) {
+// PatchUsersId operation middleware
+func (siw *ServerInterfaceWrapper) PatchUsersId(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
 	var err error
@@ -366,8 +3968,32 @@ func (siw *ServerInterfaceWrapper) PatchTasksId(w http.ResponseWriter, r *http.R
 
 	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
 
+	// Parameter object where we will unmarshal all parameters from the context
+	var params PatchUsersIdParams
+
+	headers := r.Header
+
+	// ------------- Optional header parameter "If-Match" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("If-Match")]; found {
+		var IfMatch string
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "If-Match", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "If-Match", valueList[0], &IfMatch, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "If-Match", Err: err})
+			return
+		}
+
+		params.IfMatch = &IfMatch
+
+	}
+
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.PatchTasksId(w, r, id)
+		siw.Handler.PatchUsersId(w, r, id, params)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -377,8 +4003,8 @@ func (siw *ServerInterfaceWrapper) PatchTasksId(w http.ResponseWriter, r *http.R
 	handler.ServeHTTP(w, r.WithContext(ctx))
 }
 
-// PostTasksIdStart operation middleware
-func (siw *ServerInterfaceWrapper) PostTasksIdStart(w http.ResponseWriter, r *http.Request) {
+// GetUsersIdOvertime operation middleware
+func (siw *ServerInterfaceWrapper) GetUsersIdOvertime(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
 	var err error
@@ -394,8 +4020,49 @@ func (siw *ServerInterfaceWrapper) PostTasksIdStart(w http.ResponseWriter, r *ht
 
 	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
 
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetUsersIdOvertimeParams
+
+	// ------------- Required query parameter "from" -------------
+
+	if paramValue := r.URL.Query().Get("from"); paramValue != "" {
+
+	} else {
+		siw.ErrorHandlerFunc(w, r, &RequiredParamError{ParamName: "from"})
+		return
+	}
+
+	err = runtime.BindQueryParameter("form", true, true, "from", r.URL.Query(), &params.From)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "from", Err: err})
+		return
+	}
+
+	// ------------- Required query parameter "to" -------------
+
+	if paramValue := r.URL.Query().Get("to"); paramValue != "" {
+
+	} else {
+		siw.ErrorHandlerFunc(w, r, &RequiredParamError{ParamName: "to"})
+		return
+	}
+
+	err = runtime.BindQueryParameter("form", true, true, "to", r.URL.Query(), &params.To)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "to", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "group_by" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "group_by", r.URL.Query(), &params.GroupBy)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "group_by", Err: err})
+		return
+	}
+
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.PostTasksIdStart(w, r, id)
+		siw.Handler.GetUsersIdOvertime(w, r, id, params)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -405,8 +4072,8 @@ func (siw *ServerInterfaceWrapper) PostTasksIdStart(w http.ResponseWriter, r *ht
 	handler.ServeHTTP(w, r.WithContext(ctx))
 }
 
-// PostTasksIdStop operation middleware
-func (siw *ServerInterfaceWrapper) PostTasksIdStop(w http.ResponseWriter, r *http.Request) {
+// GetUsersIdReport operation middleware
+func (siw *ServerInterfaceWrapper) GetUsersIdReport(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
 	var err error
@@ -422,69 +4089,97 @@ func (siw *ServerInterfaceWrapper) PostTasksIdStop(w http.ResponseWriter, r *htt
 
 	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
 
-	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.PostTasksIdStop(w, r, id)
-	}))
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetUsersIdReportParams
 
-	for _, middleware := range siw.HandlerMiddlewares {
-		handler = middleware(handler)
+	// ------------- Required query parameter "from" -------------
+
+	if paramValue := r.URL.Query().Get("from"); paramValue != "" {
+
+	} else {
+		siw.ErrorHandlerFunc(w, r, &RequiredParamError{ParamName: "from"})
+		return
 	}
 
-	handler.ServeHTTP(w, r.WithContext(ctx))
-}
+	err = runtime.BindQueryParameter("form", true, true, "from", r.URL.Query(), &params.From)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "from", Err: err})
+		return
+	}
 
-// GetUsers operation middleware
-func (siw *ServerInterfaceWrapper) GetUsers(w http.ResponseWriter, r *http.Request) {
-	ctx := r.Context()
+	// ------------- Required query parameter "to" -------------
 
-	var err error
+	if paramValue := r.URL.Query().Get("to"); paramValue != "" {
 
-	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+	} else {
+		siw.ErrorHandlerFunc(w, r, &RequiredParamError{ParamName: "to"})
+		return
+	}
 
-	// Parameter object where we will unmarshal all parameters from the context
-	var params GetUsersParams
+	err = runtime.BindQueryParameter("form", true, true, "to", r.URL.Query(), &params.To)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "to", Err: err})
+		return
+	}
 
-	// ------------- Optional query parameter "filter" -------------
+	// ------------- Optional query parameter "group_by" -------------
 
-	err = runtime.BindQueryParameter("form", true, false, "filter", r.URL.Query(), &params.Filter)
+	err = runtime.BindQueryParameter("form", true, false, "group_by", r.URL.Query(), &params.GroupBy)
 	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "filter", Err: err})
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "group_by", Err: err})
 		return
 	}
 
-	// ------------- Optional query parameter "offset" -------------
+	// ------------- Optional query parameter "rounding" -------------
 
-	err = runtime.BindQueryParameter("form", true, false, "offset", r.URL.Query(), &params.Offset)
+	err = runtime.BindQueryParameter("form", true, false, "rounding", r.URL.Query(), &params.Rounding)
 	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "offset", Err: err})
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "rounding", Err: err})
 		return
 	}
 
-	// ------------- Optional query parameter "limit" -------------
+	// ------------- Optional query parameter "rounding_minutes" -------------
 
-	err = runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit)
+	err = runtime.BindQueryParameter("form", true, false, "rounding_minutes", r.URL.Query(), &params.RoundingMinutes)
 	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "rounding_minutes", Err: err})
 		return
 	}
 
-	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.GetUsers(w, r, params)
-	}))
+	// ------------- Optional query parameter "rounding_scope" -------------
 
-	for _, middleware := range siw.HandlerMiddlewares {
-		handler = middleware(handler)
+	err = runtime.BindQueryParameter("form", true, false, "rounding_scope", r.URL.Query(), &params.RoundingScope)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "rounding_scope", Err: err})
+		return
 	}
 
-	handler.ServeHTTP(w, r.WithContext(ctx))
-}
+	// ------------- Optional query parameter "compare_to" -------------
 
-// PostUsers operation middleware
-func (siw *ServerInterfaceWrapper) PostUsers(w http.ResponseWriter, r *http.Request) {
-	ctx := r.Context()
+	err = runtime.BindQueryParameter("form", true, false, "compare_to", r.URL.Query(), &params.CompareTo)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "compare_to", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "include_subtasks" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "include_subtasks", r.URL.Query(), &params.IncludeSubtasks)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "include_subtasks", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "field_id" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "field_id", r.URL.Query(), &params.FieldId)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "field_id", Err: err})
+		return
+	}
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.PostUsers(w, r)
+		siw.Handler.GetUsersIdReport(w, r, id, params)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -494,14 +4189,25 @@ func (siw *ServerInterfaceWrapper) PostUsers(w http.ResponseWriter, r *http.Requ
 	handler.ServeHTTP(w, r.WithContext(ctx))
 }
 
-// GetUsersCurrent operation middleware
-func (siw *ServerInterfaceWrapper) GetUsersCurrent(w http.ResponseWriter, r *http.Request) {
+// PostUsersIdReport operation middleware
+func (siw *ServerInterfaceWrapper) PostUsersIdReport(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id int
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", r.PathValue("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
 	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.GetUsersCurrent(w, r)
+		siw.Handler.PostUsersIdReport(w, r, id)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -511,8 +4217,8 @@ func (siw *ServerInterfaceWrapper) GetUsersCurrent(w http.ResponseWriter, r *htt
 	handler.ServeHTTP(w, r.WithContext(ctx))
 }
 
-// DeleteUsersId operation middleware
-func (siw *ServerInterfaceWrapper) DeleteUsersId(w http.ResponseWriter, r *http.Request) {
+// PostUsersIdRestore operation middleware
+func (siw *ServerInterfaceWrapper) PostUsersIdRestore(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
 	var err error
@@ -529,7 +4235,7 @@ func (siw *ServerInterfaceWrapper) DeleteUsersId(w http.ResponseWriter, r *http.
 	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.DeleteUsersId(w, r, id)
+		siw.Handler.PostUsersIdRestore(w, r, id)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -539,25 +4245,75 @@ func (siw *ServerInterfaceWrapper) DeleteUsersId(w http.ResponseWriter, r *http.
 	handler.ServeHTTP(w, r.WithContext(ctx))
 }
 
-// GetUsersId operation middleware
-func (siw *ServerInterfaceWrapper) GetUsersId(w http.ResponseWriter, r *http.Request) {
+// GetWorks operation middleware
+func (siw *ServerInterfaceWrapper) GetWorks(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
 	var err error
 
-	// ------------- Path parameter "id" -------------
-	var id int
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
 
-	err = runtime.BindStyledParameterWithOptions("simple", "id", r.PathValue("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetWorksParams
+
+	// ------------- Optional query parameter "from" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "from", r.URL.Query(), &params.From)
 	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "from", Err: err})
 		return
 	}
 
-	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+	// ------------- Optional query parameter "to" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "to", r.URL.Query(), &params.To)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "to", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "taskId" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "taskId", r.URL.Query(), &params.TaskId)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "taskId", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "tagId" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "tagId", r.URL.Query(), &params.TagId)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "tagId", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "cursor" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "cursor", r.URL.Query(), &params.Cursor)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "cursor", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "offset" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "offset", r.URL.Query(), &params.Offset)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "offset", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
+		return
+	}
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.GetUsersId(w, r, id)
+		siw.Handler.GetWorks(w, r, params)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -567,8 +4323,8 @@ func (siw *ServerInterfaceWrapper) GetUsersId(w http.ResponseWriter, r *http.Req
 	handler.ServeHTTP(w, r.WithContext(ctx))
 }
 
-// PatchUsersId operation middleware
-func (siw *ServerInterfaceWrapper) PatchUsersId(w http.ResponseWriter, r *http.Request) {
+// DeleteWorksId operation middleware
+func (siw *ServerInterfaceWrapper) DeleteWorksId(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
 	var err error
@@ -585,7 +4341,7 @@ func (siw *ServerInterfaceWrapper) PatchUsersId(w http.ResponseWriter, r *http.R
 	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.PatchUsersId(w, r, id)
+		siw.Handler.DeleteWorksId(w, r, id)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -595,8 +4351,8 @@ func (siw *ServerInterfaceWrapper) PatchUsersId(w http.ResponseWriter, r *http.R
 	handler.ServeHTTP(w, r.WithContext(ctx))
 }
 
-// PostUsersIdReport operation middleware
-func (siw *ServerInterfaceWrapper) PostUsersIdReport(w http.ResponseWriter, r *http.Request) {
+// PatchWorksId operation middleware
+func (siw *ServerInterfaceWrapper) PatchWorksId(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
 	var err error
@@ -613,7 +4369,7 @@ func (siw *ServerInterfaceWrapper) PostUsersIdReport(w http.ResponseWriter, r *h
 	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.PostUsersIdReport(w, r, id)
+		siw.Handler.PatchWorksId(w, r, id)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -738,21 +4494,85 @@ func HandlerWithOptions(si ServerInterface, options StdHTTPServerOptions) http.H
 	}
 
 	m.HandleFunc("POST "+options.BaseURL+"/auth", wrapper.PostAuth)
+	m.HandleFunc("GET "+options.BaseURL+"/budget-events/", wrapper.GetBudgetEvents)
+	m.HandleFunc("GET "+options.BaseURL+"/clients/", wrapper.GetClients)
+	m.HandleFunc("POST "+options.BaseURL+"/clients/", wrapper.PostClients)
+	m.HandleFunc("DELETE "+options.BaseURL+"/clients/{id}", wrapper.DeleteClientsId)
+	m.HandleFunc("GET "+options.BaseURL+"/clients/{id}", wrapper.GetClientsId)
+	m.HandleFunc("PATCH "+options.BaseURL+"/clients/{id}", wrapper.PatchClientsId)
+	m.HandleFunc("DELETE "+options.BaseURL+"/comments/{id}", wrapper.DeleteCommentsId)
+	m.HandleFunc("PATCH "+options.BaseURL+"/comments/{id}", wrapper.PatchCommentsId)
+	m.HandleFunc("GET "+options.BaseURL+"/custom-fields/", wrapper.GetCustomFields)
+	m.HandleFunc("POST "+options.BaseURL+"/custom-fields/", wrapper.PostCustomFields)
+	m.HandleFunc("DELETE "+options.BaseURL+"/custom-fields/{id}", wrapper.DeleteCustomFieldsId)
+	m.HandleFunc("GET "+options.BaseURL+"/custom-fields/{id}", wrapper.GetCustomFieldsId)
+	m.HandleFunc("PATCH "+options.BaseURL+"/custom-fields/{id}", wrapper.PatchCustomFieldsId)
 	m.HandleFunc("GET "+options.BaseURL+"/health", wrapper.GetHealth)
+	m.HandleFunc("GET "+options.BaseURL+"/holidays/", wrapper.GetHolidays)
+	m.HandleFunc("POST "+options.BaseURL+"/holidays/", wrapper.PostHolidays)
+	m.HandleFunc("DELETE "+options.BaseURL+"/holidays/{id}", wrapper.DeleteHolidaysId)
+	m.HandleFunc("GET "+options.BaseURL+"/invoices/", wrapper.GetInvoices)
+	m.HandleFunc("POST "+options.BaseURL+"/invoices/", wrapper.PostInvoices)
+	m.HandleFunc("DELETE "+options.BaseURL+"/invoices/{id}", wrapper.DeleteInvoicesId)
+	m.HandleFunc("GET "+options.BaseURL+"/invoices/{id}", wrapper.GetInvoicesId)
+	m.HandleFunc("POST "+options.BaseURL+"/invoices/{id}/issue", wrapper.PostInvoicesIdIssue)
+	m.HandleFunc("GET "+options.BaseURL+"/projects/", wrapper.GetProjects)
+	m.HandleFunc("POST "+options.BaseURL+"/projects/", wrapper.PostProjects)
+	m.HandleFunc("DELETE "+options.BaseURL+"/projects/{id}", wrapper.DeleteProjectsId)
+	m.HandleFunc("GET "+options.BaseURL+"/projects/{id}", wrapper.GetProjectsId)
+	m.HandleFunc("PATCH "+options.BaseURL+"/projects/{id}", wrapper.PatchProjectsId)
+	m.HandleFunc("GET "+options.BaseURL+"/rates/", wrapper.GetRates)
+	m.HandleFunc("POST "+options.BaseURL+"/rates/", wrapper.PostRates)
+	m.HandleFunc("DELETE "+options.BaseURL+"/rates/{id}", wrapper.DeleteRatesId)
+	m.HandleFunc("GET "+options.BaseURL+"/schedules/", wrapper.GetSchedules)
+	m.HandleFunc("POST "+options.BaseURL+"/schedules/", wrapper.PostSchedules)
+	m.HandleFunc("DELETE "+options.BaseURL+"/schedules/{id}", wrapper.DeleteSchedulesId)
+	m.HandleFunc("GET "+options.BaseURL+"/subscriptions/", wrapper.GetSubscriptions)
+	m.HandleFunc("POST "+options.BaseURL+"/subscriptions/", wrapper.PostSubscriptions)
+	m.HandleFunc("DELETE "+options.BaseURL+"/subscriptions/{id}", wrapper.DeleteSubscriptionsId)
+	m.HandleFunc("GET "+options.BaseURL+"/tags/", wrapper.GetTags)
+	m.HandleFunc("POST "+options.BaseURL+"/tags/", wrapper.PostTags)
+	m.HandleFunc("DELETE "+options.BaseURL+"/tags/{id}", wrapper.DeleteTagsId)
+	m.HandleFunc("GET "+options.BaseURL+"/tags/{id}", wrapper.GetTagsId)
+	m.HandleFunc("PATCH "+options.BaseURL+"/tags/{id}", wrapper.PatchTagsId)
+	m.HandleFunc("GET "+options.BaseURL+"/task-templates/", wrapper.GetTaskTemplates)
+	m.HandleFunc("POST "+options.BaseURL+"/task-templates/", wrapper.PostTaskTemplates)
+	m.HandleFunc("DELETE "+options.BaseURL+"/task-templates/{id}", wrapper.DeleteTaskTemplatesId)
+	m.HandleFunc("GET "+options.BaseURL+"/task-templates/{id}", wrapper.GetTaskTemplatesId)
 	m.HandleFunc("GET "+options.BaseURL+"/tasks/", wrapper.GetTasks)
 	m.HandleFunc("POST "+options.BaseURL+"/tasks/", wrapper.PostTasks)
+	m.HandleFunc("POST "+options.BaseURL+"/tasks/batch", wrapper.PostTasksBatch)
 	m.HandleFunc("DELETE "+options.BaseURL+"/tasks/{id}", wrapper.DeleteTasksId)
 	m.HandleFunc("GET "+options.BaseURL+"/tasks/{id}", wrapper.GetTasksId)
 	m.HandleFunc("PATCH "+options.BaseURL+"/tasks/{id}", wrapper.PatchTasksId)
+	m.HandleFunc("GET "+options.BaseURL+"/tasks/{id}/activity", wrapper.GetTasksIdActivity)
+	m.HandleFunc("GET "+options.BaseURL+"/tasks/{id}/children", wrapper.GetTasksIdChildren)
+	m.HandleFunc("GET "+options.BaseURL+"/tasks/{id}/comments", wrapper.GetTasksIdComments)
+	m.HandleFunc("POST "+options.BaseURL+"/tasks/{id}/comments", wrapper.PostTasksIdComments)
+	m.HandleFunc("GET "+options.BaseURL+"/tasks/{id}/progress", wrapper.GetTasksIdProgress)
+	m.HandleFunc("POST "+options.BaseURL+"/tasks/{id}/restore", wrapper.PostTasksIdRestore)
 	m.HandleFunc("POST "+options.BaseURL+"/tasks/{id}/start", wrapper.PostTasksIdStart)
 	m.HandleFunc("POST "+options.BaseURL+"/tasks/{id}/stop", wrapper.PostTasksIdStop)
+	m.HandleFunc("GET "+options.BaseURL+"/timesheets/", wrapper.GetTimesheets)
+	m.HandleFunc("POST "+options.BaseURL+"/timesheets/", wrapper.PostTimesheets)
+	m.HandleFunc("GET "+options.BaseURL+"/timesheets/queue", wrapper.GetTimesheetsQueue)
+	m.HandleFunc("GET "+options.BaseURL+"/timesheets/{id}", wrapper.GetTimesheetsId)
+	m.HandleFunc("POST "+options.BaseURL+"/timesheets/{id}/approve", wrapper.PostTimesheetsIdApprove)
+	m.HandleFunc("POST "+options.BaseURL+"/timesheets/{id}/reject", wrapper.PostTimesheetsIdReject)
+	m.HandleFunc("POST "+options.BaseURL+"/timesheets/{id}/submit", wrapper.PostTimesheetsIdSubmit)
 	m.HandleFunc("GET "+options.BaseURL+"/users/", wrapper.GetUsers)
 	m.HandleFunc("POST "+options.BaseURL+"/users/", wrapper.PostUsers)
 	m.HandleFunc("GET "+options.BaseURL+"/users/current", wrapper.GetUsersCurrent)
 	m.HandleFunc("DELETE "+options.BaseURL+"/users/{id}", wrapper.DeleteUsersId)
 	m.HandleFunc("GET "+options.BaseURL+"/users/{id}", wrapper.GetUsersId)
 	m.HandleFunc("PATCH "+options.BaseURL+"/users/{id}", wrapper.PatchUsersId)
+	m.HandleFunc("GET "+options.BaseURL+"/users/{id}/overtime", wrapper.GetUsersIdOvertime)
+	m.HandleFunc("GET "+options.BaseURL+"/users/{id}/report", wrapper.GetUsersIdReport)
 	m.HandleFunc("POST "+options.BaseURL+"/users/{id}/report", wrapper.PostUsersIdReport)
+	m.HandleFunc("POST "+options.BaseURL+"/users/{id}/restore", wrapper.PostUsersIdRestore)
+	m.HandleFunc("GET "+options.BaseURL+"/works/", wrapper.GetWorks)
+	m.HandleFunc("DELETE "+options.BaseURL+"/works/{id}", wrapper.DeleteWorksId)
+	m.HandleFunc("PATCH "+options.BaseURL+"/works/{id}", wrapper.PatchWorksId)
 
 	return m
 }
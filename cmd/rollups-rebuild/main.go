@@ -0,0 +1,48 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+
+	"github.com/kirillgashkov/timetrack/internal/app/config"
+	"github.com/kirillgashkov/timetrack/internal/app/database"
+	"github.com/kirillgashkov/timetrack/internal/app/logging"
+	"github.com/kirillgashkov/timetrack/internal/rollup"
+)
+
+func main() {
+	if err := mainErr(); err != nil {
+		_, _ = fmt.Fprintf(os.Stderr, "error: %s\n", err.Error())
+		os.Exit(1)
+	}
+	os.Exit(0)
+}
+
+func mainErr() error {
+	ctx := context.Background()
+
+	cfg, err := config.New()
+	if err != nil {
+		return errors.Join(errors.New("failed to create config"), err)
+	}
+
+	logger := logging.NewLogger(cfg)
+	slog.SetDefault(logger)
+
+	db, err := database.NewPool(ctx, cfg)
+	if err != nil {
+		return errors.Join(errors.New("failed to create database pool"), err)
+	}
+	defer db.Close()
+
+	n, err := rollup.Rebuild(ctx, db)
+	if err != nil {
+		return errors.Join(errors.New("failed to rebuild rollups"), err)
+	}
+
+	slog.Info("rebuilt rollups", "rollups", n)
+	return nil
+}
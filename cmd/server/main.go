@@ -14,8 +14,18 @@ import (
 	"github.com/kirillgashkov/timetrack/internal/app/database"
 	"github.com/kirillgashkov/timetrack/internal/app/logging"
 	"github.com/kirillgashkov/timetrack/internal/auth"
+	"github.com/kirillgashkov/timetrack/internal/billing"
+	"github.com/kirillgashkov/timetrack/internal/client"
+	"github.com/kirillgashkov/timetrack/internal/field"
+	"github.com/kirillgashkov/timetrack/internal/invoicing"
+	"github.com/kirillgashkov/timetrack/internal/project"
+	"github.com/kirillgashkov/timetrack/internal/recurring"
 	"github.com/kirillgashkov/timetrack/internal/reporting"
+	"github.com/kirillgashkov/timetrack/internal/schedule"
+	"github.com/kirillgashkov/timetrack/internal/subscription"
+	"github.com/kirillgashkov/timetrack/internal/tag"
 	"github.com/kirillgashkov/timetrack/internal/task"
+	"github.com/kirillgashkov/timetrack/internal/timesheet"
 	"github.com/kirillgashkov/timetrack/internal/tracking"
 	"github.com/kirillgashkov/timetrack/internal/user"
 )
@@ -53,18 +63,46 @@ func mainErr() error {
 	}
 
 	authService := auth.NewServiceImpl(db)
+	billingService := billing.NewServiceImpl(db)
+	clientService := client.NewServiceImpl(db)
+	fieldService := field.NewServiceImpl(db)
+	invoicingService := invoicing.NewServiceImpl(db)
+	projectService := project.NewServiceImpl(db)
+	recurringService := recurring.NewServiceImpl(db)
 	reportingService := reporting.NewServiceImpl(db)
+	scheduleService := schedule.NewServiceImpl(db)
+	subscriptionService := subscription.NewServiceImpl(db)
+	tagService := tag.NewServiceImpl(db)
 	taskService := task.NewServiceImpl(db)
+	timesheetService := timesheet.NewServiceImpl(db)
 	trackingService := tracking.NewServiceImpl(db)
 	userService := user.NewServiceImpl(db, peopleInfoService)
 
 	srv, err := api.NewServer(
-		&cfg.Server, authService, reportingService, taskService, trackingService, userService,
+		&cfg.Server,
+		authService,
+		billingService,
+		clientService,
+		fieldService,
+		invoicingService,
+		projectService,
+		recurringService,
+		reportingService,
+		scheduleService,
+		subscriptionService,
+		tagService,
+		taskService,
+		timesheetService,
+		trackingService,
+		userService,
 	)
 	if err != nil {
 		return errors.Join(errors.New("failed to create server"), err)
 	}
 
+	generator := recurring.NewGenerator(db, time.Now)
+	go generator.Run(ctx, time.Duration(cfg.Server.TemplateInterval)*time.Second)
+
 	slog.Info("starting server", "addr", srv.Addr, "mode", cfg.Mode)
 	if err = srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
 		return errors.Join(errors.New("failed to listen and serve"), err)
@@ -0,0 +1,94 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/kirillgashkov/timetrack/internal/app/config"
+	"github.com/kirillgashkov/timetrack/internal/app/database"
+	"github.com/kirillgashkov/timetrack/internal/app/logging"
+	"github.com/kirillgashkov/timetrack/internal/app/mail"
+	"github.com/kirillgashkov/timetrack/internal/reporting"
+	"github.com/kirillgashkov/timetrack/internal/subscription"
+	"github.com/kirillgashkov/timetrack/internal/task"
+	"github.com/kirillgashkov/timetrack/internal/user"
+)
+
+func main() {
+	if err := mainErr(); err != nil {
+		_, _ = fmt.Fprintf(os.Stderr, "error: %s\n", err.Error())
+		os.Exit(1)
+	}
+	os.Exit(0)
+}
+
+func mainErr() error {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	cfg, err := config.New()
+	if err != nil {
+		return errors.Join(errors.New("failed to create config"), err)
+	}
+
+	logger := logging.NewLogger(cfg)
+	slog.SetDefault(logger)
+
+	db, err := database.NewPool(ctx, cfg)
+	if err != nil {
+		return errors.Join(errors.New("failed to create database pool"), err)
+	}
+	defer db.Close()
+
+	reportingService := reporting.NewServiceImpl(db)
+	mailer := mail.NewSMTPMailer(&cfg.SMTP)
+	scheduler := subscription.NewScheduler(db, reportingService, mailer)
+	taskService := task.NewServiceImpl(db)
+	// Purging users doesn't need the people info service.
+	userService := user.NewServiceImpl(db, nil)
+
+	interval := time.Duration(cfg.Worker.Interval) * time.Second
+	retention := time.Duration(cfg.Worker.Retention) * 24 * time.Hour
+	slog.Info("starting worker", "interval", interval, "retention", retention, "mode", cfg.Mode)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		delivered, runErr := scheduler.RunDue(ctx, time.Now())
+		if runErr != nil && ctx.Err() == nil {
+			slog.Error("failed to deliver reports", "error", runErr)
+		}
+		if delivered > 0 {
+			slog.Info("delivered reports", "count", delivered)
+		}
+
+		before := time.Now().Add(-retention)
+		purgedTasks, keptTasks, purgeErr := taskService.Purge(ctx, before)
+		if purgeErr != nil && ctx.Err() == nil {
+			slog.Error("failed to purge tasks", "error", purgeErr)
+		}
+		purgedUsers, keptUsers, purgeErr := userService.Purge(ctx, before)
+		if purgeErr != nil && ctx.Err() == nil {
+			slog.Error("failed to purge users", "error", purgeErr)
+		}
+		if purgedTasks > 0 || purgedUsers > 0 {
+			slog.Info("purged trash", "tasks", purgedTasks, "users", purgedUsers)
+		}
+		if len(keptTasks) > 0 || len(keptUsers) > 0 {
+			slog.Warn("kept trash that can't be purged", "tasks", keptTasks, "users", keptUsers)
+		}
+
+		select {
+		case <-ctx.Done():
+			slog.Info("stopping worker")
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,8 @@
+BEGIN;
+
+DROP INDEX IF EXISTS tasks_created_by_idx;
+DROP TABLE IF EXISTS task_assignees;
+ALTER TABLE tasks DROP COLUMN IF EXISTS visibility;
+ALTER TABLE tasks DROP COLUMN IF EXISTS created_by;
+
+COMMIT;
@@ -0,0 +1,21 @@
+BEGIN;
+
+-- Tasks are created by a user. Existing tasks have no creator and stay visible
+-- to everyone.
+ALTER TABLE tasks ADD COLUMN IF NOT EXISTS created_by integer REFERENCES users (id) ON DELETE SET NULL;
+ALTER TABLE tasks ADD COLUMN IF NOT EXISTS visibility text NOT NULL DEFAULT 'everyone'
+    CHECK (visibility IN ('private', 'project', 'everyone'));
+
+-- A task assignee is a user assigned to work on a task. Project members are the
+-- creators and the assignees of the tasks of a project.
+CREATE TABLE IF NOT EXISTS task_assignees (
+    task_id integer NOT NULL,
+    user_id integer NOT NULL,
+    PRIMARY KEY (task_id, user_id),
+    FOREIGN KEY (task_id) REFERENCES tasks (id) ON DELETE CASCADE,
+    FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE
+);
+CREATE INDEX IF NOT EXISTS task_assignees_user_id_idx ON task_assignees (user_id);
+CREATE INDEX IF NOT EXISTS tasks_created_by_idx ON tasks (created_by);
+
+COMMIT;
@@ -0,0 +1,6 @@
+BEGIN;
+
+DROP INDEX IF EXISTS tasks_status_idx;
+ALTER TABLE tasks DROP COLUMN IF EXISTS status;
+
+COMMIT;
@@ -0,0 +1,9 @@
+BEGIN;
+
+-- Tasks move between statuses, archived tasks are hidden from task lists
+-- instead of being deleted with their works.
+ALTER TABLE tasks ADD COLUMN IF NOT EXISTS status text NOT NULL DEFAULT 'open'
+    CHECK (status IN ('open', 'in_progress', 'done', 'archived'));
+CREATE INDEX IF NOT EXISTS tasks_status_idx ON tasks (status);
+
+COMMIT;
@@ -0,0 +1,7 @@
+BEGIN;
+
+DROP TABLE IF EXISTS work_tags;
+DROP TABLE IF EXISTS task_tags;
+DROP TABLE IF EXISTS tags;
+
+COMMIT;
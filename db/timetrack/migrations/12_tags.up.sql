@@ -0,0 +1,31 @@
+BEGIN;
+
+-- Tags categorize tasks and works across projects. Tag names are unique
+-- regardless of case across the installation, which serves one organization.
+CREATE TABLE IF NOT EXISTS tags (
+    id serial NOT NULL,
+    name text NOT NULL,
+    PRIMARY KEY (id)
+);
+CREATE UNIQUE INDEX IF NOT EXISTS tags_lower_name_idx ON tags (lower(name));
+
+CREATE TABLE IF NOT EXISTS task_tags (
+    task_id integer NOT NULL,
+    tag_id integer NOT NULL,
+    PRIMARY KEY (task_id, tag_id),
+    FOREIGN KEY (task_id) REFERENCES tasks (id) ON DELETE CASCADE,
+    FOREIGN KEY (tag_id) REFERENCES tags (id) ON DELETE CASCADE
+);
+CREATE INDEX IF NOT EXISTS task_tags_tag_id_idx ON task_tags (tag_id);
+
+-- A work is tagged with its own tags and the tags of its task.
+CREATE TABLE IF NOT EXISTS work_tags (
+    work_id integer NOT NULL,
+    tag_id integer NOT NULL,
+    PRIMARY KEY (work_id, tag_id),
+    FOREIGN KEY (work_id) REFERENCES works (id) ON DELETE CASCADE,
+    FOREIGN KEY (tag_id) REFERENCES tags (id) ON DELETE CASCADE
+);
+CREATE INDEX IF NOT EXISTS work_tags_tag_id_idx ON work_tags (tag_id);
+
+COMMIT;
@@ -0,0 +1,6 @@
+BEGIN;
+
+DROP INDEX IF EXISTS tasks_parent_id_idx;
+ALTER TABLE tasks DROP COLUMN IF EXISTS parent_id;
+
+COMMIT;
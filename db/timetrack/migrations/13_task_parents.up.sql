@@ -0,0 +1,8 @@
+BEGIN;
+
+-- Tasks are split into subtasks. Parents can't be deleted while they have
+-- children, the application archives, deletes, or detaches the children first.
+ALTER TABLE tasks ADD COLUMN IF NOT EXISTS parent_id integer REFERENCES tasks (id);
+CREATE INDEX IF NOT EXISTS tasks_parent_id_idx ON tasks (parent_id);
+
+COMMIT;
@@ -0,0 +1,6 @@
+BEGIN;
+
+DROP TABLE IF EXISTS task_activities;
+DROP TABLE IF EXISTS task_comments;
+
+COMMIT;
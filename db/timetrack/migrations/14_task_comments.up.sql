@@ -0,0 +1,36 @@
+BEGIN;
+
+-- Comments discuss tasks. Comments of deleted users are kept without an author.
+CREATE TABLE IF NOT EXISTS task_comments (
+    id serial NOT NULL,
+    task_id integer NOT NULL,
+    user_id integer,
+    body text NOT NULL,
+    created_at timestamptz NOT NULL DEFAULT now(),
+    updated_at timestamptz,
+    PRIMARY KEY (id),
+    FOREIGN KEY (task_id) REFERENCES tasks (id) ON DELETE CASCADE,
+    FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE SET NULL
+);
+CREATE INDEX IF NOT EXISTS task_comments_task_id_idx ON task_comments (task_id, id);
+
+-- Activities record who changed the description or the status of a task and
+-- who started or stopped time on it.
+CREATE TABLE IF NOT EXISTS task_activities (
+    id serial NOT NULL,
+    task_id integer NOT NULL,
+    user_id integer,
+    kind text NOT NULL
+        CHECK (kind IN ('description_changed', 'status_changed', 'work_started', 'work_stopped')),
+    old_value text,
+    new_value text,
+    work_id integer,
+    created_at timestamptz NOT NULL DEFAULT now(),
+    PRIMARY KEY (id),
+    FOREIGN KEY (task_id) REFERENCES tasks (id) ON DELETE CASCADE,
+    FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE SET NULL,
+    FOREIGN KEY (work_id) REFERENCES works (id) ON DELETE SET NULL
+);
+CREATE INDEX IF NOT EXISTS task_activities_task_id_idx ON task_activities (task_id, id);
+
+COMMIT;
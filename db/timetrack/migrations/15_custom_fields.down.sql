@@ -0,0 +1,6 @@
+BEGIN;
+
+DROP TABLE IF EXISTS task_field_values;
+DROP TABLE IF EXISTS custom_fields;
+
+COMMIT;
@@ -0,0 +1,29 @@
+BEGIN;
+
+-- Custom fields are defined by administrators to add metadata to tasks, e.g.
+-- ticket numbers or cost centers. Field names are unique regardless of case.
+-- Enum fields have the options their values are chosen from.
+CREATE TABLE IF NOT EXISTS custom_fields (
+    id serial NOT NULL,
+    name text NOT NULL,
+    type text NOT NULL,
+    options text[] NOT NULL DEFAULT '{}',
+    PRIMARY KEY (id),
+    CHECK (type IN ('text', 'number', 'enum', 'date')),
+    CHECK ((type = 'enum') = (cardinality(options) > 0))
+);
+CREATE UNIQUE INDEX IF NOT EXISTS custom_fields_lower_name_idx ON custom_fields (lower(name));
+
+-- Values are stored as text normalized according to the type of the field, so
+-- that equal values compare equal.
+CREATE TABLE IF NOT EXISTS task_field_values (
+    task_id integer NOT NULL,
+    field_id integer NOT NULL,
+    value text NOT NULL,
+    PRIMARY KEY (task_id, field_id),
+    FOREIGN KEY (task_id) REFERENCES tasks (id) ON DELETE CASCADE,
+    FOREIGN KEY (field_id) REFERENCES custom_fields (id) ON DELETE CASCADE
+);
+CREATE INDEX IF NOT EXISTS task_field_values_field_id_value_idx ON task_field_values (field_id, value);
+
+COMMIT;
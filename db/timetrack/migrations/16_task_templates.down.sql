@@ -0,0 +1,6 @@
+BEGIN;
+
+DROP TABLE IF EXISTS task_template_runs;
+DROP TABLE IF EXISTS task_templates;
+
+COMMIT;
@@ -0,0 +1,40 @@
+BEGIN;
+
+-- A task template creates a task, or reopens the task it created before, on
+-- each occurrence of an RFC 5545 recurrence rule starting at starts_at.
+-- next_run_at is NULL once the rule has no more occurrences.
+CREATE TABLE IF NOT EXISTS task_templates (
+    id serial NOT NULL,
+    owner_id integer NOT NULL,
+    description text NOT NULL,
+    billable boolean NOT NULL,
+    project_id integer,
+    visibility text NOT NULL,
+    rule text NOT NULL,
+    starts_at timestamptz NOT NULL,
+    mode text NOT NULL CHECK (mode IN ('create', 'reopen')),
+    task_id integer,
+    next_run_at timestamptz,
+    last_run_at timestamptz,
+    PRIMARY KEY (id),
+    FOREIGN KEY (owner_id) REFERENCES users (id) ON DELETE CASCADE,
+    FOREIGN KEY (project_id) REFERENCES projects (id) ON DELETE SET NULL,
+    FOREIGN KEY (task_id) REFERENCES tasks (id) ON DELETE SET NULL,
+    CHECK (visibility IN ('private', 'project', 'everyone'))
+);
+CREATE INDEX IF NOT EXISTS task_templates_owner_id_idx ON task_templates (owner_id);
+CREATE INDEX IF NOT EXISTS task_templates_next_run_at_idx ON task_templates (next_run_at);
+
+-- A run records that an occurrence of a template was generated so that an
+-- occurrence is never generated twice.
+CREATE TABLE IF NOT EXISTS task_template_runs (
+    template_id integer NOT NULL,
+    occurrence_at timestamptz NOT NULL,
+    task_id integer,
+    created_at timestamptz NOT NULL DEFAULT now(),
+    PRIMARY KEY (template_id, occurrence_at),
+    FOREIGN KEY (template_id) REFERENCES task_templates (id) ON DELETE CASCADE,
+    FOREIGN KEY (task_id) REFERENCES tasks (id) ON DELETE SET NULL
+);
+
+COMMIT;
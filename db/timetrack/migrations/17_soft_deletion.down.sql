@@ -0,0 +1,9 @@
+BEGIN;
+
+DROP INDEX IF EXISTS users_deleted_at_idx;
+ALTER TABLE users DROP COLUMN IF EXISTS deleted_at;
+
+DROP INDEX IF EXISTS tasks_deleted_at_idx;
+ALTER TABLE tasks DROP COLUMN IF EXISTS deleted_at;
+
+COMMIT;
@@ -0,0 +1,12 @@
+BEGIN;
+
+-- Deleted tasks and users are kept with their works until the retention period
+-- is over, so that they can be restored. Tasks with works and users with works
+-- are deleted only when forced by an administrator.
+ALTER TABLE tasks ADD COLUMN IF NOT EXISTS deleted_at timestamptz;
+CREATE INDEX IF NOT EXISTS tasks_deleted_at_idx ON tasks (deleted_at) WHERE deleted_at IS NOT NULL;
+
+ALTER TABLE users ADD COLUMN IF NOT EXISTS deleted_at timestamptz;
+CREATE INDEX IF NOT EXISTS users_deleted_at_idx ON users (deleted_at) WHERE deleted_at IS NOT NULL;
+
+COMMIT;
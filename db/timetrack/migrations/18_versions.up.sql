@@ -0,0 +1,9 @@
+BEGIN;
+
+-- The version of a task or a user is incremented on every update and is
+-- returned as its ETag, so that concurrent updates based on the same version
+-- don't overwrite each other.
+ALTER TABLE tasks ADD COLUMN IF NOT EXISTS version integer NOT NULL DEFAULT 1;
+ALTER TABLE users ADD COLUMN IF NOT EXISTS version integer NOT NULL DEFAULT 1;
+
+COMMIT;
@@ -0,0 +1,6 @@
+BEGIN;
+
+DROP INDEX IF EXISTS users_created_at_idx;
+ALTER TABLE users DROP COLUMN IF EXISTS created_at;
+
+COMMIT;
@@ -0,0 +1,8 @@
+BEGIN;
+
+-- Users can be sorted by when they were created. Existing users get the time
+-- of the migration.
+ALTER TABLE users ADD COLUMN IF NOT EXISTS created_at timestamptz NOT NULL DEFAULT now();
+CREATE INDEX IF NOT EXISTS users_created_at_idx ON users (created_at, id);
+
+COMMIT;
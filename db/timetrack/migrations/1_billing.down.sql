@@ -0,0 +1,11 @@
+BEGIN;
+
+DROP TABLE IF EXISTS rates;
+
+ALTER TABLE works DROP COLUMN IF EXISTS billable;
+
+ALTER TABLE tasks DROP COLUMN IF EXISTS billable;
+
+ALTER TABLE users DROP COLUMN IF EXISTS is_admin;
+
+COMMIT;
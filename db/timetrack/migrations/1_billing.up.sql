@@ -0,0 +1,40 @@
+BEGIN;
+
+ALTER TABLE users ADD COLUMN IF NOT EXISTS is_admin boolean NOT NULL DEFAULT false;
+
+ALTER TABLE tasks ADD COLUMN IF NOT EXISTS billable boolean NOT NULL DEFAULT true;
+
+-- Works take the billable flag of their task only when the column is added, so
+-- that running the migration again keeps the flags of the works.
+DO $$
+BEGIN
+    IF NOT EXISTS (
+        SELECT 1 FROM information_schema.columns WHERE table_name = 'works' AND column_name = 'billable'
+    ) THEN
+        ALTER TABLE works ADD COLUMN billable boolean NOT NULL DEFAULT true;
+        UPDATE works SET billable = tasks.billable FROM tasks WHERE works.task_id = tasks.id;
+    END IF;
+END
+$$;
+
+-- A rate applies to a user, to a task, or to a user working on a task. The most
+-- specific rate wins. Rates are never updated, a new rate with a later
+-- effective_from is added instead so that past works keep their rate.
+CREATE TABLE IF NOT EXISTS rates (
+    id serial NOT NULL,
+    user_id integer,
+    task_id integer,
+    hourly_rate numeric(12, 2) NOT NULL,
+    currency text NOT NULL,
+    effective_from timestamp with time zone NOT NULL,
+    PRIMARY KEY (id),
+    FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE,
+    FOREIGN KEY (task_id) REFERENCES tasks (id) ON DELETE CASCADE,
+    CHECK (user_id IS NOT NULL OR task_id IS NOT NULL),
+    CHECK (hourly_rate >= 0),
+    CHECK (currency ~ '^[A-Z]{3}$')
+);
+CREATE UNIQUE INDEX IF NOT EXISTS rates_user_id_task_id_effective_from_idx
+    ON rates (coalesce(user_id, 0), coalesce(task_id, 0), effective_from);
+
+COMMIT;
@@ -0,0 +1,44 @@
+BEGIN;
+
+-- A work rate rollup is the billable time a user spent on a task on a UTC day
+-- in stopped works charged at a rate. Rate rollups are kept up to date by
+-- tracking and by changes of the rates and let reports charge whole days
+-- without scanning the works.
+CREATE TABLE IF NOT EXISTS work_rate_rollups (
+    user_id integer NOT NULL,
+    task_id integer NOT NULL,
+    day date NOT NULL,
+    rate_id integer NOT NULL,
+    billable_duration interval NOT NULL,
+    PRIMARY KEY (user_id, day, task_id, rate_id),
+    FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE,
+    FOREIGN KEY (task_id) REFERENCES tasks (id) ON DELETE CASCADE,
+    FOREIGN KEY (rate_id) REFERENCES rates (id) ON DELETE CASCADE
+);
+
+INSERT INTO work_rate_rollups (user_id, task_id, day, rate_id, billable_duration)
+SELECT works.user_id,
+       works.task_id,
+       (days.started_at AT TIME ZONE 'UTC')::date,
+       rates.id,
+       SUM(LEAST(works.stopped_at, days.started_at + interval '24 hours') - GREATEST(works.started_at, days.started_at))
+FROM works
+JOIN LATERAL (
+    SELECT rates.id
+    FROM rates
+    WHERE (rates.user_id = works.user_id OR rates.user_id IS NULL)
+      AND (rates.task_id = works.task_id OR rates.task_id IS NULL)
+      AND rates.effective_from <= works.started_at
+    ORDER BY (rates.user_id IS NOT NULL AND rates.task_id IS NOT NULL) DESC,
+             (rates.task_id IS NOT NULL) DESC,
+             rates.effective_from DESC
+    LIMIT 1
+) AS rates ON true
+CROSS JOIN LATERAL generate_series(
+    date_trunc('day', works.started_at, 'UTC'), works.stopped_at, interval '24 hours'
+) AS days (started_at)
+WHERE works.stopped_at IS NOT NULL AND works.billable
+GROUP BY works.user_id, works.task_id, days.started_at, rates.id
+ON CONFLICT DO NOTHING;
+
+COMMIT;
@@ -0,0 +1,5 @@
+BEGIN;
+
+DROP INDEX IF EXISTS work_rollups_task_id_idx;
+
+COMMIT;
@@ -0,0 +1,7 @@
+BEGIN;
+
+-- Tasks sum the time of their rollups rather than of their works, so that task
+-- lists don't scan the works of every task.
+CREATE INDEX IF NOT EXISTS work_rollups_task_id_idx ON work_rollups (task_id);
+
+COMMIT;
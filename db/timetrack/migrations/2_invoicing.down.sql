@@ -0,0 +1,13 @@
+BEGIN;
+
+DROP TABLE IF EXISTS invoice_works;
+
+DROP TABLE IF EXISTS invoices;
+
+DROP TABLE IF EXISTS invoice_numbers;
+
+ALTER TABLE tasks DROP COLUMN IF EXISTS client_id;
+
+DROP TABLE IF EXISTS clients;
+
+COMMIT;
@@ -0,0 +1,57 @@
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS clients (
+    id serial NOT NULL,
+    name text NOT NULL,
+    PRIMARY KEY (id)
+);
+CREATE UNIQUE INDEX IF NOT EXISTS clients_name_idx ON clients (name);
+
+ALTER TABLE tasks ADD COLUMN IF NOT EXISTS client_id integer REFERENCES clients (id) ON DELETE SET NULL;
+CREATE INDEX IF NOT EXISTS tasks_client_id_idx ON tasks (client_id);
+
+-- Invoice numbers are taken from a single-row counter rather than a sequence.
+-- The counter is updated in the transaction that issues an invoice, so a
+-- rolled back transaction doesn't leave a gap.
+CREATE TABLE IF NOT EXISTS invoice_numbers (
+    id boolean NOT NULL DEFAULT true,
+    last_number integer NOT NULL,
+    PRIMARY KEY (id),
+    CHECK (id)
+);
+INSERT INTO invoice_numbers (id, last_number) VALUES (true, 0) ON CONFLICT DO NOTHING;
+
+CREATE TABLE IF NOT EXISTS invoices (
+    id serial NOT NULL,
+    number integer,
+    client_id integer NOT NULL,
+    period_from timestamp with time zone NOT NULL,
+    period_to timestamp with time zone NOT NULL,
+    currency text NOT NULL,
+    status text NOT NULL,
+    created_at timestamp with time zone NOT NULL DEFAULT now(),
+    issued_at timestamp with time zone,
+    PRIMARY KEY (id),
+    FOREIGN KEY (client_id) REFERENCES clients (id) ON DELETE RESTRICT,
+    CHECK (
+        status = 'draft' AND number IS NULL AND issued_at IS NULL
+        OR status = 'issued' AND number IS NOT NULL AND issued_at IS NOT NULL
+    )
+);
+CREATE UNIQUE INDEX IF NOT EXISTS invoices_number_idx ON invoices (number);
+
+-- A work is billed at most once. Works on an invoice can't be deleted, neither
+-- directly nor by deleting their task or user.
+CREATE TABLE IF NOT EXISTS invoice_works (
+    invoice_id integer NOT NULL,
+    work_id integer NOT NULL,
+    duration interval NOT NULL,
+    hourly_rate numeric(12, 2) NOT NULL,
+    amount numeric(14, 2) NOT NULL,
+    PRIMARY KEY (work_id),
+    FOREIGN KEY (invoice_id) REFERENCES invoices (id) ON DELETE CASCADE,
+    FOREIGN KEY (work_id) REFERENCES works (id) ON DELETE RESTRICT
+);
+CREATE INDEX IF NOT EXISTS invoice_works_invoice_id_idx ON invoice_works (invoice_id);
+
+COMMIT;
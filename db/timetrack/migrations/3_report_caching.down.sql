@@ -0,0 +1,7 @@
+BEGIN;
+
+DROP INDEX IF EXISTS works_user_id_started_at_idx;
+
+ALTER TABLE works DROP COLUMN IF EXISTS updated_at;
+
+COMMIT;
@@ -0,0 +1,19 @@
+BEGIN;
+
+-- Reports are cached by clients with validators derived from the latest change
+-- of the works in the reported time frame. Existing works are backfilled only
+-- when the column is added, so that running the migration again keeps the times.
+DO $$
+BEGIN
+    IF NOT EXISTS (
+        SELECT 1 FROM information_schema.columns WHERE table_name = 'works' AND column_name = 'updated_at'
+    ) THEN
+        ALTER TABLE works ADD COLUMN updated_at timestamp with time zone NOT NULL DEFAULT now();
+        UPDATE works SET updated_at = coalesce(stopped_at, started_at);
+    END IF;
+END
+$$;
+
+CREATE INDEX IF NOT EXISTS works_user_id_started_at_idx ON works (user_id, started_at);
+
+COMMIT;
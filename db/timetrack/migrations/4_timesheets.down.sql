@@ -0,0 +1,8 @@
+BEGIN;
+
+DROP TABLE IF EXISTS timesheets;
+
+DROP INDEX IF EXISTS users_manager_id_idx;
+ALTER TABLE users DROP COLUMN IF EXISTS manager_id;
+
+COMMIT;
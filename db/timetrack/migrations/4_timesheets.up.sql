@@ -0,0 +1,28 @@
+BEGIN;
+
+ALTER TABLE users ADD COLUMN IF NOT EXISTS manager_id integer REFERENCES users (id) ON DELETE SET NULL;
+CREATE INDEX IF NOT EXISTS users_manager_id_idx ON users (manager_id);
+
+-- A timesheet covers the works of a user started in a week, from Monday 00:00
+-- UTC to the next Monday. Works in submitted and approved timesheets are
+-- locked.
+CREATE TABLE IF NOT EXISTS timesheets (
+    id serial NOT NULL,
+    user_id integer NOT NULL,
+    period_from timestamp with time zone NOT NULL,
+    period_to timestamp with time zone NOT NULL,
+    status text NOT NULL,
+    comment text,
+    submitted_at timestamp with time zone,
+    decided_at timestamp with time zone,
+    decided_by integer,
+    PRIMARY KEY (id),
+    FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE,
+    FOREIGN KEY (decided_by) REFERENCES users (id) ON DELETE SET NULL,
+    CHECK (period_from < period_to),
+    CHECK (status IN ('draft', 'submitted', 'approved', 'rejected'))
+);
+CREATE UNIQUE INDEX IF NOT EXISTS timesheets_user_id_period_from_idx ON timesheets (user_id, period_from);
+CREATE INDEX IF NOT EXISTS timesheets_status_idx ON timesheets (status) WHERE status = 'submitted';
+
+COMMIT;
@@ -0,0 +1,26 @@
+BEGIN;
+
+-- A report subscription delivers a summary of the hours of a user or of the
+-- users managed by the owner for the previous period on a cron schedule.
+CREATE TABLE IF NOT EXISTS report_subscriptions (
+    id serial NOT NULL,
+    owner_id integer NOT NULL,
+    recipient text NOT NULL,
+    scope text NOT NULL,
+    user_id integer,
+    period text NOT NULL,
+    schedule text NOT NULL,
+    format text NOT NULL,
+    next_run_at timestamp with time zone NOT NULL,
+    last_run_at timestamp with time zone,
+    PRIMARY KEY (id),
+    FOREIGN KEY (owner_id) REFERENCES users (id) ON DELETE CASCADE,
+    FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE,
+    CHECK (scope = 'team' AND user_id IS NULL OR scope = 'user' AND user_id IS NOT NULL),
+    CHECK (period IN ('day', 'week', 'month')),
+    CHECK (format IN ('text', 'csv'))
+);
+CREATE INDEX IF NOT EXISTS report_subscriptions_owner_id_idx ON report_subscriptions (owner_id);
+CREATE INDEX IF NOT EXISTS report_subscriptions_next_run_at_idx ON report_subscriptions (next_run_at);
+
+COMMIT;
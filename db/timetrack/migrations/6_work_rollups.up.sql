@@ -0,0 +1,35 @@
+BEGIN;
+
+-- A work rollup is the time a user spent on a task on a UTC day in stopped
+-- works. Rollups are kept up to date by tracking and let reports sum whole days
+-- without scanning the works.
+CREATE TABLE IF NOT EXISTS work_rollups (
+    user_id integer NOT NULL,
+    task_id integer NOT NULL,
+    day date NOT NULL,
+    duration interval NOT NULL,
+    billable_duration interval NOT NULL,
+    PRIMARY KEY (user_id, day, task_id),
+    FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE,
+    FOREIGN KEY (task_id) REFERENCES tasks (id) ON DELETE CASCADE
+);
+
+INSERT INTO work_rollups (user_id, task_id, day, duration, billable_duration)
+SELECT works.user_id,
+       works.task_id,
+       (days.started_at AT TIME ZONE 'UTC')::date,
+       SUM(LEAST(works.stopped_at, days.started_at + interval '24 hours') - GREATEST(works.started_at, days.started_at)),
+       COALESCE(
+           SUM(LEAST(works.stopped_at, days.started_at + interval '24 hours') - GREATEST(works.started_at, days.started_at))
+               FILTER (WHERE works.billable),
+           '0'
+       )
+FROM works
+CROSS JOIN LATERAL generate_series(
+    date_trunc('day', works.started_at, 'UTC'), works.stopped_at, interval '24 hours'
+) AS days (started_at)
+WHERE works.stopped_at IS NOT NULL
+GROUP BY works.user_id, works.task_id, days.started_at
+ON CONFLICT DO NOTHING;
+
+COMMIT;
@@ -0,0 +1,6 @@
+BEGIN;
+
+DROP TABLE IF EXISTS holidays;
+DROP TABLE IF EXISTS work_schedules;
+
+COMMIT;
@@ -0,0 +1,38 @@
+BEGIN;
+
+-- A work schedule is the number of hours a user is contracted to work on each
+-- weekday. Like rates, schedules are never updated, a new schedule with a later
+-- effective_from is added instead so that past expected hours stay the same.
+CREATE TABLE IF NOT EXISTS work_schedules (
+    id serial NOT NULL,
+    user_id integer NOT NULL,
+    effective_from date NOT NULL,
+    monday_hours numeric(4, 2) NOT NULL,
+    tuesday_hours numeric(4, 2) NOT NULL,
+    wednesday_hours numeric(4, 2) NOT NULL,
+    thursday_hours numeric(4, 2) NOT NULL,
+    friday_hours numeric(4, 2) NOT NULL,
+    saturday_hours numeric(4, 2) NOT NULL,
+    sunday_hours numeric(4, 2) NOT NULL,
+    PRIMARY KEY (id),
+    FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE,
+    CHECK (monday_hours BETWEEN 0 AND 24),
+    CHECK (tuesday_hours BETWEEN 0 AND 24),
+    CHECK (wednesday_hours BETWEEN 0 AND 24),
+    CHECK (thursday_hours BETWEEN 0 AND 24),
+    CHECK (friday_hours BETWEEN 0 AND 24),
+    CHECK (saturday_hours BETWEEN 0 AND 24),
+    CHECK (sunday_hours BETWEEN 0 AND 24)
+);
+CREATE UNIQUE INDEX IF NOT EXISTS work_schedules_user_id_effective_from_idx ON work_schedules (user_id, effective_from);
+
+-- Nobody is expected to work on a holiday.
+CREATE TABLE IF NOT EXISTS holidays (
+    id serial NOT NULL,
+    date date NOT NULL,
+    name text NOT NULL,
+    PRIMARY KEY (id)
+);
+CREATE UNIQUE INDEX IF NOT EXISTS holidays_date_idx ON holidays (date);
+
+COMMIT;
@@ -0,0 +1,7 @@
+BEGIN;
+
+DROP TABLE IF EXISTS task_budget_events;
+DROP INDEX IF EXISTS works_task_id_idx;
+ALTER TABLE tasks DROP COLUMN IF EXISTS budget;
+
+COMMIT;
@@ -0,0 +1,25 @@
+BEGIN;
+
+ALTER TABLE tasks ADD COLUMN IF NOT EXISTS budget interval CHECK (budget > '0');
+
+-- Tasks sum the time of their works to compare it with the budget.
+CREATE INDEX IF NOT EXISTS works_task_id_idx ON works (task_id);
+
+-- A task budget event records that the time in stopped works of a task reached
+-- a threshold in percent of its budget. Each threshold is recorded once per
+-- budget, integrations read the events in the order of their IDs.
+CREATE TABLE IF NOT EXISTS task_budget_events (
+    id serial NOT NULL,
+    task_id integer NOT NULL,
+    threshold integer NOT NULL,
+    budget interval NOT NULL,
+    tracked interval NOT NULL,
+    created_at timestamp with time zone NOT NULL DEFAULT now(),
+    PRIMARY KEY (id),
+    FOREIGN KEY (task_id) REFERENCES tasks (id) ON DELETE CASCADE,
+    CHECK (threshold IN (80, 100))
+);
+CREATE UNIQUE INDEX IF NOT EXISTS task_budget_events_task_id_threshold_budget_idx
+    ON task_budget_events (task_id, threshold, budget);
+
+COMMIT;
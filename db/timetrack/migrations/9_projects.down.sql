@@ -0,0 +1,13 @@
+BEGIN;
+
+ALTER TABLE tasks ADD COLUMN IF NOT EXISTS client_id integer REFERENCES clients (id) ON DELETE SET NULL;
+CREATE INDEX IF NOT EXISTS tasks_client_id_idx ON tasks (client_id);
+UPDATE tasks SET client_id = projects.client_id FROM projects WHERE projects.id = tasks.project_id;
+
+DROP INDEX IF EXISTS tasks_project_id_idx;
+ALTER TABLE tasks DROP COLUMN IF EXISTS project_id;
+
+DROP TABLE IF EXISTS projects;
+DROP FUNCTION IF EXISTS default_project_id();
+
+COMMIT;
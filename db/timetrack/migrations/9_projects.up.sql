@@ -0,0 +1,48 @@
+BEGIN;
+
+-- A project groups tasks, optionally for a client. Tasks that are not filed
+-- under a project belong to the default project, which has no client.
+CREATE TABLE IF NOT EXISTS projects (
+    id serial NOT NULL,
+    client_id integer,
+    name text NOT NULL,
+    is_default boolean NOT NULL DEFAULT false,
+    PRIMARY KEY (id),
+    FOREIGN KEY (client_id) REFERENCES clients (id) ON DELETE RESTRICT,
+    CHECK (NOT is_default OR client_id IS NULL)
+);
+CREATE UNIQUE INDEX IF NOT EXISTS projects_client_id_name_idx ON projects (client_id, name) NULLS NOT DISTINCT;
+CREATE UNIQUE INDEX IF NOT EXISTS projects_is_default_idx ON projects (is_default) WHERE is_default;
+
+CREATE OR REPLACE FUNCTION default_project_id() RETURNS integer
+    LANGUAGE sql STABLE
+    AS $$ SELECT id FROM projects WHERE is_default $$;
+
+INSERT INTO projects (name, is_default) VALUES ('Default', true) ON CONFLICT DO NOTHING;
+
+ALTER TABLE tasks ADD COLUMN IF NOT EXISTS project_id integer REFERENCES projects (id) ON DELETE RESTRICT;
+
+-- Tasks of a client move to a project named after the client. The clients of
+-- the tasks are gone once the migration has run.
+DO $$
+BEGIN
+    IF EXISTS (SELECT 1 FROM information_schema.columns WHERE table_name = 'tasks' AND column_name = 'client_id') THEN
+        INSERT INTO projects (client_id, name)
+        SELECT DISTINCT clients.id, clients.name
+        FROM clients
+        JOIN tasks ON tasks.client_id = clients.id
+        ON CONFLICT DO NOTHING;
+
+        UPDATE tasks SET project_id = projects.id FROM projects WHERE projects.client_id = tasks.client_id;
+    END IF;
+END
+$$;
+UPDATE tasks SET project_id = default_project_id() WHERE project_id IS NULL;
+ALTER TABLE tasks ALTER COLUMN project_id SET DEFAULT default_project_id();
+ALTER TABLE tasks ALTER COLUMN project_id SET NOT NULL;
+CREATE INDEX IF NOT EXISTS tasks_project_id_idx ON tasks (project_id);
+
+DROP INDEX IF EXISTS tasks_client_id_idx;
+ALTER TABLE tasks DROP COLUMN IF EXISTS client_id;
+
+COMMIT;
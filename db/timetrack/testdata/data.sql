@@ -13,6 +13,8 @@ VALUES
     ('9012 345678', 'Смирнов', 'Анна', 'Ивановна', 'пр. Солнечный, д. 789'),
     ('0123 456789', 'Тихонов', 'Максим', 'Павлович', 'ул. Цветочная, д. 234');
 
+UPDATE users SET is_admin = true WHERE passport_number = '1234 567890';
+
 INSERT INTO tasks (description)
 VALUES
     ('Write project proposal for client A'),
@@ -103,4 +105,45 @@ VALUES
     ('2024-07-05 08:00:00', NULL, 3, 1, 'started'),
     ('2024-07-05 14:00:00', NULL, 2, 1, 'started');
 
+INSERT INTO rates (user_id, task_id, hourly_rate, currency, effective_from)
+VALUES
+    (1, NULL, 40.00, 'EUR', '2024-01-01 00:00:00'),
+    (1, NULL, 45.00, 'EUR', '2024-06-15 00:00:00'),
+    (NULL, 3, 60.00, 'EUR', '2024-01-01 00:00:00'),
+    (1, 5, 55.50, 'EUR', '2024-01-01 00:00:00');
+
+INSERT INTO clients (name)
+VALUES
+    ('Acme Corp'),
+    ('Globex');
+
+INSERT INTO projects (client_id, name)
+VALUES
+    (1, 'Proposals'),
+    (2, 'Presentations');
+
+UPDATE tasks SET project_id = (SELECT id FROM projects WHERE name = 'Proposals') WHERE id IN (1, 3);
+UPDATE tasks SET project_id = (SELECT id FROM projects WHERE name = 'Presentations') WHERE id = 4;
+
+UPDATE users SET manager_id = 1 WHERE id <> 1;
+
+-- Same as rollup.Rebuild, works inserted directly don't maintain their rollups.
+DELETE FROM work_rollups;
+INSERT INTO work_rollups (user_id, task_id, day, duration, billable_duration)
+SELECT works.user_id,
+       works.task_id,
+       (days.started_at AT TIME ZONE 'UTC')::date,
+       SUM(LEAST(works.stopped_at, days.started_at + interval '24 hours') - GREATEST(works.started_at, days.started_at)),
+       COALESCE(
+           SUM(LEAST(works.stopped_at, days.started_at + interval '24 hours') - GREATEST(works.started_at, days.started_at))
+               FILTER (WHERE works.billable),
+           '0'
+       )
+FROM works
+CROSS JOIN LATERAL generate_series(
+    date_trunc('day', works.started_at, 'UTC'), works.stopped_at, interval '24 hours'
+) AS days (started_at)
+WHERE works.stopped_at IS NOT NULL
+GROUP BY works.user_id, works.task_id, days.started_at;
+
 COMMIT;
@@ -5,3 +5,40 @@ const (
 	WorkStatusStarted = "started"
 	WorkStatusStopped = "stopped"
 )
+
+// InvoiceStatusDraft and InvoiceStatusIssued are the statuses of an invoice.
+const (
+	InvoiceStatusDraft  = "draft"
+	InvoiceStatusIssued = "issued"
+)
+
+// TimesheetStatusDraft, TimesheetStatusSubmitted, TimesheetStatusApproved, and
+// TimesheetStatusRejected are the statuses of a timesheet.
+const (
+	TimesheetStatusDraft     = "draft"
+	TimesheetStatusSubmitted = "submitted"
+	TimesheetStatusApproved  = "approved"
+	TimesheetStatusRejected  = "rejected"
+)
+
+// SubscriptionScopeTeam and SubscriptionScopeUser are the scopes of a report
+// subscription.
+const (
+	SubscriptionScopeTeam = "team"
+	SubscriptionScopeUser = "user"
+)
+
+// SubscriptionPeriodDay, SubscriptionPeriodWeek, and SubscriptionPeriodMonth
+// are the periods a report subscription reports on.
+const (
+	SubscriptionPeriodDay   = "day"
+	SubscriptionPeriodWeek  = "week"
+	SubscriptionPeriodMonth = "month"
+)
+
+// SubscriptionFormatText and SubscriptionFormatCSV are the formats of a report
+// subscription.
+const (
+	SubscriptionFormatText = "text"
+	SubscriptionFormatCSV  = "csv"
+)
@@ -0,0 +1,14 @@
+package apiutil
+
+import (
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// FormatHours formats the duration as a decimal number of hours rounded to
+// hundredths, e.g. "1.50" or "-1.50". All responses format hours with it, so
+// that the same duration reads the same everywhere.
+func FormatHours(d time.Duration) string {
+	return decimal.NewFromInt(d.Milliseconds()).Div(decimal.NewFromInt(3_600_000)).StringFixed(2)
+}
@@ -0,0 +1,28 @@
+package apiutil
+
+import (
+	"testing"
+	"time"
+)
+
+func TestFormatHours(t *testing.T) {
+	tests := []struct {
+		d    time.Duration
+		want string
+	}{
+		{0, "0.00"},
+		{90 * time.Minute, "1.50"},
+		{-90 * time.Minute, "-1.50"},
+		{time.Hour + 18*time.Second, "1.01"},
+		{18 * time.Second, "0.01"},
+		{17*time.Second + 999*time.Millisecond, "0.00"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.d.String(), func(t *testing.T) {
+			if got := FormatHours(tt.d); got != tt.want {
+				t.Errorf("expected %s, got %s", tt.want, got)
+			}
+		})
+	}
+}
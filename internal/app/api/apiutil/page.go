@@ -0,0 +1,244 @@
+package apiutil
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/kirillgashkov/timetrack/internal/app/database"
+)
+
+// processCursorSecret signs cursors when no secret is configured, so cursors
+// of such servers don't survive restarts.
+var processCursorSecret = func() []byte {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		panic(errors.Join(errors.New("failed to generate cursor secret"), err))
+	}
+	return b
+}()
+
+type cursorSecretKey struct{}
+
+// WithCursorSecret makes the handler sign and verify cursors with the secret,
+// so that cursors stay valid across restarts and servers that share it.
+func WithCursorSecret(secret []byte, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), cursorSecretKey{}, secret)))
+	})
+}
+
+func cursorSecret(ctx context.Context) []byte {
+	if secret, ok := ctx.Value(cursorSecretKey{}).([]byte); ok {
+		return secret
+	}
+	return processCursorSecret
+}
+
+// cursor is the payload of a cursor token. List is the path and the query of
+// the list without the pagination parameters, so that a cursor can't be used
+// with another list, sort, or filter. Key is the sort key of an item encoded
+// with encodeKey.
+type cursor struct {
+	List     string   `json:"l"`
+	Key      []string `json:"k"`
+	Backward bool     `json:"b,omitempty"`
+}
+
+// ParsePage validates the pagination parameters of a list request and returns
+// its page. The limit defaults to 50. The cursor must come from a link of the
+// same list. The offset is deprecated in favour of cursors and can't be used
+// with them.
+func ParsePage(r *http.Request, cursorToken *string, offset, limit *int) (*database.Page, ValidationError) {
+	e := make(ValidationError, 0)
+	p := &database.Page{Limit: 50}
+
+	if offset != nil {
+		if *offset < 0 {
+			e = append(e, "invalid offset, must be greater than or equal to 0")
+		} else {
+			p.Offset = *offset
+		}
+	}
+	if limit != nil {
+		if *limit < 1 || *limit > 100 {
+			e = append(e, "invalid limit, must be between 1 and 100")
+		} else {
+			p.Limit = *limit
+		}
+	}
+	if cursorToken != nil {
+		if offset != nil {
+			e = append(e, "invalid offset, can't be used with cursor")
+		}
+		c, ok := decodeCursor(cursorSecret(r.Context()), *cursorToken)
+		if !ok || c.List != listOf(r.URL) {
+			e = append(e, "invalid cursor")
+		} else if p.Key, ok = decodeKey(c.Key); !ok {
+			e = append(e, "invalid cursor")
+		} else {
+			p.Backward = c.Backward
+		}
+	}
+
+	if len(e) > 0 {
+		return nil, e
+	}
+	return p, nil
+}
+
+// SetPageLinks sets the Link header of the response with a page of a list to
+// the links to the next and the previous pages, if there are any. more is
+// whether there are more items past the page as reported by
+// database.PageItems. The key of an item is its sort key, which consists of
+// ints, floats, strings, and times.
+func SetPageLinks[T any](
+	w http.ResponseWriter, r *http.Request, p *database.Page, items []T, more bool, key func(*T) []any,
+) {
+	var before, after []any
+	if len(items) > 0 {
+		before, after = key(&items[0]), key(&items[len(items)-1])
+	} else {
+		// An empty page has no items, the pages around it are around its key.
+		before, after = p.Key, p.Key
+	}
+
+	hasPrev, hasNext := p.Offset > 0 || (len(p.Key) > 0 && !p.Backward), more
+	if p.Backward {
+		hasPrev, hasNext = more, true
+	}
+
+	links := make([]string, 0, 2)
+	if hasNext && after != nil {
+		links = append(links, `<`+pageURL(r, after, false)+`>; rel="next"`)
+	}
+	if hasPrev && before != nil {
+		links = append(links, `<`+pageURL(r, before, true)+`>; rel="prev"`)
+	}
+	if len(links) > 0 {
+		w.Header().Set("Link", strings.Join(links, ", "))
+	}
+}
+
+// pageURL returns the URL of the request with the cursor of the page after or,
+// if backward is true, before the item with the key.
+func pageURL(r *http.Request, key []any, backward bool) string {
+	c := &cursor{List: listOf(r.URL), Key: encodeKey(key), Backward: backward}
+
+	q := r.URL.Query()
+	q.Del("offset")
+	q.Set("cursor", encodeCursor(cursorSecret(r.Context()), c))
+	u := url.URL{Path: r.URL.Path, RawQuery: q.Encode()}
+	return u.String()
+}
+
+// listOf returns the path and the sorted query of the URL without the
+// pagination parameters.
+func listOf(u *url.URL) string {
+	q := u.Query()
+	q.Del("cursor")
+	q.Del("offset")
+	q.Del("limit")
+	return u.Path + "?" + q.Encode()
+}
+
+// encodeCursor returns the token of the cursor, which is its JSON and the
+// HMAC-SHA256 of the JSON, both in unpadded base64url separated by a dot.
+func encodeCursor(secret []byte, c *cursor) string {
+	b, err := json.Marshal(c)
+	if err != nil {
+		panic(errors.Join(errors.New("failed to encode cursor"), err))
+	}
+	return base64.RawURLEncoding.EncodeToString(b) + "." + base64.RawURLEncoding.EncodeToString(sign(secret, b))
+}
+
+func decodeCursor(secret []byte, token string) (*cursor, bool) {
+	payload, signature, ok := strings.Cut(token, ".")
+	if !ok {
+		return nil, false
+	}
+	b, err := base64.RawURLEncoding.DecodeString(payload)
+	if err != nil {
+		return nil, false
+	}
+	s, err := base64.RawURLEncoding.DecodeString(signature)
+	if err != nil || !hmac.Equal(s, sign(secret, b)) {
+		return nil, false
+	}
+
+	var c cursor
+	if err = json.Unmarshal(b, &c); err != nil {
+		return nil, false
+	}
+	return &c, true
+}
+
+func sign(secret, b []byte) []byte {
+	m := hmac.New(sha256.New, secret)
+	m.Write(b)
+	return m.Sum(nil)
+}
+
+// encodeKey encodes the values of a sort key as strings prefixed with their
+// type, so that they are decoded back to the same types.
+func encodeKey(key []any) []string {
+	s := make([]string, 0, len(key))
+	for _, v := range key {
+		switch v := v.(type) {
+		case int:
+			s = append(s, "i"+strconv.Itoa(v))
+		case float64:
+			s = append(s, "f"+strconv.FormatFloat(v, 'g', -1, 64))
+		case string:
+			s = append(s, "s"+v)
+		case time.Time:
+			s = append(s, "t"+v.Format(time.RFC3339Nano))
+		default:
+			panic("unsupported sort key type")
+		}
+	}
+	return s
+}
+
+func decodeKey(s []string) ([]any, bool) {
+	key := make([]any, 0, len(s))
+	for _, v := range s {
+		if v == "" {
+			return nil, false
+		}
+		switch v[0] {
+		case 'i':
+			i, err := strconv.Atoi(v[1:])
+			if err != nil {
+				return nil, false
+			}
+			key = append(key, i)
+		case 'f':
+			f, err := strconv.ParseFloat(v[1:], 64)
+			if err != nil {
+				return nil, false
+			}
+			key = append(key, f)
+		case 's':
+			key = append(key, v[1:])
+		case 't':
+			t, err := time.Parse(time.RFC3339Nano, v[1:])
+			if err != nil {
+				return nil, false
+			}
+			key = append(key, t)
+		default:
+			return nil, false
+		}
+	}
+	return key, true
+}
@@ -3,7 +3,10 @@ package apiutil
 import (
 	"encoding/json"
 	"errors"
+	"mime"
 	"net/http"
+	"strconv"
+	"strings"
 )
 
 func ReadJSON(r *http.Request, v any) error {
@@ -12,3 +15,56 @@ func ReadJSON(r *http.Request, v any) error {
 	}
 	return nil
 }
+
+// Accepts reports whether the request explicitly lists the media type in its
+// Accept header with a quality above 0, which marks it as not acceptable.
+// Wildcards are not taken into account, so JSON stays the default for clients
+// that accept anything.
+func Accepts(r *http.Request, mediaType string) bool {
+	for _, h := range r.Header.Values("Accept") {
+		for _, part := range strings.Split(h, ",") {
+			mt, params, err := mime.ParseMediaType(strings.TrimSpace(part))
+			if err != nil || mt != mediaType {
+				continue
+			}
+			if q, ok := params["q"]; ok {
+				if v, err := strconv.ParseFloat(q, 64); err != nil || v <= 0 {
+					continue
+				}
+			}
+			return true
+		}
+	}
+	return false
+}
+
+// IfMatchVersion returns the version in the If-Match header, which must be a
+// strong ETag of a version as returned by VersionETag. It returns nil if the
+// header is missing or "*", which matches any version.
+func IfMatchVersion(ifMatch *string) (*int, ValidationError) {
+	if ifMatch == nil || strings.TrimSpace(*ifMatch) == "*" {
+		return nil, nil
+	}
+	t := strings.TrimSpace(*ifMatch)
+	if len(t) < 2 || t[0] != '"' || t[len(t)-1] != '"' {
+		return nil, ValidationError{`invalid If-Match header, must be an ETag like "1"`}
+	}
+	version, err := strconv.Atoi(t[1 : len(t)-1])
+	if err != nil {
+		return nil, ValidationError{`invalid If-Match header, must be an ETag like "1"`}
+	}
+	return &version, nil
+}
+
+// RequireIfMatch fails requests without an If-Match header with 428
+// Precondition Required, so that clients can't update a resource without
+// saying which version of it they have seen.
+func RequireIfMatch(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("If-Match") == "" {
+			MustWriteError(w, "missing If-Match header", http.StatusPreconditionRequired)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
@@ -0,0 +1,30 @@
+package apiutil
+
+import (
+	"net/http/httptest"
+	"testing"
+)
+
+func TestAccepts(t *testing.T) {
+	tests := []struct {
+		accept string
+		want   bool
+	}{
+		{"application/pdf", true},
+		{"application/json, application/pdf;q=0.5", true},
+		{"application/pdf;q=0, application/json", false},
+		{"application/pdf;q=0.0", false},
+		{"*/*", false},
+		{"", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.accept, func(t *testing.T) {
+			r := httptest.NewRequest("GET", "/", nil)
+			r.Header.Set("Accept", tt.accept)
+			if got := Accepts(r, "application/pdf"); got != tt.want {
+				t.Errorf("got %t, want %t", got, tt.want)
+			}
+		})
+	}
+}
@@ -5,7 +5,9 @@ import (
 	"errors"
 	"log/slog"
 	"net/http"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/kirillgashkov/timetrack/api/timetrackapi/v1"
 )
@@ -31,6 +33,63 @@ func MustWriteJSON(w http.ResponseWriter, v any, code int) {
 	}
 }
 
+func MustWriteContent(w http.ResponseWriter, b []byte, contentType string, code int) {
+	w.Header().Set("Content-Type", contentType)
+	w.WriteHeader(code)
+	if _, err := w.Write(b); err != nil {
+		panic(errors.Join(errors.New("failed to write response"), err))
+	}
+}
+
+// WriteNotModified sets the ETag and, unless it is zero, the Last-Modified
+// headers of the response. If the conditional headers of the request match
+// them, it writes 304 Not Modified and reports true. If-Modified-Since is
+// ignored when If-None-Match is present.
+func WriteNotModified(w http.ResponseWriter, r *http.Request, etag string, lastModified time.Time) bool {
+	w.Header().Set("ETag", etag)
+	if !lastModified.IsZero() {
+		w.Header().Set("Last-Modified", lastModified.UTC().Format(http.TimeFormat))
+	}
+
+	if inm := r.Header.Get("If-None-Match"); inm != "" {
+		if !etagMatches(inm, etag) {
+			return false
+		}
+	} else {
+		ims, err := http.ParseTime(r.Header.Get("If-Modified-Since"))
+		if err != nil || lastModified.IsZero() || lastModified.Truncate(time.Second).After(ims) {
+			return false
+		}
+	}
+
+	w.WriteHeader(http.StatusNotModified)
+	return true
+}
+
+// etagMatches reports whether the If-None-Match header value matches the ETag
+// using the weak comparison.
+func etagMatches(ifNoneMatch, etag string) bool {
+	etag = strings.TrimPrefix(etag, "W/")
+	for _, t := range strings.Split(ifNoneMatch, ",") {
+		t = strings.TrimSpace(t)
+		if t == "*" || strings.TrimPrefix(t, "W/") == etag {
+			return true
+		}
+	}
+	return false
+}
+
+// VersionETag returns the strong ETag of a version of a resource.
+func VersionETag(version int) string {
+	return `"` + strconv.Itoa(version) + `"`
+}
+
+// MustWritePreconditionFailed writes 412 Precondition Failed for a request
+// whose If-Match header doesn't match the current version of the resource.
+func MustWritePreconditionFailed(w http.ResponseWriter, m string) {
+	MustWriteError(w, m, http.StatusPreconditionFailed)
+}
+
 func MustWriteNoContent(w http.ResponseWriter) {
 	w.WriteHeader(http.StatusNoContent)
 }
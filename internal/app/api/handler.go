@@ -6,39 +6,90 @@ import (
 	"github.com/kirillgashkov/timetrack/api/timetrackapi/v1"
 	"github.com/kirillgashkov/timetrack/internal/app/api/apiutil"
 	"github.com/kirillgashkov/timetrack/internal/auth"
+	"github.com/kirillgashkov/timetrack/internal/billing"
+	"github.com/kirillgashkov/timetrack/internal/client"
+	"github.com/kirillgashkov/timetrack/internal/field"
+	"github.com/kirillgashkov/timetrack/internal/invoicing"
+	"github.com/kirillgashkov/timetrack/internal/project"
+	"github.com/kirillgashkov/timetrack/internal/recurring"
 	"github.com/kirillgashkov/timetrack/internal/reporting"
+	"github.com/kirillgashkov/timetrack/internal/schedule"
+	"github.com/kirillgashkov/timetrack/internal/subscription"
+	"github.com/kirillgashkov/timetrack/internal/tag"
 	"github.com/kirillgashkov/timetrack/internal/task"
+	"github.com/kirillgashkov/timetrack/internal/timesheet"
 	"github.com/kirillgashkov/timetrack/internal/tracking"
 	"github.com/kirillgashkov/timetrack/internal/user"
 )
 
 type authHandler = auth.Handler
+type billingHandler = billing.Handler
+type clientHandler = client.Handler
+type fieldHandler = field.Handler
+type invoicingHandler = invoicing.Handler
+type projectHandler = project.Handler
+type recurringHandler = recurring.Handler
 type reportingHandler = reporting.Handler
+type scheduleHandler = schedule.Handler
+type subscriptionHandler = subscription.Handler
+type tagHandler = tag.Handler
 type taskHandler = task.Handler
+type timesheetHandler = timesheet.Handler
 type trackingHandler = tracking.Handler
 type userHandler = user.Handler
 
 type Handler struct {
 	*authHandler
+	*billingHandler
+	*clientHandler
+	*fieldHandler
+	*invoicingHandler
+	*projectHandler
+	*recurringHandler
 	*reportingHandler
+	*scheduleHandler
+	*subscriptionHandler
+	*tagHandler
 	*taskHandler
+	*timesheetHandler
 	*trackingHandler
 	*userHandler
 }
 
 func NewHandler(
 	authService auth.Service,
+	billingService billing.Service,
+	clientService client.Service,
+	fieldService field.Service,
+	invoicingService invoicing.Service,
+	projectService project.Service,
+	recurringService recurring.Service,
 	reportingService reporting.Service,
+	scheduleService schedule.Service,
+	subscriptionService subscription.Service,
+	tagService tag.Service,
 	taskService task.Service,
+	timesheetService timesheet.Service,
 	trackingService tracking.Service,
 	userService user.Service,
+	requireIfMatch bool,
 ) *Handler {
 	return &Handler{
-		authHandler:      auth.NewHandler(authService),
-		reportingHandler: reporting.NewHandler(reportingService),
-		taskHandler:      task.NewHandler(taskService),
-		trackingHandler:  tracking.NewHandler(trackingService),
-		userHandler:      user.NewHandler(userService),
+		authHandler:         auth.NewHandler(authService),
+		billingHandler:      billing.NewHandler(billingService),
+		clientHandler:       client.NewHandler(clientService),
+		fieldHandler:        field.NewHandler(fieldService),
+		invoicingHandler:    invoicing.NewHandler(invoicingService),
+		projectHandler:      project.NewHandler(projectService),
+		recurringHandler:    recurring.NewHandler(recurringService),
+		reportingHandler:    reporting.NewHandler(reportingService),
+		scheduleHandler:     schedule.NewHandler(scheduleService),
+		subscriptionHandler: subscription.NewHandler(subscriptionService),
+		tagHandler:          tag.NewHandler(tagService),
+		taskHandler:         task.NewHandler(taskService, requireIfMatch),
+		timesheetHandler:    timesheet.NewHandler(timesheetService),
+		trackingHandler:     tracking.NewHandler(trackingService),
+		userHandler:         user.NewHandler(userService),
 	}
 }
 
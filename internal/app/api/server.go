@@ -10,8 +10,18 @@ import (
 	"github.com/kirillgashkov/timetrack/api/timetrackapi/v1"
 	"github.com/kirillgashkov/timetrack/internal/app/config"
 	"github.com/kirillgashkov/timetrack/internal/auth"
+	"github.com/kirillgashkov/timetrack/internal/billing"
+	"github.com/kirillgashkov/timetrack/internal/client"
+	"github.com/kirillgashkov/timetrack/internal/field"
+	"github.com/kirillgashkov/timetrack/internal/invoicing"
+	"github.com/kirillgashkov/timetrack/internal/project"
+	"github.com/kirillgashkov/timetrack/internal/recurring"
 	"github.com/kirillgashkov/timetrack/internal/reporting"
+	"github.com/kirillgashkov/timetrack/internal/schedule"
+	"github.com/kirillgashkov/timetrack/internal/subscription"
+	"github.com/kirillgashkov/timetrack/internal/tag"
 	"github.com/kirillgashkov/timetrack/internal/task"
+	"github.com/kirillgashkov/timetrack/internal/timesheet"
 	"github.com/kirillgashkov/timetrack/internal/tracking"
 	"github.com/kirillgashkov/timetrack/internal/user"
 )
@@ -19,19 +29,51 @@ import (
 func NewServer(
 	cfg *config.ServerConfig,
 	authService auth.Service,
+	billingService billing.Service,
+	clientService client.Service,
+	fieldService field.Service,
+	invoicingService invoicing.Service,
+	projectService project.Service,
+	recurringService recurring.Service,
 	reportingService reporting.Service,
+	scheduleService schedule.Service,
+	subscriptionService subscription.Service,
+	tagService tag.Service,
 	taskService task.Service,
+	timesheetService timesheet.Service,
 	trackingService tracking.Service,
 	userService user.Service,
 ) (*http.Server, error) {
-	si := NewHandler(authService, reportingService, taskService, trackingService, userService)
+	si := NewHandler(
+		authService,
+		billingService,
+		clientService,
+		fieldService,
+		invoicingService,
+		projectService,
+		recurringService,
+		reportingService,
+		scheduleService,
+		subscriptionService,
+		tagService,
+		taskService,
+		timesheetService,
+		trackingService,
+		userService,
+		cfg.RequireIfMatch,
+	)
 
 	authMiddleware := auth.NewMiddleware(authService)
-	mux := newServeMux(si, authMiddleware)
+	mux := newServeMux(si, authMiddleware, cfg.RequireIfMatch)
+
+	var handler http.Handler = mux
+	if cfg.CursorSecret != "" {
+		handler = apiutil.WithCursorSecret([]byte(cfg.CursorSecret), mux)
+	}
 
 	srv := &http.Server{
 		Addr:              net.JoinHostPort(cfg.Host, cfg.Port),
-		Handler:           mux,
+		Handler:           handler,
 		ReadHeaderTimeout: time.Duration(cfg.ReadHeaderTimeout) * time.Second,
 	}
 	return srv, nil
@@ -46,7 +88,12 @@ func NewServer(
 // all handlers manually. Probably a better solution would be to switch to other
 // oapi-codegen backend that supports per-handler middlewares or other OpenAPI
 // library, but it would be too much hassle for now.
-func newServeMux(si timetrackapi.ServerInterface, authMiddleware *auth.Middleware) *http.ServeMux {
+//
+// If requireIfMatch is true, updates and deletions of versioned resources must
+// have an If-Match header.
+func newServeMux(
+	si timetrackapi.ServerInterface, authMiddleware *auth.Middleware, requireIfMatch bool,
+) *http.ServeMux {
 	wrapper := timetrackapi.ServerInterfaceWrapper{
 		Handler:            si,
 		HandlerMiddlewares: make([]timetrackapi.MiddlewareFunc, 0),
@@ -58,24 +105,97 @@ func newServeMux(si timetrackapi.ServerInterface, authMiddleware *auth.Middlewar
 	authenticated := func(f func(http.ResponseWriter, *http.Request)) http.Handler {
 		return authMiddleware.Authenticated(http.HandlerFunc(f))
 	}
+	admin := func(f func(http.ResponseWriter, *http.Request)) http.Handler {
+		return authMiddleware.Authenticated(authMiddleware.Admin(http.HandlerFunc(f)))
+	}
+	versioned := func(f func(http.ResponseWriter, *http.Request)) http.Handler {
+		if !requireIfMatch {
+			return authenticated(f)
+		}
+		return authMiddleware.Authenticated(apiutil.RequireIfMatch(http.HandlerFunc(f)))
+	}
 
 	m := http.NewServeMux()
 	m.HandleFunc("POST /auth", wrapper.PostAuth)
 	m.HandleFunc("GET /health", wrapper.GetHealth)
 	m.Handle("GET /tasks/", authenticated(wrapper.GetTasks))
 	m.Handle("POST /tasks/", authenticated(wrapper.PostTasks))
-	m.Handle("DELETE /tasks/{id}", authenticated(wrapper.DeleteTasksId))
+	m.Handle("POST /tasks/batch", authenticated(wrapper.PostTasksBatch))
+	m.Handle("DELETE /tasks/{id}", versioned(wrapper.DeleteTasksId))
 	m.Handle("GET /tasks/{id}", authenticated(wrapper.GetTasksId))
-	m.Handle("PATCH /tasks/{id}", authenticated(wrapper.PatchTasksId))
+	m.Handle("PATCH /tasks/{id}", versioned(wrapper.PatchTasksId))
+	m.Handle("POST /tasks/{id}/restore", authenticated(wrapper.PostTasksIdRestore))
+	m.Handle("GET /tasks/{id}/children", authenticated(wrapper.GetTasksIdChildren))
 	m.Handle("POST /tasks/{id}/start", authenticated(wrapper.PostTasksIdStart))
 	m.Handle("POST /tasks/{id}/stop", authenticated(wrapper.PostTasksIdStop))
+	m.Handle("GET /tasks/{id}/progress", authenticated(wrapper.GetTasksIdProgress))
+	m.Handle("GET /tasks/{id}/comments", authenticated(wrapper.GetTasksIdComments))
+	m.Handle("POST /tasks/{id}/comments", authenticated(wrapper.PostTasksIdComments))
+	m.Handle("GET /tasks/{id}/activity", authenticated(wrapper.GetTasksIdActivity))
+	m.Handle("PATCH /comments/{id}", authenticated(wrapper.PatchCommentsId))
+	m.Handle("DELETE /comments/{id}", authenticated(wrapper.DeleteCommentsId))
+	m.Handle("GET /budget-events/", authenticated(wrapper.GetBudgetEvents))
+	m.Handle("GET /works/", authenticated(wrapper.GetWorks))
+	m.Handle("DELETE /works/{id}", authenticated(wrapper.DeleteWorksId))
+	m.Handle("PATCH /works/{id}", authenticated(wrapper.PatchWorksId))
+	m.Handle("GET /rates/", admin(wrapper.GetRates))
+	m.Handle("POST /rates/", admin(wrapper.PostRates))
+	m.Handle("DELETE /rates/{id}", admin(wrapper.DeleteRatesId))
+	m.Handle("GET /clients/", authenticated(wrapper.GetClients))
+	m.Handle("POST /clients/", admin(wrapper.PostClients))
+	m.Handle("GET /clients/{id}", authenticated(wrapper.GetClientsId))
+	m.Handle("PATCH /clients/{id}", admin(wrapper.PatchClientsId))
+	m.Handle("DELETE /clients/{id}", admin(wrapper.DeleteClientsId))
+	m.Handle("GET /projects/", authenticated(wrapper.GetProjects))
+	m.Handle("POST /projects/", admin(wrapper.PostProjects))
+	m.Handle("GET /projects/{id}", authenticated(wrapper.GetProjectsId))
+	m.Handle("PATCH /projects/{id}", admin(wrapper.PatchProjectsId))
+	m.Handle("DELETE /projects/{id}", admin(wrapper.DeleteProjectsId))
+	m.Handle("GET /tags/", authenticated(wrapper.GetTags))
+	m.Handle("POST /tags/", admin(wrapper.PostTags))
+	m.Handle("GET /tags/{id}", authenticated(wrapper.GetTagsId))
+	m.Handle("PATCH /tags/{id}", admin(wrapper.PatchTagsId))
+	m.Handle("DELETE /tags/{id}", admin(wrapper.DeleteTagsId))
+	m.Handle("GET /custom-fields/", authenticated(wrapper.GetCustomFields))
+	m.Handle("POST /custom-fields/", admin(wrapper.PostCustomFields))
+	m.Handle("GET /custom-fields/{id}", authenticated(wrapper.GetCustomFieldsId))
+	m.Handle("PATCH /custom-fields/{id}", admin(wrapper.PatchCustomFieldsId))
+	m.Handle("DELETE /custom-fields/{id}", admin(wrapper.DeleteCustomFieldsId))
+	m.Handle("GET /invoices/", admin(wrapper.GetInvoices))
+	m.Handle("POST /invoices/", admin(wrapper.PostInvoices))
+	m.Handle("DELETE /invoices/{id}", admin(wrapper.DeleteInvoicesId))
+	m.Handle("GET /invoices/{id}", admin(wrapper.GetInvoicesId))
+	m.Handle("POST /invoices/{id}/issue", admin(wrapper.PostInvoicesIdIssue))
+	m.Handle("GET /timesheets/", authenticated(wrapper.GetTimesheets))
+	m.Handle("POST /timesheets/", authenticated(wrapper.PostTimesheets))
+	m.Handle("GET /timesheets/queue", authenticated(wrapper.GetTimesheetsQueue))
+	m.Handle("GET /timesheets/{id}", authenticated(wrapper.GetTimesheetsId))
+	m.Handle("POST /timesheets/{id}/submit", authenticated(wrapper.PostTimesheetsIdSubmit))
+	m.Handle("POST /timesheets/{id}/approve", authenticated(wrapper.PostTimesheetsIdApprove))
+	m.Handle("POST /timesheets/{id}/reject", authenticated(wrapper.PostTimesheetsIdReject))
+	m.Handle("GET /subscriptions/", authenticated(wrapper.GetSubscriptions))
+	m.Handle("POST /subscriptions/", authenticated(wrapper.PostSubscriptions))
+	m.Handle("DELETE /subscriptions/{id}", authenticated(wrapper.DeleteSubscriptionsId))
+	m.Handle("GET /task-templates/", authenticated(wrapper.GetTaskTemplates))
+	m.Handle("POST /task-templates/", authenticated(wrapper.PostTaskTemplates))
+	m.Handle("GET /task-templates/{id}", authenticated(wrapper.GetTaskTemplatesId))
+	m.Handle("DELETE /task-templates/{id}", authenticated(wrapper.DeleteTaskTemplatesId))
+	m.Handle("GET /schedules/", admin(wrapper.GetSchedules))
+	m.Handle("POST /schedules/", admin(wrapper.PostSchedules))
+	m.Handle("DELETE /schedules/{id}", admin(wrapper.DeleteSchedulesId))
+	m.Handle("GET /holidays/", authenticated(wrapper.GetHolidays))
+	m.Handle("POST /holidays/", admin(wrapper.PostHolidays))
+	m.Handle("DELETE /holidays/{id}", admin(wrapper.DeleteHolidaysId))
 	m.Handle("GET /users/", authenticated(wrapper.GetUsers))
 	m.HandleFunc("POST /users/", wrapper.PostUsers)
 	m.Handle("GET /users/current", authenticated(wrapper.GetUsersCurrent))
+	m.Handle("GET /users/{id}/report", authenticated(wrapper.GetUsersIdReport))
 	m.Handle("POST /users/{id}/report", authenticated(wrapper.PostUsersIdReport))
-	m.Handle("DELETE /users/{id}", authenticated(wrapper.DeleteUsersId))
+	m.Handle("GET /users/{id}/overtime", admin(wrapper.GetUsersIdOvertime))
+	m.Handle("DELETE /users/{id}", versioned(wrapper.DeleteUsersId))
 	m.Handle("GET /users/{id}", authenticated(wrapper.GetUsersId))
-	m.Handle("PATCH /users/{id}", authenticated(wrapper.PatchUsersId))
+	m.Handle("PATCH /users/{id}", versioned(wrapper.PatchUsersId))
+	m.Handle("POST /users/{id}/restore", admin(wrapper.PostUsersIdRestore))
 
 	return m
 }
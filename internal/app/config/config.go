@@ -15,6 +15,8 @@ type Config struct {
 	Mode                    string `env:"APP_MODE" envDefault:"development"`
 	Server                  ServerConfig
 	Database                DatabaseConfig
+	SMTP                    SMTPConfig
+	Worker                  WorkerConfig
 	PeopleInfoServerURL     string `env:"APP_PEOPLE_INFO_SERVER_URL,required"`
 	PeopleInfoServerTimeout int    `env:"APP_PEOPLE_INFO_SERVER_TIMEOUT" envDefault:"1"`
 }
@@ -26,6 +28,20 @@ type ServerConfig struct {
 	// ReadHeaderTimeout is the maximum duration in seconds before timing out
 	// reading the headers of the request.
 	ReadHeaderTimeout int `env:"APP_SERVER_READ_HEADER_TIMEOUT" envDefault:"1"`
+
+	// TemplateInterval is the duration in seconds between checks for due task
+	// templates.
+	TemplateInterval int `env:"APP_SERVER_TEMPLATE_INTERVAL" envDefault:"60"`
+
+	// RequireIfMatch makes updates and deletions of tasks and users without an
+	// If-Match header, and batch operations on tasks without a version, fail
+	// with 428 Precondition Required.
+	RequireIfMatch bool `env:"APP_SERVER_REQUIRE_IF_MATCH" envDefault:"false"`
+
+	// CursorSecret is the secret cursors of list pages are signed with. If it
+	// is empty, a random secret is used, so cursors don't survive restarts and
+	// can't be shared between servers.
+	CursorSecret string `env:"APP_SERVER_CURSOR_SECRET"`
 }
 
 type DatabaseConfig struct {
@@ -33,6 +49,24 @@ type DatabaseConfig struct {
 	DSN string `env:"APP_DATABASE_DSN,required"`
 }
 
+// SMTPConfig configures delivery of emails. Authentication is used only if
+// Username is set.
+type SMTPConfig struct {
+	Host     string `env:"APP_SMTP_HOST" envDefault:"127.0.0.1"`
+	Port     string `env:"APP_SMTP_PORT" envDefault:"1025"`
+	Username string `env:"APP_SMTP_USERNAME"`
+	Password string `env:"APP_SMTP_PASSWORD"`
+	From     string `env:"APP_SMTP_FROM" envDefault:"timetrack@localhost"`
+}
+
+type WorkerConfig struct {
+	// Interval is the duration in seconds between checks for due jobs.
+	Interval int `env:"APP_WORKER_INTERVAL" envDefault:"60"`
+	// Retention is the number of days deleted tasks and users are kept in the
+	// trash before they are purged with their works.
+	Retention int `env:"APP_WORKER_RETENTION" envDefault:"30"`
+}
+
 func New() (*Config, error) {
 	cfg := &Config{}
 	if err := env.Parse(cfg); err != nil {
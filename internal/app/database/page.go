@@ -0,0 +1,98 @@
+package database
+
+import (
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// Page is a page of a list sorted by a key that is unique in the list. If Key
+// is set, the page has the Limit items right after the item with the key, or
+// right before it if Backward is set, so pages don't skip or repeat items when
+// the list changes between requests. Otherwise the page has the Limit items
+// after the first Offset ones.
+type Page struct {
+	Limit    int
+	Offset   int
+	Key      []any
+	Backward bool
+}
+
+// Keyset returns the condition and the ORDER BY clause with LIMIT and OFFSET
+// that select the page of a list sorted by the columns, and their arguments
+// numbered from $n. A column is an SQL expression optionally followed by DESC.
+// A column that is a function of a column, like lower(name), is compared to the
+// function of the key value, so that the key has the values of the columns as
+// is. The clauses select an extra item past the page, so that PageItems can
+// tell whether there are more items.
+func (p *Page) Keyset(n int, columns ...string) (string, string, []any) {
+	exprs := make([]string, len(columns))
+	desc := make([]bool, len(columns))
+	for i, c := range columns {
+		exprs[i] = c
+		if e, ok := strings.CutSuffix(c, " DESC"); ok {
+			exprs[i], desc[i] = e, true
+		}
+		if p.Backward {
+			desc[i] = !desc[i]
+		}
+	}
+
+	args := make([]any, 0, len(columns)+2)
+	cond := "true"
+	if len(p.Key) == len(columns) {
+		args = append(args, p.Key...)
+		// (a > $1) OR (a = $1 AND b > $2) OR ...
+		or := make([]string, len(columns))
+		for i := range columns {
+			and := make([]string, 0, i+1)
+			for j := 0; j < i; j++ {
+				and = append(and, exprs[j]+" = "+keyArg(exprs[j], n+j))
+			}
+			op := " > "
+			if desc[i] {
+				op = " < "
+			}
+			and = append(and, exprs[i]+op+keyArg(exprs[i], n+i))
+			or[i] = "(" + strings.Join(and, " AND ") + ")"
+		}
+		cond = "(" + strings.Join(or, " OR ") + ")"
+		n += len(columns)
+	}
+
+	order := make([]string, len(columns))
+	for i := range columns {
+		order[i] = exprs[i]
+		if desc[i] {
+			order[i] += " DESC"
+		}
+	}
+	tail := "ORDER BY " + strings.Join(order, ", ") +
+		" LIMIT $" + strconv.Itoa(n) + " OFFSET $" + strconv.Itoa(n+1)
+	args = append(args, p.Limit+1, p.Offset)
+	return cond, tail, args
+}
+
+// keyArg returns the argument $n of the key value of the column, applying the
+// function of the column to it if there is one.
+func keyArg(expr string, n int) string {
+	arg := "$" + strconv.Itoa(n)
+	if i := strings.Index(expr, "("); i > 0 && strings.HasSuffix(expr, ")") {
+		return expr[:i+1] + arg + ")"
+	}
+	return arg
+}
+
+// PageItems returns the items selected with the clauses of Keyset in the order
+// of the list without the extra item, and reports whether there are more items
+// past the page.
+func PageItems[T any](p *Page, items []T) ([]T, bool) {
+	more := len(items) > p.Limit
+	if more {
+		items = items[:p.Limit]
+	}
+	if p.Backward {
+		slices.Reverse(items)
+	}
+	return items, more
+}
@@ -0,0 +1,51 @@
+package database
+
+import (
+	"strconv"
+	"strings"
+)
+
+// Query collects the arguments of an SQL query that is built from parts, some
+// of them optional, and numbers their placeholders in the order they are added.
+type Query struct {
+	args []any
+}
+
+// Arg adds the argument to the query and returns its placeholder.
+func (q *Query) Arg(v any) string {
+	q.args = append(q.args, v)
+	return "$" + strconv.Itoa(len(q.args))
+}
+
+// Args returns the arguments of the query.
+func (q *Query) Args() []any {
+	return q.args
+}
+
+// Keyset adds the arguments of the clauses of the page to the query and
+// returns the condition and the ORDER BY clause with LIMIT and OFFSET of the
+// page as Page.Keyset does.
+func (q *Query) Keyset(p *Page, columns ...string) (string, string) {
+	cond, clauses, args := p.Keyset(len(q.args)+1, columns...)
+	q.args = append(q.args, args...)
+	return cond, clauses
+}
+
+// And returns the condition that holds if all the conditions hold, true if
+// there are none.
+func And(conds ...string) string {
+	return join(conds, " AND ", "true")
+}
+
+// Or returns the condition that holds if any of the conditions holds, false if
+// there are none.
+func Or(conds ...string) string {
+	return join(conds, " OR ", "false")
+}
+
+func join(conds []string, sep, empty string) string {
+	if len(conds) == 0 {
+		return empty
+	}
+	return "(" + strings.Join(conds, sep) + ")"
+}
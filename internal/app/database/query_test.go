@@ -0,0 +1,115 @@
+package database
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestQueryArg(t *testing.T) {
+	q := &Query{}
+	if got := q.Arg("Ivanov"); got != "$1" {
+		t.Errorf("expected $1, got %s", got)
+	}
+	if got := q.Arg(2); got != "$2" {
+		t.Errorf("expected $2, got %s", got)
+	}
+	if got := q.Args(); !reflect.DeepEqual(got, []any{"Ivanov", 2}) {
+		t.Errorf("expected [Ivanov 2], got %v", got)
+	}
+}
+
+func TestAndOr(t *testing.T) {
+	tests := []struct {
+		name string
+		got  string
+		want string
+	}{
+		{"empty and", And(), "true"},
+		{"empty or", Or(), "false"},
+		{"single", And("a = $1"), "(a = $1)"},
+		{"and", And("a = $1", "b = $2"), "(a = $1 AND b = $2)"},
+		{"or", Or("a = $1", "b = $2"), "(a = $1 OR b = $2)"},
+		{"nested", And("a = $1", Or("b = $2", "c = $3")), "(a = $1 AND (b = $2 OR c = $3))"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.got != tt.want {
+				t.Errorf("expected %q, got %q", tt.want, tt.got)
+			}
+		})
+	}
+}
+
+func TestQueryKeyset(t *testing.T) {
+	tests := []struct {
+		name        string
+		page        *Page
+		columns     []string
+		wantCond    string
+		wantClauses string
+		wantArgs    []any
+	}{
+		{
+			"first page",
+			&Page{Limit: 10},
+			[]string{"id"},
+			"true",
+			"ORDER BY id LIMIT $2 OFFSET $3",
+			[]any{"Ivanov", 11, 0},
+		},
+		{
+			"offset",
+			&Page{Limit: 10, Offset: 20},
+			[]string{"id DESC"},
+			"true",
+			"ORDER BY id DESC LIMIT $2 OFFSET $3",
+			[]any{"Ivanov", 11, 20},
+		},
+		{
+			"after key",
+			&Page{Limit: 10, Key: []any{"ivanov", 3}},
+			[]string{"lower(surname)", "id"},
+			"((lower(surname) > lower($2)) OR (lower(surname) = lower($2) AND id > $3))",
+			"ORDER BY lower(surname), id LIMIT $4 OFFSET $5",
+			[]any{"Ivanov", "ivanov", 3, 11, 0},
+		},
+		{
+			"before key with mixed directions",
+			&Page{Limit: 10, Key: []any{0.5, 3}, Backward: true},
+			[]string{"score DESC", "id"},
+			"((score > $2) OR (score = $2 AND id < $3))",
+			"ORDER BY score, id DESC LIMIT $4 OFFSET $5",
+			[]any{"Ivanov", 0.5, 3, 11, 0},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			q := &Query{}
+			q.Arg("Ivanov")
+			cond, clauses := q.Keyset(tt.page, tt.columns...)
+			if cond != tt.wantCond {
+				t.Errorf("expected condition %q, got %q", tt.wantCond, cond)
+			}
+			if clauses != tt.wantClauses {
+				t.Errorf("expected clauses %q, got %q", tt.wantClauses, clauses)
+			}
+			if !reflect.DeepEqual(q.Args(), tt.wantArgs) {
+				t.Errorf("expected args %v, got %v", tt.wantArgs, q.Args())
+			}
+		})
+	}
+}
+
+func TestPageItems(t *testing.T) {
+	items, more := PageItems(&Page{Limit: 2}, []int{1, 2, 3})
+	if !reflect.DeepEqual(items, []int{1, 2}) || !more {
+		t.Errorf("expected [1 2] and more, got %v and %t", items, more)
+	}
+	// Backward pages are selected in reverse.
+	items, more = PageItems(&Page{Limit: 2, Backward: true}, []int{2, 1})
+	if !reflect.DeepEqual(items, []int{1, 2}) || more {
+		t.Errorf("expected [1 2] and no more, got %v and %t", items, more)
+	}
+}
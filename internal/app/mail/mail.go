@@ -0,0 +1,119 @@
+package mail
+
+import (
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net"
+	"net/smtp"
+	"net/textproto"
+	"strings"
+	"time"
+
+	"github.com/kirillgashkov/timetrack/internal/app/config"
+)
+
+// Message is an email with a plain text body and optional attachments.
+type Message struct {
+	To          []string
+	Subject     string
+	Body        string
+	Attachments []Attachment
+}
+
+type Attachment struct {
+	Filename    string
+	ContentType string
+	Content     []byte
+}
+
+type Mailer interface {
+	Send(msg *Message) error
+}
+
+type SMTPMailer struct {
+	addr string
+	auth smtp.Auth
+	from string
+}
+
+func NewSMTPMailer(cfg *config.SMTPConfig) *SMTPMailer {
+	var auth smtp.Auth
+	if cfg.Username != "" {
+		auth = smtp.PlainAuth("", cfg.Username, cfg.Password, cfg.Host)
+	}
+	return &SMTPMailer{addr: net.JoinHostPort(cfg.Host, cfg.Port), auth: auth, from: cfg.From}
+}
+
+func (m *SMTPMailer) Send(msg *Message) error {
+	b, err := msg.bytes(m.from, time.Now())
+	if err != nil {
+		return err
+	}
+	if err = smtp.SendMail(m.addr, m.auth, m.from, msg.To, b); err != nil {
+		return errors.Join(errors.New("failed to send mail"), err)
+	}
+	return nil
+}
+
+// bytes renders the message in the Internet Message Format. Messages with
+// attachments are rendered as multipart/mixed with the body as the first part.
+func (msg *Message) bytes(from string, date time.Time) ([]byte, error) {
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "From: %s\r\n", from)
+	fmt.Fprintf(&buf, "To: %s\r\n", strings.Join(msg.To, ", "))
+	fmt.Fprintf(&buf, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", msg.Subject))
+	fmt.Fprintf(&buf, "Date: %s\r\n", date.Format(time.RFC1123Z))
+	buf.WriteString("MIME-Version: 1.0\r\n")
+
+	if len(msg.Attachments) == 0 {
+		buf.WriteString("Content-Type: text/plain; charset=utf-8\r\n")
+		buf.WriteString("Content-Transfer-Encoding: base64\r\n\r\n")
+		writeBase64(&buf, []byte(msg.Body))
+		return buf.Bytes(), nil
+	}
+
+	mw := multipart.NewWriter(&buf)
+	fmt.Fprintf(&buf, "Content-Type: multipart/mixed; boundary=%q\r\n\r\n", mw.Boundary())
+
+	body, err := mw.CreatePart(textproto.MIMEHeader{
+		"Content-Type":              {"text/plain; charset=utf-8"},
+		"Content-Transfer-Encoding": {"base64"},
+	})
+	if err != nil {
+		return nil, errors.Join(errors.New("failed to create body part"), err)
+	}
+	writeBase64(body, []byte(msg.Body))
+
+	for _, a := range msg.Attachments {
+		part, partErr := mw.CreatePart(textproto.MIMEHeader{
+			"Content-Type":              {a.ContentType},
+			"Content-Transfer-Encoding": {"base64"},
+			"Content-Disposition":       {mime.FormatMediaType("attachment", map[string]string{"filename": a.Filename})},
+		})
+		if partErr != nil {
+			return nil, errors.Join(errors.New("failed to create attachment part"), partErr)
+		}
+		writeBase64(part, a.Content)
+	}
+
+	if err = mw.Close(); err != nil {
+		return nil, errors.Join(errors.New("failed to close multipart writer"), err)
+	}
+	return buf.Bytes(), nil
+}
+
+// writeBase64 writes the content in base64 with lines of 76 characters as
+// required by RFC 2045.
+func writeBase64(w io.Writer, content []byte) {
+	encoded := base64.StdEncoding.EncodeToString(content)
+	for len(encoded) > 76 {
+		_, _ = w.Write([]byte(encoded[:76] + "\r\n"))
+		encoded = encoded[76:]
+	}
+	_, _ = w.Write([]byte(encoded + "\r\n"))
+}
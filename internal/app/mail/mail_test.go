@@ -0,0 +1,101 @@
+package mail
+
+import (
+	"bytes"
+	"encoding/base64"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/mail"
+	"strings"
+	"testing"
+
+	"github.com/kirillgashkov/timetrack/internal/app/config"
+	"github.com/kirillgashkov/timetrack/internal/app/testutil"
+)
+
+func TestSMTPMailerSend(t *testing.T) {
+	srv, err := testutil.NewSMTPServer()
+	if err != nil {
+		t.Fatalf("failed to start SMTP server: %v", err)
+	}
+	defer func() { _ = srv.Close() }()
+
+	host, port := srv.Addr()
+	mailer := NewSMTPMailer(&config.SMTPConfig{Host: host, Port: port, From: "timetrack@example.com"})
+
+	err = mailer.Send(&Message{
+		To:      []string{"manager@example.com"},
+		Subject: "Отчёт за неделю",
+		Body:    "Иванов Александр\t40.00\n",
+		Attachments: []Attachment{
+			{Filename: "report.csv", ContentType: "text/csv", Content: []byte("user,hours\n1,40.00\n")},
+		},
+	})
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+
+	messages := srv.Messages()
+	if len(messages) != 1 {
+		t.Fatalf("expected 1 message, got %d", len(messages))
+	}
+	if messages[0].From != "timetrack@example.com" {
+		t.Errorf("expected envelope sender %q, got %q", "timetrack@example.com", messages[0].From)
+	}
+	if len(messages[0].To) != 1 || messages[0].To[0] != "manager@example.com" {
+		t.Errorf("expected envelope recipients %v, got %v", []string{"manager@example.com"}, messages[0].To)
+	}
+
+	m, err := mail.ReadMessage(bytes.NewReader(messages[0].Data))
+	if err != nil {
+		t.Fatalf("failed to parse message: %v", err)
+	}
+	subject, err := new(mime.WordDecoder).DecodeHeader(m.Header.Get("Subject"))
+	if err != nil || subject != "Отчёт за неделю" {
+		t.Errorf("expected subject %q, got %q (%v)", "Отчёт за неделю", subject, err)
+	}
+
+	mediaType, params, err := mime.ParseMediaType(m.Header.Get("Content-Type"))
+	if err != nil || mediaType != "multipart/mixed" {
+		t.Fatalf("expected multipart/mixed, got %q (%v)", mediaType, err)
+	}
+	mr := multipart.NewReader(m.Body, params["boundary"])
+
+	parts := make(map[string]string)
+	for {
+		p, partErr := mr.NextPart()
+		if partErr == io.EOF {
+			break
+		}
+		if partErr != nil {
+			t.Fatalf("failed to read part: %v", partErr)
+		}
+		raw, readErr := io.ReadAll(p)
+		if readErr != nil {
+			t.Fatalf("failed to read part: %v", readErr)
+		}
+		decoded, decodeErr := decodeBase64(raw)
+		if decodeErr != nil {
+			t.Fatalf("failed to decode part: %v", decodeErr)
+		}
+		key := p.FileName()
+		if key == "" {
+			key = "body"
+		}
+		parts[key] = decoded
+	}
+
+	if parts["body"] != "Иванов Александр\t40.00\n" {
+		t.Errorf("expected body %q, got %q", "Иванов Александр\t40.00\n", parts["body"])
+	}
+	if parts["report.csv"] != "user,hours\n1,40.00\n" {
+		t.Errorf("expected attachment %q, got %q", "user,hours\n1,40.00\n", parts["report.csv"])
+	}
+}
+
+func decodeBase64(b []byte) (string, error) {
+	s := strings.NewReplacer("\r", "", "\n", "").Replace(string(b))
+	decoded, err := base64.StdEncoding.DecodeString(s)
+	return string(decoded), err
+}
@@ -0,0 +1,28 @@
+package pdfutil
+
+import (
+	_ "embed"
+
+	"github.com/go-pdf/fpdf"
+)
+
+// FontFamily is the font family registered by New. It covers Latin and
+// Cyrillic scripts, which the core PDF fonts do not.
+const FontFamily = "DejaVuSansCondensed"
+
+var (
+	//go:embed fonts/DejaVuSansCondensed.ttf
+	regularFont []byte
+	//go:embed fonts/DejaVuSansCondensed-Bold.ttf
+	boldFont []byte
+)
+
+// New creates an A4 document in the given orientation ("P" or "L") with
+// FontFamily registered in regular and bold styles.
+func New(orientation string) *fpdf.Fpdf {
+	pdf := fpdf.New(orientation, "mm", "A4", "")
+	pdf.AddUTF8FontFromBytes(FontFamily, "", regularFont)
+	pdf.AddUTF8FontFromBytes(FontFamily, "B", boldFont)
+	pdf.SetFont(FontFamily, "", 10)
+	return pdf
+}
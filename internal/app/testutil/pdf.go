@@ -0,0 +1,91 @@
+package testutil
+
+import (
+	"bytes"
+	"compress/zlib"
+	"errors"
+	"io"
+	"regexp"
+	"unicode/utf16"
+)
+
+// PDFText extracts text of cells from a PDF produced by pdfutil. It supports
+// only what fpdf emits for UTF-8 fonts: Flate-compressed or plain content
+// streams with UTF-16BE literal strings. It returns a string per cell in the
+// order they appear in the document.
+func PDFText(pdf []byte) ([]string, error) {
+	text := make([]string, 0)
+	for _, s := range pdfStreams(pdf) {
+		if r, err := zlib.NewReader(bytes.NewReader(s)); err == nil {
+			if decompressed, readErr := io.ReadAll(r); readErr == nil {
+				s = decompressed
+			}
+		}
+
+		t, err := contentStreamText(s)
+		if err != nil {
+			return nil, err
+		}
+		text = append(text, t...)
+	}
+	return text, nil
+}
+
+func pdfStreams(pdf []byte) [][]byte {
+	streams := make([][]byte, 0)
+	for {
+		i := bytes.Index(pdf, []byte("stream\n"))
+		if i < 0 {
+			return streams
+		}
+		pdf = pdf[i+len("stream\n"):]
+
+		j := bytes.Index(pdf, []byte("\nendstream"))
+		if j < 0 {
+			return streams
+		}
+		streams = append(streams, pdf[:j])
+		pdf = pdf[j+len("\nendstream"):]
+	}
+}
+
+// textObjectRegexp matches text objects that fpdf writes for cells, e.g.
+// "BT 31.19 553.44 Td (...)Tj ET".
+var textObjectRegexp = regexp.MustCompile(`(?s)BT [0-9.-]+ [0-9.-]+ Td \(((?:[^\\)]|\\.)*)\)Tj ET`)
+
+func contentStreamText(s []byte) ([]string, error) {
+	text := make([]string, 0)
+	for _, m := range textObjectRegexp.FindAllSubmatch(s, -1) {
+		literal := unescapePDFLiteral(m[1])
+		if len(literal)%2 != 0 {
+			return nil, errors.New("odd length of UTF-16BE string")
+		}
+
+		u := make([]uint16, 0, len(literal)/2)
+		for j := 0; j < len(literal); j += 2 {
+			u = append(u, uint16(literal[j])<<8|uint16(literal[j+1]))
+		}
+		text = append(text, string(utf16.Decode(u)))
+	}
+	return text, nil
+}
+
+func unescapePDFLiteral(s []byte) []byte {
+	literal := make([]byte, 0, len(s))
+	for i := 0; i < len(s); i++ {
+		if s[i] == '\\' && i+1 < len(s) {
+			i++
+			switch s[i] {
+			case 'r':
+				literal = append(literal, '\r')
+			case 'n':
+				literal = append(literal, '\n')
+			default:
+				literal = append(literal, s[i])
+			}
+			continue
+		}
+		literal = append(literal, s[i])
+	}
+	return literal
+}
@@ -0,0 +1,136 @@
+package testutil
+
+import (
+	"bufio"
+	"net"
+	"net/textproto"
+	"strings"
+	"sync"
+)
+
+// SMTPMessage is a message received by SMTPServer.
+type SMTPMessage struct {
+	From string
+	To   []string
+	Data []byte
+}
+
+// SMTPServer is an in-process stand-in for an SMTP server. It accepts every
+// message without authentication and TLS and keeps it in memory.
+type SMTPServer struct {
+	listener net.Listener
+	wg       sync.WaitGroup
+
+	mu       sync.Mutex
+	messages []SMTPMessage
+}
+
+// NewSMTPServer starts an SMTP server on a random local port. The server must
+// be closed with Close.
+func NewSMTPServer() (*SMTPServer, error) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return nil, err
+	}
+
+	s := &SMTPServer{listener: l}
+	s.wg.Add(1)
+	go s.serve()
+	return s, nil
+}
+
+// Addr returns the host and the port the server listens on.
+func (s *SMTPServer) Addr() (host, port string) {
+	host, port, _ = net.SplitHostPort(s.listener.Addr().String())
+	return host, port
+}
+
+// Messages returns the messages received so far.
+func (s *SMTPServer) Messages() []SMTPMessage {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]SMTPMessage(nil), s.messages...)
+}
+
+func (s *SMTPServer) Close() error {
+	err := s.listener.Close()
+	s.wg.Wait()
+	return err
+}
+
+func (s *SMTPServer) serve() {
+	defer s.wg.Done()
+	for {
+		conn, err := s.listener.Accept()
+		if err != nil {
+			return
+		}
+		s.wg.Add(1)
+		go func() {
+			defer s.wg.Done()
+			defer func() { _ = conn.Close() }()
+			s.handle(textproto.NewConn(conn))
+		}()
+	}
+}
+
+func (s *SMTPServer) handle(c *textproto.Conn) {
+	var msg SMTPMessage
+	_ = c.PrintfLine("220 localhost SMTP stand-in")
+	for {
+		line, err := c.ReadLine()
+		if err != nil {
+			return
+		}
+		verb, arg, _ := strings.Cut(line, " ")
+
+		switch strings.ToUpper(verb) {
+		case "EHLO", "HELO":
+			_ = c.PrintfLine("250 localhost")
+		case "MAIL":
+			msg = SMTPMessage{From: trimPath(strings.TrimPrefix(arg, "FROM:"))}
+			_ = c.PrintfLine("250 OK")
+		case "RCPT":
+			msg.To = append(msg.To, trimPath(strings.TrimPrefix(arg, "TO:")))
+			_ = c.PrintfLine("250 OK")
+		case "DATA":
+			_ = c.PrintfLine("354 End data with <CR><LF>.<CR><LF>")
+			data, readErr := readData(c.Reader.R)
+			if readErr != nil {
+				return
+			}
+			msg.Data = data
+			s.mu.Lock()
+			s.messages = append(s.messages, msg)
+			s.mu.Unlock()
+			_ = c.PrintfLine("250 OK")
+		case "RSET", "NOOP":
+			_ = c.PrintfLine("250 OK")
+		case "QUIT":
+			_ = c.PrintfLine("221 Bye")
+			return
+		default:
+			_ = c.PrintfLine("502 Command not implemented")
+		}
+	}
+}
+
+// readData reads the message up to the terminating line and undoes dot
+// stuffing. Lines are kept with CRLF endings.
+func readData(r *bufio.Reader) ([]byte, error) {
+	var data []byte
+	for {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			return nil, err
+		}
+		if line == ".\r\n" {
+			return data, nil
+		}
+		data = append(data, strings.TrimPrefix(line, ".")...)
+	}
+}
+
+func trimPath(s string) string {
+	return strings.Trim(strings.TrimSpace(s), "<>")
+}
@@ -15,15 +15,20 @@ var (
 
 type ServiceMock struct {
 	AuthorizeFunc           func(ctx context.Context, g *PasswordGrant) (*Token, error)
-	UserFromAccessTokenFunc func(accessToken string) (*User, error)
+	UserFromAccessTokenFunc func(ctx context.Context, accessToken string) (*User, error)
+	IsAdminFunc             func(ctx context.Context, userID int) (bool, error)
 }
 
 func (s *ServiceMock) Authorize(ctx context.Context, g *PasswordGrant) (*Token, error) {
 	return s.AuthorizeFunc(ctx, g)
 }
 
-func (s *ServiceMock) UserFromAccessToken(accessToken string) (*User, error) {
-	return s.UserFromAccessTokenFunc(accessToken)
+func (s *ServiceMock) UserFromAccessToken(ctx context.Context, accessToken string) (*User, error) {
+	return s.UserFromAccessTokenFunc(ctx, accessToken)
+}
+
+func (s *ServiceMock) IsAdmin(ctx context.Context, userID int) (bool, error) {
+	return s.IsAdminFunc(ctx, userID)
 }
 
 func TestMain(m *testing.M) {
@@ -30,7 +30,7 @@ func (m *Middleware) Authenticated(next http.Handler) http.Handler {
 			return
 		}
 
-		u, err := m.service.UserFromAccessToken(t)
+		u, err := m.service.UserFromAccessToken(r.Context(), t)
 		if err != nil {
 			if errors.Is(err, ErrInvalidAccessToken) {
 				apiutil.MustWriteUnauthorized(w, "invalid access token")
@@ -46,6 +46,26 @@ func (m *Middleware) Authenticated(next http.Handler) http.Handler {
 	})
 }
 
+// Admin allows only administrators to pass. It must be used after
+// Authenticated.
+func (m *Middleware) Admin(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		u := MustUserFromContext(r.Context())
+
+		isAdmin, err := m.service.IsAdmin(r.Context(), u.ID)
+		if err != nil {
+			apiutil.MustWriteInternalServerError(w, "failed to check if user is admin", err)
+			return
+		}
+		if !isAdmin {
+			apiutil.MustWriteForbidden(w)
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
 func parseAccessToken(r *http.Request) (string, error) {
 	header := r.Header.Get("Authorization")
 	if header == "" {
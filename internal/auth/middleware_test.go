@@ -1,6 +1,8 @@
 package auth
 
 import (
+	"context"
+	"errors"
 	"net/http"
 	"net/http/httptest"
 	"strings"
@@ -11,14 +13,14 @@ func TestAuthenticated(t *testing.T) {
 	tests := []struct {
 		name                    string
 		authHeader              *string
-		userFromAccessTokenFunc func(string) (*User, error)
+		userFromAccessTokenFunc func(context.Context, string) (*User, error)
 		expectedStatusCode      int
 		expectedBody            string
 	}{
 		{
 			"ok",
 			stringPtr("Bearer valid_token"),
-			func(string) (*User, error) {
+			func(context.Context, string) (*User, error) {
 				return &User{ID: 1}, nil
 			},
 			http.StatusOK,
@@ -41,7 +43,7 @@ func TestAuthenticated(t *testing.T) {
 		{
 			"invalid access token",
 			stringPtr("Bearer invalid_token"),
-			func(string) (*User, error) {
+			func(context.Context, string) (*User, error) {
 				return nil, ErrInvalidAccessToken
 			},
 			http.StatusUnauthorized,
@@ -80,6 +82,68 @@ func TestAuthenticated(t *testing.T) {
 	}
 }
 
+func TestAdmin(t *testing.T) {
+	tests := []struct {
+		name               string
+		isAdminFunc        func(context.Context, int) (bool, error)
+		expectedStatusCode int
+		expectedBody       string
+	}{
+		{
+			"ok",
+			func(context.Context, int) (bool, error) {
+				return true, nil
+			},
+			http.StatusOK,
+			"ok",
+		},
+		{
+			"not admin",
+			func(context.Context, int) (bool, error) {
+				return false, nil
+			},
+			http.StatusForbidden,
+			`{"message":"forbidden"}`,
+		},
+		{
+			"error",
+			func(context.Context, int) (bool, error) {
+				return false, errors.New("error")
+			},
+			http.StatusInternalServerError,
+			`{"message":"internal server error"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := &ServiceMock{
+				IsAdminFunc: tt.isAdminFunc,
+			}
+			middleware := NewMiddleware(mockService)
+			handler := middleware.Admin(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				_, _ = w.Write([]byte("ok"))
+			}))
+
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			req = req.WithContext(ContextWithUser(req.Context(), &User{ID: 1}))
+			w := httptest.NewRecorder()
+
+			handler.ServeHTTP(w, req)
+
+			resp := w.Result()
+			if resp.StatusCode != tt.expectedStatusCode {
+				t.Errorf("expected status code %d, got %d", tt.expectedStatusCode, resp.StatusCode)
+			}
+
+			body := w.Body.String()
+			if !strings.Contains(body, tt.expectedBody) {
+				t.Errorf("expected body to contain %s, got %s", tt.expectedBody, body)
+			}
+		})
+	}
+}
+
 func stringPtr(s string) *string {
 	return &s
 }
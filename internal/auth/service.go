@@ -29,7 +29,8 @@ type User struct {
 
 type Service interface {
 	Authorize(ctx context.Context, g *PasswordGrant) (*Token, error)
-	UserFromAccessToken(accessToken string) (*User, error)
+	UserFromAccessToken(ctx context.Context, accessToken string) (*User, error)
+	IsAdmin(ctx context.Context, userID int) (bool, error)
 }
 
 type ServiceImpl struct {
@@ -41,7 +42,7 @@ func NewServiceImpl(db database.DB) *ServiceImpl {
 }
 
 func (s *ServiceImpl) Authorize(ctx context.Context, g *PasswordGrant) (*Token, error) {
-	q := `SELECT id FROM users WHERE passport_number = $1`
+	q := `SELECT id FROM users WHERE passport_number = $1 AND deleted_at IS NULL`
 	rows, err := s.db.Query(ctx, q, g.Username)
 	if err != nil {
 		return nil, errors.Join(errors.New("failed to select user"), err)
@@ -65,10 +66,42 @@ func (s *ServiceImpl) Authorize(ctx context.Context, g *PasswordGrant) (*Token,
 	return &Token{AccessToken: strconv.Itoa(id)}, nil
 }
 
-func (s *ServiceImpl) UserFromAccessToken(accessToken string) (*User, error) {
+// UserFromAccessToken returns the user of the access token. Tokens of deleted
+// users are invalid, so that users in the trash can't keep working with tokens
+// issued before they were deleted.
+func (s *ServiceImpl) UserFromAccessToken(ctx context.Context, accessToken string) (*User, error) {
 	id, err := strconv.Atoi(accessToken)
 	if err != nil {
 		return nil, errors.Join(ErrInvalidAccessToken, err)
 	}
+
+	q := `SELECT EXISTS (SELECT 1 FROM users WHERE id = $1 AND deleted_at IS NULL)`
+	var exists bool
+	if err = s.db.QueryRow(ctx, q, id).Scan(&exists); err != nil {
+		return nil, errors.Join(errors.New("failed to select user"), err)
+	}
+	if !exists {
+		return nil, ErrInvalidAccessToken
+	}
 	return &User{ID: id}, nil
 }
+
+// IsAdmin reports whether the user is an administrator. Administrators are
+// appointed directly in the database, deleted users aren't administrators.
+func (s *ServiceImpl) IsAdmin(ctx context.Context, userID int) (bool, error) {
+	q := `SELECT is_admin FROM users WHERE id = $1 AND deleted_at IS NULL`
+	rows, err := s.db.Query(ctx, q, userID)
+	if err != nil {
+		return false, errors.Join(errors.New("failed to select user"), err)
+	}
+	defer rows.Close()
+
+	isAdmin, err := pgx.CollectExactlyOneRow(rows, pgx.RowTo[bool])
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return false, nil
+		}
+		return false, errors.Join(errors.New("failed to collect user"), err)
+	}
+	return isAdmin, nil
+}
@@ -74,7 +74,7 @@ func TestUserFromAccessToken(t *testing.T) {
 		id := setupUser(t, passportNumber)
 		defer teardownUser(t, id)
 
-		user, err := service.UserFromAccessToken(strconv.Itoa(id))
+		user, err := service.UserFromAccessToken(context.Background(), strconv.Itoa(id))
 		if err != nil {
 			t.Fatalf("expected no error, got: %v", err)
 		}
@@ -87,7 +87,7 @@ func TestUserFromAccessToken(t *testing.T) {
 	})
 
 	t.Run("invalid access token", func(t *testing.T) {
-		user, err := service.UserFromAccessToken("not_a_number")
+		user, err := service.UserFromAccessToken(context.Background(), "not_a_number")
 		if err == nil {
 			t.Fatalf("expected error, got nil")
 		}
@@ -98,4 +98,22 @@ func TestUserFromAccessToken(t *testing.T) {
 			t.Errorf("expected nil user, got: %v", user)
 		}
 	})
+
+	t.Run("deleted user", func(t *testing.T) {
+		passportNumber := "0410 000000"
+		id := setupUser(t, passportNumber)
+		defer teardownUser(t, id)
+
+		if _, err := db.Exec(context.Background(), `UPDATE users SET deleted_at = now() WHERE id = $1`, id); err != nil {
+			t.Fatalf("failed to delete user: %v", err)
+		}
+
+		user, err := service.UserFromAccessToken(context.Background(), strconv.Itoa(id))
+		if !errors.Is(err, ErrInvalidAccessToken) {
+			t.Errorf("expected ErrInvalidAccessToken, got: %v", err)
+		}
+		if user != nil {
+			t.Errorf("expected nil user, got: %v", user)
+		}
+	})
 }
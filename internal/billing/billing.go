@@ -0,0 +1,17 @@
+package billing
+
+// WorkRateSubquery selects id, hourly_rate, and currency of the rate that
+// applies to a work. It refers to the work as "works" and is meant to be used
+// in a lateral join, e.g. "JOIN LATERAL (" + WorkRateSubquery + ") AS rates ON
+// true". Works without a rate are filtered out by such a join.
+const WorkRateSubquery = `
+	SELECT rates.id, rates.hourly_rate, rates.currency
+	FROM rates
+	WHERE (rates.user_id = works.user_id OR rates.user_id IS NULL)
+	  AND (rates.task_id = works.task_id OR rates.task_id IS NULL)
+	  AND rates.effective_from <= works.started_at
+	ORDER BY (rates.user_id IS NOT NULL AND rates.task_id IS NOT NULL) DESC,
+			 (rates.task_id IS NOT NULL) DESC,
+			 rates.effective_from DESC
+	LIMIT 1
+`
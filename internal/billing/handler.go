@@ -0,0 +1,131 @@
+package billing
+
+import (
+	"errors"
+	"net/http"
+	"regexp"
+
+	"github.com/kirillgashkov/timetrack/api/timetrackapi/v1"
+	"github.com/kirillgashkov/timetrack/internal/app/api/apiutil"
+	"github.com/shopspring/decimal"
+)
+
+var currencyRegexp = regexp.MustCompile(`^[A-Z]{3}$`)
+
+type Handler struct {
+	service Service
+}
+
+func NewHandler(service Service) *Handler {
+	return &Handler{service: service}
+}
+
+// GetRates handles "GET /rates/".
+func (h *Handler) GetRates(w http.ResponseWriter, r *http.Request, params timetrackapi.GetRatesParams) {
+	rates, err := h.service.ListRates(r.Context(), &FilterRate{UserID: params.UserId, TaskID: params.TaskId})
+	if err != nil {
+		apiutil.MustWriteInternalServerError(w, "failed to list rates", err)
+		return
+	}
+
+	resp := make([]*timetrackapi.RateResponse, 0, len(rates))
+	for _, rate := range rates {
+		resp = append(resp, toRateResponse(&rate))
+	}
+	apiutil.MustWriteJSON(w, resp, http.StatusOK)
+}
+
+// PostRates handles "POST /rates/".
+func (h *Handler) PostRates(w http.ResponseWriter, r *http.Request) {
+	create, err := parseAndValidateCreateRateRequest(r)
+	if err != nil {
+		var ve apiutil.ValidationError
+		if errors.As(err, &ve) {
+			apiutil.MustWriteUnprocessableEntity(w, ve)
+			return
+		}
+		apiutil.MustWriteInternalServerError(w, "failed to parse and validate request", err)
+		return
+	}
+
+	rate, err := h.service.CreateRate(r.Context(), create)
+	if err != nil {
+		if errors.Is(err, ErrRateAlreadyExists) {
+			apiutil.MustWriteError(w, "rate with the same effective date already exists", http.StatusBadRequest)
+			return
+		}
+		if errors.Is(err, ErrUserOrTaskInvalid) {
+			apiutil.MustWriteUnprocessableEntity(w, apiutil.ValidationError{"user or task not found"})
+			return
+		}
+		apiutil.MustWriteInternalServerError(w, "failed to create rate", err)
+		return
+	}
+
+	apiutil.MustWriteJSON(w, toRateResponse(rate), http.StatusOK)
+}
+
+func parseAndValidateCreateRateRequest(r *http.Request) (*CreateRate, error) {
+	var req *timetrackapi.CreateRateRequest
+	if err := apiutil.ReadJSON(r, &req); err != nil {
+		return nil, errors.Join(apiutil.ValidationError{"bad JSON"}, err)
+	}
+
+	e := make([]string, 0)
+
+	if req.UserId == nil && req.TaskId == nil {
+		e = append(e, "missing userId or taskId")
+	}
+	hourlyRate, err := decimal.NewFromString(req.HourlyRate)
+	if err != nil || hourlyRate.IsNegative() || hourlyRate.Exponent() < -2 {
+		e = append(e, "invalid hourlyRate, must be a non-negative decimal number with at most 2 decimal places")
+	}
+	if !currencyRegexp.MatchString(req.Currency) {
+		e = append(e, "invalid currency, must be an ISO 4217 code")
+	}
+	if req.EffectiveFrom.IsZero() {
+		e = append(e, "missing effectiveFrom")
+	}
+
+	if len(e) > 0 {
+		return nil, apiutil.ValidationError(e)
+	}
+	return &CreateRate{
+		UserID:        req.UserId,
+		TaskID:        req.TaskId,
+		HourlyRate:    hourlyRate,
+		Currency:      req.Currency,
+		EffectiveFrom: req.EffectiveFrom,
+	}, nil
+}
+
+// DeleteRatesId handles "DELETE /rates/{id}".
+//
+// Deleting a rate changes amounts of past works, so it is meant for fixing
+// mistakes. To change a rate, add a new one instead.
+//
+//nolint:revive
+func (h *Handler) DeleteRatesId(w http.ResponseWriter, r *http.Request, id int) {
+	rate, err := h.service.DeleteRate(r.Context(), id)
+	if err != nil {
+		if errors.Is(err, ErrRateNotFound) {
+			apiutil.MustWriteError(w, "rate not found", http.StatusNotFound)
+			return
+		}
+		apiutil.MustWriteInternalServerError(w, "failed to delete rate", err)
+		return
+	}
+
+	apiutil.MustWriteJSON(w, toRateResponse(rate), http.StatusOK)
+}
+
+func toRateResponse(r *Rate) *timetrackapi.RateResponse {
+	return &timetrackapi.RateResponse{
+		Id:            r.ID,
+		UserId:        r.UserID,
+		TaskId:        r.TaskID,
+		HourlyRate:    r.HourlyRate.StringFixed(2),
+		Currency:      r.Currency,
+		EffectiveFrom: r.EffectiveFrom,
+	}
+}
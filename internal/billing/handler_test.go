@@ -0,0 +1,99 @@
+package billing
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+type ServiceMock struct {
+	CreateRateFunc func(ctx context.Context, create *CreateRate) (*Rate, error)
+	ListRatesFunc  func(ctx context.Context, filter *FilterRate) ([]Rate, error)
+	DeleteRateFunc func(ctx context.Context, id int) (*Rate, error)
+}
+
+func (s *ServiceMock) CreateRate(ctx context.Context, create *CreateRate) (*Rate, error) {
+	return s.CreateRateFunc(ctx, create)
+}
+
+func (s *ServiceMock) ListRates(ctx context.Context, filter *FilterRate) ([]Rate, error) {
+	return s.ListRatesFunc(ctx, filter)
+}
+
+func (s *ServiceMock) DeleteRate(ctx context.Context, id int) (*Rate, error) {
+	return s.DeleteRateFunc(ctx, id)
+}
+
+func TestPostRates(t *testing.T) {
+	createRateFunc := func(_ context.Context, create *CreateRate) (*Rate, error) {
+		return &Rate{
+			ID:            1,
+			UserID:        create.UserID,
+			TaskID:        create.TaskID,
+			HourlyRate:    create.HourlyRate,
+			Currency:      create.Currency,
+			EffectiveFrom: create.EffectiveFrom,
+		}, nil
+	}
+
+	tests := []struct {
+		name               string
+		body               string
+		expectedStatusCode int
+		expectedBody       string
+	}{
+		{
+			"ok",
+			`{"userId":1,"hourlyRate":"42.5","currency":"EUR","effectiveFrom":"2024-06-01T00:00:00Z"}`,
+			http.StatusOK,
+			`"hourlyRate":"42.50"`,
+		},
+		{
+			"missing user and task",
+			`{"hourlyRate":"42.50","currency":"EUR","effectiveFrom":"2024-06-01T00:00:00Z"}`,
+			http.StatusUnprocessableEntity,
+			`{"message":"missing userId or taskId"}`,
+		},
+		{
+			"too precise rate",
+			`{"taskId":1,"hourlyRate":"42.505","currency":"EUR","effectiveFrom":"2024-06-01T00:00:00Z"}`,
+			http.StatusUnprocessableEntity,
+			`at most 2 decimal places`,
+		},
+		{
+			"negative rate",
+			`{"taskId":1,"hourlyRate":"-1","currency":"EUR","effectiveFrom":"2024-06-01T00:00:00Z"}`,
+			http.StatusUnprocessableEntity,
+			`non-negative decimal number`,
+		},
+		{
+			"invalid currency",
+			`{"taskId":1,"hourlyRate":"42.50","currency":"euro","effectiveFrom":"2024-06-01T00:00:00Z"}`,
+			http.StatusUnprocessableEntity,
+			`{"message":"invalid currency, must be an ISO 4217 code"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := NewHandler(&ServiceMock{CreateRateFunc: createRateFunc})
+
+			req := httptest.NewRequest(http.MethodPost, "/rates/", bytes.NewBufferString(tt.body))
+			w := httptest.NewRecorder()
+			handler.PostRates(w, req)
+
+			resp := w.Result()
+			if resp.StatusCode != tt.expectedStatusCode {
+				t.Errorf("expected status code %d, got %d", tt.expectedStatusCode, resp.StatusCode)
+			}
+
+			body := w.Body.String()
+			if !strings.Contains(body, tt.expectedBody) {
+				t.Errorf("expected body to contain %s, got %s", tt.expectedBody, body)
+			}
+		})
+	}
+}
@@ -0,0 +1,87 @@
+package billing
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// rateRollupsQuery returns a query that selects the billable time of stopped
+// works on UTC days by the rate that applies to the works. The condition
+// filters the works and the days.
+func rateRollupsQuery(condition string) string {
+	return `
+		SELECT works.user_id AS user_id,
+			   works.task_id AS task_id,
+			   (days.started_at AT TIME ZONE 'UTC')::date AS day,
+			   rates.id AS rate_id,
+			   SUM(
+				   LEAST(works.stopped_at, days.started_at + interval '24 hours')
+					   - GREATEST(works.started_at, days.started_at)
+			   ) AS billable_duration
+		FROM works
+		JOIN LATERAL (` + WorkRateSubquery + `) AS rates ON true
+		CROSS JOIN LATERAL generate_series(
+			date_trunc('day', works.started_at, 'UTC'), works.stopped_at, interval '24 hours'
+		) AS days (started_at)
+		WHERE works.stopped_at IS NOT NULL AND works.billable AND (` + condition + `)
+		GROUP BY works.user_id, works.task_id, days.started_at, rates.id
+	`
+}
+
+// RefreshRateRollups recomputes the rate rollups of the works of the user on
+// the task for the UTC days from fromDay to toDay, both inclusive. A nil user
+// or task matches any, a nil toDay has no upper bound. It must be called in the
+// transaction that changes the works or the rates, after the change.
+func RefreshRateRollups(ctx context.Context, tx pgx.Tx, userID, taskID *int, fromDay time.Time, toDay *time.Time) error {
+	q := `
+		DELETE FROM work_rate_rollups
+		WHERE ($1::integer IS NULL OR user_id = $1)
+		  AND ($2::integer IS NULL OR task_id = $2)
+		  AND day >= $3::date
+		  AND ($4::date IS NULL OR day <= $4::date)
+	`
+	if _, err := tx.Exec(ctx, q, userID, taskID, fromDay, toDay); err != nil {
+		return errors.Join(errors.New("failed to delete rate rollups"), err)
+	}
+
+	q = `INSERT INTO work_rate_rollups (user_id, task_id, day, rate_id, billable_duration)` + rateRollupsQuery(`
+		($1::integer IS NULL OR works.user_id = $1)
+		AND ($2::integer IS NULL OR works.task_id = $2)
+		AND ($4::timestamptz IS NULL OR works.started_at < $4::timestamptz + interval '24 hours')
+		AND works.stopped_at >= $3
+		AND days.started_at >= $3
+		AND ($4::timestamptz IS NULL OR days.started_at <= $4)
+	`)
+	if _, err := tx.Exec(ctx, q, userID, taskID, fromDay, toDay); err != nil {
+		return errors.Join(errors.New("failed to insert rate rollups"), err)
+	}
+	return nil
+}
+
+// RebuildRateRollups recomputes all rate rollups from the works and the rates.
+func RebuildRateRollups(ctx context.Context, tx pgx.Tx) error {
+	if _, err := tx.Exec(ctx, `DELETE FROM work_rate_rollups`); err != nil {
+		return errors.Join(errors.New("failed to delete rate rollups"), err)
+	}
+
+	q := `INSERT INTO work_rate_rollups (user_id, task_id, day, rate_id, billable_duration)` + rateRollupsQuery(`true`)
+	if _, err := tx.Exec(ctx, q); err != nil {
+		return errors.Join(errors.New("failed to insert rate rollups"), err)
+	}
+	return nil
+}
+
+// refreshRateRollupsOf recomputes the rate rollups of the works that the rate
+// may apply to, which are the works in its scope started after it is in effect.
+// Tracking waits for the refresh to finish, reports don't.
+func refreshRateRollupsOf(ctx context.Context, tx pgx.Tx, r *Rate) error {
+	if _, err := tx.Exec(ctx, `LOCK TABLE work_rate_rollups IN EXCLUSIVE MODE`); err != nil {
+		return errors.Join(errors.New("failed to lock rate rollups"), err)
+	}
+	y, m, d := r.EffectiveFrom.UTC().Date()
+	fromDay := time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+	return RefreshRateRollups(ctx, tx, r.UserID, r.TaskID, fromDay, nil)
+}
@@ -0,0 +1,180 @@
+package billing
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/jackc/pgerrcode"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/kirillgashkov/timetrack/internal/app/database"
+	"github.com/shopspring/decimal"
+)
+
+var (
+	ErrRateNotFound      = errors.New("rate not found")
+	ErrRateAlreadyExists = errors.New("rate already exists")
+	ErrUserOrTaskInvalid = errors.New("user or task not found")
+)
+
+// Rate is an hourly rate of a user, a task, or a user working on a task.
+// When several rates apply to a work, the most specific one wins: a rate of
+// the user working on the task, then a rate of the task, then a rate of the
+// user. Among rates of the same kind the one with the latest EffectiveFrom not
+// after the start of the work wins.
+type Rate struct {
+	ID            int
+	UserID        *int            `db:"user_id"`
+	TaskID        *int            `db:"task_id"`
+	HourlyRate    decimal.Decimal `db:"hourly_rate"`
+	Currency      string
+	EffectiveFrom time.Time `db:"effective_from"`
+}
+
+type CreateRate struct {
+	UserID        *int
+	TaskID        *int
+	HourlyRate    decimal.Decimal
+	Currency      string
+	EffectiveFrom time.Time
+}
+
+type FilterRate struct {
+	UserID *int
+	TaskID *int
+}
+
+type Service interface {
+	CreateRate(ctx context.Context, create *CreateRate) (*Rate, error)
+	ListRates(ctx context.Context, filter *FilterRate) ([]Rate, error)
+	DeleteRate(ctx context.Context, id int) (*Rate, error)
+}
+
+type ServiceImpl struct {
+	db database.DB
+}
+
+func NewServiceImpl(db database.DB) *ServiceImpl {
+	return &ServiceImpl{db: db}
+}
+
+// CreateRate creates a rate and refreshes the rate rollups of the works it may
+// apply to.
+func (s *ServiceImpl) CreateRate(ctx context.Context, create *CreateRate) (*Rate, error) {
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return nil, errors.Join(errors.New("failed to start transaction"), err)
+	}
+	defer func(tx pgx.Tx) {
+		if txErr := tx.Rollback(ctx); txErr != nil && !errors.Is(txErr, pgx.ErrTxClosed) {
+			slog.Error("failed to rollback transaction", "error", txErr)
+		}
+	}(tx)
+
+	q := `
+		INSERT INTO rates (user_id, task_id, hourly_rate, currency, effective_from)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING id, user_id, task_id, hourly_rate, currency, effective_from
+	`
+	args := []any{
+		create.UserID,
+		create.TaskID,
+		create.HourlyRate,
+		create.Currency,
+		create.EffectiveFrom,
+	}
+	r, err := queryOneRate(ctx, tx, q, args...)
+	if err != nil {
+		return nil, err
+	}
+	if err = refreshRateRollupsOf(ctx, tx, r); err != nil {
+		return nil, err
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return nil, errors.Join(errors.New("failed to commit transaction"), err)
+	}
+	return r, nil
+}
+
+func (s *ServiceImpl) ListRates(ctx context.Context, filter *FilterRate) ([]Rate, error) {
+	q := `
+		SELECT id, user_id, task_id, hourly_rate, currency, effective_from
+		FROM rates
+		WHERE ($1::integer IS NULL OR user_id = $1) AND ($2::integer IS NULL OR task_id = $2)
+		ORDER BY effective_from DESC, id
+	`
+	return s.queryAllRates(ctx, q, filter.UserID, filter.TaskID)
+}
+
+// DeleteRate deletes a rate and refreshes the rate rollups of the works it may
+// have applied to.
+func (s *ServiceImpl) DeleteRate(ctx context.Context, id int) (*Rate, error) {
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return nil, errors.Join(errors.New("failed to start transaction"), err)
+	}
+	defer func(tx pgx.Tx) {
+		if txErr := tx.Rollback(ctx); txErr != nil && !errors.Is(txErr, pgx.ErrTxClosed) {
+			slog.Error("failed to rollback transaction", "error", txErr)
+		}
+	}(tx)
+
+	q := `
+		DELETE FROM rates
+		WHERE id = $1
+		RETURNING id, user_id, task_id, hourly_rate, currency, effective_from
+	`
+	r, err := queryOneRate(ctx, tx, q, id)
+	if err != nil {
+		return nil, err
+	}
+	if err = refreshRateRollupsOf(ctx, tx, r); err != nil {
+		return nil, err
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return nil, errors.Join(errors.New("failed to commit transaction"), err)
+	}
+	return r, nil
+}
+
+func (s *ServiceImpl) queryAllRates(ctx context.Context, query string, args ...any) ([]Rate, error) {
+	rows, err := s.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, errors.Join(errors.New("failed to select rates"), err)
+	}
+	defer rows.Close()
+
+	rates, err := pgx.CollectRows(rows, pgx.RowToStructByName[Rate])
+	if err != nil {
+		return nil, errors.Join(errors.New("failed to collect rates"), err)
+	}
+	return rates, nil
+}
+
+func queryOneRate(ctx context.Context, db database.DB, query string, args ...any) (*Rate, error) {
+	rows, err := db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, errors.Join(errors.New("failed to select rate"), err)
+	}
+	defer rows.Close()
+
+	rate, err := pgx.CollectExactlyOneRow(rows, pgx.RowToStructByName[Rate])
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == pgerrcode.UniqueViolation {
+			return nil, errors.Join(ErrRateAlreadyExists, err)
+		}
+		if errors.As(err, &pgErr) && pgErr.Code == pgerrcode.ForeignKeyViolation {
+			return nil, errors.Join(ErrUserOrTaskInvalid, err)
+		}
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrRateNotFound
+		}
+		return nil, errors.Join(errors.New("failed to collect rate"), err)
+	}
+	return &rate, nil
+}
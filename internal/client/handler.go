@@ -0,0 +1,141 @@
+package client
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/kirillgashkov/timetrack/api/timetrackapi/v1"
+	"github.com/kirillgashkov/timetrack/internal/app/api/apiutil"
+)
+
+type Handler struct {
+	service Service
+}
+
+func NewHandler(service Service) *Handler {
+	return &Handler{service: service}
+}
+
+// PostClients handles "POST /clients/".
+func (h *Handler) PostClients(w http.ResponseWriter, r *http.Request) {
+	var req *timetrackapi.CreateClientRequest
+	if err := apiutil.ReadJSON(r, &req); err != nil {
+		apiutil.MustWriteUnprocessableEntity(w, apiutil.ValidationError{"bad JSON"})
+		return
+	}
+	if strings.TrimSpace(req.Name) == "" {
+		apiutil.MustWriteUnprocessableEntity(w, apiutil.ValidationError{"missing name"})
+		return
+	}
+
+	c, err := h.service.Create(r.Context(), &CreateClient{Name: req.Name})
+	if err != nil {
+		if errors.Is(err, ErrAlreadyExists) {
+			apiutil.MustWriteError(w, "client already exists", http.StatusBadRequest)
+			return
+		}
+		apiutil.MustWriteInternalServerError(w, "failed to create client", err)
+		return
+	}
+
+	apiutil.MustWriteJSON(w, toClientResponse(c), http.StatusOK)
+}
+
+// GetClients handles "GET /clients/".
+func (h *Handler) GetClients(w http.ResponseWriter, r *http.Request, params timetrackapi.GetClientsParams) {
+	page, ve := apiutil.ParsePage(r, params.Cursor, params.Offset, params.Limit)
+	if ve != nil {
+		apiutil.MustWriteUnprocessableEntity(w, ve)
+		return
+	}
+
+	clients, more, err := h.service.List(r.Context(), page)
+	if err != nil {
+		apiutil.MustWriteInternalServerError(w, "failed to list clients", err)
+		return
+	}
+
+	resp := make([]*timetrackapi.ClientResponse, 0, len(clients))
+	for _, c := range clients {
+		resp = append(resp, toClientResponse(&c))
+	}
+	apiutil.SetPageLinks(w, r, page, clients, more, func(c *Client) []any { return []any{c.ID} })
+	apiutil.MustWriteJSON(w, resp, http.StatusOK)
+}
+
+// GetClientsId handles "GET /clients/{id}".
+//
+//nolint:revive
+func (h *Handler) GetClientsId(w http.ResponseWriter, r *http.Request, id int) {
+	c, err := h.service.Get(r.Context(), id)
+	if err != nil {
+		if errors.Is(err, ErrNotFound) {
+			apiutil.MustWriteError(w, "client not found", http.StatusNotFound)
+			return
+		}
+		apiutil.MustWriteInternalServerError(w, "failed to get client", err)
+		return
+	}
+
+	apiutil.MustWriteJSON(w, toClientResponse(c), http.StatusOK)
+}
+
+// PatchClientsId handles "PATCH /clients/{id}".
+//
+//nolint:revive
+func (h *Handler) PatchClientsId(w http.ResponseWriter, r *http.Request, id int) {
+	var req *timetrackapi.UpdateClientRequest
+	if err := apiutil.ReadJSON(r, &req); err != nil {
+		apiutil.MustWriteUnprocessableEntity(w, apiutil.ValidationError{"bad JSON"})
+		return
+	}
+	if req.Name != nil && strings.TrimSpace(*req.Name) == "" {
+		apiutil.MustWriteUnprocessableEntity(w, apiutil.ValidationError{"invalid name, must not be empty"})
+		return
+	}
+
+	c, err := h.service.Update(r.Context(), id, &UpdateClient{Name: req.Name})
+	if err != nil {
+		if errors.Is(err, ErrNotFound) {
+			apiutil.MustWriteError(w, "client not found", http.StatusNotFound)
+			return
+		}
+		if errors.Is(err, ErrAlreadyExists) {
+			apiutil.MustWriteError(w, "client already exists", http.StatusBadRequest)
+			return
+		}
+		apiutil.MustWriteInternalServerError(w, "failed to update client", err)
+		return
+	}
+
+	apiutil.MustWriteJSON(w, toClientResponse(c), http.StatusOK)
+}
+
+// DeleteClientsId handles "DELETE /clients/{id}".
+//
+//nolint:revive
+func (h *Handler) DeleteClientsId(w http.ResponseWriter, r *http.Request, id int) {
+	c, err := h.service.Delete(r.Context(), id)
+	if err != nil {
+		if errors.Is(err, ErrNotFound) {
+			apiutil.MustWriteError(w, "client not found", http.StatusNotFound)
+			return
+		}
+		if errors.Is(err, ErrInUse) {
+			apiutil.MustWriteError(w, "client has projects or invoices", http.StatusConflict)
+			return
+		}
+		apiutil.MustWriteInternalServerError(w, "failed to delete client", err)
+		return
+	}
+
+	apiutil.MustWriteJSON(w, toClientResponse(c), http.StatusOK)
+}
+
+func toClientResponse(c *Client) *timetrackapi.ClientResponse {
+	return &timetrackapi.ClientResponse{
+		Id:   c.ID,
+		Name: c.Name,
+	}
+}
@@ -0,0 +1,119 @@
+package client
+
+import (
+	"context"
+	"errors"
+
+	"github.com/jackc/pgerrcode"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/kirillgashkov/timetrack/internal/app/database"
+)
+
+var (
+	ErrNotFound      = errors.New("client not found")
+	ErrAlreadyExists = errors.New("client already exists")
+	ErrInUse         = errors.New("client has projects or invoices")
+)
+
+type Client struct {
+	ID   int
+	Name string
+}
+
+type CreateClient struct {
+	Name string
+}
+
+type UpdateClient struct {
+	Name *string
+}
+
+type Service interface {
+	Create(ctx context.Context, create *CreateClient) (*Client, error)
+	Get(ctx context.Context, id int) (*Client, error)
+	List(ctx context.Context, page *database.Page) ([]Client, bool, error)
+	Update(ctx context.Context, id int, update *UpdateClient) (*Client, error)
+	Delete(ctx context.Context, id int) (*Client, error)
+}
+
+type ServiceImpl struct {
+	db database.DB
+}
+
+func NewServiceImpl(db database.DB) *ServiceImpl {
+	return &ServiceImpl{db: db}
+}
+
+func (s *ServiceImpl) Create(ctx context.Context, create *CreateClient) (*Client, error) {
+	q := `INSERT INTO clients (name) VALUES ($1) RETURNING id, name`
+	return s.queryOne(ctx, q, create.Name)
+}
+
+func (s *ServiceImpl) Get(ctx context.Context, id int) (*Client, error) {
+	q := `SELECT id, name FROM clients WHERE id = $1`
+	return s.queryOne(ctx, q, id)
+}
+
+// List lists the clients sorted by ID and reports whether there are more
+// clients past the page.
+func (s *ServiceImpl) List(ctx context.Context, page *database.Page) ([]Client, bool, error) {
+	pageCondition, pageClauses, pageArgs := page.Keyset(1, "id")
+	q := `SELECT id, name FROM clients WHERE ` + pageCondition + ` ` + pageClauses
+	clients, err := s.queryAll(ctx, q, pageArgs...)
+	if err != nil {
+		return nil, false, err
+	}
+	clients, more := database.PageItems(page, clients)
+	return clients, more, nil
+}
+
+func (s *ServiceImpl) Update(ctx context.Context, id int, update *UpdateClient) (*Client, error) {
+	q := `UPDATE clients SET name = coalesce($1, name) WHERE id = $2 RETURNING id, name`
+	return s.queryOne(ctx, q, update.Name, id)
+}
+
+// Delete deletes the client. Clients with projects or invoices cannot be
+// deleted.
+func (s *ServiceImpl) Delete(ctx context.Context, id int) (*Client, error) {
+	q := `DELETE FROM clients WHERE id = $1 RETURNING id, name`
+	return s.queryOne(ctx, q, id)
+}
+
+func (s *ServiceImpl) queryAll(ctx context.Context, query string, args ...any) ([]Client, error) {
+	rows, err := s.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, errors.Join(errors.New("failed to select clients"), err)
+	}
+	defer rows.Close()
+
+	clients, err := pgx.CollectRows(rows, pgx.RowToStructByName[Client])
+	if err != nil {
+		return nil, errors.Join(errors.New("failed to collect clients"), err)
+	}
+	return clients, nil
+}
+
+func (s *ServiceImpl) queryOne(ctx context.Context, query string, args ...any) (*Client, error) {
+	rows, err := s.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, errors.Join(errors.New("failed to select client"), err)
+	}
+	defer rows.Close()
+
+	client, err := pgx.CollectExactlyOneRow(rows, pgx.RowToStructByName[Client])
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == pgerrcode.UniqueViolation {
+			return nil, errors.Join(ErrAlreadyExists, err)
+		}
+		if errors.As(err, &pgErr) && pgErr.Code == pgerrcode.ForeignKeyViolation {
+			return nil, errors.Join(ErrInUse, err)
+		}
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, errors.Join(ErrNotFound, ErrNotFound)
+		}
+		return nil, errors.Join(errors.New("failed to collect client"), err)
+	}
+	return &client, nil
+}
@@ -0,0 +1,220 @@
+package field
+
+import (
+	"errors"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/kirillgashkov/timetrack/api/timetrackapi/v1"
+	"github.com/kirillgashkov/timetrack/internal/app/api/apiutil"
+)
+
+type Handler struct {
+	service Service
+}
+
+func NewHandler(service Service) *Handler {
+	return &Handler{service: service}
+}
+
+// PostCustomFields handles "POST /custom-fields/".
+func (h *Handler) PostCustomFields(w http.ResponseWriter, r *http.Request) {
+	var req *timetrackapi.CreateCustomFieldRequest
+	if err := apiutil.ReadJSON(r, &req); err != nil {
+		apiutil.MustWriteUnprocessableEntity(w, apiutil.ValidationError{"bad JSON"})
+		return
+	}
+	create, ve := createFieldFromRequest(req)
+	if ve != nil {
+		apiutil.MustWriteUnprocessableEntity(w, ve)
+		return
+	}
+
+	f, err := h.service.Create(r.Context(), create)
+	if err != nil {
+		if errors.Is(err, ErrAlreadyExists) {
+			apiutil.MustWriteError(w, "custom field already exists", http.StatusBadRequest)
+			return
+		}
+		apiutil.MustWriteInternalServerError(w, "failed to create custom field", err)
+		return
+	}
+
+	apiutil.MustWriteJSON(w, toFieldResponse(f), http.StatusOK)
+}
+
+func createFieldFromRequest(req *timetrackapi.CreateCustomFieldRequest) (*CreateField, apiutil.ValidationError) {
+	e := make(apiutil.ValidationError, 0)
+
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		e = append(e, "missing name")
+	}
+	t := Type(req.Type)
+	var options []string
+	switch t {
+	case TypeEnum:
+		if req.Options == nil || len(*req.Options) == 0 {
+			e = append(e, "missing options, required for enum fields")
+		} else {
+			var msg string
+			if options, msg = normalizeOptions(*req.Options); msg != "" {
+				e = append(e, msg)
+			}
+		}
+	case TypeText, TypeNumber, TypeDate:
+		if req.Options != nil && len(*req.Options) > 0 {
+			e = append(e, "invalid options, allowed for enum fields only")
+		}
+	default:
+		e = append(e, "invalid type, must be one of text, number, enum, date")
+	}
+
+	if len(e) > 0 {
+		return nil, e
+	}
+	return &CreateField{Name: name, Type: t, Options: options}, nil
+}
+
+// normalizeOptions trims the options and checks that they are not empty and
+// unique. It returns a validation message if they aren't.
+func normalizeOptions(options []string) ([]string, string) {
+	normalized := make([]string, 0, len(options))
+	for _, o := range options {
+		o = strings.TrimSpace(o)
+		if o == "" {
+			return nil, "invalid options, must not be empty"
+		}
+		if slices.Contains(normalized, o) {
+			return nil, "invalid options, must be unique"
+		}
+		normalized = append(normalized, o)
+	}
+	return normalized, ""
+}
+
+// GetCustomFields handles "GET /custom-fields/".
+func (h *Handler) GetCustomFields(w http.ResponseWriter, r *http.Request, params timetrackapi.GetCustomFieldsParams) {
+	page, ve := apiutil.ParsePage(r, params.Cursor, params.Offset, params.Limit)
+	if ve != nil {
+		apiutil.MustWriteUnprocessableEntity(w, ve)
+		return
+	}
+
+	fields, more, err := h.service.List(r.Context(), page)
+	if err != nil {
+		apiutil.MustWriteInternalServerError(w, "failed to list custom fields", err)
+		return
+	}
+
+	resp := make([]*timetrackapi.CustomFieldResponse, 0, len(fields))
+	for _, f := range fields {
+		resp = append(resp, toFieldResponse(&f))
+	}
+	apiutil.SetPageLinks(w, r, page, fields, more, func(f *Field) []any { return []any{f.Name, f.ID} })
+	apiutil.MustWriteJSON(w, resp, http.StatusOK)
+}
+
+// GetCustomFieldsId handles "GET /custom-fields/{id}".
+//
+//nolint:revive
+func (h *Handler) GetCustomFieldsId(w http.ResponseWriter, r *http.Request, id int) {
+	f, err := h.service.Get(r.Context(), id)
+	if err != nil {
+		if errors.Is(err, ErrNotFound) {
+			apiutil.MustWriteError(w, "custom field not found", http.StatusNotFound)
+			return
+		}
+		apiutil.MustWriteInternalServerError(w, "failed to get custom field", err)
+		return
+	}
+
+	apiutil.MustWriteJSON(w, toFieldResponse(f), http.StatusOK)
+}
+
+// PatchCustomFieldsId handles "PATCH /custom-fields/{id}".
+//
+//nolint:revive
+func (h *Handler) PatchCustomFieldsId(w http.ResponseWriter, r *http.Request, id int) {
+	var req *timetrackapi.UpdateCustomFieldRequest
+	if err := apiutil.ReadJSON(r, &req); err != nil {
+		apiutil.MustWriteUnprocessableEntity(w, apiutil.ValidationError{"bad JSON"})
+		return
+	}
+	update := &UpdateField{}
+	if req.Name != nil {
+		name := strings.TrimSpace(*req.Name)
+		if name == "" {
+			apiutil.MustWriteUnprocessableEntity(w, apiutil.ValidationError{"invalid name, must not be empty"})
+			return
+		}
+		update.Name = &name
+	}
+	if req.Options != nil {
+		if len(*req.Options) == 0 {
+			apiutil.MustWriteUnprocessableEntity(w, apiutil.ValidationError{"invalid options, must not be empty"})
+			return
+		}
+		options, msg := normalizeOptions(*req.Options)
+		if msg != "" {
+			apiutil.MustWriteUnprocessableEntity(w, apiutil.ValidationError{msg})
+			return
+		}
+		update.Options = options
+	}
+
+	f, err := h.service.Update(r.Context(), id, update)
+	if err != nil {
+		if errors.Is(err, ErrNotFound) {
+			apiutil.MustWriteError(w, "custom field not found", http.StatusNotFound)
+			return
+		}
+		if errors.Is(err, ErrAlreadyExists) {
+			apiutil.MustWriteError(w, "custom field already exists", http.StatusBadRequest)
+			return
+		}
+		if errors.Is(err, ErrNotEnum) {
+			apiutil.MustWriteUnprocessableEntity(w, apiutil.ValidationError{"invalid options, allowed for enum fields only"})
+			return
+		}
+		if errors.Is(err, ErrOptionInUse) {
+			apiutil.MustWriteError(w, "removed options are values of tasks", http.StatusConflict)
+			return
+		}
+		apiutil.MustWriteInternalServerError(w, "failed to update custom field", err)
+		return
+	}
+
+	apiutil.MustWriteJSON(w, toFieldResponse(f), http.StatusOK)
+}
+
+// DeleteCustomFieldsId handles "DELETE /custom-fields/{id}".
+//
+//nolint:revive
+func (h *Handler) DeleteCustomFieldsId(w http.ResponseWriter, r *http.Request, id int) {
+	f, err := h.service.Delete(r.Context(), id)
+	if err != nil {
+		if errors.Is(err, ErrNotFound) {
+			apiutil.MustWriteError(w, "custom field not found", http.StatusNotFound)
+			return
+		}
+		apiutil.MustWriteInternalServerError(w, "failed to delete custom field", err)
+		return
+	}
+
+	apiutil.MustWriteJSON(w, toFieldResponse(f), http.StatusOK)
+}
+
+func toFieldResponse(f *Field) *timetrackapi.CustomFieldResponse {
+	options := f.Options
+	if options == nil {
+		options = []string{}
+	}
+	return &timetrackapi.CustomFieldResponse{
+		Id:      f.ID,
+		Name:    f.Name,
+		Type:    timetrackapi.CustomFieldType(f.Type),
+		Options: options,
+	}
+}
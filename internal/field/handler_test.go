@@ -0,0 +1,116 @@
+package field
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+
+	"github.com/kirillgashkov/timetrack/internal/app/database"
+)
+
+type ServiceMock struct {
+	CreateFunc func(ctx context.Context, create *CreateField) (*Field, error)
+	GetFunc    func(ctx context.Context, id int) (*Field, error)
+	ListFunc   func(ctx context.Context, page *database.Page) ([]Field, bool, error)
+	UpdateFunc func(ctx context.Context, id int, update *UpdateField) (*Field, error)
+	DeleteFunc func(ctx context.Context, id int) (*Field, error)
+}
+
+func (s *ServiceMock) Create(ctx context.Context, create *CreateField) (*Field, error) {
+	return s.CreateFunc(ctx, create)
+}
+
+func (s *ServiceMock) Get(ctx context.Context, id int) (*Field, error) {
+	return s.GetFunc(ctx, id)
+}
+
+func (s *ServiceMock) List(ctx context.Context, page *database.Page) ([]Field, bool, error) {
+	return s.ListFunc(ctx, page)
+}
+
+func (s *ServiceMock) Update(ctx context.Context, id int, update *UpdateField) (*Field, error) {
+	return s.UpdateFunc(ctx, id, update)
+}
+
+func (s *ServiceMock) Delete(ctx context.Context, id int) (*Field, error) {
+	return s.DeleteFunc(ctx, id)
+}
+
+func TestPostCustomFields(t *testing.T) {
+	tests := []struct {
+		name               string
+		body               string
+		expectedOptions    []string
+		expectedStatusCode int
+	}{
+		{"text", `{"name":"Ticket","type":"text"}`, nil, http.StatusOK},
+		{"enum", `{"name":"Priority","type":"enum","options":[" high ","low"]}`, []string{"high", "low"}, http.StatusOK},
+		{"enum without options", `{"name":"Priority","type":"enum"}`, nil, http.StatusUnprocessableEntity},
+		{"repeated options", `{"name":"Priority","type":"enum","options":["high","high"]}`, nil,
+			http.StatusUnprocessableEntity},
+		{"options of text", `{"name":"Ticket","type":"text","options":["a"]}`, nil, http.StatusUnprocessableEntity},
+		{"unknown type", `{"name":"Ticket","type":"url"}`, nil, http.StatusUnprocessableEntity},
+		{"empty name", `{"name":" ","type":"date"}`, nil, http.StatusUnprocessableEntity},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := NewHandler(&ServiceMock{
+				CreateFunc: func(_ context.Context, create *CreateField) (*Field, error) {
+					if !slices.Equal(create.Options, tt.expectedOptions) {
+						t.Errorf("expected options %v, got %v", tt.expectedOptions, create.Options)
+					}
+					return &Field{ID: 1, Name: create.Name, Type: create.Type, Options: create.Options}, nil
+				},
+			})
+
+			req := httptest.NewRequest(http.MethodPost, "/custom-fields/", bytes.NewBufferString(tt.body))
+			w := httptest.NewRecorder()
+			handler.PostCustomFields(w, req)
+
+			if w.Code != tt.expectedStatusCode {
+				t.Errorf("expected status code %d, got %d: %s", tt.expectedStatusCode, w.Code, w.Body.String())
+			}
+		})
+	}
+}
+
+func TestPatchCustomFieldsId(t *testing.T) {
+	tests := []struct {
+		name               string
+		body               string
+		updateErr          error
+		expectedStatusCode int
+	}{
+		{"rename", `{"name":"Priority"}`, nil, http.StatusOK},
+		{"options", `{"options":["high","low"]}`, nil, http.StatusOK},
+		{"no options", `{"options":[]}`, nil, http.StatusUnprocessableEntity},
+		{"not an enum", `{"options":["high"]}`, ErrNotEnum, http.StatusUnprocessableEntity},
+		{"option in use", `{"options":["high"]}`, ErrOptionInUse, http.StatusConflict},
+		{"not found", `{"name":"Priority"}`, ErrNotFound, http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := NewHandler(&ServiceMock{
+				UpdateFunc: func(_ context.Context, id int, update *UpdateField) (*Field, error) {
+					if tt.updateErr != nil {
+						return nil, tt.updateErr
+					}
+					return &Field{ID: id, Name: "Priority", Type: TypeEnum, Options: update.Options}, nil
+				},
+			})
+
+			req := httptest.NewRequest(http.MethodPatch, "/custom-fields/1", bytes.NewBufferString(tt.body))
+			w := httptest.NewRecorder()
+			handler.PatchCustomFieldsId(w, req, 1)
+
+			if w.Code != tt.expectedStatusCode {
+				t.Errorf("expected status code %d, got %d: %s", tt.expectedStatusCode, w.Code, w.Body.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,226 @@
+package field
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgerrcode"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/kirillgashkov/timetrack/internal/app/database"
+	"github.com/shopspring/decimal"
+)
+
+var (
+	ErrNotFound      = errors.New("custom field not found")
+	ErrAlreadyExists = errors.New("custom field already exists")
+	ErrNotEnum       = errors.New("custom field is not an enum")
+	ErrOptionInUse   = errors.New("custom field option is in use")
+)
+
+// Type is the type of the values of a custom field.
+type Type string
+
+const (
+	TypeText   Type = "text"
+	TypeNumber Type = "number"
+	TypeEnum   Type = "enum"
+	TypeDate   Type = "date"
+)
+
+// Field is a custom field defined by administrators to add metadata to tasks,
+// e.g. a ticket number or a cost center. Field names are unique regardless of
+// case. Options are the values of an enum field and are empty for other
+// types.
+type Field struct {
+	ID      int
+	Name    string
+	Type    Type     `db:"type"`
+	Options []string `db:"options"`
+}
+
+// ValueError describes a value that doesn't match the type of its field.
+type ValueError struct {
+	Field  string
+	Reason string
+}
+
+func (e *ValueError) Error() string {
+	return fmt.Sprintf("invalid value of custom field %q, %s", e.Field, e.Reason)
+}
+
+// Normalize checks that the value matches the type of the field and returns it
+// in the form it is stored in, so that equal values compare equal. Text is
+// trimmed, numbers are decimal numbers without trailing zeros, and dates are
+// formatted as YYYY-MM-DD.
+func (f *Field) Normalize(value string) (string, error) {
+	switch f.Type {
+	case TypeNumber:
+		d, err := decimal.NewFromString(strings.TrimSpace(value))
+		if err != nil {
+			return "", &ValueError{Field: f.Name, Reason: "must be a decimal number"}
+		}
+		return d.String(), nil
+	case TypeEnum:
+		if !slices.Contains(f.Options, value) {
+			return "", &ValueError{Field: f.Name, Reason: "must be one of " + strings.Join(f.Options, ", ")}
+		}
+		return value, nil
+	case TypeDate:
+		d, err := time.Parse(time.DateOnly, strings.TrimSpace(value))
+		if err != nil {
+			return "", &ValueError{Field: f.Name, Reason: "must be a date in the YYYY-MM-DD format"}
+		}
+		return d.Format(time.DateOnly), nil
+	default:
+		v := strings.TrimSpace(value)
+		if v == "" {
+			return "", &ValueError{Field: f.Name, Reason: "must not be empty"}
+		}
+		return v, nil
+	}
+}
+
+type CreateField struct {
+	Name    string
+	Type    Type
+	Options []string
+}
+
+// UpdateField updates a custom field, Options replace the options of an enum
+// field if not nil. The type of a field can't change.
+type UpdateField struct {
+	Name    *string
+	Options []string
+}
+
+type Service interface {
+	Create(ctx context.Context, create *CreateField) (*Field, error)
+	Get(ctx context.Context, id int) (*Field, error)
+	List(ctx context.Context, page *database.Page) ([]Field, bool, error)
+	Update(ctx context.Context, id int, update *UpdateField) (*Field, error)
+	Delete(ctx context.Context, id int) (*Field, error)
+}
+
+type ServiceImpl struct {
+	db database.DB
+}
+
+func NewServiceImpl(db database.DB) *ServiceImpl {
+	return &ServiceImpl{db: db}
+}
+
+const fieldColumns = `id, name, type, options`
+
+func (s *ServiceImpl) Create(ctx context.Context, create *CreateField) (*Field, error) {
+	options := create.Options
+	if options == nil {
+		options = []string{}
+	}
+	q := `INSERT INTO custom_fields (name, type, options) VALUES ($1, $2, $3) RETURNING ` + fieldColumns
+	return queryOne(ctx, s.db, q, create.Name, create.Type, options)
+}
+
+func (s *ServiceImpl) Get(ctx context.Context, id int) (*Field, error) {
+	q := `SELECT ` + fieldColumns + ` FROM custom_fields WHERE id = $1`
+	return queryOne(ctx, s.db, q, id)
+}
+
+// List lists the fields sorted by name case-insensitively and reports whether
+// there are more fields past the page.
+func (s *ServiceImpl) List(ctx context.Context, page *database.Page) ([]Field, bool, error) {
+	pageCondition, pageClauses, pageArgs := page.Keyset(1, "lower(name)", "id")
+	q := `SELECT ` + fieldColumns + ` FROM custom_fields WHERE ` + pageCondition + ` ` + pageClauses
+	rows, err := s.db.Query(ctx, q, pageArgs...)
+	if err != nil {
+		return nil, false, errors.Join(errors.New("failed to select custom fields"), err)
+	}
+	defer rows.Close()
+
+	fields, err := pgx.CollectRows(rows, pgx.RowToStructByName[Field])
+	if err != nil {
+		return nil, false, errors.Join(errors.New("failed to collect custom fields"), err)
+	}
+	fields, more := database.PageItems(page, fields)
+	return fields, more, nil
+}
+
+// Update updates the custom field. Options of an enum field can't be removed
+// while tasks have them as values.
+func (s *ServiceImpl) Update(ctx context.Context, id int, update *UpdateField) (*Field, error) {
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return nil, errors.Join(errors.New("failed to start transaction"), err)
+	}
+	defer func(tx pgx.Tx) {
+		if txErr := tx.Rollback(ctx); txErr != nil && !errors.Is(txErr, pgx.ErrTxClosed) {
+			slog.Error("failed to rollback transaction", "error", txErr)
+		}
+	}(tx)
+
+	f, err := queryOne(ctx, tx, `SELECT `+fieldColumns+` FROM custom_fields WHERE id = $1 FOR UPDATE`, id)
+	if err != nil {
+		return nil, err
+	}
+	if update.Options != nil {
+		if f.Type != TypeEnum {
+			return nil, ErrNotEnum
+		}
+		q := `SELECT EXISTS (SELECT 1 FROM task_field_values WHERE field_id = $1 AND NOT (value = ANY ($2)))`
+		var inUse bool
+		if err = tx.QueryRow(ctx, q, id, update.Options).Scan(&inUse); err != nil {
+			return nil, errors.Join(errors.New("failed to select custom field values"), err)
+		}
+		if inUse {
+			return nil, ErrOptionInUse
+		}
+	}
+
+	q := `
+		UPDATE custom_fields
+		SET name = coalesce($1, name),
+			options = coalesce($2, options)
+		WHERE id = $3
+		RETURNING ` + fieldColumns
+	f, err = queryOne(ctx, tx, q, update.Name, update.Options, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return nil, errors.Join(errors.New("failed to commit transaction"), err)
+	}
+	return f, nil
+}
+
+// Delete deletes the custom field and its values on tasks.
+func (s *ServiceImpl) Delete(ctx context.Context, id int) (*Field, error) {
+	q := `DELETE FROM custom_fields WHERE id = $1 RETURNING ` + fieldColumns
+	return queryOne(ctx, s.db, q, id)
+}
+
+func queryOne(ctx context.Context, db database.DB, query string, args ...any) (*Field, error) {
+	rows, err := db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, errors.Join(errors.New("failed to select custom field"), err)
+	}
+	defer rows.Close()
+
+	f, err := pgx.CollectExactlyOneRow(rows, pgx.RowToStructByName[Field])
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == pgerrcode.UniqueViolation {
+			return nil, errors.Join(ErrAlreadyExists, err)
+		}
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, errors.Join(errors.New("failed to collect custom field"), err)
+	}
+	return &f, nil
+}
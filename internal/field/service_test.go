@@ -0,0 +1,44 @@
+package field
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestFieldNormalize(t *testing.T) {
+	tests := []struct {
+		typ    Type
+		value  string
+		want   string
+		wantOK bool
+	}{
+		{TypeText, " INC-42 ", "INC-42", true},
+		{TypeText, " ", "", false},
+		{TypeNumber, "12.50", "12.5", true},
+		{TypeNumber, "-3", "-3", true},
+		{TypeNumber, "twelve", "", false},
+		{TypeEnum, "high", "high", true},
+		{TypeEnum, "High", "", false},
+		{TypeDate, "2024-07-01", "2024-07-01", true},
+		{TypeDate, "2024-02-30", "", false},
+		{TypeDate, "01.07.2024", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.typ)+" "+tt.value, func(t *testing.T) {
+			f := &Field{Name: "Field", Type: tt.typ}
+			if tt.typ == TypeEnum {
+				f.Options = []string{"high", "low"}
+			}
+
+			got, err := f.Normalize(tt.value)
+			if got != tt.want || (err == nil) != tt.wantOK {
+				t.Errorf("expected %q, %t, got %q, %v", tt.want, tt.wantOK, got, err)
+			}
+			var ve *ValueError
+			if err != nil && !errors.As(err, &ve) {
+				t.Errorf("expected a value error, got %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,215 @@
+package invoicing
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"net/http"
+	"regexp"
+
+	"github.com/kirillgashkov/timetrack/api/timetrackapi/v1"
+	"github.com/kirillgashkov/timetrack/internal/app/api/apiutil"
+)
+
+var currencyRegexp = regexp.MustCompile(`^[A-Z]{3}$`)
+
+type Handler struct {
+	service Service
+}
+
+func NewHandler(service Service) *Handler {
+	return &Handler{service: service}
+}
+
+// GetInvoices handles "GET /invoices/".
+func (h *Handler) GetInvoices(w http.ResponseWriter, r *http.Request, params timetrackapi.GetInvoicesParams) {
+	page, ve := apiutil.ParsePage(r, params.Cursor, params.Offset, params.Limit)
+	if ve != nil {
+		apiutil.MustWriteUnprocessableEntity(w, ve)
+		return
+	}
+
+	filter := &FilterInvoice{ClientID: params.ClientId}
+	invoices, more, err := h.service.List(r.Context(), filter, page)
+	if err != nil {
+		apiutil.MustWriteInternalServerError(w, "failed to list invoices", err)
+		return
+	}
+
+	resp := make([]*timetrackapi.InvoiceResponse, 0, len(invoices))
+	for _, i := range invoices {
+		resp = append(resp, toInvoiceResponse(&i))
+	}
+	apiutil.SetPageLinks(w, r, page, invoices, more, func(i *Invoice) []any { return []any{i.ID} })
+	apiutil.MustWriteJSON(w, resp, http.StatusOK)
+}
+
+// PostInvoices handles "POST /invoices/".
+func (h *Handler) PostInvoices(w http.ResponseWriter, r *http.Request) {
+	create, err := parseAndValidateCreateInvoiceRequest(r)
+	if err != nil {
+		var ve apiutil.ValidationError
+		if errors.As(err, &ve) {
+			apiutil.MustWriteUnprocessableEntity(w, ve)
+			return
+		}
+		apiutil.MustWriteInternalServerError(w, "failed to parse and validate request", err)
+		return
+	}
+
+	invoice, err := h.service.Create(r.Context(), create)
+	if err != nil {
+		if errors.Is(err, ErrClientNotFound) {
+			apiutil.MustWriteUnprocessableEntity(w, apiutil.ValidationError{"client not found"})
+			return
+		}
+		if errors.Is(err, ErrNoBillableWorks) {
+			apiutil.MustWriteError(w, "no billable works to invoice", http.StatusBadRequest)
+			return
+		}
+		if errors.Is(err, ErrWorksBeingBilled) {
+			apiutil.MustWriteError(w, "works are being invoiced, try again", http.StatusBadRequest)
+			return
+		}
+		apiutil.MustWriteInternalServerError(w, "failed to create invoice", err)
+		return
+	}
+
+	apiutil.MustWriteJSON(w, toInvoiceResponse(invoice), http.StatusOK)
+}
+
+func parseAndValidateCreateInvoiceRequest(r *http.Request) (*CreateInvoice, error) {
+	var req *timetrackapi.CreateInvoiceRequest
+	if err := apiutil.ReadJSON(r, &req); err != nil {
+		return nil, errors.Join(apiutil.ValidationError{"bad JSON"}, err)
+	}
+
+	e := make([]string, 0)
+
+	if req.From.IsZero() {
+		e = append(e, "missing from")
+	}
+	if req.To.IsZero() {
+		e = append(e, "missing to")
+	}
+	if !req.From.Before(req.To) {
+		e = append(e, "from must be before to")
+	}
+	if !currencyRegexp.MatchString(req.Currency) {
+		e = append(e, "invalid currency, must be an ISO 4217 code")
+	}
+
+	if len(e) > 0 {
+		return nil, apiutil.ValidationError(e)
+	}
+
+	return &CreateInvoice{ClientID: req.ClientId, From: req.From, To: req.To, Currency: req.Currency}, nil
+}
+
+// GetInvoicesId handles "GET /invoices/{id}".
+//
+// If the client accepts "application/pdf", the invoice is rendered as a
+// printable document instead of JSON.
+//
+//nolint:revive
+func (h *Handler) GetInvoicesId(w http.ResponseWriter, r *http.Request, id int) {
+	invoice, err := h.service.Get(r.Context(), id)
+	if err != nil {
+		if errors.Is(err, ErrNotFound) {
+			apiutil.MustWriteError(w, "invoice not found", http.StatusNotFound)
+			return
+		}
+		apiutil.MustWriteInternalServerError(w, "failed to get invoice", err)
+		return
+	}
+
+	if apiutil.Accepts(r, "application/pdf") {
+		writeInvoicePDF(w, invoice)
+		return
+	}
+
+	apiutil.MustWriteJSON(w, toInvoiceResponse(invoice), http.StatusOK)
+}
+
+func writeInvoicePDF(w http.ResponseWriter, invoice *Invoice) {
+	var buf bytes.Buffer
+	if err := WriteInvoicePDF(&buf, invoice); err != nil {
+		apiutil.MustWriteInternalServerError(w, "failed to render invoice", err)
+		return
+	}
+
+	filename := fmt.Sprintf("invoice-draft-%d.pdf", invoice.ID)
+	if invoice.Number != nil {
+		filename = fmt.Sprintf("invoice-%d.pdf", *invoice.Number)
+	}
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+	apiutil.MustWriteContent(w, buf.Bytes(), "application/pdf", http.StatusOK)
+}
+
+// DeleteInvoicesId handles "DELETE /invoices/{id}".
+//
+//nolint:revive
+func (h *Handler) DeleteInvoicesId(w http.ResponseWriter, r *http.Request, id int) {
+	invoice, err := h.service.Delete(r.Context(), id)
+	if err != nil {
+		h.writeModifyError(w, err, "failed to delete invoice")
+		return
+	}
+
+	apiutil.MustWriteJSON(w, toInvoiceResponse(invoice), http.StatusOK)
+}
+
+// PostInvoicesIdIssue handles "POST /invoices/{id}/issue".
+//
+//nolint:revive
+func (h *Handler) PostInvoicesIdIssue(w http.ResponseWriter, r *http.Request, id int) {
+	invoice, err := h.service.Issue(r.Context(), id)
+	if err != nil {
+		h.writeModifyError(w, err, "failed to issue invoice")
+		return
+	}
+
+	apiutil.MustWriteJSON(w, toInvoiceResponse(invoice), http.StatusOK)
+}
+
+func (h *Handler) writeModifyError(w http.ResponseWriter, err error, message string) {
+	if errors.Is(err, ErrNotFound) {
+		apiutil.MustWriteError(w, "invoice not found", http.StatusNotFound)
+		return
+	}
+	if errors.Is(err, ErrNotDraft) {
+		apiutil.MustWriteError(w, "invoice is already issued", http.StatusConflict)
+		return
+	}
+	apiutil.MustWriteInternalServerError(w, message, err)
+}
+
+func toInvoiceResponse(i *Invoice) *timetrackapi.InvoiceResponse {
+	lines := make([]timetrackapi.InvoiceLineResponse, 0, len(i.Lines))
+	for _, l := range i.Lines {
+		lines = append(lines, timetrackapi.InvoiceLineResponse{
+			Task: timetrackapi.TaskResponse{
+				Id:          l.Task.ID,
+				Description: l.Task.Description,
+				Billable:    l.Task.Billable,
+				ClientId:    l.Task.ClientID,
+			},
+			Hours:  apiutil.FormatHours(l.Duration),
+			Amount: l.Amount.StringFixed(2),
+		})
+	}
+
+	return &timetrackapi.InvoiceResponse{
+		Id:        i.ID,
+		Number:    i.Number,
+		Status:    timetrackapi.InvoiceResponseStatus(i.Status),
+		Client:    timetrackapi.ClientResponse{Id: i.Client.ID, Name: i.Client.Name},
+		From:      i.From,
+		To:        i.To,
+		Currency:  i.Currency,
+		CreatedAt: i.CreatedAt,
+		IssuedAt:  i.IssuedAt,
+		Lines:     lines,
+		Total:     i.Total().StringFixed(2),
+	}
+}
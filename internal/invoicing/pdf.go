@@ -0,0 +1,92 @@
+package invoicing
+
+import (
+	"errors"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/go-pdf/fpdf"
+	"github.com/kirillgashkov/timetrack/internal/app/api/apiutil"
+	"github.com/kirillgashkov/timetrack/internal/app/pdfutil"
+)
+
+const (
+	invoiceTaskWidth   = 110.0
+	invoiceHoursWidth  = 30.0
+	invoiceAmountWidth = 0.0
+	invoiceRowHeight   = 6.0
+)
+
+// WriteInvoicePDF renders the invoice as a printable PDF document with a line
+// for each task. Draft invoices are marked as such and have no number.
+func WriteInvoicePDF(w io.Writer, invoice *Invoice) error {
+	pdf := pdfutil.New("P")
+	pdf.SetTitle("Invoice", true)
+	pdf.SetAutoPageBreak(true, 15)
+	pdf.AddPage()
+
+	writeInvoiceHeader(pdf, invoice)
+	writeInvoiceTable(pdf, invoice)
+
+	if err := pdf.Output(w); err != nil {
+		return errors.Join(errors.New("failed to write PDF"), err)
+	}
+	return nil
+}
+
+func writeInvoiceHeader(pdf *fpdf.Fpdf, invoice *Invoice) {
+	title := "Invoice (draft)"
+	if invoice.Number != nil {
+		title = "Invoice No. " + strconv.Itoa(*invoice.Number)
+	}
+	pdf.SetFont(pdfutil.FontFamily, "B", 16)
+	pdf.CellFormat(0, 10, title, "", 1, "L", false, 0, "")
+
+	pdf.SetFont(pdfutil.FontFamily, "", 10)
+	if invoice.IssuedAt != nil {
+		pdf.CellFormat(0, invoiceRowHeight, "Date: "+invoice.IssuedAt.Format("2006-01-02"), "", 1, "L", false, 0, "")
+	}
+	pdf.CellFormat(0, invoiceRowHeight, "Bill to: "+invoice.Client.Name, "", 1, "L", false, 0, "")
+	pdf.CellFormat(0, invoiceRowHeight, "Period: "+invoicePeriod(invoice.From, invoice.To), "", 1, "L", false, 0, "")
+	pdf.Ln(invoiceRowHeight)
+}
+
+func writeInvoiceTable(pdf *fpdf.Fpdf, invoice *Invoice) {
+	pdf.SetFont(pdfutil.FontFamily, "B", 10)
+	pdf.CellFormat(invoiceTaskWidth, invoiceRowHeight, "Task", "1", 0, "L", false, 0, "")
+	pdf.CellFormat(invoiceHoursWidth, invoiceRowHeight, "Hours", "1", 0, "R", false, 0, "")
+	pdf.CellFormat(invoiceAmountWidth, invoiceRowHeight, "Amount, "+invoice.Currency, "1", 1, "R", false, 0, "")
+
+	pdf.SetFont(pdfutil.FontFamily, "", 10)
+	for _, l := range invoice.Lines {
+		task := "#" + strconv.Itoa(l.Task.ID) + " " + l.Task.Description
+		pdf.CellFormat(invoiceTaskWidth, invoiceRowHeight, truncate(pdf, task, invoiceTaskWidth), "1", 0, "L", false, 0, "")
+		pdf.CellFormat(invoiceHoursWidth, invoiceRowHeight, apiutil.FormatHours(l.Duration), "1", 0, "R", false, 0, "")
+		pdf.CellFormat(invoiceAmountWidth, invoiceRowHeight, l.Amount.StringFixed(2), "1", 1, "R", false, 0, "")
+	}
+
+	pdf.SetFont(pdfutil.FontFamily, "B", 10)
+	pdf.CellFormat(invoiceTaskWidth+invoiceHoursWidth, invoiceRowHeight, "Total", "1", 0, "L", false, 0, "")
+	pdf.CellFormat(invoiceAmountWidth, invoiceRowHeight, invoice.Total().StringFixed(2), "1", 1, "R", false, 0, "")
+}
+
+// invoicePeriod formats the period as a range of dates. The end of the period
+// is exclusive, so a period that ends at midnight ends on the previous day.
+func invoicePeriod(from, to time.Time) string {
+	last := to.In(from.Location()).Add(-time.Nanosecond)
+	return from.Format("2006-01-02") + " – " + last.Format("2006-01-02")
+}
+
+// truncate shortens the text with an ellipsis to fit into a cell of the width.
+func truncate(pdf *fpdf.Fpdf, s string, width float64) string {
+	width -= 2 * pdf.GetCellMargin()
+	if pdf.GetStringWidth(s) <= width {
+		return s
+	}
+	r := []rune(s)
+	for len(r) > 0 && pdf.GetStringWidth(string(r)+"…") > width {
+		r = r[:len(r)-1]
+	}
+	return string(r) + "…"
+}
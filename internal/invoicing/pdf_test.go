@@ -0,0 +1,100 @@
+package invoicing
+
+import (
+	"bytes"
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kirillgashkov/timetrack/internal/app/testutil"
+	"github.com/kirillgashkov/timetrack/internal/client"
+	"github.com/kirillgashkov/timetrack/internal/task"
+	"github.com/shopspring/decimal"
+)
+
+var update = flag.Bool("update", false, "update golden files")
+
+func TestWriteInvoicePDF(t *testing.T) {
+	number := 42
+	issuedAt := time.Date(2024, 7, 2, 10, 0, 0, 0, time.UTC)
+	lines := []InvoiceLine{
+		{
+			Task:     task.Task{ID: 3, Description: "Research market trends for sector analysis"},
+			Duration: 10*time.Hour + 35*time.Minute,
+			Amount:   decimal.RequireFromString("529.17"),
+		},
+		{
+			Task: task.Task{
+				ID:          1,
+				Description: "Подготовить коммерческое предложение для клиента А с расчётом стоимости работ по этапам",
+			},
+			Duration: 45 * time.Minute,
+			Amount:   decimal.RequireFromString("37.50"),
+		},
+	}
+
+	tests := []struct {
+		name    string
+		invoice *Invoice
+	}{
+		{
+			"issued",
+			&Invoice{
+				ID:       5,
+				Number:   &number,
+				Status:   "issued",
+				Client:   client.Client{ID: 1, Name: "ООО «Ромашка»"},
+				From:     time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC),
+				To:       time.Date(2024, 7, 1, 0, 0, 0, 0, time.UTC),
+				Currency: "RUB",
+				IssuedAt: &issuedAt,
+				Lines:    lines,
+			},
+		},
+		{
+			"draft",
+			&Invoice{
+				ID:       6,
+				Status:   "draft",
+				Client:   client.Client{ID: 2, Name: "Acme Corp"},
+				From:     time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC),
+				To:       time.Date(2024, 7, 1, 0, 0, 0, 0, time.UTC),
+				Currency: "USD",
+				Lines:    lines[:1],
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := WriteInvoicePDF(&buf, tt.invoice); err != nil {
+				t.Fatalf("expected no error, got: %v", err)
+			}
+
+			text, err := testutil.PDFText(buf.Bytes())
+			if err != nil {
+				t.Fatalf("failed to extract text: %v", err)
+			}
+			got := strings.Join(text, "\n") + "\n"
+
+			golden := filepath.Join("testdata", "invoice_"+tt.name+".golden")
+			if *update {
+				if err = os.WriteFile(golden, []byte(got), 0o600); err != nil {
+					t.Fatalf("failed to update golden file: %v", err)
+				}
+			}
+			want, err := os.ReadFile(golden)
+			if err != nil {
+				t.Fatalf("failed to read golden file: %v", err)
+			}
+
+			if got != string(want) {
+				t.Errorf("text mismatch, got:\n%s\nwant:\n%s", got, want)
+			}
+		})
+	}
+}
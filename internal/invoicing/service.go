@@ -0,0 +1,387 @@
+package invoicing
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/jackc/pgerrcode"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/kirillgashkov/timetrack/db/timetrackdb"
+	"github.com/kirillgashkov/timetrack/internal/app/database"
+	"github.com/kirillgashkov/timetrack/internal/billing"
+	"github.com/kirillgashkov/timetrack/internal/client"
+	"github.com/kirillgashkov/timetrack/internal/task"
+	"github.com/shopspring/decimal"
+)
+
+var (
+	ErrNotFound         = errors.New("invoice not found")
+	ErrNotDraft         = errors.New("invoice is not a draft")
+	ErrClientNotFound   = errors.New("client not found")
+	ErrNoBillableWorks  = errors.New("no billable works to invoice")
+	ErrWorksBeingBilled = errors.New("works are being invoiced concurrently")
+)
+
+// Invoice bills a client for works on the client's tasks. Draft invoices have
+// no number, the number is assigned when the invoice is issued.
+type Invoice struct {
+	ID        int
+	Number    *int
+	Status    string
+	Client    client.Client
+	From      time.Time
+	To        time.Time
+	Currency  string
+	CreatedAt time.Time
+	IssuedAt  *time.Time
+	Lines     []InvoiceLine
+}
+
+// InvoiceLine is the time spent on a task and its cost. Amount is the sum of
+// amounts of the invoiced works, each rounded to cents.
+type InvoiceLine struct {
+	Task     task.Task
+	Duration time.Duration
+	Amount   decimal.Decimal
+}
+
+func (i *Invoice) Total() decimal.Decimal {
+	total := decimal.Zero
+	for _, l := range i.Lines {
+		total = total.Add(l.Amount)
+	}
+	return total
+}
+
+type CreateInvoice struct {
+	ClientID int
+	From     time.Time
+	To       time.Time
+	Currency string
+}
+
+type FilterInvoice struct {
+	ClientID *int
+}
+
+type invoiceRow struct {
+	ID         int
+	Number     *int
+	Status     string
+	ClientID   int        `db:"client_id"`
+	ClientName string     `db:"client_name"`
+	PeriodFrom time.Time  `db:"period_from"`
+	PeriodTo   time.Time  `db:"period_to"`
+	Currency   string     `db:"currency"`
+	CreatedAt  time.Time  `db:"created_at"`
+	IssuedAt   *time.Time `db:"issued_at"`
+}
+
+type invoiceLineRow struct {
+	InvoiceID       int             `db:"invoice_id"`
+	TaskID          int             `db:"task_id"`
+	TaskDescription string          `db:"task_description"`
+	TaskBillable    bool            `db:"task_billable"`
+	TaskClientID    *int            `db:"task_client_id"`
+	Duration        time.Duration   `db:"duration"`
+	Amount          decimal.Decimal `db:"amount"`
+}
+
+type Service interface {
+	Create(ctx context.Context, create *CreateInvoice) (*Invoice, error)
+	Get(ctx context.Context, id int) (*Invoice, error)
+	List(ctx context.Context, filter *FilterInvoice, page *database.Page) ([]Invoice, bool, error)
+	Issue(ctx context.Context, id int) (*Invoice, error)
+	Delete(ctx context.Context, id int) (*Invoice, error)
+}
+
+type ServiceImpl struct {
+	db database.DB
+}
+
+func NewServiceImpl(db database.DB) *ServiceImpl {
+	return &ServiceImpl{db: db}
+}
+
+// Create drafts an invoice from billable works of the client's tasks that were
+// started in the period, are stopped, are not on another invoice, and are
+// charged in the currency. Works are charged at the rate in effect when they
+// were started. Works on deleted tasks or by deleted users aren't drafted.
+func (s *ServiceImpl) Create(ctx context.Context, create *CreateInvoice) (*Invoice, error) {
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return nil, errors.Join(errors.New("failed to start transaction"), err)
+	}
+	defer rollback(ctx, tx)
+
+	var id int
+	q := `
+		INSERT INTO invoices (client_id, period_from, period_to, currency, status)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING id
+	`
+	args := []any{create.ClientID, create.From, create.To, create.Currency, timetrackdb.InvoiceStatusDraft}
+	if err = tx.QueryRow(ctx, q, args...).Scan(&id); err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == pgerrcode.ForeignKeyViolation {
+			return nil, errors.Join(ErrClientNotFound, err)
+		}
+		return nil, errors.Join(errors.New("failed to insert invoice"), err)
+	}
+
+	q = `
+		INSERT INTO invoice_works (invoice_id, work_id, duration, hourly_rate, amount)
+		SELECT $1,
+			   works.id,
+			   works.stopped_at - works.started_at,
+			   rates.hourly_rate,
+			   ROUND(rates.hourly_rate * EXTRACT(EPOCH FROM works.stopped_at - works.started_at) / 3600, 2)
+		FROM works
+		JOIN tasks ON works.task_id = tasks.id
+		JOIN projects ON tasks.project_id = projects.id
+		JOIN users ON works.user_id = users.id
+		JOIN LATERAL (` + billing.WorkRateSubquery + `) AS rates ON true
+		WHERE projects.client_id = $2
+		  AND tasks.deleted_at IS NULL AND users.deleted_at IS NULL
+		  AND works.billable
+		  AND works.status = $3
+		  AND works.started_at >= $4 AND works.started_at < $5
+		  AND rates.currency = $6
+		  AND NOT EXISTS (SELECT 1 FROM invoice_works WHERE invoice_works.work_id = works.id)
+	`
+	args = []any{id, create.ClientID, timetrackdb.WorkStatusStopped, create.From, create.To, create.Currency}
+	tag, err := tx.Exec(ctx, q, args...)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == pgerrcode.UniqueViolation {
+			return nil, errors.Join(ErrWorksBeingBilled, err)
+		}
+		return nil, errors.Join(errors.New("failed to insert invoice works"), err)
+	}
+	if tag.RowsAffected() == 0 {
+		return nil, ErrNoBillableWorks
+	}
+
+	invoice, err := getInvoice(ctx, tx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err = tx.Commit(ctx); err != nil {
+		return nil, errors.Join(errors.New("failed to commit transaction"), err)
+	}
+	return invoice, nil
+}
+
+func (s *ServiceImpl) Get(ctx context.Context, id int) (*Invoice, error) {
+	return getInvoice(ctx, s.db, id)
+}
+
+// List lists the invoices that match the filter, latest first, and reports
+// whether there are more invoices past the page.
+func (s *ServiceImpl) List(ctx context.Context, filter *FilterInvoice, page *database.Page) ([]Invoice, bool, error) {
+	pageCondition, pageClauses, pageArgs := page.Keyset(2, "invoices.id DESC")
+	q := `
+		SELECT invoices.id, invoices.number, invoices.status, invoices.client_id, clients.name AS client_name,
+			   invoices.period_from, invoices.period_to, invoices.currency, invoices.created_at, invoices.issued_at
+		FROM invoices
+		JOIN clients ON invoices.client_id = clients.id
+		WHERE ($1::integer IS NULL OR invoices.client_id = $1) AND ` + pageCondition + `
+		` + pageClauses
+	invoices, err := queryInvoices(ctx, s.db, q, append([]any{filter.ClientID}, pageArgs...)...)
+	if err != nil {
+		return nil, false, err
+	}
+	invoices, more := database.PageItems(page, invoices)
+	return invoices, more, nil
+}
+
+// Issue assigns the next number to a draft invoice. Numbers are taken from a
+// counter that is updated in the same transaction, so they have no gaps.
+func (s *ServiceImpl) Issue(ctx context.Context, id int) (*Invoice, error) {
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return nil, errors.Join(errors.New("failed to start transaction"), err)
+	}
+	defer rollback(ctx, tx)
+
+	if err = lockDraft(ctx, tx, id); err != nil {
+		return nil, err
+	}
+
+	var number int
+	q := `UPDATE invoice_numbers SET last_number = last_number + 1 RETURNING last_number`
+	if err = tx.QueryRow(ctx, q).Scan(&number); err != nil {
+		return nil, errors.Join(errors.New("failed to update invoice number"), err)
+	}
+
+	q = `UPDATE invoices SET status = $2, number = $3, issued_at = now() WHERE id = $1`
+	if _, err = tx.Exec(ctx, q, id, timetrackdb.InvoiceStatusIssued, number); err != nil {
+		return nil, errors.Join(errors.New("failed to update invoice"), err)
+	}
+
+	invoice, err := getInvoice(ctx, tx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err = tx.Commit(ctx); err != nil {
+		return nil, errors.Join(errors.New("failed to commit transaction"), err)
+	}
+	return invoice, nil
+}
+
+// Delete deletes a draft invoice and releases its works. Issued invoices can't
+// be deleted.
+func (s *ServiceImpl) Delete(ctx context.Context, id int) (*Invoice, error) {
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return nil, errors.Join(errors.New("failed to start transaction"), err)
+	}
+	defer rollback(ctx, tx)
+
+	if err = lockDraft(ctx, tx, id); err != nil {
+		return nil, err
+	}
+
+	invoice, err := getInvoice(ctx, tx, id)
+	if err != nil {
+		return nil, err
+	}
+	if _, err = tx.Exec(ctx, `DELETE FROM invoices WHERE id = $1`, id); err != nil {
+		return nil, errors.Join(errors.New("failed to delete invoice"), err)
+	}
+	if err = tx.Commit(ctx); err != nil {
+		return nil, errors.Join(errors.New("failed to commit transaction"), err)
+	}
+	return invoice, nil
+}
+
+func lockDraft(ctx context.Context, tx pgx.Tx, id int) error {
+	rows, err := tx.Query(ctx, `SELECT status FROM invoices WHERE id = $1 FOR UPDATE`, id)
+	if err != nil {
+		return errors.Join(errors.New("failed to select invoice"), err)
+	}
+	defer rows.Close()
+
+	status, err := pgx.CollectExactlyOneRow(rows, pgx.RowTo[string])
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return ErrNotFound
+		}
+		return errors.Join(errors.New("failed to collect invoice"), err)
+	}
+	if status != timetrackdb.InvoiceStatusDraft {
+		return ErrNotDraft
+	}
+	return nil
+}
+
+func getInvoice(ctx context.Context, db database.DB, id int) (*Invoice, error) {
+	q := `
+		SELECT invoices.id, invoices.number, invoices.status, invoices.client_id, clients.name AS client_name,
+			   invoices.period_from, invoices.period_to, invoices.currency, invoices.created_at, invoices.issued_at
+		FROM invoices
+		JOIN clients ON invoices.client_id = clients.id
+		WHERE invoices.id = $1
+	`
+	invoices, err := queryInvoices(ctx, db, q, id)
+	if err != nil {
+		return nil, err
+	}
+	if len(invoices) == 0 {
+		return nil, ErrNotFound
+	}
+	return &invoices[0], nil
+}
+
+// queryInvoices runs a query that selects invoiceRow and loads lines of the
+// selected invoices.
+func queryInvoices(ctx context.Context, db database.DB, query string, args ...any) ([]Invoice, error) {
+	rows, err := db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, errors.Join(errors.New("failed to select invoices"), err)
+	}
+	defer rows.Close()
+
+	invoiceRows, err := pgx.CollectRows(rows, pgx.RowToStructByName[invoiceRow])
+	if err != nil {
+		return nil, errors.Join(errors.New("failed to collect invoices"), err)
+	}
+
+	ids := make([]int, 0, len(invoiceRows))
+	for _, ir := range invoiceRows {
+		ids = append(ids, ir.ID)
+	}
+	lineRows, err := queryInvoiceLines(ctx, db, ids)
+	if err != nil {
+		return nil, err
+	}
+	lines := make(map[int][]InvoiceLine)
+	for _, lr := range lineRows {
+		lines[lr.InvoiceID] = append(lines[lr.InvoiceID], InvoiceLine{
+			Task: task.Task{
+				ID:          lr.TaskID,
+				Description: lr.TaskDescription,
+				Billable:    lr.TaskBillable,
+				ClientID:    lr.TaskClientID,
+			},
+			Duration: lr.Duration,
+			Amount:   lr.Amount,
+		})
+	}
+
+	invoices := make([]Invoice, 0, len(invoiceRows))
+	for _, ir := range invoiceRows {
+		invoices = append(invoices, Invoice{
+			ID:        ir.ID,
+			Number:    ir.Number,
+			Status:    ir.Status,
+			Client:    client.Client{ID: ir.ClientID, Name: ir.ClientName},
+			From:      ir.PeriodFrom,
+			To:        ir.PeriodTo,
+			Currency:  ir.Currency,
+			CreatedAt: ir.CreatedAt,
+			IssuedAt:  ir.IssuedAt,
+			Lines:     lines[ir.ID],
+		})
+	}
+	return invoices, nil
+}
+
+func queryInvoiceLines(ctx context.Context, db database.DB, invoiceIDs []int) ([]invoiceLineRow, error) {
+	q := `
+		SELECT invoice_works.invoice_id,
+			   tasks.id AS task_id,
+			   tasks.description AS task_description,
+			   tasks.billable AS task_billable,
+			   projects.client_id AS task_client_id,
+			   SUM(invoice_works.duration) AS duration,
+			   SUM(invoice_works.amount) AS amount
+		FROM invoice_works
+		JOIN works ON invoice_works.work_id = works.id
+		JOIN tasks ON works.task_id = tasks.id
+		JOIN projects ON tasks.project_id = projects.id
+		WHERE invoice_works.invoice_id = ANY($1)
+		GROUP BY invoice_works.invoice_id, tasks.id, projects.id
+		ORDER BY invoice_works.invoice_id, tasks.id
+	`
+	rows, err := db.Query(ctx, q, invoiceIDs)
+	if err != nil {
+		return nil, errors.Join(errors.New("failed to select invoice lines"), err)
+	}
+	defer rows.Close()
+
+	lines, err := pgx.CollectRows(rows, pgx.RowToStructByName[invoiceLineRow])
+	if err != nil {
+		return nil, errors.Join(errors.New("failed to collect invoice lines"), err)
+	}
+	return lines, nil
+}
+
+func rollback(ctx context.Context, tx pgx.Tx) {
+	if txErr := tx.Rollback(ctx); txErr != nil && !errors.Is(txErr, pgx.ErrTxClosed) {
+		slog.Error("failed to rollback transaction", "error", txErr)
+	}
+}
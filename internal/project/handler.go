@@ -0,0 +1,176 @@
+package project
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/kirillgashkov/timetrack/api/timetrackapi/v1"
+	"github.com/kirillgashkov/timetrack/internal/app/api/apiutil"
+)
+
+type Handler struct {
+	service Service
+}
+
+func NewHandler(service Service) *Handler {
+	return &Handler{service: service}
+}
+
+// PostProjects handles "POST /projects/".
+func (h *Handler) PostProjects(w http.ResponseWriter, r *http.Request) {
+	var req *timetrackapi.CreateProjectRequest
+	if err := apiutil.ReadJSON(r, &req); err != nil {
+		apiutil.MustWriteUnprocessableEntity(w, apiutil.ValidationError{"bad JSON"})
+		return
+	}
+	if strings.TrimSpace(req.Name) == "" {
+		apiutil.MustWriteUnprocessableEntity(w, apiutil.ValidationError{"missing name"})
+		return
+	}
+
+	p, err := h.service.Create(r.Context(), &CreateProject{ClientID: req.ClientId, Name: req.Name})
+	if err != nil {
+		if errors.Is(err, ErrAlreadyExists) {
+			apiutil.MustWriteError(w, "project already exists", http.StatusBadRequest)
+			return
+		}
+		if errors.Is(err, ErrClientNotFound) {
+			apiutil.MustWriteUnprocessableEntity(w, apiutil.ValidationError{"client not found"})
+			return
+		}
+		apiutil.MustWriteInternalServerError(w, "failed to create project", err)
+		return
+	}
+
+	apiutil.MustWriteJSON(w, toProjectResponse(p), http.StatusOK)
+}
+
+// GetProjects handles "GET /projects/".
+func (h *Handler) GetProjects(w http.ResponseWriter, r *http.Request, params timetrackapi.GetProjectsParams) {
+	page, ve := apiutil.ParsePage(r, params.Cursor, params.Offset, params.Limit)
+	if ve != nil {
+		apiutil.MustWriteUnprocessableEntity(w, ve)
+		return
+	}
+
+	filter := &FilterProject{ClientID: params.ClientId}
+	projects, more, err := h.service.List(r.Context(), filter, page)
+	if err != nil {
+		apiutil.MustWriteInternalServerError(w, "failed to list projects", err)
+		return
+	}
+
+	resp := make([]*timetrackapi.ProjectResponse, 0, len(projects))
+	for _, p := range projects {
+		resp = append(resp, toProjectResponse(&p))
+	}
+	apiutil.SetPageLinks(w, r, page, projects, more, func(p *Project) []any { return []any{p.ID} })
+	apiutil.MustWriteJSON(w, resp, http.StatusOK)
+}
+
+// GetProjectsId handles "GET /projects/{id}".
+//
+//nolint:revive
+func (h *Handler) GetProjectsId(w http.ResponseWriter, r *http.Request, id int) {
+	p, err := h.service.Get(r.Context(), id)
+	if err != nil {
+		if errors.Is(err, ErrNotFound) {
+			apiutil.MustWriteError(w, "project not found", http.StatusNotFound)
+			return
+		}
+		apiutil.MustWriteInternalServerError(w, "failed to get project", err)
+		return
+	}
+
+	apiutil.MustWriteJSON(w, toProjectResponse(p), http.StatusOK)
+}
+
+// PatchProjectsId handles "PATCH /projects/{id}".
+//
+//nolint:revive
+func (h *Handler) PatchProjectsId(w http.ResponseWriter, r *http.Request, id int) {
+	var req *timetrackapi.UpdateProjectRequest
+	if err := apiutil.ReadJSON(r, &req); err != nil {
+		apiutil.MustWriteUnprocessableEntity(w, apiutil.ValidationError{"bad JSON"})
+		return
+	}
+	if req.Name != nil && strings.TrimSpace(*req.Name) == "" {
+		apiutil.MustWriteUnprocessableEntity(w, apiutil.ValidationError{"invalid name, must not be empty"})
+		return
+	}
+
+	p, err := h.service.Update(r.Context(), id, updateProjectFromRequest(req))
+	if err != nil {
+		if errors.Is(err, ErrNotFound) {
+			apiutil.MustWriteError(w, "project not found", http.StatusNotFound)
+			return
+		}
+		if errors.Is(err, ErrAlreadyExists) {
+			apiutil.MustWriteError(w, "project already exists", http.StatusBadRequest)
+			return
+		}
+		if errors.Is(err, ErrIsDefault) {
+			apiutil.MustWriteError(w, "default project cannot have a client", http.StatusConflict)
+			return
+		}
+		if errors.Is(err, ErrClientNotFound) {
+			apiutil.MustWriteUnprocessableEntity(w, apiutil.ValidationError{"client not found"})
+			return
+		}
+		apiutil.MustWriteInternalServerError(w, "failed to update project", err)
+		return
+	}
+
+	apiutil.MustWriteJSON(w, toProjectResponse(p), http.StatusOK)
+}
+
+func updateProjectFromRequest(req *timetrackapi.UpdateProjectRequest) *UpdateProject {
+	var clientID *sql.Null[int]
+	if req.ClientId != nil {
+		clientID = &sql.Null[int]{V: *req.ClientId, Valid: true}
+	}
+	if req.ClientIdNull != nil {
+		if clientID == nil {
+			clientID = &sql.Null[int]{}
+		}
+		clientID.Valid = !*req.ClientIdNull
+	}
+
+	return &UpdateProject{ClientID: clientID, Name: req.Name}
+}
+
+// DeleteProjectsId handles "DELETE /projects/{id}".
+//
+//nolint:revive
+func (h *Handler) DeleteProjectsId(w http.ResponseWriter, r *http.Request, id int) {
+	p, err := h.service.Delete(r.Context(), id)
+	if err != nil {
+		if errors.Is(err, ErrNotFound) {
+			apiutil.MustWriteError(w, "project not found", http.StatusNotFound)
+			return
+		}
+		if errors.Is(err, ErrIsDefault) {
+			apiutil.MustWriteError(w, "default project cannot be deleted", http.StatusConflict)
+			return
+		}
+		if errors.Is(err, ErrHasTasks) {
+			apiutil.MustWriteError(w, "project has tasks", http.StatusConflict)
+			return
+		}
+		apiutil.MustWriteInternalServerError(w, "failed to delete project", err)
+		return
+	}
+
+	apiutil.MustWriteJSON(w, toProjectResponse(p), http.StatusOK)
+}
+
+func toProjectResponse(p *Project) *timetrackapi.ProjectResponse {
+	return &timetrackapi.ProjectResponse{
+		Id:        p.ID,
+		ClientId:  p.ClientID,
+		Name:      p.Name,
+		IsDefault: p.IsDefault,
+	}
+}
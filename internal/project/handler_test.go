@@ -0,0 +1,117 @@
+package project
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/kirillgashkov/timetrack/internal/app/database"
+)
+
+type ServiceMock struct {
+	CreateFunc func(ctx context.Context, create *CreateProject) (*Project, error)
+	GetFunc    func(ctx context.Context, id int) (*Project, error)
+	ListFunc   func(ctx context.Context, filter *FilterProject, page *database.Page) ([]Project, bool, error)
+	UpdateFunc func(ctx context.Context, id int, update *UpdateProject) (*Project, error)
+	DeleteFunc func(ctx context.Context, id int) (*Project, error)
+}
+
+func (s *ServiceMock) Create(ctx context.Context, create *CreateProject) (*Project, error) {
+	return s.CreateFunc(ctx, create)
+}
+
+func (s *ServiceMock) Get(ctx context.Context, id int) (*Project, error) {
+	return s.GetFunc(ctx, id)
+}
+
+func (s *ServiceMock) List(ctx context.Context, filter *FilterProject, page *database.Page) ([]Project, bool, error) {
+	return s.ListFunc(ctx, filter, page)
+}
+
+func (s *ServiceMock) Update(ctx context.Context, id int, update *UpdateProject) (*Project, error) {
+	return s.UpdateFunc(ctx, id, update)
+}
+
+func (s *ServiceMock) Delete(ctx context.Context, id int) (*Project, error) {
+	return s.DeleteFunc(ctx, id)
+}
+
+func TestPatchProjectsId(t *testing.T) {
+	tests := []struct {
+		name               string
+		body               string
+		updateErr          error
+		expectedStatusCode int
+	}{
+		{"rename", `{"name":"Research"}`, nil, http.StatusOK},
+		{"move to client", `{"clientId":2}`, nil, http.StatusOK},
+		{"empty name", `{"name":" "}`, nil, http.StatusUnprocessableEntity},
+		{"client not found", `{"clientId":9}`, ErrClientNotFound, http.StatusUnprocessableEntity},
+		{"default project", `{"clientId":2}`, ErrIsDefault, http.StatusConflict},
+		{"not found", `{"name":"Research"}`, ErrNotFound, http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := NewHandler(&ServiceMock{
+				UpdateFunc: func(_ context.Context, id int, update *UpdateProject) (*Project, error) {
+					if tt.updateErr != nil {
+						return nil, tt.updateErr
+					}
+					p := &Project{ID: id, Name: "Proposals"}
+					if update.Name != nil {
+						p.Name = *update.Name
+					}
+					if update.ClientID != nil && update.ClientID.Valid {
+						p.ClientID = &update.ClientID.V
+					}
+					return p, nil
+				},
+			})
+
+			req := httptest.NewRequest(http.MethodPatch, "/projects/2", bytes.NewBufferString(tt.body))
+			w := httptest.NewRecorder()
+			handler.PatchProjectsId(w, req, 2)
+
+			if w.Code != tt.expectedStatusCode {
+				t.Errorf("expected status code %d, got %d: %s", tt.expectedStatusCode, w.Code, w.Body.String())
+			}
+		})
+	}
+}
+
+func TestDeleteProjectsId(t *testing.T) {
+	tests := []struct {
+		name               string
+		deleteErr          error
+		expectedStatusCode int
+	}{
+		{"deleted", nil, http.StatusOK},
+		{"default project", ErrIsDefault, http.StatusConflict},
+		{"has tasks", ErrHasTasks, http.StatusConflict},
+		{"not found", ErrNotFound, http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := NewHandler(&ServiceMock{
+				DeleteFunc: func(_ context.Context, id int) (*Project, error) {
+					if tt.deleteErr != nil {
+						return nil, tt.deleteErr
+					}
+					return &Project{ID: id, Name: "Proposals"}, nil
+				},
+			})
+
+			req := httptest.NewRequest(http.MethodDelete, "/projects/2", http.NoBody)
+			w := httptest.NewRecorder()
+			handler.DeleteProjectsId(w, req, 2)
+
+			if w.Code != tt.expectedStatusCode {
+				t.Errorf("expected status code %d, got %d: %s", tt.expectedStatusCode, w.Code, w.Body.String())
+			}
+		})
+	}
+}
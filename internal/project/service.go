@@ -0,0 +1,151 @@
+package project
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/jackc/pgerrcode"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/kirillgashkov/timetrack/internal/app/database"
+)
+
+var (
+	ErrNotFound       = errors.New("project not found")
+	ErrAlreadyExists  = errors.New("project already exists")
+	ErrClientNotFound = errors.New("client not found")
+	ErrHasTasks       = errors.New("project has tasks")
+	ErrIsDefault      = errors.New("project is the default project")
+)
+
+// Project groups tasks, optionally for a client. Tasks created without a
+// project belong to the default project, which has no client and cannot be
+// deleted.
+type Project struct {
+	ID        int
+	ClientID  *int `db:"client_id"`
+	Name      string
+	IsDefault bool `db:"is_default"`
+}
+
+type CreateProject struct {
+	ClientID *int
+	Name     string
+}
+
+type UpdateProject struct {
+	ClientID *sql.Null[int]
+	Name     *string
+}
+
+type FilterProject struct {
+	ClientID *int
+}
+
+type Service interface {
+	Create(ctx context.Context, create *CreateProject) (*Project, error)
+	Get(ctx context.Context, id int) (*Project, error)
+	List(ctx context.Context, filter *FilterProject, page *database.Page) ([]Project, bool, error)
+	Update(ctx context.Context, id int, update *UpdateProject) (*Project, error)
+	Delete(ctx context.Context, id int) (*Project, error)
+}
+
+type ServiceImpl struct {
+	db database.DB
+}
+
+func NewServiceImpl(db database.DB) *ServiceImpl {
+	return &ServiceImpl{db: db}
+}
+
+func (s *ServiceImpl) Create(ctx context.Context, create *CreateProject) (*Project, error) {
+	q := `INSERT INTO projects (client_id, name) VALUES ($1, $2) RETURNING id, client_id, name, is_default`
+	return s.queryOne(ctx, q, create.ClientID, create.Name)
+}
+
+func (s *ServiceImpl) Get(ctx context.Context, id int) (*Project, error) {
+	q := `SELECT id, client_id, name, is_default FROM projects WHERE id = $1`
+	return s.queryOne(ctx, q, id)
+}
+
+// List lists the projects that match the filter sorted by ID and reports
+// whether there are more projects past the page.
+func (s *ServiceImpl) List(ctx context.Context, filter *FilterProject, page *database.Page) ([]Project, bool, error) {
+	pageCondition, pageClauses, pageArgs := page.Keyset(2, "id")
+	q := `
+		SELECT id, client_id, name, is_default
+		FROM projects
+		WHERE ($1::integer IS NULL OR client_id = $1) AND ` + pageCondition + `
+		` + pageClauses
+	rows, err := s.db.Query(ctx, q, append([]any{filter.ClientID}, pageArgs...)...)
+	if err != nil {
+		return nil, false, errors.Join(errors.New("failed to select projects"), err)
+	}
+	defer rows.Close()
+
+	projects, err := pgx.CollectRows(rows, pgx.RowToStructByName[Project])
+	if err != nil {
+		return nil, false, errors.Join(errors.New("failed to collect projects"), err)
+	}
+	projects, more := database.PageItems(page, projects)
+	return projects, more, nil
+}
+
+// Update updates the project. The default project cannot be moved to a
+// client.
+func (s *ServiceImpl) Update(ctx context.Context, id int, update *UpdateProject) (*Project, error) {
+	q := `
+		UPDATE projects
+		SET name = coalesce($1, name),
+			client_id = CASE WHEN $3 THEN $2 ELSE client_id END
+		WHERE id = $4
+		RETURNING id, client_id, name, is_default
+	`
+	return s.queryOne(ctx, q, update.Name, update.ClientID, update.ClientID != nil, id)
+}
+
+// Delete deletes the project. Projects with tasks and the default project
+// cannot be deleted.
+func (s *ServiceImpl) Delete(ctx context.Context, id int) (*Project, error) {
+	p, err := s.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if p.IsDefault {
+		return nil, ErrIsDefault
+	}
+
+	q := `DELETE FROM projects WHERE id = $1 RETURNING id, client_id, name, is_default`
+	return s.queryOne(ctx, q, id)
+}
+
+func (s *ServiceImpl) queryOne(ctx context.Context, query string, args ...any) (*Project, error) {
+	rows, err := s.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, errors.Join(errors.New("failed to select project"), err)
+	}
+	defer rows.Close()
+
+	project, err := pgx.CollectExactlyOneRow(rows, pgx.RowToStructByName[Project])
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) {
+			switch {
+			case pgErr.Code == pgerrcode.UniqueViolation:
+				return nil, errors.Join(ErrAlreadyExists, err)
+			case pgErr.Code == pgerrcode.CheckViolation:
+				return nil, errors.Join(ErrIsDefault, err)
+			case pgErr.Code == pgerrcode.ForeignKeyViolation && pgErr.TableName == "tasks":
+				return nil, errors.Join(ErrHasTasks, err)
+			case pgErr.Code == pgerrcode.ForeignKeyViolation:
+				return nil, errors.Join(ErrClientNotFound, err)
+			}
+		}
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, errors.Join(errors.New("failed to collect project"), err)
+	}
+	return &project, nil
+}
@@ -0,0 +1,181 @@
+package recurring
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/kirillgashkov/timetrack/internal/app/database"
+	"github.com/kirillgashkov/timetrack/internal/task"
+)
+
+// Generator generates the tasks of the templates that are due. The clock is
+// injected so that runs can be tested at any time.
+type Generator struct {
+	db  database.DB
+	now func() time.Time
+}
+
+func NewGenerator(db database.DB, now func() time.Time) *Generator {
+	return &Generator{db: db, now: now}
+}
+
+// Run generates the due tasks every interval until the context is done.
+func (g *Generator) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		generated, err := g.RunDue(ctx)
+		if err != nil && ctx.Err() == nil {
+			slog.Error("failed to generate tasks", "error", err)
+		}
+		if generated > 0 {
+			slog.Info("generated tasks", "count", generated)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// RunDue runs the templates with next_run_at at or before the current time and
+// returns the number of generated occurrences. Occurrences missed while the
+// server was down are collapsed into the latest one. Each occurrence is
+// recorded in the same transaction as its task, so an occurrence is generated
+// once even if several servers run at the same time or a server restarts. A
+// template that fails to run is logged and retried on the next run.
+func (g *Generator) RunDue(ctx context.Context) (int, error) {
+	now := g.now()
+	failed := make([]int, 0)
+	generated := 0
+	for {
+		ok, id, err := g.runNext(ctx, now, failed)
+		if err != nil {
+			if id == 0 {
+				return generated, err
+			}
+			slog.Error("failed to run task template", "template_id", id, "error", err)
+			failed = append(failed, id)
+			continue
+		}
+		if !ok {
+			return generated, nil
+		}
+		generated++
+	}
+}
+
+// runNext runs one due template. It reports false if there are no due
+// templates left and the ID of the template if it failed to run.
+func (g *Generator) runNext(ctx context.Context, now time.Time, skip []int) (bool, int, error) {
+	tx, err := g.db.Begin(ctx)
+	if err != nil {
+		return false, 0, errors.Join(errors.New("failed to start transaction"), err)
+	}
+	defer func(tx pgx.Tx) {
+		if txErr := tx.Rollback(ctx); txErr != nil && !errors.Is(txErr, pgx.ErrTxClosed) {
+			slog.Error("failed to rollback transaction", "error", txErr)
+		}
+	}(tx)
+
+	q := `
+		SELECT ` + templateColumns + `
+		FROM task_templates
+		WHERE next_run_at <= $1 AND id <> ALL($2)
+			AND NOT EXISTS (SELECT 1 FROM users WHERE users.id = owner_id AND users.deleted_at IS NOT NULL)
+		ORDER BY next_run_at
+		LIMIT 1
+		FOR UPDATE SKIP LOCKED
+	`
+	t, err := queryOne(ctx, tx, q, now, skip)
+	if err != nil {
+		if errors.Is(err, ErrNotFound) {
+			return false, 0, nil
+		}
+		return false, 0, err
+	}
+
+	rule, err := ParseRule(t.Rule, t.StartsAt)
+	if err != nil {
+		return false, t.ID, errors.Join(errors.New("failed to parse rule"), err)
+	}
+	occurrence, ok := rule.Last(now)
+	if !ok {
+		occurrence = *t.NextRunAt
+	}
+
+	q = `
+		INSERT INTO task_template_runs (template_id, occurrence_at)
+		VALUES ($1, $2)
+		ON CONFLICT (template_id, occurrence_at) DO NOTHING
+	`
+	tag, err := tx.Exec(ctx, q, t.ID, occurrence)
+	if err != nil {
+		return false, t.ID, errors.Join(errors.New("failed to insert task template run"), err)
+	}
+	taskID := t.TaskID
+	if tag.RowsAffected() == 1 {
+		id, generateErr := generate(ctx, tx, t)
+		if generateErr != nil {
+			return false, t.ID, generateErr
+		}
+		taskID = &id
+
+		q = `UPDATE task_template_runs SET task_id = $3 WHERE template_id = $1 AND occurrence_at = $2`
+		if _, err = tx.Exec(ctx, q, t.ID, occurrence, id); err != nil {
+			return false, t.ID, errors.Join(errors.New("failed to update task template run"), err)
+		}
+	}
+
+	var nextRunAt *time.Time
+	if next, found := rule.Next(occurrence); found {
+		nextRunAt = &next
+	}
+	q = `UPDATE task_templates SET task_id = $2, next_run_at = $3, last_run_at = $4 WHERE id = $1`
+	if _, err = tx.Exec(ctx, q, t.ID, taskID, nextRunAt, occurrence); err != nil {
+		return false, t.ID, errors.Join(errors.New("failed to update task template"), err)
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return false, t.ID, errors.Join(errors.New("failed to commit transaction"), err)
+	}
+	return true, t.ID, nil
+}
+
+// generate creates a task of the template on behalf of its owner, or reopens
+// the task of a template in the reopen mode, and returns the ID of the task.
+func generate(ctx context.Context, db database.DB, t *Template) (int, error) {
+	tasks := task.NewServiceImpl(db)
+
+	if t.Mode == ModeReopen && t.TaskID != nil {
+		existing, err := tasks.Get(ctx, *t.TaskID, t.OwnerID)
+		if err != nil && !errors.Is(err, task.ErrNotFound) {
+			return 0, errors.Join(errors.New("failed to get task"), err)
+		}
+		if err == nil {
+			if existing.Status == task.StatusDone || existing.Status == task.StatusArchived {
+				status := task.StatusOpen
+				if _, err = tasks.Update(ctx, existing.ID, t.OwnerID, &task.UpdateTask{Status: &status}); err != nil {
+					return 0, errors.Join(errors.New("failed to reopen task"), err)
+				}
+			}
+			return existing.ID, nil
+		}
+	}
+
+	created, err := tasks.Create(ctx, t.OwnerID, &task.CreateTask{
+		Description: t.Description,
+		Billable:    t.Billable,
+		ProjectID:   t.ProjectID,
+		Visibility:  t.Visibility,
+	})
+	if err != nil {
+		return 0, errors.Join(errors.New("failed to create task"), err)
+	}
+	return created.ID, nil
+}
@@ -0,0 +1,106 @@
+package recurring
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/kirillgashkov/timetrack/internal/app/testutil"
+	"github.com/kirillgashkov/timetrack/internal/task"
+)
+
+// TestGeneratorRunDue runs a weekly template with a fake clock. It needs a test
+// database, the data is created in a transaction that is rolled back.
+func TestGeneratorRunDue(t *testing.T) {
+	if os.Getenv("TEST_APP_DATABASE_DSN") == "" {
+		t.Skip("TEST_APP_DATABASE_DSN is not set")
+	}
+
+	ctx := context.Background()
+	db := testutil.NewTestPool()
+	defer db.Close()
+
+	tx, err := db.Begin(ctx)
+	if err != nil {
+		t.Fatalf("failed to start transaction: %v", err)
+	}
+	defer func(tx pgx.Tx) {
+		if txErr := tx.Rollback(ctx); txErr != nil && !errors.Is(txErr, pgx.ErrTxClosed) {
+			slog.Error("failed to rollback transaction", "error", txErr)
+		}
+	}(tx)
+
+	passportNumber := fmt.Sprintf("recurring %d", time.Now().UnixNano())
+	q := `
+		INSERT INTO users (passport_number, surname, name, address)
+		VALUES ($1, 'Surname', 'Name', 'Address')
+		RETURNING id
+	`
+	var userID int
+	if err = tx.QueryRow(ctx, q, passportNumber).Scan(&userID); err != nil {
+		t.Fatalf("failed to insert user: %v", err)
+	}
+
+	// Monday.
+	start := time.Date(2024, 7, 1, 9, 0, 0, 0, time.UTC)
+	s := NewServiceImpl(tx)
+	created, err := s.Create(ctx, userID, &CreateTemplate{
+		Description: "Weekly sync",
+		Billable:    true,
+		Visibility:  task.VisibilityPrivate,
+		Rule:        "FREQ=WEEKLY;BYDAY=MO",
+		StartsAt:    start,
+		Mode:        ModeCreate,
+	})
+	if err != nil {
+		t.Fatalf("failed to create template: %v", err)
+	}
+
+	now := start.AddDate(0, 0, 16)
+	g := NewGenerator(tx, func() time.Time { return now })
+
+	tests := []struct {
+		name          string
+		now           time.Time
+		wantGenerated int
+		wantNextRunAt time.Time
+	}{
+		{"missed weeks", start.AddDate(0, 0, 16), 1, start.AddDate(0, 0, 21)},
+		{"same time", start.AddDate(0, 0, 16), 0, start.AddDate(0, 0, 21)},
+		{"before next", start.AddDate(0, 0, 21).Add(-time.Second), 0, start.AddDate(0, 0, 21)},
+		{"next", start.AddDate(0, 0, 21), 1, start.AddDate(0, 0, 28)},
+	}
+
+	for _, tt := range tests {
+		now = tt.now
+		generated, runErr := g.RunDue(ctx)
+		if runErr != nil {
+			t.Fatalf("%s: failed to run: %v", tt.name, runErr)
+		}
+		if generated != tt.wantGenerated {
+			t.Errorf("%s: expected %d generated, got %d", tt.name, tt.wantGenerated, generated)
+		}
+
+		got, getErr := s.Get(ctx, created.ID, userID)
+		if getErr != nil {
+			t.Fatalf("%s: failed to get template: %v", tt.name, getErr)
+		}
+		if got.NextRunAt == nil || !got.NextRunAt.Equal(tt.wantNextRunAt) {
+			t.Errorf("%s: expected next run at %v, got %v", tt.name, tt.wantNextRunAt, got.NextRunAt)
+		}
+	}
+
+	var tasks int
+	q = `SELECT count(*) FROM tasks WHERE created_by = $1 AND description = 'Weekly sync'`
+	if err = tx.QueryRow(ctx, q, userID).Scan(&tasks); err != nil {
+		t.Fatalf("failed to count tasks: %v", err)
+	}
+	if tasks != 2 {
+		t.Errorf("expected 2 tasks, got %d", tasks)
+	}
+}
@@ -0,0 +1,163 @@
+package recurring
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/kirillgashkov/timetrack/api/timetrackapi/v1"
+	"github.com/kirillgashkov/timetrack/internal/app/api/apiutil"
+	"github.com/kirillgashkov/timetrack/internal/auth"
+	"github.com/kirillgashkov/timetrack/internal/task"
+)
+
+type Handler struct {
+	service Service
+}
+
+func NewHandler(service Service) *Handler {
+	return &Handler{service: service}
+}
+
+// GetTaskTemplates handles "GET /task-templates/".
+func (h *Handler) GetTaskTemplates(w http.ResponseWriter, r *http.Request) {
+	u := auth.MustUserFromContext(r.Context())
+	templates, err := h.service.List(r.Context(), u.ID)
+	if err != nil {
+		apiutil.MustWriteInternalServerError(w, "failed to list task templates", err)
+		return
+	}
+
+	resp := make([]*timetrackapi.TaskTemplateResponse, 0, len(templates))
+	for _, t := range templates {
+		resp = append(resp, toTaskTemplateResponse(&t))
+	}
+	apiutil.MustWriteJSON(w, resp, http.StatusOK)
+}
+
+// PostTaskTemplates handles "POST /task-templates/".
+func (h *Handler) PostTaskTemplates(w http.ResponseWriter, r *http.Request) {
+	var req *timetrackapi.CreateTaskTemplateRequest
+	if err := apiutil.ReadJSON(r, &req); err != nil {
+		apiutil.MustWriteUnprocessableEntity(w, apiutil.ValidationError{"bad JSON"})
+		return
+	}
+	create, err := validateCreateTemplate(req)
+	if err != nil {
+		apiutil.MustWriteUnprocessableEntity(w, err)
+		return
+	}
+
+	u := auth.MustUserFromContext(r.Context())
+	t, createErr := h.service.Create(r.Context(), u.ID, create)
+	if createErr != nil {
+		if errors.Is(createErr, ErrNoOccurrences) {
+			apiutil.MustWriteUnprocessableEntity(w, apiutil.ValidationError{"invalid rule, no occurrences after startsAt"})
+			return
+		}
+		if errors.Is(createErr, ErrProjectNotFound) {
+			apiutil.MustWriteUnprocessableEntity(w, apiutil.ValidationError{"project not found"})
+			return
+		}
+		apiutil.MustWriteInternalServerError(w, "failed to create task template", createErr)
+		return
+	}
+
+	apiutil.MustWriteJSON(w, toTaskTemplateResponse(t), http.StatusOK)
+}
+
+// validateCreateTemplate creates a template of billable tasks visible to
+// everyone in the create mode unless the request says otherwise.
+func validateCreateTemplate(req *timetrackapi.CreateTaskTemplateRequest) (*CreateTemplate, apiutil.ValidationError) {
+	e := make([]string, 0)
+	create := &CreateTemplate{
+		Description: req.Description,
+		Billable:    true,
+		ProjectID:   req.ProjectId,
+		Visibility:  task.VisibilityEveryone,
+		Rule:        req.Rule,
+		StartsAt:    req.StartsAt,
+		Mode:        ModeCreate,
+	}
+
+	if req.Billable != nil {
+		create.Billable = *req.Billable
+	}
+	if req.Visibility != nil {
+		switch v := task.Visibility(*req.Visibility); v {
+		case task.VisibilityPrivate, task.VisibilityProject, task.VisibilityEveryone:
+			create.Visibility = v
+		default:
+			e = append(e, "invalid visibility, must be one of: private, project, everyone")
+		}
+	}
+	if req.Mode != nil {
+		switch m := Mode(*req.Mode); m {
+		case ModeCreate, ModeReopen:
+			create.Mode = m
+		default:
+			e = append(e, "invalid mode, must be one of: create, reopen")
+		}
+	}
+	if req.StartsAt.IsZero() {
+		e = append(e, "missing startsAt")
+	} else if _, err := ParseRule(req.Rule, req.StartsAt); err != nil {
+		e = append(e, "invalid rule, "+err.Error())
+	}
+
+	if len(e) > 0 {
+		return nil, apiutil.ValidationError(e)
+	}
+	return create, nil
+}
+
+// GetTaskTemplatesId handles "GET /task-templates/{id}".
+//
+//nolint:revive
+func (h *Handler) GetTaskTemplatesId(w http.ResponseWriter, r *http.Request, id int) {
+	u := auth.MustUserFromContext(r.Context())
+	t, err := h.service.Get(r.Context(), id, u.ID)
+	if err != nil {
+		if errors.Is(err, ErrNotFound) {
+			apiutil.MustWriteError(w, "task template not found", http.StatusNotFound)
+			return
+		}
+		apiutil.MustWriteInternalServerError(w, "failed to get task template", err)
+		return
+	}
+
+	apiutil.MustWriteJSON(w, toTaskTemplateResponse(t), http.StatusOK)
+}
+
+// DeleteTaskTemplatesId handles "DELETE /task-templates/{id}".
+//
+//nolint:revive
+func (h *Handler) DeleteTaskTemplatesId(w http.ResponseWriter, r *http.Request, id int) {
+	u := auth.MustUserFromContext(r.Context())
+	t, err := h.service.Delete(r.Context(), id, u.ID)
+	if err != nil {
+		if errors.Is(err, ErrNotFound) {
+			apiutil.MustWriteError(w, "task template not found", http.StatusNotFound)
+			return
+		}
+		apiutil.MustWriteInternalServerError(w, "failed to delete task template", err)
+		return
+	}
+
+	apiutil.MustWriteJSON(w, toTaskTemplateResponse(t), http.StatusOK)
+}
+
+func toTaskTemplateResponse(t *Template) *timetrackapi.TaskTemplateResponse {
+	return &timetrackapi.TaskTemplateResponse{
+		Id:          t.ID,
+		Description: t.Description,
+		Billable:    t.Billable,
+		ProjectId:   t.ProjectID,
+		Visibility:  timetrackapi.TaskVisibility(t.Visibility),
+		Rule:        t.Rule,
+		StartsAt:    t.StartsAt,
+		Mode:        timetrackapi.TaskTemplateMode(t.Mode),
+		TaskId:      t.TaskID,
+		NextRunAt:   t.NextRunAt,
+		LastRunAt:   t.LastRunAt,
+	}
+}
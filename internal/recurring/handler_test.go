@@ -0,0 +1,130 @@
+package recurring
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/kirillgashkov/timetrack/internal/auth"
+)
+
+type ServiceMock struct {
+	CreateFunc func(ctx context.Context, ownerID int, create *CreateTemplate) (*Template, error)
+	GetFunc    func(ctx context.Context, id, ownerID int) (*Template, error)
+	ListFunc   func(ctx context.Context, ownerID int) ([]Template, error)
+	DeleteFunc func(ctx context.Context, id, ownerID int) (*Template, error)
+}
+
+func (s *ServiceMock) Create(ctx context.Context, ownerID int, create *CreateTemplate) (*Template, error) {
+	return s.CreateFunc(ctx, ownerID, create)
+}
+
+func (s *ServiceMock) Get(ctx context.Context, id, ownerID int) (*Template, error) {
+	return s.GetFunc(ctx, id, ownerID)
+}
+
+func (s *ServiceMock) List(ctx context.Context, ownerID int) ([]Template, error) {
+	return s.ListFunc(ctx, ownerID)
+}
+
+func (s *ServiceMock) Delete(ctx context.Context, id, ownerID int) (*Template, error) {
+	return s.DeleteFunc(ctx, id, ownerID)
+}
+
+func TestPostTaskTemplates(t *testing.T) {
+	tests := []struct {
+		name               string
+		body               string
+		createErr          error
+		expectedMode       Mode
+		expectedStatusCode int
+	}{
+		{
+			"weekly",
+			`{"description":"Weekly sync","rule":"FREQ=WEEKLY;BYDAY=MO","startsAt":"2024-07-01T09:00:00Z"}`,
+			nil,
+			ModeCreate,
+			http.StatusOK,
+		},
+		{
+			"reopen",
+			`{"description":"Monthly close","rule":"RRULE:FREQ=MONTHLY;BYMONTHDAY=-1","startsAt":"2024-07-01T09:00:00Z",` +
+				`"mode":"reopen","visibility":"private"}`,
+			nil,
+			ModeReopen,
+			http.StatusOK,
+		},
+		{
+			"no occurrences",
+			`{"description":"Sync","rule":"FREQ=YEARLY;BYMONTH=2;BYMONTHDAY=30","startsAt":"2024-07-01T09:00:00Z"}`,
+			ErrNoOccurrences,
+			ModeCreate,
+			http.StatusUnprocessableEntity,
+		},
+		{
+			"project not found",
+			`{"description":"Sync","rule":"FREQ=DAILY","startsAt":"2024-07-01T09:00:00Z","projectId":2}`,
+			ErrProjectNotFound,
+			ModeCreate,
+			http.StatusUnprocessableEntity,
+		},
+		{
+			"unsupported rule",
+			`{"description":"Sync","rule":"FREQ=HOURLY","startsAt":"2024-07-01T09:00:00Z"}`,
+			nil,
+			"",
+			http.StatusUnprocessableEntity,
+		},
+		{
+			"missing start",
+			`{"description":"Sync","rule":"FREQ=DAILY"}`,
+			nil,
+			"",
+			http.StatusUnprocessableEntity,
+		},
+		{
+			"invalid mode",
+			`{"description":"Sync","rule":"FREQ=DAILY","startsAt":"2024-07-01T09:00:00Z","mode":"delete"}`,
+			nil,
+			"",
+			http.StatusUnprocessableEntity,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := NewHandler(&ServiceMock{
+				CreateFunc: func(_ context.Context, ownerID int, create *CreateTemplate) (*Template, error) {
+					if create.Mode != tt.expectedMode {
+						t.Errorf("expected mode %q, got %q", tt.expectedMode, create.Mode)
+					}
+					if tt.createErr != nil {
+						return nil, tt.createErr
+					}
+					return &Template{
+						ID:          1,
+						OwnerID:     ownerID,
+						Description: create.Description,
+						Billable:    create.Billable,
+						ProjectID:   create.ProjectID,
+						Visibility:  create.Visibility,
+						Rule:        create.Rule,
+						StartsAt:    create.StartsAt,
+						Mode:        create.Mode,
+					}, nil
+				},
+			})
+
+			req := httptest.NewRequest(http.MethodPost, "/task-templates/", bytes.NewBufferString(tt.body))
+			req = req.WithContext(auth.ContextWithUser(req.Context(), &auth.User{ID: 1}))
+			w := httptest.NewRecorder()
+			handler.PostTaskTemplates(w, req)
+
+			if w.Code != tt.expectedStatusCode {
+				t.Errorf("expected status code %d, got %d: %s", tt.expectedStatusCode, w.Code, w.Body.String())
+			}
+		})
+	}
+}
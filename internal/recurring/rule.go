@@ -0,0 +1,384 @@
+package recurring
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type frequency string
+
+const (
+	frequencyDaily   frequency = "DAILY"
+	frequencyWeekly  frequency = "WEEKLY"
+	frequencyMonthly frequency = "MONTHLY"
+	frequencyYearly  frequency = "YEARLY"
+)
+
+// maxEmptyPeriods is the number of consecutive periods without occurrences
+// after which a rule is considered exhausted, e.g. "FREQ=YEARLY;BYMONTH=2;
+// BYMONTHDAY=30" never occurs. It is more than the days between two leap days.
+const maxEmptyPeriods = 4000
+
+var weekdays = map[string]time.Weekday{
+	"SU": time.Sunday,
+	"MO": time.Monday,
+	"TU": time.Tuesday,
+	"WE": time.Wednesday,
+	"TH": time.Thursday,
+	"FR": time.Friday,
+	"SA": time.Saturday,
+}
+
+// Rule is a recurrence rule of RFC 5545 evaluated from a start in UTC. All
+// occurrences are at the time of day of the start, and the start itself is an
+// occurrence only if it matches the rule.
+type Rule struct {
+	start      time.Time
+	freq       frequency
+	interval   int
+	count      int
+	until      time.Time
+	byDay      []weekdayNum
+	byMonthDay []int
+	byMonth    []time.Month
+	weekStart  time.Weekday
+}
+
+// weekdayNum is a weekday of BYDAY, e.g. "MO" or "-1FR". N is the ordinal of
+// the weekday within the month, zero means every such weekday.
+type weekdayNum struct {
+	N   int
+	Day time.Weekday
+}
+
+// ParseRule parses a recurrence rule such as "FREQ=WEEKLY;BYDAY=MO,WE" with an
+// optional "RRULE:" prefix. The supported parts are FREQ with DAILY, WEEKLY,
+// MONTHLY and YEARLY, INTERVAL, COUNT, UNTIL, BYDAY, BYMONTHDAY, BYMONTH and
+// WKST. BYDAY with ordinals such as "2TU" is supported in monthly rules and in
+// yearly rules with BYMONTH.
+func ParseRule(s string, start time.Time) (*Rule, error) {
+	s = strings.TrimPrefix(strings.TrimSpace(s), "RRULE:")
+	if s == "" {
+		return nil, errors.New("empty rule")
+	}
+
+	r := &Rule{start: start.UTC(), interval: 1, weekStart: time.Monday}
+	seen := make(map[string]bool)
+	for _, part := range strings.Split(s, ";") {
+		name, value, ok := strings.Cut(part, "=")
+		if !ok || value == "" {
+			return nil, fmt.Errorf("malformed part %q", part)
+		}
+		name = strings.ToUpper(name)
+		value = strings.ToUpper(value)
+		if seen[name] {
+			return nil, fmt.Errorf("repeated part %s", name)
+		}
+		seen[name] = true
+
+		var err error
+		switch name {
+		case "FREQ":
+			err = r.parseFreq(value)
+		case "INTERVAL":
+			r.interval, err = parsePositive(value)
+		case "COUNT":
+			r.count, err = parsePositive(value)
+		case "UNTIL":
+			r.until, err = parseUntil(value)
+		case "BYDAY":
+			r.byDay, err = parseByDay(value)
+		case "BYMONTHDAY":
+			r.byMonthDay, err = parseList(value, func(n int) bool { return n != 0 && n >= -31 && n <= 31 })
+		case "BYMONTH":
+			var months []int
+			months, err = parseList(value, func(n int) bool { return n >= 1 && n <= 12 })
+			for _, m := range months {
+				r.byMonth = append(r.byMonth, time.Month(m))
+			}
+		case "WKST":
+			day, known := weekdays[value]
+			if !known {
+				err = errors.New("must be a weekday such as MO")
+			}
+			r.weekStart = day
+		default:
+			return nil, fmt.Errorf("unsupported part %s", name)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s, %w", name, err)
+		}
+	}
+
+	if err := r.validate(seen); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+func (r *Rule) parseFreq(value string) error {
+	switch f := frequency(value); f {
+	case frequencyDaily, frequencyWeekly, frequencyMonthly, frequencyYearly:
+		r.freq = f
+		return nil
+	default:
+		return errors.New("must be one of: DAILY, WEEKLY, MONTHLY, YEARLY")
+	}
+}
+
+func (r *Rule) validate(seen map[string]bool) error {
+	if r.freq == "" {
+		return errors.New("missing FREQ")
+	}
+	if seen["COUNT"] && seen["UNTIL"] {
+		return errors.New("COUNT and UNTIL can't be used together")
+	}
+	if r.freq == frequencyWeekly && len(r.byMonthDay) > 0 {
+		return errors.New("BYMONTHDAY can't be used with FREQ=WEEKLY")
+	}
+	if r.freq == frequencyYearly && len(r.byDay) > 0 && len(r.byMonth) == 0 {
+		return errors.New("BYDAY requires BYMONTH with FREQ=YEARLY")
+	}
+	for _, d := range r.byDay {
+		if d.N != 0 && r.freq != frequencyMonthly && r.freq != frequencyYearly {
+			return errors.New("BYDAY ordinals require FREQ=MONTHLY or FREQ=YEARLY")
+		}
+	}
+	return nil
+}
+
+func parsePositive(value string) (int, error) {
+	n, err := strconv.Atoi(value)
+	if err != nil || n < 1 {
+		return 0, errors.New("must be a positive integer")
+	}
+	return n, nil
+}
+
+// parseUntil parses a UTC date-time such as "20240701T090000Z" or a date such
+// as "20240701", which includes the whole day.
+func parseUntil(value string) (time.Time, error) {
+	if t, err := time.Parse("20060102T150405Z", value); err == nil {
+		return t, nil
+	}
+	if t, err := time.Parse("20060102", value); err == nil {
+		return t.AddDate(0, 0, 1).Add(-time.Nanosecond), nil
+	}
+	return time.Time{}, errors.New("must be a UTC date-time such as 20240701T090000Z or a date such as 20240701")
+}
+
+func parseByDay(value string) ([]weekdayNum, error) {
+	days := make([]weekdayNum, 0)
+	for _, item := range strings.Split(value, ",") {
+		if len(item) < 2 {
+			return nil, fmt.Errorf("invalid weekday %q", item)
+		}
+		day, ok := weekdays[item[len(item)-2:]]
+		if !ok {
+			return nil, fmt.Errorf("invalid weekday %q", item)
+		}
+		n := 0
+		if ordinal := item[:len(item)-2]; ordinal != "" {
+			var err error
+			n, err = strconv.Atoi(ordinal)
+			if err != nil || n == 0 || n < -5 || n > 5 {
+				return nil, fmt.Errorf("invalid weekday ordinal %q, must be between -5 and 5", item)
+			}
+		}
+		days = append(days, weekdayNum{N: n, Day: day})
+	}
+	return days, nil
+}
+
+func parseList(value string, valid func(int) bool) ([]int, error) {
+	list := make([]int, 0)
+	for _, item := range strings.Split(value, ",") {
+		n, err := strconv.Atoi(item)
+		if err != nil || !valid(n) {
+			return nil, fmt.Errorf("invalid value %q", item)
+		}
+		list = append(list, n)
+	}
+	return list, nil
+}
+
+// Next returns the first occurrence after t. It reports false if there are no
+// occurrences after t.
+func (r *Rule) Next(t time.Time) (time.Time, bool) {
+	var next time.Time
+	found := false
+	r.each(func(o time.Time) bool {
+		if o.After(t) {
+			next, found = o, true
+			return false
+		}
+		return true
+	})
+	return next, found
+}
+
+// Last returns the last occurrence at or before t. It reports false if there
+// are no occurrences at or before t.
+func (r *Rule) Last(t time.Time) (time.Time, bool) {
+	var last time.Time
+	found := false
+	r.each(func(o time.Time) bool {
+		if o.After(t) {
+			return false
+		}
+		last, found = o, true
+		return true
+	})
+	return last, found
+}
+
+// each calls yield with the occurrences in order until it returns false or the
+// occurrences run out.
+func (r *Rule) each(yield func(time.Time) bool) {
+	n, empty := 0, 0
+	for period := 0; empty < maxEmptyPeriods; period += r.interval {
+		occurrences, ok := r.expand(period)
+		if !ok {
+			return
+		}
+		if len(occurrences) == 0 {
+			empty++
+			continue
+		}
+		empty = 0
+
+		for _, o := range occurrences {
+			if o.Before(r.start) {
+				continue
+			}
+			if !r.until.IsZero() && o.After(r.until) {
+				return
+			}
+			if !yield(o) {
+				return
+			}
+			n++
+			if r.count > 0 && n == r.count {
+				return
+			}
+		}
+	}
+}
+
+// expand returns the occurrences in the period with the index counted in
+// units of the frequency from the period of the start. It reports false if the
+// occurrences are beyond year 9999.
+func (r *Rule) expand(period int) ([]time.Time, bool) {
+	y, m, d := r.start.Date()
+	startDay := time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+	clock := r.start.Sub(startDay)
+
+	days := make([]time.Time, 0)
+	switch r.freq {
+	case frequencyDaily:
+		day := startDay.AddDate(0, 0, period)
+		if r.monthMatches(day.Month()) && r.dayMatches(day) {
+			days = append(days, day)
+		}
+	case frequencyWeekly:
+		offset := (int(startDay.Weekday()) - int(r.weekStart) + 7) % 7
+		week := startDay.AddDate(0, 0, 7*period-offset)
+		for i := range 7 {
+			day := week.AddDate(0, 0, i)
+			if r.monthMatches(day.Month()) && r.weekdayMatches(day.Weekday()) {
+				days = append(days, day)
+			}
+		}
+	case frequencyMonthly:
+		month := time.Date(y, m, 1, 0, 0, 0, 0, time.UTC).AddDate(0, period, 0)
+		if r.monthMatches(month.Month()) {
+			days = r.monthDays(month, days)
+		}
+	case frequencyYearly:
+		year := y + period
+		months := r.byMonth
+		if len(months) == 0 && len(r.byMonthDay) > 0 {
+			months = []time.Month{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}
+		} else if len(months) == 0 {
+			months = []time.Month{m}
+		}
+		months = slices.Clone(months)
+		slices.Sort(months)
+		for _, month := range months {
+			days = r.monthDays(time.Date(year, month, 1, 0, 0, 0, 0, time.UTC), days)
+		}
+	}
+
+	if len(days) > 0 && days[len(days)-1].Year() > 9999 {
+		return nil, false
+	}
+
+	occurrences := make([]time.Time, 0, len(days))
+	for _, day := range days {
+		occurrences = append(occurrences, day.Add(clock))
+	}
+	return occurrences, true
+}
+
+// monthDays appends the days of the month starting at first that match the
+// rule, or the day of the month of the start if the rule has neither BYDAY nor
+// BYMONTHDAY.
+func (r *Rule) monthDays(first time.Time, days []time.Time) []time.Time {
+	for day := first; day.Month() == first.Month(); day = day.AddDate(0, 0, 1) {
+		if len(r.byMonthDay) == 0 && len(r.byDay) == 0 {
+			if day.Day() == r.start.Day() {
+				days = append(days, day)
+			}
+			continue
+		}
+		if r.dayMatches(day) {
+			days = append(days, day)
+		}
+	}
+	return days
+}
+
+func (r *Rule) monthMatches(m time.Month) bool {
+	return len(r.byMonth) == 0 || slices.Contains(r.byMonth, m)
+}
+
+// weekdayMatches reports whether the weekday is in BYDAY, or is the weekday of
+// the start if BYDAY is empty.
+func (r *Rule) weekdayMatches(wd time.Weekday) bool {
+	if len(r.byDay) == 0 {
+		return wd == r.start.Weekday()
+	}
+	return slices.ContainsFunc(r.byDay, func(d weekdayNum) bool { return d.Day == wd })
+}
+
+// dayMatches reports whether the day matches both BYMONTHDAY and BYDAY if they
+// are set. Negative values count from the end of the month.
+func (r *Rule) dayMatches(day time.Time) bool {
+	d := day.Day()
+	last := time.Date(day.Year(), day.Month()+1, 0, 0, 0, 0, 0, time.UTC).Day()
+
+	if len(r.byMonthDay) > 0 {
+		if !slices.ContainsFunc(r.byMonthDay, func(n int) bool { return n == d || n < 0 && last+n+1 == d }) {
+			return false
+		}
+	}
+	if len(r.byDay) > 0 {
+		return slices.ContainsFunc(r.byDay, func(wd weekdayNum) bool {
+			switch {
+			case wd.Day != day.Weekday():
+				return false
+			case wd.N > 0:
+				return (d-1)/7+1 == wd.N
+			case wd.N < 0:
+				return (last-d)/7+1 == -wd.N
+			default:
+				return true
+			}
+		})
+	}
+	return true
+}
@@ -0,0 +1,127 @@
+package recurring
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseRule(t *testing.T) {
+	// Wednesday.
+	start := time.Date(2024, 7, 3, 9, 0, 0, 0, time.UTC)
+	at := func(month time.Month, day int) time.Time {
+		return time.Date(2024, month, day, 9, 0, 0, 0, time.UTC)
+	}
+
+	tests := []struct {
+		name    string
+		rule    string
+		want    []time.Time
+		wantErr bool
+	}{
+		{"daily", "FREQ=DAILY;COUNT=3", []time.Time{at(7, 3), at(7, 4), at(7, 5)}, false},
+		{"prefix", "RRULE:FREQ=DAILY;INTERVAL=2;COUNT=2", []time.Time{at(7, 3), at(7, 5)}, false},
+		{"weekly", "FREQ=WEEKLY;COUNT=2", []time.Time{at(7, 3), at(7, 10)}, false},
+		{
+			"weekly by day",
+			"FREQ=WEEKLY;BYDAY=MO,WE,FR;COUNT=4",
+			[]time.Time{at(7, 3), at(7, 5), at(7, 8), at(7, 10)},
+			false,
+		},
+		{"biweekly", "FREQ=WEEKLY;INTERVAL=2;BYDAY=MO;COUNT=2", []time.Time{at(7, 15), at(7, 29)}, false},
+		{"monthly", "FREQ=MONTHLY;COUNT=2", []time.Time{at(7, 3), at(8, 3)}, false},
+		{"last day of month", "FREQ=MONTHLY;BYMONTHDAY=-1;COUNT=3", []time.Time{at(7, 31), at(8, 31), at(9, 30)}, false},
+		{"second tuesday", "FREQ=MONTHLY;BYDAY=2TU;COUNT=2", []time.Time{at(7, 9), at(8, 13)}, false},
+		{"last friday", "FREQ=MONTHLY;BYDAY=-1FR;COUNT=2", []time.Time{at(7, 26), at(8, 30)}, false},
+		{
+			"early workdays",
+			"FREQ=MONTHLY;BYDAY=MO,TU,WE,TH,FR;BYMONTHDAY=1,2,3;COUNT=2",
+			[]time.Time{at(7, 3), at(8, 1)},
+			false,
+		},
+		{"skips short months", "FREQ=MONTHLY;BYMONTHDAY=31;COUNT=3", []time.Time{at(7, 31), at(8, 31), at(10, 31)}, false},
+		{
+			"yearly",
+			"FREQ=YEARLY;BYMONTH=1,7;BYMONTHDAY=3;COUNT=3",
+			[]time.Time{at(7, 3), time.Date(2025, 1, 3, 9, 0, 0, 0, time.UTC), time.Date(2025, 7, 3, 9, 0, 0, 0, time.UTC)},
+			false,
+		},
+		{
+			"thanksgiving",
+			"FREQ=YEARLY;BYMONTH=11;BYDAY=4TH;COUNT=2",
+			[]time.Time{at(11, 28), time.Date(2025, 11, 27, 9, 0, 0, 0, time.UTC)},
+			false,
+		},
+		{"until date", "FREQ=DAILY;UNTIL=20240705", []time.Time{at(7, 3), at(7, 4), at(7, 5)}, false},
+		{"until date-time", "FREQ=DAILY;UNTIL=20240705T080000Z", []time.Time{at(7, 3), at(7, 4)}, false},
+		{"never", "FREQ=YEARLY;BYMONTH=2;BYMONTHDAY=30", []time.Time{}, false},
+		{"missing freq", "COUNT=3", nil, true},
+		{"hourly", "FREQ=HOURLY", nil, true},
+		{"unsupported part", "FREQ=DAILY;BYHOUR=9", nil, true},
+		{"count and until", "FREQ=DAILY;COUNT=3;UNTIL=20240705", nil, true},
+		{"repeated part", "FREQ=DAILY;FREQ=WEEKLY", nil, true},
+		{"weekly ordinal", "FREQ=WEEKLY;BYDAY=1MO", nil, true},
+		{"weekly month day", "FREQ=WEEKLY;BYMONTHDAY=1", nil, true},
+		{"yearly by day without month", "FREQ=YEARLY;BYDAY=MO", nil, true},
+		{"bad interval", "FREQ=DAILY;INTERVAL=0", nil, true},
+		{"bad weekday", "FREQ=WEEKLY;BYDAY=XX", nil, true},
+		{"garbage", "every monday", nil, true},
+		{"empty", "", nil, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rule, err := ParseRule(tt.rule, start)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("expected error, got nil")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("expected no error, got: %v", err)
+			}
+
+			got := make([]time.Time, 0)
+			for o, ok := rule.Next(start.Add(-time.Nanosecond)); ok && len(got) <= len(tt.want); o, ok = rule.Next(o) {
+				got = append(got, o)
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("expected occurrences %v, got %v", tt.want, got)
+			}
+			for i := range got {
+				if !got[i].Equal(tt.want[i]) {
+					t.Errorf("expected occurrences %v, got %v", tt.want, got)
+					break
+				}
+			}
+		})
+	}
+}
+
+func TestRuleLast(t *testing.T) {
+	start := time.Date(2024, 7, 1, 9, 0, 0, 0, time.UTC)
+	rule, err := ParseRule("FREQ=WEEKLY;BYDAY=MO", start)
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+
+	tests := []struct {
+		name   string
+		t      time.Time
+		want   time.Time
+		wantOK bool
+	}{
+		{"before start", start.Add(-time.Hour), time.Time{}, false},
+		{"at start", start, start, true},
+		{"missed weeks", time.Date(2024, 7, 24, 0, 0, 0, 0, time.UTC), time.Date(2024, 7, 22, 9, 0, 0, 0, time.UTC), true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := rule.Last(tt.t)
+			if ok != tt.wantOK || !got.Equal(tt.want) {
+				t.Errorf("expected %v %t, got %v %t", tt.want, tt.wantOK, got, ok)
+			}
+		})
+	}
+}
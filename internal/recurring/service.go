@@ -0,0 +1,161 @@
+package recurring
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/jackc/pgerrcode"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/kirillgashkov/timetrack/internal/app/database"
+	"github.com/kirillgashkov/timetrack/internal/task"
+)
+
+var (
+	ErrNotFound        = errors.New("task template not found")
+	ErrProjectNotFound = errors.New("project not found")
+	ErrNoOccurrences   = errors.New("rule has no occurrences")
+)
+
+// Mode is what a template does on each occurrence of its rule.
+type Mode string
+
+const (
+	// ModeCreate creates a new task on each occurrence.
+	ModeCreate Mode = "create"
+	// ModeReopen creates a task on the first occurrence and reopens it on the
+	// following occurrences if it is done or archived. A new task is created if
+	// the task was deleted.
+	ModeReopen Mode = "reopen"
+)
+
+// Template generates tasks of the owner on the occurrences of the rule, see
+// ParseRule, starting at StartsAt. TaskID is the last generated task. NextRunAt
+// is nil once the rule has no more occurrences.
+type Template struct {
+	ID          int
+	OwnerID     int             `db:"owner_id"`
+	Description string          `db:"description"`
+	Billable    bool            `db:"billable"`
+	ProjectID   *int            `db:"project_id"`
+	Visibility  task.Visibility `db:"visibility"`
+	Rule        string          `db:"rule"`
+	StartsAt    time.Time       `db:"starts_at"`
+	Mode        Mode            `db:"mode"`
+	TaskID      *int            `db:"task_id"`
+	NextRunAt   *time.Time      `db:"next_run_at"`
+	LastRunAt   *time.Time      `db:"last_run_at"`
+}
+
+// CreateTemplate creates a template of tasks in the project, or in the default
+// project if ProjectID is nil.
+type CreateTemplate struct {
+	Description string
+	Billable    bool
+	ProjectID   *int
+	Visibility  task.Visibility
+	Rule        string
+	StartsAt    time.Time
+	Mode        Mode
+}
+
+type Service interface {
+	Create(ctx context.Context, ownerID int, create *CreateTemplate) (*Template, error)
+	Get(ctx context.Context, id, ownerID int) (*Template, error)
+	List(ctx context.Context, ownerID int) ([]Template, error)
+	Delete(ctx context.Context, id, ownerID int) (*Template, error)
+}
+
+type ServiceImpl struct {
+	db database.DB
+}
+
+func NewServiceImpl(db database.DB) *ServiceImpl {
+	return &ServiceImpl{db: db}
+}
+
+const templateColumns = `
+	id, owner_id, description, billable, project_id, visibility, rule, starts_at, mode, task_id, next_run_at,
+	last_run_at
+`
+
+// Create creates a template of the owner. The first run is at the first
+// occurrence of the rule at or after the start, a rule without occurrences is
+// rejected.
+func (s *ServiceImpl) Create(ctx context.Context, ownerID int, create *CreateTemplate) (*Template, error) {
+	rule, err := ParseRule(create.Rule, create.StartsAt)
+	if err != nil {
+		return nil, errors.Join(errors.New("failed to parse rule"), err)
+	}
+	first, ok := rule.Next(create.StartsAt.Add(-time.Nanosecond))
+	if !ok {
+		return nil, ErrNoOccurrences
+	}
+
+	q := `
+		INSERT INTO task_templates (
+			owner_id, description, billable, project_id, visibility, rule, starts_at, mode, next_run_at
+		)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		RETURNING ` + templateColumns
+	args := []any{
+		ownerID,
+		create.Description,
+		create.Billable,
+		create.ProjectID,
+		create.Visibility,
+		create.Rule,
+		create.StartsAt,
+		create.Mode,
+		first,
+	}
+	return queryOne(ctx, s.db, q, args...)
+}
+
+func (s *ServiceImpl) Get(ctx context.Context, id, ownerID int) (*Template, error) {
+	q := `SELECT ` + templateColumns + ` FROM task_templates WHERE id = $1 AND owner_id = $2`
+	return queryOne(ctx, s.db, q, id, ownerID)
+}
+
+func (s *ServiceImpl) List(ctx context.Context, ownerID int) ([]Template, error) {
+	q := `SELECT ` + templateColumns + ` FROM task_templates WHERE owner_id = $1 ORDER BY id`
+	rows, err := s.db.Query(ctx, q, ownerID)
+	if err != nil {
+		return nil, errors.Join(errors.New("failed to select task templates"), err)
+	}
+	defer rows.Close()
+
+	templates, err := pgx.CollectRows(rows, pgx.RowToStructByName[Template])
+	if err != nil {
+		return nil, errors.Join(errors.New("failed to collect task templates"), err)
+	}
+	return templates, nil
+}
+
+// Delete deletes the template. The tasks it generated are kept.
+func (s *ServiceImpl) Delete(ctx context.Context, id, ownerID int) (*Template, error) {
+	q := `DELETE FROM task_templates WHERE id = $1 AND owner_id = $2 RETURNING ` + templateColumns
+	return queryOne(ctx, s.db, q, id, ownerID)
+}
+
+func queryOne(ctx context.Context, db database.DB, query string, args ...any) (*Template, error) {
+	rows, err := db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, errors.Join(errors.New("failed to query task template"), err)
+	}
+	defer rows.Close()
+
+	t, err := pgx.CollectExactlyOneRow(rows, pgx.RowToStructByName[Template])
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == pgerrcode.ForeignKeyViolation {
+			return nil, errors.Join(ErrProjectNotFound, err)
+		}
+		return nil, errors.Join(errors.New("failed to collect task template"), err)
+	}
+	return &t, nil
+}
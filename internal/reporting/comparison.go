@@ -0,0 +1,120 @@
+package reporting
+
+import (
+	"cmp"
+	"slices"
+	"time"
+
+	"github.com/kirillgashkov/timetrack/internal/task"
+	"github.com/shopspring/decimal"
+)
+
+// CompareTo is the period a report is compared to.
+type CompareTo string
+
+const (
+	CompareToPreviousPeriod CompareTo = "previous_period"
+	CompareToPreviousMonth  CompareTo = "previous_month"
+	CompareToPreviousYear   CompareTo = "previous_year"
+)
+
+// Period returns the period that [from, to) is compared to. The previous
+// period has the same length and ends at from, the previous month and year are
+// shifted by calendar months and years, e.g. July is compared to June.
+func (c CompareTo) Period(from, to time.Time) (time.Time, time.Time) {
+	switch c {
+	case CompareToPreviousMonth:
+		return from.AddDate(0, -1, 0), to.AddDate(0, -1, 0)
+	case CompareToPreviousYear:
+		return from.AddDate(-1, 0, 0), to.AddDate(-1, 0, 0)
+	default:
+		return from.Add(-to.Sub(from)), from
+	}
+}
+
+// Comparison compares the time spent on tasks in a report with the time spent
+// on them in an earlier period. Durations are rounded the same way as in the
+// report.
+type Comparison struct {
+	From  time.Time
+	To    time.Time
+	Tasks []ComparisonTask
+	Total ComparisonTotal
+}
+
+// ComparisonEntry is the time spent in the report period and in the period it
+// is compared to.
+type ComparisonEntry struct {
+	Duration         time.Duration
+	PreviousDuration time.Duration
+}
+
+// Change returns the absolute change, negative if less time was spent.
+func (e ComparisonEntry) Change() time.Duration {
+	return e.Duration - e.PreviousDuration
+}
+
+// ChangePercent returns the change relative to the previous duration in
+// percent rounded to hundredths. It reports false if no time was spent in the
+// previous period.
+func (e ComparisonEntry) ChangePercent() (decimal.Decimal, bool) {
+	if e.PreviousDuration == 0 {
+		return decimal.Decimal{}, false
+	}
+	change := decimal.NewFromInt(e.Change().Milliseconds())
+	previous := decimal.NewFromInt(e.PreviousDuration.Milliseconds())
+	return change.Mul(decimal.NewFromInt(100)).Div(previous).Round(2), true
+}
+
+// ComparisonTask is a task present in either of the periods. Trend is the
+// exact time spent on the task on each day of the report period.
+type ComparisonTask struct {
+	Task task.Task
+	ComparisonEntry
+	Trend []time.Duration
+}
+
+// ComparisonTotal is the total of both periods. Trend and PreviousTrend are
+// the exact time spent on each day of the periods.
+type ComparisonTotal struct {
+	ComparisonEntry
+	Trend         []time.Duration
+	PreviousTrend []time.Duration
+}
+
+// compareTasks merges the tasks of both periods by task. Tasks are ordered by
+// the time spent on them in the report period, then in the previous period.
+// Tasks without a trend get a trend of zeros of the given length.
+func compareTasks(current, previous []ReportTask, trends map[int][]time.Duration, days int) []ComparisonTask {
+	indexes := make(map[int]int, len(current)+len(previous))
+	tasks := make([]ComparisonTask, 0, len(current)+len(previous))
+	for _, rt := range current {
+		indexes[rt.Task.ID] = len(tasks)
+		tasks = append(tasks, ComparisonTask{Task: rt.Task, ComparisonEntry: ComparisonEntry{Duration: rt.Duration}})
+	}
+	for _, rt := range previous {
+		i, ok := indexes[rt.Task.ID]
+		if !ok {
+			i = len(tasks)
+			indexes[rt.Task.ID] = i
+			tasks = append(tasks, ComparisonTask{Task: rt.Task})
+		}
+		tasks[i].PreviousDuration = rt.Duration
+	}
+
+	for i := range tasks {
+		tasks[i].Trend = trends[tasks[i].Task.ID]
+		if tasks[i].Trend == nil {
+			tasks[i].Trend = make([]time.Duration, days)
+		}
+	}
+
+	slices.SortFunc(tasks, func(a, b ComparisonTask) int {
+		return cmp.Or(
+			cmp.Compare(b.Duration, a.Duration),
+			cmp.Compare(b.PreviousDuration, a.PreviousDuration),
+			cmp.Compare(a.Task.ID, b.Task.ID),
+		)
+	})
+	return tasks
+}
@@ -0,0 +1,112 @@
+package reporting
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/kirillgashkov/timetrack/internal/task"
+)
+
+func TestCompareToPeriod(t *testing.T) {
+	date := func(month time.Month, day int) time.Time {
+		return time.Date(2024, month, day, 0, 0, 0, 0, time.UTC)
+	}
+
+	tests := []struct {
+		name      string
+		compareTo CompareTo
+		from, to  time.Time
+		wantFrom  time.Time
+		wantTo    time.Time
+	}{
+		{"previous period", CompareToPreviousPeriod, date(7, 8), date(7, 15), date(7, 1), date(7, 8)},
+		{"previous month", CompareToPreviousMonth, date(7, 1), date(8, 1), date(6, 1), date(7, 1)},
+		{
+			"previous year",
+			CompareToPreviousYear,
+			date(7, 1),
+			date(8, 1),
+			time.Date(2023, 7, 1, 0, 0, 0, 0, time.UTC),
+			time.Date(2023, 8, 1, 0, 0, 0, 0, time.UTC),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			from, to := tt.compareTo.Period(tt.from, tt.to)
+			if !from.Equal(tt.wantFrom) || !to.Equal(tt.wantTo) {
+				t.Errorf("expected [%v, %v), got [%v, %v)", tt.wantFrom, tt.wantTo, from, to)
+			}
+		})
+	}
+}
+
+func TestComparisonEntryChangePercent(t *testing.T) {
+	tests := []struct {
+		name   string
+		entry  ComparisonEntry
+		want   string
+		wantOK bool
+	}{
+		{"growth", ComparisonEntry{Duration: 3 * time.Hour, PreviousDuration: 2 * time.Hour}, "50.00", true},
+		{"decline", ComparisonEntry{Duration: time.Hour, PreviousDuration: 3 * time.Hour}, "-66.67", true},
+		{"no change", ComparisonEntry{Duration: time.Hour, PreviousDuration: time.Hour}, "0.00", true},
+		{"new", ComparisonEntry{Duration: time.Hour}, "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := tt.entry.ChangePercent()
+			if ok != tt.wantOK {
+				t.Fatalf("expected ok %t, got %t", tt.wantOK, ok)
+			}
+			if ok && got.StringFixed(2) != tt.want {
+				t.Errorf("expected %s, got %s", tt.want, got.StringFixed(2))
+			}
+		})
+	}
+}
+
+func TestCompareTasks(t *testing.T) {
+	current := []ReportTask{
+		{Task: task.Task{ID: 2}, ReportEntry: ReportEntry{Duration: 3 * time.Hour}},
+		{Task: task.Task{ID: 1}, ReportEntry: ReportEntry{Duration: time.Hour}},
+	}
+	previous := []ReportTask{
+		{Task: task.Task{ID: 3}, ReportEntry: ReportEntry{Duration: 5 * time.Hour}},
+		{Task: task.Task{ID: 1}, ReportEntry: ReportEntry{Duration: 2 * time.Hour}},
+	}
+	trends := map[int][]time.Duration{
+		1: {time.Hour, 0},
+		2: {time.Hour, 2 * time.Hour},
+	}
+
+	got := compareTasks(current, previous, trends, 2)
+
+	want := []struct {
+		id               int
+		duration         time.Duration
+		previousDuration time.Duration
+		trend            []time.Duration
+	}{
+		{2, 3 * time.Hour, 0, []time.Duration{time.Hour, 2 * time.Hour}},
+		{1, time.Hour, 2 * time.Hour, []time.Duration{time.Hour, 0}},
+		{3, 0, 5 * time.Hour, []time.Duration{0, 0}},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("expected %d tasks, got %d", len(want), len(got))
+	}
+	for i, w := range want {
+		g := got[i]
+		if g.Task.ID != w.id || g.Duration != w.duration || g.PreviousDuration != w.previousDuration {
+			t.Errorf(
+				"expected task %d with %v and %v before, got task %d with %v and %v before",
+				w.id, w.duration, w.previousDuration, g.Task.ID, g.Duration, g.PreviousDuration,
+			)
+		}
+		if !slices.Equal(g.Trend, w.trend) {
+			t.Errorf("expected task %d trend %v, got %v", w.id, w.trend, g.Trend)
+		}
+	}
+}
@@ -0,0 +1,129 @@
+package reporting
+
+import (
+	"context"
+	"errors"
+	"slices"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/kirillgashkov/timetrack/internal/field"
+)
+
+var (
+	ErrFieldNotFound = errors.New("custom field not found")
+	ErrFieldNotEnum  = errors.New("custom field is not an enum")
+)
+
+// GroupByField groups the time by the values of an enum custom field of the
+// tasks. A task has at most one value of a field, so the groups don't overlap.
+const GroupByField GroupBy = "field"
+
+// ReportFieldValue is the time spent on tasks with a value of the custom field.
+// Value is nil for the time spent on tasks without a value.
+type ReportFieldValue struct {
+	Value *string
+	ReportEntry
+}
+
+type taskFieldValueRow struct {
+	TaskID int    `db:"task_id"`
+	Value  string `db:"value"`
+}
+
+// reportFieldValues reports the time spent on the tasks with each value of the
+// enum custom field. The exact time of the tasks is summed before the sum is
+// rounded.
+func (s *ServiceImpl) reportFieldValues(ctx context.Context, userID int, opts *ReportOptions) (*Report, error) {
+	f, err := s.queryEnumField(ctx, opts.FieldID)
+	if err != nil {
+		return nil, err
+	}
+
+	reportTaskRows, amounts, err := s.reportTaskEntries(ctx, userID, opts.From, opts.To)
+	if err != nil {
+		return nil, err
+	}
+	taskIDs := make([]int, 0, len(reportTaskRows))
+	for _, rtr := range reportTaskRows {
+		taskIDs = append(taskIDs, rtr.TaskID)
+	}
+	values, err := s.queryTaskFieldValues(ctx, f.ID, taskIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &Report{FieldValues: groupReportFieldValues(reportTaskRows, amounts, values, f.Options, opts.Rounding)}
+	entries := make([]ReportEntry, 0, len(report.FieldValues))
+	for _, rfv := range report.FieldValues {
+		entries = append(entries, rfv.ReportEntry)
+	}
+	report.Total = reportTotal(entries, opts.Rounding)
+	return report, nil
+}
+
+// groupReportFieldValues groups the time by the values of the tasks. Values
+// are keyed by their position in the options, so that values with the same
+// time are ordered as the options are, and the time spent on tasks without a
+// value is grouped under the key 0.
+func groupReportFieldValues(
+	rows []reportTaskRow, amounts map[int][]Amount, values map[int]string, options []string, rounding Rounding,
+) []ReportFieldValue {
+	groups := groupReportTasks(rows, amounts, func(rtr *reportTaskRow) int {
+		v, ok := values[rtr.TaskID]
+		if !ok {
+			return 0
+		}
+		return slices.Index(options, v) + 1
+	})
+	fieldValues := make([]ReportFieldValue, 0, len(groups))
+	for _, g := range groups {
+		rfv := ReportFieldValue{ReportEntry: roundReportEntry(g.entry, rounding)}
+		if g.key > 0 {
+			rfv.Value = &options[g.key-1]
+		}
+		fieldValues = append(fieldValues, rfv)
+	}
+	return fieldValues
+}
+
+func (s *ServiceImpl) queryEnumField(ctx context.Context, id int) (*field.Field, error) {
+	rows, err := s.db.Query(ctx, `SELECT id, name, type, options FROM custom_fields WHERE id = $1`, id)
+	if err != nil {
+		return nil, errors.Join(errors.New("failed to select custom field"), err)
+	}
+	defer rows.Close()
+
+	f, err := pgx.CollectExactlyOneRow(rows, pgx.RowToStructByName[field.Field])
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrFieldNotFound
+		}
+		return nil, errors.Join(errors.New("failed to collect custom field"), err)
+	}
+	if f.Type != field.TypeEnum {
+		return nil, ErrFieldNotEnum
+	}
+	return &f, nil
+}
+
+// queryTaskFieldValues returns the values of the custom field of the tasks by
+// task ID. Options can't be removed while tasks have them as values, so the
+// values of an enum field are always among its options.
+func (s *ServiceImpl) queryTaskFieldValues(ctx context.Context, fieldID int, taskIDs []int) (map[int]string, error) {
+	q := `SELECT task_id, value FROM task_field_values WHERE field_id = $1 AND task_id = ANY ($2)`
+	rows, err := s.db.Query(ctx, q, fieldID, taskIDs)
+	if err != nil {
+		return nil, errors.Join(errors.New("failed to select task field values"), err)
+	}
+	defer rows.Close()
+
+	taskFieldValues, err := pgx.CollectRows(rows, pgx.RowToStructByName[taskFieldValueRow])
+	if err != nil {
+		return nil, errors.Join(errors.New("failed to collect task field values"), err)
+	}
+	values := make(map[int]string, len(taskFieldValues))
+	for _, tfv := range taskFieldValues {
+		values[tfv.TaskID] = tfv.Value
+	}
+	return values, nil
+}
@@ -0,0 +1,94 @@
+package reporting
+
+import (
+	"cmp"
+	"slices"
+	"time"
+
+	"github.com/kirillgashkov/timetrack/internal/client"
+	"github.com/kirillgashkov/timetrack/internal/project"
+)
+
+// reportGroup is the exact time spent on the tasks of a group. Row is the first
+// task row of the group, it describes the project and the client.
+type reportGroup struct {
+	key   int
+	row   reportTaskRow
+	entry ReportEntry
+}
+
+// groupReportTasks sums the time and the amounts of the tasks by key. Groups
+// are ordered by the time spent on them, then by key.
+func groupReportTasks(
+	rows []reportTaskRow, amounts map[int][]Amount, key func(*reportTaskRow) int,
+) []reportGroup {
+	indexes := make(map[int]int)
+	entries := make([][]ReportEntry, 0)
+	groups := make([]reportGroup, 0)
+	for _, rtr := range rows {
+		k := key(&rtr)
+		i, ok := indexes[k]
+		if !ok {
+			i = len(groups)
+			indexes[k] = i
+			groups = append(groups, reportGroup{key: k, row: rtr})
+			entries = append(entries, nil)
+		}
+		entries[i] = append(entries[i], ReportEntry{
+			Duration:         rtr.Duration.Truncate(time.Millisecond),
+			BillableDuration: rtr.BillableDuration.Truncate(time.Millisecond),
+			Amounts:          amounts[rtr.TaskID],
+		})
+	}
+
+	for i := range groups {
+		groups[i].entry = reportTotal(entries[i], Rounding{})
+	}
+	slices.SortFunc(groups, func(a, b reportGroup) int {
+		return cmp.Or(cmp.Compare(b.entry.Duration, a.entry.Duration), cmp.Compare(a.key, b.key))
+	})
+	return groups
+}
+
+func groupReportProjects(rows []reportTaskRow, amounts map[int][]Amount, rounding Rounding) []ReportProject {
+	groups := groupReportTasks(rows, amounts, func(rtr *reportTaskRow) int { return rtr.ProjectID })
+	projects := make([]ReportProject, 0, len(groups))
+	for _, g := range groups {
+		projects = append(projects, ReportProject{
+			Project: project.Project{
+				ID:        g.row.ProjectID,
+				ClientID:  g.row.ClientID,
+				Name:      g.row.ProjectName,
+				IsDefault: g.row.ProjectIsDefault,
+			},
+			ReportEntry: roundReportEntry(g.entry, rounding),
+		})
+	}
+	return projects
+}
+
+// groupReportClients groups the time by client, the time spent on tasks of
+// projects without a client is grouped under the key 0.
+func groupReportClients(rows []reportTaskRow, amounts map[int][]Amount, rounding Rounding) []ReportClient {
+	groups := groupReportTasks(rows, amounts, func(rtr *reportTaskRow) int {
+		if rtr.ClientID == nil {
+			return 0
+		}
+		return *rtr.ClientID
+	})
+	clients := make([]ReportClient, 0, len(groups))
+	for _, g := range groups {
+		rc := ReportClient{ReportEntry: roundReportEntry(g.entry, rounding)}
+		if g.row.ClientID != nil {
+			rc.Client = &client.Client{ID: *g.row.ClientID, Name: *g.row.ClientName}
+		}
+		clients = append(clients, rc)
+	}
+	return clients
+}
+
+func roundReportEntry(e ReportEntry, rounding Rounding) ReportEntry {
+	e.Duration = rounding.roundGroup(e.Duration)
+	e.BillableDuration = rounding.roundGroup(e.BillableDuration)
+	return e
+}
@@ -0,0 +1,80 @@
+package reporting
+
+import (
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+func TestGroupReportTasks(t *testing.T) {
+	clientID := 1
+	clientName := "Acme Corp"
+	rows := []reportTaskRow{
+		{TaskID: 1, ProjectID: 2, ProjectName: "Proposals", ClientID: &clientID, ClientName: &clientName,
+			Duration: 50 * time.Minute, BillableDuration: 50 * time.Minute},
+		{TaskID: 2, ProjectID: 1, ProjectName: "Default", ProjectIsDefault: true, Duration: 40 * time.Minute},
+		{TaskID: 3, ProjectID: 3, ProjectName: "Research", ClientID: &clientID, ClientName: &clientName,
+			Duration: 20 * time.Minute, BillableDuration: 10 * time.Minute},
+	}
+	amounts := map[int][]Amount{
+		1: {{Value: decimal.RequireFromString("37.50"), Currency: "EUR"}},
+		3: {{Value: decimal.RequireFromString("7.50"), Currency: "EUR"}},
+	}
+	rounding := Rounding{Mode: RoundingModeUp, Step: 15 * time.Minute, Scope: RoundingScopeGroup}
+
+	projects := groupReportProjects(rows, amounts, rounding)
+	if len(projects) != 3 {
+		t.Fatalf("expected 3 projects, got %d", len(projects))
+	}
+	if projects[0].Project.Name != "Proposals" || projects[0].Duration != time.Hour {
+		t.Errorf("expected Proposals rounded up to an hour first, got %+v", projects[0])
+	}
+	if !projects[1].Project.IsDefault || projects[1].Project.ClientID != nil {
+		t.Errorf("expected the default project without a client second, got %+v", projects[1])
+	}
+
+	// The client's tasks sum to 70 minutes which is rounded once, not 60 + 30.
+	clients := groupReportClients(rows, amounts, rounding)
+	if len(clients) != 2 {
+		t.Fatalf("expected 2 clients, got %d", len(clients))
+	}
+	if clients[0].Client == nil || clients[0].Client.Name != clientName {
+		t.Fatalf("expected %s first, got %+v", clientName, clients[0])
+	}
+	if clients[0].Duration != 75*time.Minute || clients[0].BillableDuration != time.Hour {
+		t.Errorf("expected 75m and 60m billable, got %v and %v", clients[0].Duration, clients[0].BillableDuration)
+	}
+	if len(clients[0].Amounts) != 1 || clients[0].Amounts[0].Value.StringFixed(2) != "45.00" {
+		t.Errorf("expected 45.00 EUR, got %+v", clients[0].Amounts)
+	}
+	if clients[1].Client != nil || clients[1].Duration != 45*time.Minute {
+		t.Errorf("expected time without a client last, got %+v", clients[1])
+	}
+}
+
+func TestGroupReportFieldValues(t *testing.T) {
+	rows := []reportTaskRow{
+		{TaskID: 1, Duration: 20 * time.Minute},
+		{TaskID: 2, Duration: 40 * time.Minute, BillableDuration: 40 * time.Minute},
+		{TaskID: 3, Duration: 20 * time.Minute},
+		{TaskID: 4, Duration: 10 * time.Minute},
+	}
+	amounts := map[int][]Amount{2: {{Value: decimal.RequireFromString("30.00"), Currency: "EUR"}}}
+	values := map[int]string{1: "low", 2: "high", 3: "low"}
+	options := []string{"high", "medium", "low"}
+
+	fieldValues := groupReportFieldValues(rows, amounts, values, options, Rounding{})
+	if len(fieldValues) != 3 {
+		t.Fatalf("expected 3 values, got %d", len(fieldValues))
+	}
+	if fieldValues[0].Value == nil || *fieldValues[0].Value != "high" || len(fieldValues[0].Amounts) != 1 {
+		t.Errorf("expected high with amounts first, got %+v", fieldValues[0])
+	}
+	if fieldValues[1].Value == nil || *fieldValues[1].Value != "low" || fieldValues[1].Duration != 40*time.Minute {
+		t.Errorf("expected low with 40m second, got %+v", fieldValues[1])
+	}
+	if fieldValues[2].Value != nil || fieldValues[2].Duration != 10*time.Minute {
+		t.Errorf("expected time without a value last, got %+v", fieldValues[2])
+	}
+}
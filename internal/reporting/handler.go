@@ -1,25 +1,184 @@
 package reporting
 
 import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
 	"errors"
+	"fmt"
 	"net/http"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/kirillgashkov/timetrack/internal/auth"
 
 	"github.com/kirillgashkov/timetrack/api/timetrackapi/v1"
 	"github.com/kirillgashkov/timetrack/internal/app/api/apiutil"
+	openapi_types "github.com/oapi-codegen/runtime/types"
 )
 
 type Handler struct {
 	service Service
 }
 
+// reportFormat is the representation of a report. Reports are an array of tasks
+// in JSON by default, so that clients written before reports had totals,
+// groups, and comparisons keep working. The whole report is returned in its
+// own media type.
+type reportFormat string
+
+const (
+	reportFormatTasks  reportFormat = "application/json"
+	reportFormatReport reportFormat = "application/vnd.timetrack.report+json"
+	reportFormatPDF    reportFormat = "application/pdf"
+)
+
+func reportFormatOf(r *http.Request) reportFormat {
+	switch {
+	case apiutil.Accepts(r, string(reportFormatPDF)):
+		return reportFormatPDF
+	case apiutil.Accepts(r, string(reportFormatReport)):
+		return reportFormatReport
+	default:
+		return reportFormatTasks
+	}
+}
+
+// checkReportFormat writes 406 Not Acceptable and reports false if the report
+// can't be represented in the format. Reports grouped by anything but task and
+// comparisons have no array of tasks to be represented as.
+func checkReportFormat(w http.ResponseWriter, req *timetrackapi.ReportRequest, format reportFormat) bool {
+	opts := toReportOptions(req)
+	if format == reportFormatTasks && (opts.GroupBy != GroupByTask || opts.CompareTo != "") {
+		msg := "grouped and compared reports require Accept: " + string(reportFormatReport)
+		apiutil.MustWriteError(w, msg, http.StatusNotAcceptable)
+		return false
+	}
+	return true
+}
+
 func NewHandler(service Service) *Handler {
 	return &Handler{service: service}
 }
 
+// GetUsersIdReport handles "GET /users/{id}/report".
+//
+// It generates the same report as PostUsersIdReport. The response can be
+// cached by the client and revalidated with conditional requests. The ETag
+// changes whenever a work in the period or a row the report reads for the
+// works, e.g. a task or a rate, changes, Last-Modified is the time of the
+// latest change of the works.
+//
+//nolint:revive
+func (h *Handler) GetUsersIdReport(
+	w http.ResponseWriter, r *http.Request, id int, params timetrackapi.GetUsersIdReportParams,
+) {
+	u := auth.MustUserFromContext(r.Context())
+	if u.ID != id {
+		apiutil.MustWriteForbidden(w)
+		return
+	}
+
+	req := reportRequestFromParams(&params)
+	if err := validateReportRequest(req); err != nil {
+		var ve apiutil.ValidationError
+		if errors.As(err, &ve) {
+			apiutil.MustWriteUnprocessableEntity(w, ve)
+			return
+		}
+		apiutil.MustWriteInternalServerError(w, "failed to validate request", err)
+		return
+	}
+
+	format := reportFormatOf(r)
+	if !checkReportFormat(w, req, format) {
+		return
+	}
+
+	// The previous periods precede the report period, so the version of the
+	// works from the start of the compared period covers both.
+	opts := toReportOptions(req)
+	versionFrom := opts.From
+	if opts.CompareTo != "" {
+		versionFrom, _ = opts.CompareTo.Period(opts.From, opts.To)
+	}
+	version, err := h.service.ReportVersion(r.Context(), id, versionFrom, opts.To)
+	if err != nil {
+		apiutil.MustWriteInternalServerError(w, "failed to get report version", err)
+		return
+	}
+
+	var lastModified time.Time
+	if version.UpdatedAt != nil {
+		lastModified = *version.UpdatedAt
+	}
+	w.Header().Set("Cache-Control", "private, no-cache")
+	w.Header().Set("Vary", "Accept, Authorization")
+	if apiutil.WriteNotModified(w, r, reportETag(id, req, format, version), lastModified) {
+		return
+	}
+
+	h.writeReport(w, r, id, req, format)
+}
+
+func reportRequestFromParams(params *timetrackapi.GetUsersIdReportParams) *timetrackapi.ReportRequest {
+	req := &timetrackapi.ReportRequest{
+		From:            params.From,
+		To:              params.To,
+		GroupBy:         params.GroupBy,
+		CompareTo:       params.CompareTo,
+		IncludeSubtasks: params.IncludeSubtasks,
+		FieldId:         params.FieldId,
+	}
+	if params.Rounding != nil || params.RoundingMinutes != nil || params.RoundingScope != nil {
+		req.Rounding = &timetrackapi.ReportRoundingRequest{
+			Minutes: params.RoundingMinutes,
+			Scope:   params.RoundingScope,
+		}
+		if params.Rounding != nil {
+			req.Rounding.Mode = *params.Rounding
+		}
+	}
+	return req
+}
+
+// reportETag derives an entity tag from everything the report depends on: the
+// request, the representation, and the version of the works and other inputs.
+func reportETag(id int, req *timetrackapi.ReportRequest, format reportFormat, version *ReportVersion) string {
+	opts := toReportOptions(req)
+	var updatedAt int64
+	if version.UpdatedAt != nil {
+		updatedAt = version.UpdatedAt.UnixMicro()
+	}
+	s := fmt.Sprintf(
+		"%d|%s|%s|%s|%s|%d|%s|%s|%t|%d|%s|%d|%s|%d",
+		id,
+		opts.From.Format(time.RFC3339Nano),
+		opts.To.Format(time.RFC3339Nano),
+		opts.GroupBy,
+		opts.Rounding.Mode,
+		opts.Rounding.Step,
+		opts.Rounding.Scope,
+		opts.CompareTo,
+		opts.IncludeSubtasks,
+		opts.FieldID,
+		format,
+		version.Works,
+		version.Inputs,
+		updatedAt,
+	)
+	sum := sha256.Sum256([]byte(s))
+	return `"` + hex.EncodeToString(sum[:16]) + `"`
+}
+
 // PostUsersIdReport handles "POST /users/{id}/report".
 //
+// If the client accepts "application/pdf", the report is rendered as a
+// printable timesheet instead of JSON. The report is an array of tasks unless
+// the client accepts its own media type, see reportFormat.
+//
 //nolint:revive
 func (h *Handler) PostUsersIdReport(w http.ResponseWriter, r *http.Request, id int) {
 	u := auth.MustUserFromContext(r.Context())
@@ -39,28 +198,298 @@ func (h *Handler) PostUsersIdReport(w http.ResponseWriter, r *http.Request, id i
 		return
 	}
 
-	reportTasks, err := h.service.Report(r.Context(), id, req.From, req.To)
+	format := reportFormatOf(r)
+	if !checkReportFormat(w, req, format) {
+		return
+	}
+	h.writeReport(w, r, id, req, format)
+}
+
+func (h *Handler) writeReport(
+	w http.ResponseWriter, r *http.Request, id int, req *timetrackapi.ReportRequest, format reportFormat,
+) {
+	if format == reportFormatPDF {
+		h.writeTimesheetPDF(w, r, id, req)
+		return
+	}
+
+	report, err := h.service.Report(r.Context(), id, toReportOptions(req))
 	if err != nil {
+		if errors.Is(err, ErrFieldNotFound) {
+			apiutil.MustWriteUnprocessableEntity(w, apiutil.ValidationError{"custom field not found"})
+			return
+		}
+		if errors.Is(err, ErrFieldNotEnum) {
+			apiutil.MustWriteUnprocessableEntity(w, apiutil.ValidationError{"invalid field ID, must be an enum field"})
+			return
+		}
 		apiutil.MustWriteInternalServerError(w, "failed to generate report", err)
 		return
 	}
 
-	resp := make([]*timetrackapi.ReportTaskResponse, 0, len(reportTasks))
-	for _, t := range reportTasks {
-		reportTaskResp := &timetrackapi.ReportTaskResponse{
-			Task: &timetrackapi.TaskResponse{
+	resp := toReportResponse(report)
+	if format == reportFormatTasks {
+		tasks := make([]timetrackapi.ReportTaskResponse, 0)
+		if resp.Tasks != nil {
+			tasks = *resp.Tasks
+		}
+		apiutil.MustWriteJSON(w, tasks, http.StatusOK)
+		return
+	}
+	b, err := json.Marshal(resp)
+	if err != nil {
+		apiutil.MustWriteInternalServerError(w, "failed to encode report", err)
+		return
+	}
+	apiutil.MustWriteContent(w, b, string(reportFormatReport), http.StatusOK)
+}
+
+func toReportOptions(req *timetrackapi.ReportRequest) *ReportOptions {
+	opts := &ReportOptions{From: req.From, To: req.To, GroupBy: GroupByTask}
+	if req.GroupBy != nil {
+		opts.GroupBy = GroupBy(*req.GroupBy)
+	}
+	if req.CompareTo != nil {
+		opts.CompareTo = CompareTo(*req.CompareTo)
+	}
+	if req.IncludeSubtasks != nil {
+		opts.IncludeSubtasks = *req.IncludeSubtasks
+	}
+	if req.FieldId != nil {
+		opts.FieldID = *req.FieldId
+	}
+	if req.Rounding != nil {
+		opts.Rounding.Mode = RoundingMode(req.Rounding.Mode)
+		if req.Rounding.Minutes != nil {
+			opts.Rounding.Step = time.Duration(*req.Rounding.Minutes) * time.Minute
+		}
+		opts.Rounding.Scope = RoundingScopeGroup
+		if req.Rounding.Scope != nil {
+			opts.Rounding.Scope = RoundingScope(*req.Rounding.Scope)
+		}
+	}
+	return opts
+}
+
+func toReportResponse(report *Report) *timetrackapi.ReportResponse {
+	resp := &timetrackapi.ReportResponse{Total: timetrackapi.ReportTotalResponse{
+		Duration:         toReportDurationResponse(report.Total.Duration),
+		BillableDuration: toReportDurationResponse(report.Total.BillableDuration),
+		Amounts:          toAmountResponses(report.Total.Amounts),
+	}}
+
+	if report.Tasks != nil {
+		tasks := make([]timetrackapi.ReportTaskResponse, 0, len(report.Tasks))
+		for _, t := range report.Tasks {
+			tasks = append(tasks, timetrackapi.ReportTaskResponse{
+				Task: timetrackapi.TaskResponse{
+					Id:          t.Task.ID,
+					Description: t.Task.Description,
+					Billable:    t.Task.Billable,
+				},
+				Duration:         toReportDurationResponse(t.Duration),
+				BillableDuration: toReportDurationResponse(t.BillableDuration),
+				Amounts:          toAmountResponses(t.Amounts),
+			})
+		}
+		resp.Tasks = &tasks
+	}
+
+	if report.Projects != nil {
+		projects := make([]timetrackapi.ReportProjectResponse, 0, len(report.Projects))
+		for _, p := range report.Projects {
+			projects = append(projects, timetrackapi.ReportProjectResponse{
+				Project: timetrackapi.ProjectResponse{
+					Id:        p.Project.ID,
+					ClientId:  p.Project.ClientID,
+					Name:      p.Project.Name,
+					IsDefault: p.Project.IsDefault,
+				},
+				Duration:         toReportDurationResponse(p.Duration),
+				BillableDuration: toReportDurationResponse(p.BillableDuration),
+				Amounts:          toAmountResponses(p.Amounts),
+			})
+		}
+		resp.Projects = &projects
+	}
+
+	if report.Clients != nil {
+		clients := make([]timetrackapi.ReportClientResponse, 0, len(report.Clients))
+		for _, c := range report.Clients {
+			rc := timetrackapi.ReportClientResponse{
+				Duration:         toReportDurationResponse(c.Duration),
+				BillableDuration: toReportDurationResponse(c.BillableDuration),
+				Amounts:          toAmountResponses(c.Amounts),
+			}
+			if c.Client != nil {
+				rc.Client = &timetrackapi.ClientResponse{Id: c.Client.ID, Name: c.Client.Name}
+			}
+			clients = append(clients, rc)
+		}
+		resp.Clients = &clients
+	}
+
+	if report.Tags != nil {
+		tags := make([]timetrackapi.ReportTagResponse, 0, len(report.Tags))
+		for _, t := range report.Tags {
+			rt := timetrackapi.ReportTagResponse{
+				Duration:         toReportDurationResponse(t.Duration),
+				BillableDuration: toReportDurationResponse(t.BillableDuration),
+				Amounts:          toAmountResponses(t.Amounts),
+			}
+			if t.Tag != nil {
+				rt.Tag = &timetrackapi.TagResponse{Id: t.Tag.ID, Name: t.Tag.Name}
+			}
+			tags = append(tags, rt)
+		}
+		resp.Tags = &tags
+	}
+
+	if report.FieldValues != nil {
+		fieldValues := make([]timetrackapi.ReportFieldValueResponse, 0, len(report.FieldValues))
+		for _, fv := range report.FieldValues {
+			fieldValues = append(fieldValues, timetrackapi.ReportFieldValueResponse{
+				Value:            fv.Value,
+				Duration:         toReportDurationResponse(fv.Duration),
+				BillableDuration: toReportDurationResponse(fv.BillableDuration),
+				Amounts:          toAmountResponses(fv.Amounts),
+			})
+		}
+		resp.FieldValues = &fieldValues
+	}
+
+	if report.Days != nil {
+		days := make([]timetrackapi.ReportDayResponse, 0, len(report.Days))
+		for _, d := range report.Days {
+			days = append(days, timetrackapi.ReportDayResponse{
+				Date:             openapi_types.Date{Time: d.Date},
+				Duration:         toReportDurationResponse(d.Duration),
+				BillableDuration: toReportDurationResponse(d.BillableDuration),
+				Amounts:          toAmountResponses(d.Amounts),
+			})
+		}
+		resp.Days = &days
+	}
+
+	if report.Comparison != nil {
+		resp.Comparison = toReportComparisonResponse(report.Comparison)
+	}
+
+	return resp
+}
+
+func toReportComparisonResponse(c *Comparison) *timetrackapi.ReportComparisonResponse {
+	tasks := make([]timetrackapi.ReportComparisonTaskResponse, 0, len(c.Tasks))
+	for _, t := range c.Tasks {
+		tasks = append(tasks, timetrackapi.ReportComparisonTaskResponse{
+			Task: timetrackapi.TaskResponse{
 				Id:          t.Task.ID,
 				Description: t.Task.Description,
+				Billable:    t.Task.Billable,
 			},
-			Duration: &timetrackapi.ReportDurationResponse{
-				Hours:   int(t.Duration.Hours()),
-				Minutes: int(t.Duration.Minutes()) % 60,
-				Seconds: int(t.Duration.Seconds()) % 60,
-			},
+			Duration:         toReportDurationResponse(t.Duration),
+			PreviousDuration: toReportDurationResponse(t.PreviousDuration),
+			Change:           toReportChangeResponse(t.ComparisonEntry),
+			Trend:            toReportTrend(t.Trend),
+		})
+	}
+
+	return &timetrackapi.ReportComparisonResponse{
+		From:  c.From,
+		To:    c.To,
+		Tasks: tasks,
+		Total: timetrackapi.ReportComparisonTotalResponse{
+			Duration:         toReportDurationResponse(c.Total.Duration),
+			PreviousDuration: toReportDurationResponse(c.Total.PreviousDuration),
+			Change:           toReportChangeResponse(c.Total.ComparisonEntry),
+			Trend:            toReportTrend(c.Total.Trend),
+			PreviousTrend:    toReportTrend(c.Total.PreviousTrend),
+		},
+	}
+}
+
+func toReportChangeResponse(e ComparisonEntry) timetrackapi.ReportChangeResponse {
+	resp := timetrackapi.ReportChangeResponse{Milliseconds: e.Change().Milliseconds()}
+	if percent, ok := e.ChangePercent(); ok {
+		p := percent.StringFixed(2)
+		resp.Percent = &p
+	}
+	return resp
+}
+
+func toReportTrend(trend []time.Duration) timetrackapi.ReportTrend {
+	resp := make(timetrackapi.ReportTrend, 0, len(trend))
+	for _, d := range trend {
+		resp = append(resp, d.Milliseconds())
+	}
+	return resp
+}
+
+func toAmountResponses(amounts []Amount) []timetrackapi.AmountResponse {
+	resp := make([]timetrackapi.AmountResponse, 0, len(amounts))
+	for _, a := range amounts {
+		resp = append(resp, timetrackapi.AmountResponse{Amount: a.Value.StringFixed(2), Currency: a.Currency})
+	}
+	return resp
+}
+
+func (h *Handler) writeTimesheetPDF(w http.ResponseWriter, r *http.Request, id int, req *timetrackapi.ReportRequest) {
+	ts, err := h.service.Timesheet(r.Context(), id, req.From, req.To)
+	if err != nil {
+		if errors.Is(err, ErrUserNotFound) {
+			apiutil.MustWriteError(w, "user not found", http.StatusNotFound)
+			return
 		}
-		resp = append(resp, reportTaskResp)
+		apiutil.MustWriteInternalServerError(w, "failed to generate timesheet", err)
+		return
+	}
+
+	var buf bytes.Buffer
+	if err = WriteTimesheetPDF(&buf, ts); err != nil {
+		apiutil.MustWriteInternalServerError(w, "failed to render timesheet", err)
+		return
+	}
+
+	filename := fmt.Sprintf("timesheet-%d-%s.pdf", id, req.From.Format("2006-01-02"))
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+	apiutil.MustWriteContent(w, buf.Bytes(), "application/pdf", http.StatusOK)
+}
+
+func toReportDurationResponse(d time.Duration) timetrackapi.ReportDurationResponse {
+	return timetrackapi.ReportDurationResponse{
+		Hours:        int(d.Hours()),
+		Minutes:      int(d.Minutes()) % 60,
+		Seconds:      int(d.Seconds()) % 60,
+		Milliseconds: d.Milliseconds(),
+		Iso8601:      formatISO8601Duration(d),
 	}
-	apiutil.MustWriteJSON(w, resp, http.StatusOK)
+}
+
+// formatISO8601Duration formats the duration with millisecond precision as an
+// ISO 8601 duration in hours, minutes, and seconds, e.g. "PT26H5M0.25S". Days
+// are not used because they are not always 24 hours long.
+func formatISO8601Duration(d time.Duration) string {
+	d = d.Truncate(time.Millisecond)
+	if d == 0 {
+		return "PT0S"
+	}
+
+	var b strings.Builder
+	if d < 0 {
+		b.WriteByte('-')
+		d = -d
+	}
+	b.WriteString("PT")
+	if h := d / time.Hour; h > 0 {
+		b.WriteString(strconv.FormatInt(int64(h), 10) + "H")
+	}
+	if m := d % time.Hour / time.Minute; m > 0 {
+		b.WriteString(strconv.FormatInt(int64(m), 10) + "M")
+	}
+	if s := d % time.Minute; s > 0 {
+		b.WriteString(strconv.FormatFloat(s.Seconds(), 'f', -1, 64) + "S")
+	}
+	return b.String()
 }
 
 func parseAndValidateReportRequest(r *http.Request) (*timetrackapi.ReportRequest, error) {
@@ -86,9 +515,138 @@ func validateReportRequest(req *timetrackapi.ReportRequest) error {
 	if req.From.After(req.To) {
 		e = append(e, "from must be before to")
 	}
+	if req.GroupBy != nil {
+		switch GroupBy(*req.GroupBy) {
+		case GroupByTask, GroupByProject, GroupByClient, GroupByTag, GroupByField, GroupByDay:
+		default:
+			e = append(e, "invalid group by, must be one of task, project, client, tag, field, day")
+		}
+	}
+	groupByField := req.GroupBy != nil && GroupBy(*req.GroupBy) == GroupByField
+	if groupByField && req.FieldId == nil {
+		e = append(e, "missing field ID, required with group by field")
+	}
+	if !groupByField && req.FieldId != nil {
+		e = append(e, "invalid field ID, must be used with group by field")
+	}
+	if req.IncludeSubtasks != nil && *req.IncludeSubtasks && req.GroupBy != nil && GroupBy(*req.GroupBy) != GroupByTask {
+		e = append(e, "invalid include subtasks, must be used with group by task")
+	}
+	if req.Rounding != nil {
+		e = append(e, validateReportRoundingRequest(req.Rounding)...)
+	}
+	if req.CompareTo != nil {
+		switch CompareTo(*req.CompareTo) {
+		case CompareToPreviousPeriod, CompareToPreviousMonth, CompareToPreviousYear:
+		default:
+			e = append(e, "invalid compare to, must be one of previous_period, previous_month, previous_year")
+		}
+	}
 
 	if len(e) > 0 {
 		return apiutil.ValidationError(e)
 	}
 	return nil
 }
+
+func validateReportRoundingRequest(req *timetrackapi.ReportRoundingRequest) []string {
+	e := make([]string, 0)
+
+	switch RoundingMode(req.Mode) {
+	case RoundingModeNone:
+		if req.Minutes != nil {
+			e = append(e, "invalid rounding minutes, must be omitted when rounding mode is none")
+		}
+	case RoundingModeNearest, RoundingModeUp:
+		if req.Minutes == nil {
+			e = append(e, "missing rounding minutes")
+		} else if *req.Minutes < 1 || *req.Minutes > 1440 {
+			e = append(e, "invalid rounding minutes, must be between 1 and 1440")
+		}
+	default:
+		e = append(e, "invalid rounding mode, must be one of none, nearest, up")
+	}
+	if req.Scope != nil {
+		switch RoundingScope(*req.Scope) {
+		case RoundingScopeGroup, RoundingScopeTotal:
+		default:
+			e = append(e, "invalid rounding scope, must be one of group, total")
+		}
+	}
+
+	return e
+}
+
+// GetUsersIdOvertime handles "GET /users/{id}/overtime".
+//
+//nolint:revive
+func (h *Handler) GetUsersIdOvertime(
+	w http.ResponseWriter, r *http.Request, id int, params timetrackapi.GetUsersIdOvertimeParams,
+) {
+	opts, err := validateOvertimeParams(&params)
+	if err != nil {
+		apiutil.MustWriteUnprocessableEntity(w, err)
+		return
+	}
+
+	overtime, overtimeErr := h.service.Overtime(r.Context(), id, opts)
+	if overtimeErr != nil {
+		if errors.Is(overtimeErr, ErrUserNotFound) {
+			apiutil.MustWriteError(w, "user not found", http.StatusNotFound)
+			return
+		}
+		apiutil.MustWriteInternalServerError(w, "failed to get overtime", overtimeErr)
+		return
+	}
+
+	apiutil.MustWriteJSON(w, toOvertimeResponse(overtime), http.StatusOK)
+}
+
+func validateOvertimeParams(params *timetrackapi.GetUsersIdOvertimeParams) (*OvertimeOptions, apiutil.ValidationError) {
+	e := make([]string, 0)
+
+	if params.From.IsZero() {
+		e = append(e, "missing from")
+	}
+	if params.To.IsZero() {
+		e = append(e, "missing to")
+	}
+	if params.From.After(params.To) {
+		e = append(e, "from must be before to")
+	}
+	groupBy := GroupByDay
+	if params.GroupBy != nil {
+		groupBy = GroupBy(*params.GroupBy)
+		switch groupBy {
+		case GroupByDay, GroupByWeek:
+		default:
+			e = append(e, "invalid group by, must be one of day, week")
+		}
+	}
+
+	if len(e) > 0 {
+		return nil, apiutil.ValidationError(e)
+	}
+	return &OvertimeOptions{From: params.From, To: params.To, GroupBy: groupBy}, nil
+}
+
+func toOvertimeResponse(o *Overtime) *timetrackapi.OvertimeResponse {
+	resp := &timetrackapi.OvertimeResponse{
+		Entries: make([]timetrackapi.OvertimeEntryResponse, 0, len(o.Entries)),
+		Total: timetrackapi.OvertimeTotalResponse{
+			ExpectedHours: apiutil.FormatHours(o.Total.Expected),
+			TrackedHours:  apiutil.FormatHours(o.Total.Tracked),
+			OvertimeHours: apiutil.FormatHours(o.Total.Overtime()),
+		},
+	}
+	for _, e := range o.Entries {
+		resp.Entries = append(resp.Entries, timetrackapi.OvertimeEntryResponse{
+			Date:          openapi_types.Date{Time: e.Date},
+			ExpectedHours: apiutil.FormatHours(e.Expected),
+			TrackedHours:  apiutil.FormatHours(e.Tracked),
+			OvertimeHours: apiutil.FormatHours(e.Overtime()),
+			BalanceHours:  apiutil.FormatHours(e.Balance),
+		})
+	}
+	return resp
+}
@@ -0,0 +1,259 @@
+package reporting
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kirillgashkov/timetrack/api/timetrackapi/v1"
+	"github.com/kirillgashkov/timetrack/internal/auth"
+	"github.com/kirillgashkov/timetrack/internal/task"
+)
+
+type ServiceMock struct {
+	ReportFunc        func(ctx context.Context, userID int, opts *ReportOptions) (*Report, error)
+	ReportVersionFunc func(ctx context.Context, userID int, from, to time.Time) (*ReportVersion, error)
+	TimesheetFunc     func(ctx context.Context, userID int, from, to time.Time) (*Timesheet, error)
+	OvertimeFunc      func(ctx context.Context, userID int, opts *OvertimeOptions) (*Overtime, error)
+}
+
+func (s *ServiceMock) Report(ctx context.Context, userID int, opts *ReportOptions) (*Report, error) {
+	return s.ReportFunc(ctx, userID, opts)
+}
+
+func (s *ServiceMock) ReportVersion(ctx context.Context, userID int, from, to time.Time) (*ReportVersion, error) {
+	return s.ReportVersionFunc(ctx, userID, from, to)
+}
+
+func (s *ServiceMock) Timesheet(ctx context.Context, userID int, from, to time.Time) (*Timesheet, error) {
+	return s.TimesheetFunc(ctx, userID, from, to)
+}
+
+func (s *ServiceMock) Overtime(ctx context.Context, userID int, opts *OvertimeOptions) (*Overtime, error) {
+	return s.OvertimeFunc(ctx, userID, opts)
+}
+
+func TestGetUsersIdReport(t *testing.T) {
+	updatedAt := time.Date(2024, 7, 5, 12, 30, 15, 500_000_000, time.UTC)
+	version := &ReportVersion{Works: 3, Inputs: "a", UpdatedAt: &updatedAt}
+	service := &ServiceMock{
+		ReportFunc: func(context.Context, int, *ReportOptions) (*Report, error) {
+			return &Report{Tasks: []ReportTask{}}, nil
+		},
+		ReportVersionFunc: func(context.Context, int, time.Time, time.Time) (*ReportVersion, error) {
+			return version, nil
+		},
+		TimesheetFunc: func(context.Context, int, time.Time, time.Time) (*Timesheet, error) {
+			return &Timesheet{}, nil
+		},
+	}
+	params := timetrackapi.GetUsersIdReportParams{
+		From: time.Date(2024, 7, 1, 0, 0, 0, 0, time.UTC),
+		To:   time.Date(2024, 8, 1, 0, 0, 0, 0, time.UTC),
+	}
+
+	get := func(header http.Header, params timetrackapi.GetUsersIdReportParams) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/users/1/report", http.NoBody)
+		req = req.WithContext(auth.ContextWithUser(req.Context(), &auth.User{ID: 1}))
+		for k, v := range header {
+			req.Header[k] = v
+		}
+		w := httptest.NewRecorder()
+		NewHandler(service).GetUsersIdReport(w, req, 1, params)
+		return w
+	}
+
+	first := get(http.Header{}, params)
+	if first.Code != http.StatusOK {
+		t.Fatalf("expected status code %d, got %d", http.StatusOK, first.Code)
+	}
+	etag := first.Header().Get("ETag")
+	if etag == "" {
+		t.Fatal("expected ETag header")
+	}
+	if got, want := first.Header().Get("Last-Modified"), "Fri, 05 Jul 2024 12:30:15 GMT"; got != want {
+		t.Errorf("expected Last-Modified %q, got %q", want, got)
+	}
+
+	dayParams := params
+	day := timetrackapi.ReportGroupBy("day")
+	dayParams.GroupBy = &day
+	compareParams := params
+	previousMonth := timetrackapi.ReportCompareTo("previous_month")
+	compareParams.CompareTo = &previousMonth
+	invalidCompareParams := params
+	nextMonth := timetrackapi.ReportCompareTo("next_month")
+	invalidCompareParams.CompareTo = &nextMonth
+	fieldParams := params
+	field := timetrackapi.ReportGroupBy("field")
+	fieldParams.GroupBy = &field
+	fieldID := 1
+	fieldParams.FieldId = &fieldID
+	missingFieldParams := fieldParams
+	missingFieldParams.FieldId = nil
+
+	report := "application/vnd.timetrack.report+json"
+
+	tests := []struct {
+		name               string
+		header             http.Header
+		params             timetrackapi.GetUsersIdReportParams
+		expectedStatusCode int
+	}{
+		{"matching etag", http.Header{"If-None-Match": {etag}}, params, http.StatusNotModified},
+		{"weak matching etag", http.Header{"If-None-Match": {`"other", W/` + etag}}, params, http.StatusNotModified},
+		{"other etag", http.Header{"If-None-Match": {`"other"`}}, params, http.StatusOK},
+		{"other params", http.Header{"If-None-Match": {etag}, "Accept": {report}}, dayParams, http.StatusOK},
+		{"grouped tasks", http.Header{}, dayParams, http.StatusNotAcceptable},
+		{"compare to", http.Header{"If-None-Match": {etag}, "Accept": {report}}, compareParams, http.StatusOK},
+		{"compared tasks", http.Header{}, compareParams, http.StatusNotAcceptable},
+		{"report", http.Header{"If-None-Match": {etag}, "Accept": {report}}, params, http.StatusOK},
+		{"invalid compare to", http.Header{}, invalidCompareParams, http.StatusUnprocessableEntity},
+		{"field", http.Header{"If-None-Match": {etag}, "Accept": {report}}, fieldParams, http.StatusOK},
+		{"missing field", http.Header{}, missingFieldParams, http.StatusUnprocessableEntity},
+		{"pdf", http.Header{"If-None-Match": {etag}, "Accept": {"application/pdf"}}, params, http.StatusOK},
+		{"not modified", http.Header{"If-Modified-Since": {"Fri, 05 Jul 2024 12:30:15 GMT"}}, params, http.StatusNotModified},
+		{"modified", http.Header{"If-Modified-Since": {"Fri, 05 Jul 2024 12:30:14 GMT"}}, params, http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := get(tt.header, tt.params)
+			if w.Code != tt.expectedStatusCode {
+				t.Errorf("expected status code %d, got %d", tt.expectedStatusCode, w.Code)
+			}
+		})
+	}
+
+	// A changed task or rate doesn't change the works but changes the report.
+	version = &ReportVersion{Works: 3, Inputs: "b", UpdatedAt: &updatedAt}
+	if w := get(http.Header{"If-None-Match": {etag}}, params); w.Code != http.StatusOK {
+		t.Errorf("expected status code %d after the inputs changed, got %d", http.StatusOK, w.Code)
+	}
+}
+
+func TestPostUsersIdReportFormat(t *testing.T) {
+	service := &ServiceMock{
+		ReportFunc: func(context.Context, int, *ReportOptions) (*Report, error) {
+			return &Report{Tasks: []ReportTask{{Task: task.Task{ID: 2}}}, Total: ReportEntry{Duration: time.Hour}}, nil
+		},
+	}
+
+	tests := []struct {
+		name                string
+		accept              string
+		expectedContentType string
+		expectedBody        string
+	}{
+		{"tasks", "application/json", "application/json", `[{"amounts":[],`},
+		{"default", "*/*", "application/json", `[{"amounts":[],`},
+		{"report", "application/vnd.timetrack.report+json", "application/vnd.timetrack.report+json", `{"tasks":[{`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			body := `{"from":"2024-07-01T00:00:00Z","to":"2024-08-01T00:00:00Z"}`
+			req := httptest.NewRequest(http.MethodPost, "/users/1/report", strings.NewReader(body))
+			req = req.WithContext(auth.ContextWithUser(req.Context(), &auth.User{ID: 1}))
+			req.Header.Set("Accept", tt.accept)
+			w := httptest.NewRecorder()
+			NewHandler(service).PostUsersIdReport(w, req, 1)
+
+			if w.Code != http.StatusOK {
+				t.Fatalf("expected status code %d, got %d: %s", http.StatusOK, w.Code, w.Body.String())
+			}
+			if got := w.Header().Get("Content-Type"); got != tt.expectedContentType {
+				t.Errorf("expected Content-Type %q, got %q", tt.expectedContentType, got)
+			}
+			if !strings.HasPrefix(w.Body.String(), tt.expectedBody) {
+				t.Errorf("expected body to start with %s, got %s", tt.expectedBody, w.Body.String())
+			}
+		})
+	}
+}
+
+func TestGetUsersIdOvertime(t *testing.T) {
+	from := time.Date(2024, 7, 1, 0, 0, 0, 0, time.UTC)
+	service := &ServiceMock{
+		OvertimeFunc: func(_ context.Context, userID int, opts *OvertimeOptions) (*Overtime, error) {
+			if userID != 1 {
+				return nil, ErrUserNotFound
+			}
+			if opts.GroupBy != GroupByWeek {
+				t.Errorf("expected group by %q, got %q", GroupByWeek, opts.GroupBy)
+			}
+			entry := OvertimeEntry{Date: from, Expected: 40 * time.Hour, Tracked: 38*time.Hour + 45*time.Minute}
+			entry.Balance = entry.Overtime()
+			return &Overtime{Entries: []OvertimeEntry{entry}, Total: entry}, nil
+		},
+	}
+	week := timetrackapi.OvertimeGroupBy("week")
+	month := timetrackapi.OvertimeGroupBy("month")
+
+	tests := []struct {
+		name               string
+		id                 int
+		to                 time.Time
+		groupBy            *timetrackapi.OvertimeGroupBy
+		expectedStatusCode int
+	}{
+		{"week", 1, from.AddDate(0, 0, 7), &week, http.StatusOK},
+		{"month", 1, from.AddDate(0, 1, 0), &month, http.StatusUnprocessableEntity},
+		{"reversed", 1, from.AddDate(0, 0, -7), &week, http.StatusUnprocessableEntity},
+		{"not found", 2, from.AddDate(0, 0, 7), &week, http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/users/1/overtime", http.NoBody)
+			w := httptest.NewRecorder()
+			params := timetrackapi.GetUsersIdOvertimeParams{From: from, To: tt.to, GroupBy: tt.groupBy}
+			NewHandler(service).GetUsersIdOvertime(w, req, tt.id, params)
+
+			if w.Code != tt.expectedStatusCode {
+				t.Fatalf("expected status code %d, got %d: %s", tt.expectedStatusCode, w.Code, w.Body.String())
+			}
+			if w.Code != http.StatusOK {
+				return
+			}
+			var resp timetrackapi.OvertimeResponse
+			if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+				t.Fatal(err)
+			}
+			e := resp.Entries[0]
+			if e.ExpectedHours != "40.00" || e.TrackedHours != "38.75" || e.OvertimeHours != "-1.25" {
+				t.Errorf("unexpected entry %+v", e)
+			}
+			if resp.Total.OvertimeHours != "-1.25" {
+				t.Errorf("expected total overtime -1.25, got %s", resp.Total.OvertimeHours)
+			}
+		})
+	}
+}
+
+func TestFormatISO8601Duration(t *testing.T) {
+	tests := []struct {
+		d    time.Duration
+		want string
+	}{
+		{0, "PT0S"},
+		{time.Millisecond, "PT0.001S"},
+		{90 * time.Second, "PT1M30S"},
+		{26*time.Hour + 5*time.Minute + 250*time.Millisecond, "PT26H5M0.25S"},
+		{2 * time.Hour, "PT2H"},
+		{time.Second + 999999*time.Microsecond, "PT1.999S"},
+		{-90 * time.Minute, "-PT1H30M"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			if got := formatISO8601Duration(tt.d); got != tt.want {
+				t.Errorf("expected %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
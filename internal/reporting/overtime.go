@@ -0,0 +1,150 @@
+package reporting
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/kirillgashkov/timetrack/internal/schedule"
+	"github.com/shopspring/decimal"
+)
+
+// GroupByWeek groups overtime by weeks starting on Monday. It is not supported
+// by reports.
+const GroupByWeek GroupBy = "week"
+
+type OvertimeOptions struct {
+	From    time.Time
+	To      time.Time
+	GroupBy GroupBy
+}
+
+// Overtime compares the time a user is expected to work according to their
+// work schedules and the holiday calendar with the time they tracked. Days are
+// expected to be worked in full even if the period cuts them.
+type Overtime struct {
+	Entries []OvertimeEntry
+	Total   OvertimeEntry
+}
+
+// OvertimeEntry is the expected and the tracked time of a day or a week that
+// starts at Date. Balance is the overtime from the start of the period up to
+// and including the entry.
+type OvertimeEntry struct {
+	Date     time.Time
+	Expected time.Duration
+	Tracked  time.Duration
+	Balance  time.Duration
+}
+
+// Overtime returns the tracked minus the expected time, negative for
+// undertime.
+func (e OvertimeEntry) Overtime() time.Duration {
+	return e.Tracked - e.Expected
+}
+
+type expectedHoursRow struct {
+	Index int64           `db:"day_index"`
+	Hours decimal.Decimal `db:"hours"`
+}
+
+func (s *ServiceImpl) Overtime(ctx context.Context, userID int, opts *OvertimeOptions) (*Overtime, error) {
+	dayStarts, dayStops := splitDays(opts.From, opts.To)
+
+	expected, err := s.queryExpectedHours(ctx, userID, dayStarts)
+	if err != nil {
+		return nil, err
+	}
+
+	reportDayRows, err := s.queryReportDays(ctx, userID, dayStarts, dayStops)
+	if err != nil {
+		return nil, err
+	}
+	durations := make(map[int64]time.Duration, len(reportDayRows))
+	for _, rdr := range reportDayRows {
+		durations[rdr.DayStartedAt.Unix()] = rdr.Duration.Truncate(time.Millisecond)
+	}
+	tracked := make([]time.Duration, len(dayStarts))
+	for i, ds := range dayStarts {
+		tracked[i] = durations[ds.Unix()]
+	}
+
+	entries := overtimeEntries(dayStarts, expected, tracked, opts.GroupBy)
+	total := OvertimeEntry{Date: opts.From}
+	for _, e := range entries {
+		total.Expected += e.Expected
+		total.Tracked += e.Tracked
+	}
+	total.Balance = total.Overtime()
+	return &Overtime{Entries: entries, Total: total}, nil
+}
+
+// overtimeEntries groups the expected and the tracked time of each day by day
+// or by week and calculates the running balance. Weeks start on Monday in the
+// location of the first day, the first and the last weeks may be partial.
+func overtimeEntries(
+	dayStarts []time.Time, expected, tracked []time.Duration, groupBy GroupBy,
+) []OvertimeEntry {
+	entries := make([]OvertimeEntry, 0, len(dayStarts))
+	var balance time.Duration
+	for i, ds := range dayStarts {
+		if groupBy != GroupByWeek || len(entries) == 0 || !sameWeek(entries[len(entries)-1].Date, ds) {
+			entries = append(entries, OvertimeEntry{Date: ds})
+		}
+		e := &entries[len(entries)-1]
+		e.Expected += expected[i]
+		e.Tracked += tracked[i]
+		balance += tracked[i] - expected[i]
+		e.Balance = balance
+	}
+	return entries
+}
+
+func sameWeek(a, b time.Time) bool {
+	return weekStart(a).Equal(weekStart(b.In(a.Location())))
+}
+
+// weekStart returns midnight of Monday of the week of t in the location of t.
+func weekStart(t time.Time) time.Time {
+	y, m, d := t.Date()
+	offset := (int(t.Weekday()) + 6) % 7
+	return time.Date(y, m, d-offset, 0, 0, 0, 0, t.Location())
+}
+
+// queryExpectedHours returns the time the user is expected to work on the
+// calendar day of each day start. Days before the first schedule of the user
+// are not expected to be worked.
+func (s *ServiceImpl) queryExpectedHours(
+	ctx context.Context, userID int, dayStarts []time.Time,
+) ([]time.Duration, error) {
+	q := `
+		SELECT days.day_index, COALESCE(expected.hours, 0) AS hours
+		FROM users
+		CROSS JOIN unnest($2::date[]) WITH ORDINALITY AS days (date, day_index)
+		LEFT JOIN LATERAL (` + schedule.DayHoursSubquery + `) AS expected ON true
+		WHERE users.id = $1
+		ORDER BY days.day_index
+	`
+	// Dates are encoded from the calendar day of each time in its location.
+	rows, err := s.db.Query(ctx, q, userID, dayStarts)
+	if err != nil {
+		return nil, errors.Join(errors.New("failed to select expected hours"), err)
+	}
+	defer rows.Close()
+
+	expectedRows, err := pgx.CollectRows(rows, pgx.RowToStructByName[expectedHoursRow])
+	if err != nil {
+		return nil, errors.Join(errors.New("failed to collect expected hours"), err)
+	}
+	if len(expectedRows) == 0 && len(dayStarts) > 0 {
+		return nil, ErrUserNotFound
+	}
+
+	expected := make([]time.Duration, len(dayStarts))
+	for _, er := range expectedRows {
+		seconds := er.Hours.Mul(decimal.NewFromInt(3600)).IntPart()
+		expected[er.Index-1] = time.Duration(seconds) * time.Second
+	}
+	return expected, nil
+}
@@ -0,0 +1,62 @@
+package reporting
+
+import (
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestOvertimeEntries(t *testing.T) {
+	// Wednesday, 2024-07-03 to Tuesday, 2024-07-09.
+	from := time.Date(2024, 7, 3, 0, 0, 0, 0, time.UTC)
+	dayStarts, _ := splitDays(from, from.AddDate(0, 0, 7))
+	h := time.Hour
+	expected := []time.Duration{8 * h, 8 * h, 8 * h, 0, 0, 8 * h, 8 * h}
+	tracked := []time.Duration{9 * h, 8 * h, 6 * h, 2 * h, 0, 8 * h, 7*h + 30*time.Minute}
+
+	t.Run("day", func(t *testing.T) {
+		entries := overtimeEntries(dayStarts, expected, tracked, GroupByDay)
+		if len(entries) != 7 {
+			t.Fatalf("expected 7 entries, got %d", len(entries))
+		}
+		balances := make([]time.Duration, 0, len(entries))
+		for _, e := range entries {
+			balances = append(balances, e.Balance)
+		}
+		want := []time.Duration{h, h, -h, h, h, h, h / 2}
+		if !slices.Equal(balances, want) {
+			t.Errorf("expected balances %v, got %v", want, balances)
+		}
+	})
+
+	t.Run("week", func(t *testing.T) {
+		entries := overtimeEntries(dayStarts, expected, tracked, GroupByWeek)
+		want := []OvertimeEntry{
+			{Date: dayStarts[0], Expected: 24 * h, Tracked: 25 * h, Balance: h},
+			{Date: dayStarts[5], Expected: 16 * h, Tracked: 15*h + 30*time.Minute, Balance: h / 2},
+		}
+		if !slices.Equal(entries, want) {
+			t.Errorf("expected %+v, got %+v", want, entries)
+		}
+		if entries[1].Overtime() != -h/2 {
+			t.Errorf("expected overtime of the second week %v, got %v", -h/2, entries[1].Overtime())
+		}
+	})
+}
+
+func TestWeekStart(t *testing.T) {
+	loc := time.FixedZone("UTC+3", 3*60*60)
+	tests := []struct {
+		t    time.Time
+		want time.Time
+	}{
+		{time.Date(2024, 7, 1, 0, 0, 0, 0, loc), time.Date(2024, 7, 1, 0, 0, 0, 0, loc)},
+		{time.Date(2024, 7, 7, 23, 59, 0, 0, loc), time.Date(2024, 7, 1, 0, 0, 0, 0, loc)},
+		{time.Date(2024, 8, 1, 12, 0, 0, 0, loc), time.Date(2024, 7, 29, 0, 0, 0, 0, loc)},
+	}
+	for _, tt := range tests {
+		if got := weekStart(tt.t); !got.Equal(tt.want) {
+			t.Errorf("weekStart(%v): expected %v, got %v", tt.t, tt.want, got)
+		}
+	}
+}
@@ -0,0 +1,148 @@
+package reporting
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-pdf/fpdf"
+	"github.com/kirillgashkov/timetrack/internal/app/pdfutil"
+)
+
+const (
+	timesheetMaxTaskColumns = 12
+	timesheetDateWidth      = 30.0
+	timesheetTotalWidth     = 22.0
+	timesheetRowHeight      = 5.0
+)
+
+// WriteTimesheetPDF renders the timesheet as a printable PDF document with a
+// row for each day and a column for each task. Tasks are referred to by their
+// IDs in the table and are listed with their descriptions below it. If there
+// are too many tasks to fit on a page, the table is split into several ones.
+func WriteTimesheetPDF(w io.Writer, ts *Timesheet) error {
+	pdf := pdfutil.New("L")
+	pdf.SetTitle("Timesheet", true)
+	pdf.SetAutoPageBreak(true, 15)
+	pdf.AddPage()
+
+	writeTimesheetHeader(pdf, ts)
+
+	if len(ts.Tasks) == 0 {
+		pdf.CellFormat(0, timesheetRowHeight, "No time was tracked in this period.", "", 1, "L", false, 0, "")
+	}
+	for start := 0; start < len(ts.Tasks); start += timesheetMaxTaskColumns {
+		end := min(start+timesheetMaxTaskColumns, len(ts.Tasks))
+		writeTimesheetTable(pdf, ts, start, end)
+		pdf.Ln(timesheetRowHeight)
+	}
+
+	writeTimesheetTasks(pdf, ts)
+	writeTimesheetSignatures(pdf)
+
+	if err := pdf.Output(w); err != nil {
+		return errors.Join(errors.New("failed to write PDF"), err)
+	}
+	return nil
+}
+
+func writeTimesheetHeader(pdf *fpdf.Fpdf, ts *Timesheet) {
+	pdf.SetFont(pdfutil.FontFamily, "B", 16)
+	pdf.CellFormat(0, 10, "Timesheet", "", 1, "L", false, 0, "")
+
+	pdf.SetFont(pdfutil.FontFamily, "", 10)
+	pdf.CellFormat(0, timesheetRowHeight, "Employee: "+timesheetUserName(&ts.User), "", 1, "L", false, 0, "")
+	pdf.CellFormat(0, timesheetRowHeight, "Period: "+timesheetPeriod(ts.From, ts.To), "", 1, "L", false, 0, "")
+	pdf.Ln(timesheetRowHeight)
+}
+
+// writeTimesheetTable writes a table with the tasks from start to end as
+// columns.
+func writeTimesheetTable(pdf *fpdf.Fpdf, ts *Timesheet, start, end int) {
+	left, _, right, _ := pdf.GetMargins()
+	pageWidth, _ := pdf.GetPageSize()
+	taskWidth := (pageWidth - left - right - timesheetDateWidth - timesheetTotalWidth) / timesheetMaxTaskColumns
+
+	header := func() {
+		pdf.SetFont(pdfutil.FontFamily, "B", 9)
+		pdf.CellFormat(timesheetDateWidth, timesheetRowHeight, "Date", "1", 0, "L", false, 0, "")
+		for _, t := range ts.Tasks[start:end] {
+			pdf.CellFormat(taskWidth, timesheetRowHeight, "#"+strconv.Itoa(t.ID), "1", 0, "R", false, 0, "")
+		}
+		pdf.CellFormat(timesheetTotalWidth, timesheetRowHeight, "Total", "1", 1, "R", false, 0, "")
+		pdf.SetFont(pdfutil.FontFamily, "", 9)
+	}
+	pdf.SetHeaderFunc(header)
+	defer pdf.SetHeaderFunc(nil)
+	header()
+
+	for _, d := range ts.Days {
+		pdf.CellFormat(timesheetDateWidth, timesheetRowHeight, d.Date.Format("Mon 2006-01-02"), "1", 0, "L", false, 0, "")
+		for _, dur := range d.Durations[start:end] {
+			pdf.CellFormat(taskWidth, timesheetRowHeight, formatTimesheetDuration(dur), "1", 0, "R", false, 0, "")
+		}
+		pdf.CellFormat(timesheetTotalWidth, timesheetRowHeight, formatTimesheetDuration(d.Total()), "1", 1, "R", false, 0, "")
+	}
+
+	pdf.SetFont(pdfutil.FontFamily, "B", 9)
+	pdf.CellFormat(timesheetDateWidth, timesheetRowHeight, "Total", "1", 0, "L", false, 0, "")
+	for i := start; i < end; i++ {
+		pdf.CellFormat(taskWidth, timesheetRowHeight, formatTimesheetDuration(ts.TaskTotal(i)), "1", 0, "R", false, 0, "")
+	}
+	pdf.CellFormat(timesheetTotalWidth, timesheetRowHeight, formatTimesheetDuration(ts.Total()), "1", 1, "R", false, 0, "")
+	pdf.SetFont(pdfutil.FontFamily, "", 9)
+}
+
+func writeTimesheetTasks(pdf *fpdf.Fpdf, ts *Timesheet) {
+	if len(ts.Tasks) == 0 {
+		return
+	}
+
+	pdf.SetFont(pdfutil.FontFamily, "B", 10)
+	pdf.CellFormat(0, timesheetRowHeight, "Tasks", "", 1, "L", false, 0, "")
+	pdf.SetFont(pdfutil.FontFamily, "", 9)
+	for _, t := range ts.Tasks {
+		pdf.MultiCell(0, timesheetRowHeight, "#"+strconv.Itoa(t.ID)+" "+t.Description, "", "L", false)
+	}
+	pdf.Ln(timesheetRowHeight)
+}
+
+func writeTimesheetSignatures(pdf *fpdf.Fpdf) {
+	pdf.SetFont(pdfutil.FontFamily, "", 10)
+	pdf.Ln(timesheetRowHeight)
+	pdf.CellFormat(135, timesheetRowHeight, "Employee signature: ____________________", "", 0, "L", false, 0, "")
+	pdf.CellFormat(0, timesheetRowHeight, "Date: ____________", "", 1, "L", false, 0, "")
+	pdf.Ln(timesheetRowHeight)
+	pdf.CellFormat(135, timesheetRowHeight, "Approved by: ____________________", "", 0, "L", false, 0, "")
+	pdf.CellFormat(0, timesheetRowHeight, "Date: ____________", "", 1, "L", false, 0, "")
+}
+
+func timesheetUserName(u *TimesheetUser) string {
+	parts := []string{u.Surname, u.Name}
+	if u.Patronymic != nil {
+		parts = append(parts, *u.Patronymic)
+	}
+	return strings.Join(parts, " ")
+}
+
+// timesheetPeriod formats the period as a range of dates. The end of the
+// period is exclusive, so a period that ends at midnight ends on the previous
+// day.
+func timesheetPeriod(from, to time.Time) string {
+	last := to.In(from.Location()).Add(-time.Nanosecond)
+	return from.Format("2006-01-02") + " – " + last.Format("2006-01-02")
+}
+
+// formatTimesheetDuration formats the duration as hours and minutes, e.g.
+// "12:05". Zero durations are formatted as an empty string to keep the table
+// readable.
+func formatTimesheetDuration(d time.Duration) string {
+	if d == 0 {
+		return ""
+	}
+	d = d.Truncate(time.Minute)
+	return fmt.Sprintf("%d:%02d", int(d.Hours()), int(d.Minutes())%60)
+}
@@ -0,0 +1,92 @@
+package reporting
+
+import (
+	"bytes"
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kirillgashkov/timetrack/internal/app/testutil"
+	"github.com/kirillgashkov/timetrack/internal/task"
+)
+
+var update = flag.Bool("update", false, "update golden files")
+
+func TestWriteTimesheetPDF(t *testing.T) {
+	loc := time.FixedZone("MSK", 3*60*60)
+	patronymic := "Игоревич"
+
+	tests := []struct {
+		name      string
+		timesheet *Timesheet
+	}{
+		{
+			"month",
+			&Timesheet{
+				User: TimesheetUser{ID: 1, Surname: "Иванов", Name: "Александр", Patronymic: &patronymic},
+				From: time.Date(2024, 6, 1, 0, 0, 0, 0, loc),
+				To:   time.Date(2024, 6, 4, 0, 0, 0, 0, loc),
+				Tasks: []task.Task{
+					{ID: 3, Description: "Research market trends for sector analysis"},
+					{ID: 1, Description: "Write project proposal for client A"},
+				},
+				Days: []TimesheetDay{
+					{
+						Date:      time.Date(2024, 6, 1, 0, 0, 0, 0, loc),
+						Durations: []time.Duration{8*time.Hour + 30*time.Minute, 0},
+					},
+					{
+						Date:      time.Date(2024, 6, 2, 0, 0, 0, 0, loc),
+						Durations: []time.Duration{0, 0},
+					},
+					{
+						Date:      time.Date(2024, 6, 3, 0, 0, 0, 0, loc),
+						Durations: []time.Duration{2*time.Hour + 5*time.Minute + 59*time.Second, 45 * time.Minute},
+					},
+				},
+			},
+		},
+		{
+			"empty",
+			&Timesheet{
+				User: TimesheetUser{ID: 7, Surname: "Павлов", Name: "Дмитрий"},
+				From: time.Date(2024, 6, 1, 0, 0, 0, 0, loc),
+				To:   time.Date(2024, 6, 2, 0, 0, 0, 0, loc),
+				Days: []TimesheetDay{{Date: time.Date(2024, 6, 1, 0, 0, 0, 0, loc)}},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := WriteTimesheetPDF(&buf, tt.timesheet); err != nil {
+				t.Fatalf("expected no error, got: %v", err)
+			}
+
+			text, err := testutil.PDFText(buf.Bytes())
+			if err != nil {
+				t.Fatalf("failed to extract text: %v", err)
+			}
+			got := strings.Join(text, "\n") + "\n"
+
+			golden := filepath.Join("testdata", "timesheet_"+tt.name+".golden")
+			if *update {
+				if err = os.WriteFile(golden, []byte(got), 0o600); err != nil {
+					t.Fatalf("failed to update golden file: %v", err)
+				}
+			}
+			want, err := os.ReadFile(golden)
+			if err != nil {
+				t.Fatalf("failed to read golden file: %v", err)
+			}
+
+			if got != string(want) {
+				t.Errorf("text mismatch, got:\n%s\nwant:\n%s", got, want)
+			}
+		})
+	}
+}
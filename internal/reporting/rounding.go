@@ -0,0 +1,63 @@
+package reporting
+
+import "time"
+
+type RoundingMode string
+
+const (
+	RoundingModeNone    RoundingMode = "none"
+	RoundingModeNearest RoundingMode = "nearest"
+	RoundingModeUp      RoundingMode = "up"
+)
+
+type RoundingScope string
+
+const (
+	RoundingScopeGroup RoundingScope = "group"
+	RoundingScopeTotal RoundingScope = "total"
+)
+
+// Rounding is a rule for rounding reported durations. With the group scope the
+// time of each group of a report, e.g. a task or a day, is rounded after the
+// time of its works is summed, works aren't rounded one by one. With the total
+// scope only the total is rounded. The zero value keeps durations exact.
+type Rounding struct {
+	Mode  RoundingMode
+	Step  time.Duration
+	Scope RoundingScope
+}
+
+// Round rounds the duration to a multiple of the step. Halves are rounded up.
+func (r Rounding) Round(d time.Duration) time.Duration {
+	if r.Step <= 0 {
+		return d
+	}
+	switch r.Mode {
+	case RoundingModeNearest:
+		return d.Round(r.Step)
+	case RoundingModeUp:
+		if rem := d % r.Step; rem != 0 {
+			return d - rem + r.Step
+		}
+		return d
+	default:
+		return d
+	}
+}
+
+// roundGroup rounds the duration of a group of a report.
+func (r Rounding) roundGroup(d time.Duration) time.Duration {
+	if r.Scope == RoundingScopeTotal {
+		return d
+	}
+	return r.Round(d)
+}
+
+// roundTotal rounds the total of a report which is the sum of already rounded
+// groups.
+func (r Rounding) roundTotal(d time.Duration) time.Duration {
+	if r.Scope != RoundingScopeTotal {
+		return d
+	}
+	return r.Round(d)
+}
@@ -0,0 +1,87 @@
+package reporting
+
+import (
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+func TestRoundingRound(t *testing.T) {
+	nearest := Rounding{Mode: RoundingModeNearest, Step: 15 * time.Minute}
+	up := Rounding{Mode: RoundingModeUp, Step: 6 * time.Minute}
+
+	tests := []struct {
+		name     string
+		rounding Rounding
+		d        time.Duration
+		want     time.Duration
+	}{
+		{"none", Rounding{Mode: RoundingModeNone}, 1500 * time.Millisecond, 1500 * time.Millisecond},
+		{"zero value", Rounding{}, 7 * time.Minute, 7 * time.Minute},
+		{"nearest down", nearest, 52 * time.Minute, 45 * time.Minute},
+		{"nearest half", nearest, 7*time.Minute + 30*time.Second, 15 * time.Minute},
+		{"nearest up", nearest, 53 * time.Minute, time.Hour},
+		{"up", up, 12*time.Minute + time.Millisecond, 18 * time.Minute},
+		{"up exact", up, 12 * time.Minute, 12 * time.Minute},
+		{"up zero", up, 0, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.rounding.Round(tt.d); got != tt.want {
+				t.Errorf("expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestReportTotal(t *testing.T) {
+	entries := []ReportEntry{
+		{
+			Duration:         10 * time.Minute,
+			BillableDuration: 10 * time.Minute,
+			Amounts: []Amount{
+				{Value: decimal.RequireFromString("8.33"), Currency: "USD"},
+				{Value: decimal.RequireFromString("7.50"), Currency: "EUR"},
+			},
+		},
+		{
+			Duration: 20 * time.Minute,
+			Amounts:  []Amount{{Value: decimal.RequireFromString("16.67"), Currency: "USD"}},
+		},
+	}
+
+	tests := []struct {
+		name             string
+		scope            RoundingScope
+		wantDuration     time.Duration
+		wantBillable     time.Duration
+		wantAmountsTotal string
+	}{
+		{"group scope", RoundingScopeGroup, 30 * time.Minute, 10 * time.Minute, "EUR 7.50, USD 25.00"},
+		{"total scope", RoundingScopeTotal, time.Hour, time.Hour, "EUR 7.50, USD 25.00"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			total := reportTotal(entries, Rounding{Mode: RoundingModeUp, Step: time.Hour, Scope: tt.scope})
+			if total.Duration != tt.wantDuration {
+				t.Errorf("expected duration %v, got %v", tt.wantDuration, total.Duration)
+			}
+			if total.BillableDuration != tt.wantBillable {
+				t.Errorf("expected billable duration %v, got %v", tt.wantBillable, total.BillableDuration)
+			}
+			var amounts string
+			for i, a := range total.Amounts {
+				if i > 0 {
+					amounts += ", "
+				}
+				amounts += a.Currency + " " + a.Value.StringFixed(2)
+			}
+			if amounts != tt.wantAmountsTotal {
+				t.Errorf("expected amounts %q, got %q", tt.wantAmountsTotal, amounts)
+			}
+		})
+	}
+}
@@ -3,26 +3,203 @@ package reporting
 import (
 	"context"
 	"errors"
+	"slices"
+	"strings"
 	"time"
 
 	"github.com/jackc/pgx/v5"
 	"github.com/kirillgashkov/timetrack/internal/app/database"
+	"github.com/kirillgashkov/timetrack/internal/billing"
+	"github.com/kirillgashkov/timetrack/internal/client"
+	"github.com/kirillgashkov/timetrack/internal/project"
+	"github.com/kirillgashkov/timetrack/internal/rollup"
 	"github.com/kirillgashkov/timetrack/internal/task"
+	"github.com/shopspring/decimal"
 )
 
+var (
+	ErrUserNotFound = errors.New("user not found")
+)
+
+// Report is the time a user spent on tasks in a period, grouped by task, by
+// project, by client, by tag, by the value of a custom field, or by day.
+// Durations are truncated to milliseconds and rounded according to the report
+// options, the total is calculated from the rounded durations so that it
+// matches the sum of the entries unless the total itself is rounded.
+type Report struct {
+	Tasks       []ReportTask
+	Projects    []ReportProject
+	Clients     []ReportClient
+	Tags        []ReportTag
+	FieldValues []ReportFieldValue
+	Days        []ReportDay
+	Total       ReportEntry
+	Comparison  *Comparison
+}
+
+type GroupBy string
+
+const (
+	GroupByTask    GroupBy = "task"
+	GroupByProject GroupBy = "project"
+	GroupByClient  GroupBy = "client"
+	GroupByDay     GroupBy = "day"
+)
+
+// ReportOptions configure a report. If CompareTo is set, the report is
+// compared to the same report for an earlier period. If IncludeSubtasks is
+// set, the time of each task in a report by task includes the time of its
+// subtasks, the total and the comparison don't. FieldID is the enum custom
+// field of a report by field.
+type ReportOptions struct {
+	From            time.Time
+	To              time.Time
+	GroupBy         GroupBy
+	Rounding        Rounding
+	CompareTo       CompareTo
+	IncludeSubtasks bool
+	FieldID         int
+}
+
+// ReportEntry is the time spent in a group of works. Amounts are the cost of
+// the billable part of the time, one for each currency of the rates that
+// apply.
+type ReportEntry struct {
+	Duration         time.Duration
+	BillableDuration time.Duration
+	Amounts          []Amount
+}
+
 type ReportTask struct {
-	Task     task.Task
-	Duration time.Duration
+	Task task.Task
+	ReportEntry
+}
+
+type ReportProject struct {
+	Project project.Project
+	ReportEntry
+}
+
+// ReportClient is the time spent on tasks of the projects of a client. Client
+// is nil for the time spent on tasks of projects without a client.
+type ReportClient struct {
+	Client *client.Client
+	ReportEntry
+}
+
+// ReportDay is the time spent on a calendar day in the location of the start
+// of the report period.
+type ReportDay struct {
+	Date time.Time
+	ReportEntry
+}
+
+type Amount struct {
+	Value    decimal.Decimal
+	Currency string
+}
+
+// ReportVersion identifies the state of the works that a report for a period
+// is generated from and of the other rows the report reads for them, see
+// reportInputs. UpdatedAt is nil if there are no works.
+type ReportVersion struct {
+	Works     int        `db:"works"`
+	Inputs    string     `db:"inputs"`
+	UpdatedAt *time.Time `db:"updated_at"`
+}
+
+// Timesheet is a per-day, per-task breakdown of the time a user spent on tasks
+// in a period.
+type Timesheet struct {
+	User  TimesheetUser
+	From  time.Time
+	To    time.Time
+	Tasks []task.Task
+	Days  []TimesheetDay
+}
+
+type TimesheetUser struct {
+	ID         int
+	Surname    string
+	Name       string
+	Patronymic *string
+}
+
+// TimesheetDay is a day of a timesheet. Durations are aligned with
+// Timesheet.Tasks.
+type TimesheetDay struct {
+	Date      time.Time
+	Durations []time.Duration
+}
+
+func (d *TimesheetDay) Total() time.Duration {
+	var total time.Duration
+	for _, dur := range d.Durations {
+		total += dur
+	}
+	return total
+}
+
+// TaskTotal returns the time spent on the i-th task of the timesheet.
+func (ts *Timesheet) TaskTotal(i int) time.Duration {
+	var total time.Duration
+	for _, d := range ts.Days {
+		total += d.Durations[i]
+	}
+	return total
+}
+
+func (ts *Timesheet) Total() time.Duration {
+	var total time.Duration
+	for _, d := range ts.Days {
+		total += d.Total()
+	}
+	return total
 }
 
 type reportTaskRow struct {
+	TaskID           int           `db:"task_id"`
+	TaskDescription  string        `db:"task_description"`
+	TaskBillable     bool          `db:"task_billable"`
+	ProjectID        int           `db:"project_id"`
+	ProjectName      string        `db:"project_name"`
+	ProjectIsDefault bool          `db:"project_is_default"`
+	ClientID         *int          `db:"client_id"`
+	ClientName       *string       `db:"client_name"`
+	Duration         time.Duration `db:"duration"`
+	BillableDuration time.Duration `db:"billable_duration"`
+}
+
+type reportAmountRow struct {
+	TaskID   int             `db:"task_id"`
+	Amount   decimal.Decimal `db:"amount"`
+	Currency string
+}
+
+type reportDayRow struct {
+	DayStartedAt     time.Time     `db:"day_started_at"`
+	Duration         time.Duration `db:"duration"`
+	BillableDuration time.Duration `db:"billable_duration"`
+}
+
+type reportDayAmountRow struct {
+	DayStartedAt time.Time       `db:"day_started_at"`
+	Amount       decimal.Decimal `db:"amount"`
+	Currency     string
+}
+
+type timesheetRow struct {
+	DayStartedAt    time.Time     `db:"day_started_at"`
 	TaskID          int           `db:"task_id"`
 	TaskDescription string        `db:"task_description"`
 	Duration        time.Duration `db:"duration"`
 }
 
 type Service interface {
-	Report(ctx context.Context, userID int, from, to time.Time) ([]ReportTask, error)
+	Report(ctx context.Context, userID int, opts *ReportOptions) (*Report, error)
+	ReportVersion(ctx context.Context, userID int, from, to time.Time) (*ReportVersion, error)
+	Timesheet(ctx context.Context, userID int, from, to time.Time) (*Timesheet, error)
+	Overtime(ctx context.Context, userID int, opts *OvertimeOptions) (*Overtime, error)
 }
 
 type ServiceImpl struct {
@@ -33,34 +210,509 @@ func NewServiceImpl(db database.DB) *ServiceImpl {
 	return &ServiceImpl{db: db}
 }
 
-func (s *ServiceImpl) Report(ctx context.Context, userID int, from, to time.Time) ([]ReportTask, error) {
-	reportTaskRows, err := s.queryReportTasks(ctx, userID, from, to)
+func (s *ServiceImpl) Report(ctx context.Context, userID int, opts *ReportOptions) (*Report, error) {
+	var report *Report
+	var err error
+	switch opts.GroupBy {
+	case GroupByDay:
+		report, err = s.reportDays(ctx, userID, opts)
+	case GroupByProject, GroupByClient:
+		report, err = s.reportGroups(ctx, userID, opts)
+	case GroupByTag:
+		report, err = s.reportTags(ctx, userID, opts)
+	case GroupByField:
+		report, err = s.reportFieldValues(ctx, userID, opts)
+	default:
+		report, err = s.reportTasks(ctx, userID, opts)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if opts.CompareTo != "" {
+		if report.Comparison, err = s.compare(ctx, userID, opts, report); err != nil {
+			return nil, err
+		}
+	}
+	return report, nil
+}
+
+// compare runs the report by task for the period the report is compared to
+// and merges it with the report by task for the report period. Tasks are
+// compared by their own time.
+func (s *ServiceImpl) compare(
+	ctx context.Context, userID int, opts *ReportOptions, report *Report,
+) (*Comparison, error) {
+	currentOpts := *opts
+	currentOpts.IncludeSubtasks = false
+	current := report
+	if opts.GroupBy != GroupByTask || opts.IncludeSubtasks {
+		var err error
+		if current, err = s.reportTasks(ctx, userID, &currentOpts); err != nil {
+			return nil, err
+		}
+	}
+
+	previousOpts := currentOpts
+	previousOpts.From, previousOpts.To = opts.CompareTo.Period(opts.From, opts.To)
+	previous, err := s.reportTasks(ctx, userID, &previousOpts)
+	if err != nil {
+		return nil, err
+	}
+
+	taskTrends, trend, err := s.trend(ctx, userID, opts.From, opts.To)
+	if err != nil {
+		return nil, err
+	}
+	_, previousTrend, err := s.trend(ctx, userID, previousOpts.From, previousOpts.To)
 	if err != nil {
 		return nil, err
 	}
 
-	reportTasks := make([]ReportTask, 0, len(reportTaskRows))
-	for _, rtr := range reportTaskRows {
-		reportTasks = append(reportTasks, ReportTask{
+	return &Comparison{
+		From:  previousOpts.From,
+		To:    previousOpts.To,
+		Tasks: compareTasks(current.Tasks, previous.Tasks, taskTrends, len(trend)),
+		Total: ComparisonTotal{
+			ComparisonEntry: ComparisonEntry{Duration: current.Total.Duration, PreviousDuration: previous.Total.Duration},
+			Trend:           trend,
+			PreviousTrend:   previousTrend,
+		},
+	}, nil
+}
+
+// trend returns the time spent on each task and in total on each day of the
+// period. Durations are truncated to milliseconds.
+func (s *ServiceImpl) trend(
+	ctx context.Context, userID int, from, to time.Time,
+) (map[int][]time.Duration, []time.Duration, error) {
+	dayStarts, dayStops := splitDays(from, to)
+	timesheetRows, err := s.queryTimesheet(ctx, userID, dayStarts, dayStops)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	dayIndexes := make(map[int64]int, len(dayStarts))
+	for i, ds := range dayStarts {
+		dayIndexes[ds.Unix()] = i
+	}
+
+	tasks := make(map[int][]time.Duration)
+	total := make([]time.Duration, len(dayStarts))
+	for _, tr := range timesheetRows {
+		i, ok := dayIndexes[tr.DayStartedAt.Unix()]
+		if !ok {
+			return nil, nil, errors.New("trend row has unexpected day")
+		}
+		if tasks[tr.TaskID] == nil {
+			tasks[tr.TaskID] = make([]time.Duration, len(dayStarts))
+		}
+		d := tr.Duration.Truncate(time.Millisecond)
+		tasks[tr.TaskID][i] += d
+		total[i] += d
+	}
+	return tasks, total, nil
+}
+
+// reportTasks reports the time spent on each task. With subtasks included, the
+// time of the subtasks is added to their ancestors after the total is
+// calculated, so that the time isn't counted twice.
+func (s *ServiceImpl) reportTasks(ctx context.Context, userID int, opts *ReportOptions) (*Report, error) {
+	reportTaskRows, amounts, err := s.reportTaskEntries(ctx, userID, opts.From, opts.To)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &Report{Tasks: toReportTasks(reportTaskRows, amounts, opts.Rounding)}
+	entries := make([]ReportEntry, 0, len(report.Tasks))
+	for _, rt := range report.Tasks {
+		entries = append(entries, rt.ReportEntry)
+	}
+	report.Total = reportTotal(entries, opts.Rounding)
+
+	if opts.IncludeSubtasks {
+		taskIDs := make([]int, 0, len(reportTaskRows))
+		for _, rtr := range reportTaskRows {
+			taskIDs = append(taskIDs, rtr.TaskID)
+		}
+		ancestors, err := s.queryTaskAncestors(ctx, taskIDs)
+		if err != nil {
+			return nil, err
+		}
+		rolledUpRows, rolledUpAmounts := includeSubtasks(reportTaskRows, amounts, ancestors)
+		report.Tasks = toReportTasks(rolledUpRows, rolledUpAmounts, opts.Rounding)
+	}
+	return report, nil
+}
+
+func toReportTasks(rows []reportTaskRow, amounts map[int][]Amount, rounding Rounding) []ReportTask {
+	tasks := make([]ReportTask, 0, len(rows))
+	for _, rtr := range rows {
+		tasks = append(tasks, ReportTask{
 			Task: task.Task{
 				ID:          rtr.TaskID,
 				Description: rtr.TaskDescription,
+				Billable:    rtr.TaskBillable,
+				ProjectID:   rtr.ProjectID,
+				ClientID:    rtr.ClientID,
+			},
+			ReportEntry: ReportEntry{
+				Duration:         rounding.roundGroup(rtr.Duration.Truncate(time.Millisecond)),
+				BillableDuration: rounding.roundGroup(rtr.BillableDuration.Truncate(time.Millisecond)),
+				Amounts:          amounts[rtr.TaskID],
 			},
-			Duration: rtr.Duration,
 		})
 	}
-	return reportTasks, nil
+	return tasks
 }
 
+// reportTaskEntries returns the time spent on each task in the period and the
+// amounts of each task by task ID.
+func (s *ServiceImpl) reportTaskEntries(
+	ctx context.Context, userID int, from, to time.Time,
+) ([]reportTaskRow, map[int][]Amount, error) {
+	reportTaskRows, err := s.queryReportTasks(ctx, userID, from, to)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	reportAmountRows, err := s.queryReportAmounts(ctx, userID, from, to)
+	if err != nil {
+		return nil, nil, err
+	}
+	amounts := make(map[int][]Amount)
+	for _, rar := range reportAmountRows {
+		amounts[rar.TaskID] = append(amounts[rar.TaskID], Amount{Value: rar.Amount, Currency: rar.Currency})
+	}
+	return reportTaskRows, amounts, nil
+}
+
+// reportGroups reports the time spent on the tasks of each project or client.
+// The exact time of the tasks is summed before the sum is rounded.
+func (s *ServiceImpl) reportGroups(ctx context.Context, userID int, opts *ReportOptions) (*Report, error) {
+	reportTaskRows, amounts, err := s.reportTaskEntries(ctx, userID, opts.From, opts.To)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &Report{}
+	var entries []ReportEntry
+	if opts.GroupBy == GroupByClient {
+		report.Clients = groupReportClients(reportTaskRows, amounts, opts.Rounding)
+		for _, rc := range report.Clients {
+			entries = append(entries, rc.ReportEntry)
+		}
+	} else {
+		report.Projects = groupReportProjects(reportTaskRows, amounts, opts.Rounding)
+		for _, rp := range report.Projects {
+			entries = append(entries, rp.ReportEntry)
+		}
+	}
+	report.Total = reportTotal(entries, opts.Rounding)
+	return report, nil
+}
+
+// reportDays reports the time spent on each day of the period. Days without
+// any time are included.
+func (s *ServiceImpl) reportDays(ctx context.Context, userID int, opts *ReportOptions) (*Report, error) {
+	dayStarts, dayStops := splitDays(opts.From, opts.To)
+
+	reportDayRows, err := s.queryReportDays(ctx, userID, dayStarts, dayStops)
+	if err != nil {
+		return nil, err
+	}
+	durations := make(map[int64]reportDayRow, len(reportDayRows))
+	for _, rdr := range reportDayRows {
+		durations[rdr.DayStartedAt.Unix()] = rdr
+	}
+
+	reportDayAmountRows, err := s.queryReportDayAmounts(ctx, userID, dayStarts, dayStops)
+	if err != nil {
+		return nil, err
+	}
+	amounts := make(map[int64][]Amount)
+	for _, rar := range reportDayAmountRows {
+		day := rar.DayStartedAt.Unix()
+		amounts[day] = append(amounts[day], Amount{Value: rar.Amount, Currency: rar.Currency})
+	}
+
+	report := &Report{Days: make([]ReportDay, 0, len(dayStarts))}
+	entries := make([]ReportEntry, 0, len(dayStarts))
+	for _, ds := range dayStarts {
+		rdr := durations[ds.Unix()]
+		rd := ReportDay{
+			Date: ds,
+			ReportEntry: ReportEntry{
+				Duration:         opts.Rounding.roundGroup(rdr.Duration.Truncate(time.Millisecond)),
+				BillableDuration: opts.Rounding.roundGroup(rdr.BillableDuration.Truncate(time.Millisecond)),
+				Amounts:          amounts[ds.Unix()],
+			},
+		}
+		report.Days = append(report.Days, rd)
+		entries = append(entries, rd.ReportEntry)
+	}
+	report.Total = reportTotal(entries, opts.Rounding)
+	return report, nil
+}
+
+// reportTotal sums the entries of a report. Amounts are summed for each
+// currency and are ordered by currency.
+func reportTotal(entries []ReportEntry, rounding Rounding) ReportEntry {
+	var total ReportEntry
+	amounts := make(map[string]decimal.Decimal)
+	for _, e := range entries {
+		total.Duration += e.Duration
+		total.BillableDuration += e.BillableDuration
+		for _, a := range e.Amounts {
+			amounts[a.Currency] = amounts[a.Currency].Add(a.Value)
+		}
+	}
+	total.Duration = rounding.roundTotal(total.Duration)
+	total.BillableDuration = rounding.roundTotal(total.BillableDuration)
+
+	currencies := make([]string, 0, len(amounts))
+	for c := range amounts {
+		currencies = append(currencies, c)
+	}
+	slices.Sort(currencies)
+	total.Amounts = make([]Amount, 0, len(currencies))
+	for _, c := range currencies {
+		total.Amounts = append(total.Amounts, Amount{Value: amounts[c], Currency: c})
+	}
+	return total
+}
+
+// reportInputs select the rows other than the works that a report reads, as
+// text. They refer to the works of the report as "report_works", to their
+// tasks and the ancestors of the tasks, which reports with subtasks read, as
+// "report_tasks", and to the user as $1. The rows are read only for
+// the works of the user, so changes for other users don't change the version.
+var reportInputs = []string{
+	`SELECT string_agg(report_tasks::text, ',' ORDER BY report_tasks.id) FROM report_tasks`,
+	`
+		SELECT string_agg(rates::text, ',' ORDER BY rates.id)
+		FROM rates
+		WHERE (rates.user_id = $1 OR rates.user_id IS NULL)
+		  AND (rates.task_id IN (SELECT report_tasks.id FROM report_tasks) OR rates.task_id IS NULL)
+	`,
+	`SELECT ROW (users.surname, users.name, users.patronymic)::text FROM users WHERE users.id = $1`,
+	`
+		SELECT string_agg(projects::text, ',' ORDER BY projects.id)
+		FROM projects
+		WHERE projects.id IN (SELECT report_tasks.project_id FROM report_tasks)
+	`,
+	`
+		SELECT string_agg(clients::text, ',' ORDER BY clients.id)
+		FROM clients
+		WHERE clients.id IN (
+			SELECT projects.client_id
+			FROM projects
+			WHERE projects.id IN (SELECT report_tasks.project_id FROM report_tasks)
+		)
+	`,
+	`
+		SELECT string_agg(task_tags::text, ',' ORDER BY task_tags.task_id, task_tags.tag_id)
+		FROM task_tags
+		WHERE task_tags.task_id IN (SELECT report_tasks.id FROM report_tasks)
+	`,
+	`
+		SELECT string_agg(work_tags::text, ',' ORDER BY work_tags.work_id, work_tags.tag_id)
+		FROM work_tags
+		WHERE work_tags.work_id IN (SELECT report_works.id FROM report_works)
+	`,
+	`
+		SELECT string_agg(tags::text, ',' ORDER BY tags.id)
+		FROM tags
+		WHERE tags.id IN (
+			SELECT task_tags.tag_id FROM task_tags WHERE task_tags.task_id IN (SELECT report_tasks.id FROM report_tasks)
+			UNION
+			SELECT work_tags.tag_id FROM work_tags WHERE work_tags.work_id IN (SELECT report_works.id FROM report_works)
+		)
+	`,
+	`
+		SELECT string_agg(task_field_values::text, ',' ORDER BY task_field_values.task_id, task_field_values.field_id)
+		FROM task_field_values
+		WHERE task_field_values.task_id IN (SELECT report_tasks.id FROM report_tasks)
+	`,
+	`
+		SELECT string_agg(custom_fields::text, ',' ORDER BY custom_fields.id)
+		FROM custom_fields
+		WHERE custom_fields.id IN (
+			SELECT task_field_values.field_id
+			FROM task_field_values
+			WHERE task_field_values.task_id IN (SELECT report_tasks.id FROM report_tasks)
+		)
+	`,
+}
+
+// ReportVersion returns the number of works that a report for the period is
+// generated from, the time of the latest change of them, and a digest of the
+// other report inputs. Deleting a work changes the number of works, any other
+// change of a work updates the time, and any change of the rows read for the
+// works changes the digest.
+func (s *ServiceImpl) ReportVersion(ctx context.Context, userID int, from, to time.Time) (*ReportVersion, error) {
+	inputs := make([]string, 0, len(reportInputs))
+	for _, q := range reportInputs {
+		inputs = append(inputs, "("+q+")")
+	}
+	q := `
+		WITH RECURSIVE report_works AS (
+			SELECT works.id, works.task_id, works.updated_at
+			FROM works
+			WHERE works.user_id = $1 AND works.started_at <= $3 AND works.stopped_at >= $2
+		), report_task_ids (id) AS (
+			SELECT report_works.task_id FROM report_works
+			UNION
+			SELECT tasks.parent_id
+			FROM report_task_ids
+			JOIN tasks ON tasks.id = report_task_ids.id
+			WHERE tasks.parent_id IS NOT NULL
+		), report_tasks AS (
+			SELECT tasks.* FROM tasks WHERE tasks.id IN (SELECT report_task_ids.id FROM report_task_ids)
+		)
+		SELECT count(*) AS works,
+			   max(report_works.updated_at) AS updated_at,
+			   md5(ROW (` + strings.Join(inputs, ", ") + `)::text) AS inputs
+		FROM report_works
+	`
+	rows, err := s.db.Query(ctx, q, userID, from, to)
+	if err != nil {
+		return nil, errors.Join(errors.New("failed to select report version"), err)
+	}
+	defer rows.Close()
+
+	v, err := pgx.CollectExactlyOneRow(rows, pgx.RowToStructByName[ReportVersion])
+	if err != nil {
+		return nil, errors.Join(errors.New("failed to collect report version"), err)
+	}
+	return &v, nil
+}
+
+// Timesheet splits the period into days in the location of from and reports
+// the time spent on each task on each day. Tasks without any time in the
+// period are omitted.
+func (s *ServiceImpl) Timesheet(ctx context.Context, userID int, from, to time.Time) (*Timesheet, error) {
+	u, err := s.queryTimesheetUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	dayStarts, dayStops := splitDays(from, to)
+	timesheetRows, err := s.queryTimesheet(ctx, userID, dayStarts, dayStops)
+	if err != nil {
+		return nil, err
+	}
+
+	ts := &Timesheet{User: *u, From: from, To: to}
+
+	taskIndexes := make(map[int]int)
+	for _, tr := range timesheetRows {
+		if _, ok := taskIndexes[tr.TaskID]; !ok {
+			taskIndexes[tr.TaskID] = len(ts.Tasks)
+			ts.Tasks = append(ts.Tasks, task.Task{ID: tr.TaskID, Description: tr.TaskDescription})
+		}
+	}
+
+	dayIndexes := make(map[int64]int, len(dayStarts))
+	for i, ds := range dayStarts {
+		dayIndexes[ds.Unix()] = i
+		ts.Days = append(ts.Days, TimesheetDay{Date: ds, Durations: make([]time.Duration, len(ts.Tasks))})
+	}
+	for _, tr := range timesheetRows {
+		i, ok := dayIndexes[tr.DayStartedAt.Unix()]
+		if !ok {
+			return nil, errors.New("timesheet row has unexpected day")
+		}
+		ts.Days[i].Durations[taskIndexes[tr.TaskID]] += tr.Duration
+	}
+	return ts, nil
+}
+
+// reportTaskProjectColumns select the project and the client of a task as the
+// columns of reportTaskRow.
+const reportTaskProjectColumns = `
+	projects.id AS project_id,
+	projects.name AS project_name,
+	projects.is_default AS project_is_default,
+	clients.id AS client_id,
+	clients.name AS client_name
+`
+
+// queryReportTasks returns the time spent on each task in the period. Whole
+// UTC days are summed from the rollups, the partial days at the edges of the
+// period from the works.
 func (s *ServiceImpl) queryReportTasks(ctx context.Context, userID int, from, to time.Time) ([]reportTaskRow, error) {
+	fromDay, toDay, ok := rollup.FullDays(from, to)
+	if !ok {
+		return s.queryReportTasksFromWorks(ctx, userID, from, to)
+	}
+
+	q := `
+		WITH parts AS (
+			SELECT task_id, duration, billable_duration
+			FROM work_rollups
+			WHERE user_id = $1 AND day >= $2::date AND day < $3::date
+			UNION ALL
+			SELECT works.task_id,
+				   LEAST(works.stopped_at, edges.stopped_at) - GREATEST(works.started_at, edges.started_at),
+				   CASE
+					   WHEN works.billable
+						   THEN LEAST(works.stopped_at, edges.stopped_at) - GREATEST(works.started_at, edges.started_at)
+					   ELSE '0'
+				   END
+			FROM unnest($4::timestamptz[], $5::timestamptz[]) AS edges (started_at, stopped_at)
+			JOIN works ON works.started_at <= edges.stopped_at AND works.stopped_at >= edges.started_at
+			WHERE works.user_id = $1
+		)
+		SELECT tasks.id AS task_id,
+			   tasks.description AS task_description,
+			   tasks.billable AS task_billable,
+			   ` + reportTaskProjectColumns + `,
+			   SUM(parts.duration) AS duration,
+			   SUM(parts.billable_duration) AS billable_duration
+		FROM parts
+		JOIN tasks ON parts.task_id = tasks.id
+		JOIN projects ON tasks.project_id = projects.id
+		LEFT JOIN clients ON projects.client_id = clients.id
+		GROUP BY tasks.id, projects.id, clients.id
+		ORDER BY duration DESC, task_id
+	`
+	edgeStarts := []time.Time{from, toDay}
+	edgeStops := []time.Time{fromDay, to}
+	rows, err := s.db.Query(ctx, q, userID, fromDay, toDay, edgeStarts, edgeStops)
+	if err != nil {
+		return nil, errors.Join(errors.New("failed to select report"), err)
+	}
+	defer rows.Close()
+
+	reportTasks, err := pgx.CollectRows(rows, pgx.RowToStructByName[reportTaskRow])
+	if err != nil {
+		return nil, errors.Join(errors.New("failed to collect report tasks"), err)
+	}
+
+	return reportTasks, nil
+}
+
+func (s *ServiceImpl) queryReportTasksFromWorks(
+	ctx context.Context, userID int, from, to time.Time,
+) ([]reportTaskRow, error) {
 	q := `
 		SELECT tasks.id AS task_id,
 			   tasks.description AS task_description,
-			   SUM(LEAST(COALESCE(works.stopped_at, $3), $3) - GREATEST(works.started_at, $2)) AS duration
+			   tasks.billable AS task_billable,
+			   ` + reportTaskProjectColumns + `,
+			   SUM(LEAST(COALESCE(works.stopped_at, $3), $3) - GREATEST(works.started_at, $2)) AS duration,
+			   COALESCE(
+				   SUM(LEAST(COALESCE(works.stopped_at, $3), $3) - GREATEST(works.started_at, $2))
+					   FILTER (WHERE works.billable),
+				   '0'
+			   ) AS billable_duration
 		FROM works
 		JOIN tasks ON works.task_id = tasks.id
+		JOIN projects ON tasks.project_id = projects.id
+		LEFT JOIN clients ON projects.client_id = clients.id
 		WHERE user_id = $1 AND works.started_at <= $3 AND works.stopped_at >= $2
-		GROUP BY tasks.id
+		GROUP BY tasks.id, projects.id, clients.id
 		ORDER BY duration DESC, task_id
 	`
 	rows, err := s.db.Query(ctx, q, userID, from, to)
@@ -76,3 +728,225 @@ func (s *ServiceImpl) queryReportTasks(ctx context.Context, userID int, from, to
 
 	return reportTasks, nil
 }
+
+// queryReportAmounts returns the cost of billable works for each task and
+// currency. A work is charged at the rate in effect when it was started, works
+// without a rate are not charged. The cost is calculated with numeric
+// arithmetic and rounded to cents for each task. Whole UTC days are charged
+// from the rate rollups, the partial days at the edges of the period from the
+// works.
+func (s *ServiceImpl) queryReportAmounts(
+	ctx context.Context, userID int, from, to time.Time,
+) ([]reportAmountRow, error) {
+	fromDay, toDay, ok := rollup.FullDays(from, to)
+	if !ok {
+		return s.queryReportAmountsFromWorks(ctx, userID, from, to)
+	}
+
+	q := `
+		WITH parts AS (
+			SELECT task_id, rate_id, billable_duration
+			FROM work_rate_rollups
+			WHERE user_id = $1 AND day >= $2::date AND day < $3::date
+			UNION ALL
+			SELECT works.task_id,
+				   rates.id,
+				   LEAST(works.stopped_at, edges.stopped_at) - GREATEST(works.started_at, edges.started_at)
+			FROM unnest($4::timestamptz[], $5::timestamptz[]) AS edges (started_at, stopped_at)
+			JOIN works ON works.started_at <= edges.stopped_at AND works.stopped_at >= edges.started_at
+			JOIN LATERAL (` + billing.WorkRateSubquery + `) AS rates ON true
+			WHERE works.user_id = $1 AND works.billable
+		)
+		SELECT parts.task_id AS task_id,
+			   ROUND(SUM(rates.hourly_rate * EXTRACT(EPOCH FROM parts.billable_duration) / 3600), 2) AS amount,
+			   rates.currency AS currency
+		FROM parts
+		JOIN rates ON parts.rate_id = rates.id
+		GROUP BY parts.task_id, rates.currency
+		ORDER BY parts.task_id, rates.currency
+	`
+	edgeStarts := []time.Time{from, toDay}
+	edgeStops := []time.Time{fromDay, to}
+	rows, err := s.db.Query(ctx, q, userID, fromDay, toDay, edgeStarts, edgeStops)
+	if err != nil {
+		return nil, errors.Join(errors.New("failed to select report amounts"), err)
+	}
+	defer rows.Close()
+
+	reportAmounts, err := pgx.CollectRows(rows, pgx.RowToStructByName[reportAmountRow])
+	if err != nil {
+		return nil, errors.Join(errors.New("failed to collect report amounts"), err)
+	}
+	return reportAmounts, nil
+}
+
+func (s *ServiceImpl) queryReportAmountsFromWorks(
+	ctx context.Context, userID int, from, to time.Time,
+) ([]reportAmountRow, error) {
+	q := `
+		SELECT works.task_id AS task_id,
+			   ROUND(
+				   SUM(
+					   rates.hourly_rate
+						   * EXTRACT(EPOCH FROM LEAST(works.stopped_at, $3) - GREATEST(works.started_at, $2))
+						   / 3600
+				   ),
+				   2
+			   ) AS amount,
+			   rates.currency AS currency
+		FROM works
+		JOIN LATERAL (` + billing.WorkRateSubquery + `) AS rates ON true
+		WHERE works.user_id = $1 AND works.billable AND works.started_at <= $3 AND works.stopped_at >= $2
+		GROUP BY works.task_id, rates.currency
+		ORDER BY works.task_id, rates.currency
+	`
+	rows, err := s.db.Query(ctx, q, userID, from, to)
+	if err != nil {
+		return nil, errors.Join(errors.New("failed to select report amounts"), err)
+	}
+	defer rows.Close()
+
+	reportAmounts, err := pgx.CollectRows(rows, pgx.RowToStructByName[reportAmountRow])
+	if err != nil {
+		return nil, errors.Join(errors.New("failed to collect report amounts"), err)
+	}
+	return reportAmounts, nil
+}
+
+func (s *ServiceImpl) queryReportDays(
+	ctx context.Context, userID int, dayStarts, dayStops []time.Time,
+) ([]reportDayRow, error) {
+	q := `
+		SELECT days.started_at AS day_started_at,
+			   SUM(LEAST(works.stopped_at, days.stopped_at) - GREATEST(works.started_at, days.started_at)) AS duration,
+			   COALESCE(
+				   SUM(LEAST(works.stopped_at, days.stopped_at) - GREATEST(works.started_at, days.started_at))
+					   FILTER (WHERE works.billable),
+				   '0'
+			   ) AS billable_duration
+		FROM unnest($2::timestamptz[], $3::timestamptz[]) AS days (started_at, stopped_at)
+		JOIN works ON works.started_at < days.stopped_at AND works.stopped_at > days.started_at
+		WHERE works.user_id = $1
+		GROUP BY days.started_at
+		ORDER BY days.started_at
+	`
+	rows, err := s.db.Query(ctx, q, userID, dayStarts, dayStops)
+	if err != nil {
+		return nil, errors.Join(errors.New("failed to select report days"), err)
+	}
+	defer rows.Close()
+
+	reportDays, err := pgx.CollectRows(rows, pgx.RowToStructByName[reportDayRow])
+	if err != nil {
+		return nil, errors.Join(errors.New("failed to collect report days"), err)
+	}
+	return reportDays, nil
+}
+
+// queryReportDayAmounts returns the cost of billable works for each day and
+// currency, the same way as queryReportAmounts does for tasks.
+func (s *ServiceImpl) queryReportDayAmounts(
+	ctx context.Context, userID int, dayStarts, dayStops []time.Time,
+) ([]reportDayAmountRow, error) {
+	q := `
+		SELECT days.started_at AS day_started_at,
+			   ROUND(
+				   SUM(
+					   rates.hourly_rate
+						   * EXTRACT(
+							   EPOCH FROM LEAST(works.stopped_at, days.stopped_at)
+								   - GREATEST(works.started_at, days.started_at)
+						   )
+						   / 3600
+				   ),
+				   2
+			   ) AS amount,
+			   rates.currency AS currency
+		FROM unnest($2::timestamptz[], $3::timestamptz[]) AS days (started_at, stopped_at)
+		JOIN works ON works.started_at < days.stopped_at AND works.stopped_at > days.started_at
+		JOIN LATERAL (` + billing.WorkRateSubquery + `) AS rates ON true
+		WHERE works.user_id = $1 AND works.billable
+		GROUP BY days.started_at, rates.currency
+		ORDER BY days.started_at, rates.currency
+	`
+	rows, err := s.db.Query(ctx, q, userID, dayStarts, dayStops)
+	if err != nil {
+		return nil, errors.Join(errors.New("failed to select report day amounts"), err)
+	}
+	defer rows.Close()
+
+	reportDayAmounts, err := pgx.CollectRows(rows, pgx.RowToStructByName[reportDayAmountRow])
+	if err != nil {
+		return nil, errors.Join(errors.New("failed to collect report day amounts"), err)
+	}
+	return reportDayAmounts, nil
+}
+
+func (s *ServiceImpl) queryTimesheetUser(ctx context.Context, userID int) (*TimesheetUser, error) {
+	q := `SELECT id, surname, name, patronymic FROM users WHERE id = $1`
+	rows, err := s.db.Query(ctx, q, userID)
+	if err != nil {
+		return nil, errors.Join(errors.New("failed to select user"), err)
+	}
+	defer rows.Close()
+
+	u, err := pgx.CollectExactlyOneRow(rows, pgx.RowToStructByName[TimesheetUser])
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrUserNotFound
+		}
+		return nil, errors.Join(errors.New("failed to collect user"), err)
+	}
+	return &u, nil
+}
+
+// queryTimesheet returns the time spent on each task on each day. Days are
+// given as parallel slices of their bounds. Tasks are ordered by the total time
+// spent on them in the period.
+func (s *ServiceImpl) queryTimesheet(
+	ctx context.Context, userID int, dayStarts, dayStops []time.Time,
+) ([]timesheetRow, error) {
+	q := `
+		SELECT days.started_at AS day_started_at,
+			   tasks.id AS task_id,
+			   tasks.description AS task_description,
+			   SUM(LEAST(works.stopped_at, days.stopped_at) - GREATEST(works.started_at, days.started_at)) AS duration
+		FROM unnest($2::timestamptz[], $3::timestamptz[]) AS days (started_at, stopped_at)
+		JOIN works ON works.started_at < days.stopped_at AND works.stopped_at > days.started_at
+		JOIN tasks ON works.task_id = tasks.id
+		WHERE works.user_id = $1
+		GROUP BY days.started_at, tasks.id
+		ORDER BY SUM(SUM(LEAST(works.stopped_at, days.stopped_at) - GREATEST(works.started_at, days.started_at)))
+					 OVER (PARTITION BY tasks.id) DESC,
+				 tasks.id,
+				 days.started_at
+	`
+	rows, err := s.db.Query(ctx, q, userID, dayStarts, dayStops)
+	if err != nil {
+		return nil, errors.Join(errors.New("failed to select timesheet"), err)
+	}
+	defer rows.Close()
+
+	timesheetRows, err := pgx.CollectRows(rows, pgx.RowToStructByName[timesheetRow])
+	if err != nil {
+		return nil, errors.Join(errors.New("failed to collect timesheet"), err)
+	}
+	return timesheetRows, nil
+}
+
+// splitDays splits the period into calendar days in the location of from. The
+// first and the last days are cut to the period bounds.
+func splitDays(from, to time.Time) (starts, stops []time.Time) {
+	loc := from.Location()
+	for start := from; start.Before(to); {
+		y, m, d := start.In(loc).Date()
+		stop := time.Date(y, m, d+1, 0, 0, 0, 0, loc)
+		if stop.After(to) {
+			stop = to
+		}
+		starts = append(starts, start)
+		stops = append(stops, stop)
+		start = stop
+	}
+	return starts, stops
+}
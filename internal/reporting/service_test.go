@@ -0,0 +1,322 @@
+package reporting
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/kirillgashkov/timetrack/internal/app/testutil"
+	"github.com/kirillgashkov/timetrack/internal/rollup"
+)
+
+func TestSplitDays(t *testing.T) {
+	loc := time.FixedZone("MSK", 3*60*60)
+	at := func(day, hour int) time.Time {
+		return time.Date(2024, 6, day, hour, 0, 0, 0, loc)
+	}
+
+	tests := []struct {
+		name       string
+		from, to   time.Time
+		wantStarts []time.Time
+		wantStops  []time.Time
+	}{
+		{"whole days", at(1, 0), at(3, 0), []time.Time{at(1, 0), at(2, 0)}, []time.Time{at(2, 0), at(3, 0)}},
+		{"partial days", at(1, 12), at(2, 6), []time.Time{at(1, 12), at(2, 0)}, []time.Time{at(2, 0), at(2, 6)}},
+		{"within a day", at(1, 6), at(1, 12), []time.Time{at(1, 6)}, []time.Time{at(1, 12)}},
+		{"empty", at(1, 0), at(1, 0), nil, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			starts, stops := splitDays(tt.from, tt.to)
+			if !equalTimes(starts, tt.wantStarts) {
+				t.Errorf("expected starts %v, got %v", tt.wantStarts, starts)
+			}
+			if !equalTimes(stops, tt.wantStops) {
+				t.Errorf("expected stops %v, got %v", tt.wantStops, stops)
+			}
+		})
+	}
+}
+
+func equalTimes(a, b []time.Time) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if !a[i].Equal(b[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// TestReportVersion checks that changes of the data a report reads besides the
+// works change the version. It needs a test database, the data is created in a
+// transaction that is rolled back.
+func TestReportVersion(t *testing.T) {
+	if os.Getenv("TEST_APP_DATABASE_DSN") == "" {
+		t.Skip("TEST_APP_DATABASE_DSN is not set")
+	}
+
+	ctx := context.Background()
+	db := testutil.NewTestPool()
+	defer db.Close()
+
+	tx, err := db.Begin(ctx)
+	if err != nil {
+		t.Fatalf("failed to start transaction: %v", err)
+	}
+	defer func(tx pgx.Tx) {
+		if txErr := tx.Rollback(ctx); txErr != nil && !errors.Is(txErr, pgx.ErrTxClosed) {
+			slog.Error("failed to rollback transaction", "error", txErr)
+		}
+	}(tx)
+
+	passportNumber := fmt.Sprintf("report version %d", time.Now().UnixNano())
+	q := `
+		INSERT INTO users (passport_number, surname, name, address)
+		VALUES ($1, 'Surname', 'Name', 'Address')
+		RETURNING id
+	`
+	var userID int
+	if err = tx.QueryRow(ctx, q, passportNumber).Scan(&userID); err != nil {
+		t.Fatalf("failed to insert user: %v", err)
+	}
+	var parentID, taskID int
+	q = `INSERT INTO tasks (description) VALUES ('Parent') RETURNING id`
+	if err = tx.QueryRow(ctx, q).Scan(&parentID); err != nil {
+		t.Fatalf("failed to insert task: %v", err)
+	}
+	q = `INSERT INTO tasks (description) VALUES ('Task') RETURNING id`
+	if err = tx.QueryRow(ctx, q).Scan(&taskID); err != nil {
+		t.Fatalf("failed to insert task: %v", err)
+	}
+	start := time.Date(2024, 7, 1, 9, 0, 0, 0, time.UTC)
+	q = `
+		INSERT INTO works (started_at, stopped_at, task_id, user_id, status, billable)
+		VALUES ($1, $2, $3, $4, 'stopped', true)
+		RETURNING id
+	`
+	var workID int
+	if err = tx.QueryRow(ctx, q, start, start.Add(time.Hour), taskID, userID).Scan(&workID); err != nil {
+		t.Fatalf("failed to insert work: %v", err)
+	}
+	var fieldID int
+	q = `INSERT INTO custom_fields (name, type, options) VALUES ($1, 'enum', '{a,b}') RETURNING id`
+	if err = tx.QueryRow(ctx, q, passportNumber).Scan(&fieldID); err != nil {
+		t.Fatalf("failed to insert custom field: %v", err)
+	}
+	var tagID int
+	q = `INSERT INTO tags (name) VALUES ($1) RETURNING id`
+	if err = tx.QueryRow(ctx, q, passportNumber).Scan(&tagID); err != nil {
+		t.Fatalf("failed to insert tag: %v", err)
+	}
+
+	s := NewServiceImpl(tx)
+	from, to := start.Add(-24*time.Hour), start.Add(24*time.Hour)
+	previous, err := s.ReportVersion(ctx, userID, from, to)
+	if err != nil {
+		t.Fatalf("failed to get report version: %v", err)
+	}
+	if previous.Works != 1 || previous.UpdatedAt == nil {
+		t.Fatalf("expected a version of 1 work, got %+v", previous)
+	}
+
+	rateQuery := `
+		INSERT INTO rates (user_id, hourly_rate, currency, effective_from)
+		VALUES ($1, 10, 'USD', $2)
+	`
+	tests := []struct {
+		name string
+		q    string
+		args []any
+	}{
+		{"description", `UPDATE tasks SET description = 'Renamed' WHERE id = $1`, []any{taskID}},
+		{"parent", `UPDATE tasks SET parent_id = $1 WHERE id = $2`, []any{parentID, taskID}},
+		{"parent description", `UPDATE tasks SET description = 'Renamed parent' WHERE id = $1`, []any{parentID}},
+		{"rate", rateQuery, []any{userID, start}},
+		{"project", `UPDATE projects SET name = 'Renamed' WHERE id = default_project_id()`, nil},
+		{"task tag", `INSERT INTO task_tags (task_id, tag_id) VALUES ($1, $2)`, []any{taskID, tagID}},
+		{"tag", `UPDATE tags SET name = name || ' renamed' WHERE id = $1`, []any{tagID}},
+		{"work tag", `INSERT INTO work_tags (work_id, tag_id) VALUES ($1, $2)`, []any{workID, tagID}},
+		{"task tag removed", `DELETE FROM task_tags WHERE task_id = $1`, []any{taskID}},
+		{"field value", `INSERT INTO task_field_values VALUES ($1, $2, 'a')`, []any{taskID, fieldID}},
+		{"field options", `UPDATE custom_fields SET options = '{a,b,c}' WHERE id = $1`, []any{fieldID}},
+		{"field value removed", `DELETE FROM task_field_values WHERE task_id = $1`, []any{taskID}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := tx.Exec(ctx, tt.q, tt.args...); err != nil {
+				t.Fatalf("failed to change report input: %v", err)
+			}
+			v, err := s.ReportVersion(ctx, userID, from, to)
+			if err != nil {
+				t.Fatalf("failed to get report version: %v", err)
+			}
+			if v.Works != previous.Works || v.Inputs == previous.Inputs {
+				t.Errorf("expected a version of the same works with other inputs than %+v, got %+v", previous, v)
+			}
+			previous = v
+		})
+	}
+
+	// Tasks that the user didn't work on aren't read by the report.
+	if _, err = tx.Exec(ctx, `INSERT INTO tasks (description) VALUES ('Other')`); err != nil {
+		t.Fatalf("failed to insert task: %v", err)
+	}
+	v, err := s.ReportVersion(ctx, userID, from, to)
+	if err != nil {
+		t.Fatalf("failed to get report version: %v", err)
+	}
+	if v.Works != previous.Works || v.Inputs != previous.Inputs || !v.UpdatedAt.Equal(*previous.UpdatedAt) {
+		t.Errorf("expected version %+v, got %+v", previous, v)
+	}
+}
+
+// BenchmarkReport compares a yearly report by task with amounts summed from the
+// works with the same report summed from the rollups. It needs a test database,
+// the data is seeded in a transaction that is rolled back.
+func BenchmarkReport(b *testing.B) {
+	if os.Getenv("TEST_APP_DATABASE_DSN") == "" {
+		b.Skip("TEST_APP_DATABASE_DSN is not set")
+	}
+
+	ctx := context.Background()
+	db := testutil.NewTestPool()
+	defer db.Close()
+
+	tx, err := db.Begin(ctx)
+	if err != nil {
+		b.Fatalf("failed to start transaction: %v", err)
+	}
+	defer func(tx pgx.Tx) {
+		if txErr := tx.Rollback(ctx); txErr != nil && !errors.Is(txErr, pgx.ErrTxClosed) {
+			slog.Error("failed to rollback transaction", "error", txErr)
+		}
+	}(tx)
+
+	start := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+	stop := start.AddDate(3, 0, 0)
+	userID, taskIDs := seedWorks(ctx, b, tx, start, stop)
+	for _, taskID := range taskIDs {
+		if err = rollup.Refresh(ctx, tx, userID, taskID, start, stop); err != nil {
+			b.Fatalf("failed to refresh rollups: %v", err)
+		}
+	}
+
+	s := NewServiceImpl(tx)
+	loc := time.FixedZone("MSK", 3*60*60)
+	from := time.Date(2022, 3, 14, 9, 30, 0, 0, loc)
+	to := from.AddDate(1, 0, 0).Add(5 * time.Hour)
+
+	fromWorks, err := s.queryReportTasksFromWorks(ctx, userID, from, to)
+	if err != nil {
+		b.Fatalf("failed to query report from works: %v", err)
+	}
+	fromRollups, err := s.queryReportTasks(ctx, userID, from, to)
+	if err != nil {
+		b.Fatalf("failed to query report from rollups: %v", err)
+	}
+	if !slices.Equal(fromWorks, fromRollups) {
+		b.Fatalf("expected report from rollups to match report from works:\n%v\n%v", fromWorks, fromRollups)
+	}
+	amountsFromWorks, err := s.queryReportAmountsFromWorks(ctx, userID, from, to)
+	if err != nil {
+		b.Fatalf("failed to query amounts from works: %v", err)
+	}
+	amountsFromRollups, err := s.queryReportAmounts(ctx, userID, from, to)
+	if err != nil {
+		b.Fatalf("failed to query amounts from rollups: %v", err)
+	}
+	if len(amountsFromWorks) == 0 || !slices.EqualFunc(amountsFromWorks, amountsFromRollups, equalAmountRows) {
+		b.Fatalf(
+			"expected amounts from rollups to match amounts from works:\n%v\n%v", amountsFromWorks, amountsFromRollups,
+		)
+	}
+
+	b.Run("works", func(b *testing.B) {
+		for range b.N {
+			if _, err = s.queryReportTasksFromWorks(ctx, userID, from, to); err != nil {
+				b.Fatal(err)
+			}
+			if _, err = s.queryReportAmountsFromWorks(ctx, userID, from, to); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("rollups", func(b *testing.B) {
+		for range b.N {
+			if _, err = s.Report(ctx, userID, &ReportOptions{From: from, To: to}); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
+
+func equalAmountRows(a, b reportAmountRow) bool {
+	return a.TaskID == b.TaskID && a.Currency == b.Currency && a.Amount.Equal(b.Amount)
+}
+
+// seedWorks creates a user with five tasks and a work of 50 minutes every hour
+// in [start, stop). Every third work isn't billable. The user has a rate from
+// start, the first task has a rate of its own from the middle of a day in the
+// second year.
+func seedWorks(ctx context.Context, b *testing.B, tx pgx.Tx, start, stop time.Time) (int, []int) {
+	b.Helper()
+
+	passportNumber := fmt.Sprintf("bench %d", time.Now().UnixNano())
+	q := `
+		INSERT INTO users (passport_number, surname, name, address)
+		VALUES ($1, 'Surname', 'Name', 'Address')
+		RETURNING id
+	`
+	var userID int
+	if err := tx.QueryRow(ctx, q, passportNumber).Scan(&userID); err != nil {
+		b.Fatalf("failed to insert user: %v", err)
+	}
+
+	q = `INSERT INTO tasks (description) SELECT 'Task ' || n FROM generate_series(1, 5) AS n RETURNING id`
+	rows, err := tx.Query(ctx, q)
+	if err != nil {
+		b.Fatalf("failed to insert tasks: %v", err)
+	}
+	taskIDs, err := pgx.CollectRows(rows, pgx.RowTo[int])
+	if err != nil {
+		b.Fatalf("failed to collect tasks: %v", err)
+	}
+
+	q = `
+		INSERT INTO works (started_at, stopped_at, task_id, user_id, status, billable)
+		SELECT hours.started_at,
+			   hours.started_at + interval '50 minutes',
+			   ($2::integer[])[1 + n % 5],
+			   $1,
+			   'stopped',
+			   n % 3 <> 0
+		FROM generate_series(0, $5) AS n,
+			 LATERAL (SELECT $3::timestamptz + n * interval '1 hour' AS started_at) AS hours
+		WHERE hours.started_at < $4
+	`
+	hours := int(stop.Sub(start) / time.Hour)
+	if _, err = tx.Exec(ctx, q, userID, taskIDs, start, stop, hours); err != nil {
+		b.Fatalf("failed to insert works: %v", err)
+	}
+
+	q = `
+		INSERT INTO rates (user_id, task_id, hourly_rate, currency, effective_from)
+		VALUES ($1, NULL, 10, 'USD', $3), (NULL, $2, 45.5, 'EUR', $4)
+	`
+	if _, err = tx.Exec(ctx, q, userID, taskIDs[0], start, start.AddDate(1, 2, 3).Add(13*time.Hour)); err != nil {
+		b.Fatalf("failed to insert rates: %v", err)
+	}
+	return userID, taskIDs
+}
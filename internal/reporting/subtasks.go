@@ -0,0 +1,101 @@
+package reporting
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"slices"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/shopspring/decimal"
+)
+
+// taskAncestorRow links a task to one of its ancestors, or to itself. The
+// embedded row describes the ancestor, its durations are zero.
+type taskAncestorRow struct {
+	DescendantID int `db:"descendant_id"`
+	reportTaskRow
+}
+
+// includeSubtasks adds the time and the amounts of each task to the task
+// itself and to each of its ancestors. Ancestors without time of their own are
+// added to the rows. Rows are ordered by the time spent on them, then by task
+// ID, and amounts by currency.
+func includeSubtasks(
+	rows []reportTaskRow, amounts map[int][]Amount, ancestors []taskAncestorRow,
+) ([]reportTaskRow, map[int][]Amount) {
+	own := make(map[int]reportTaskRow, len(rows))
+	for _, rtr := range rows {
+		own[rtr.TaskID] = rtr
+	}
+
+	indexes := make(map[int]int)
+	totals := make([]reportTaskRow, 0, len(ancestors))
+	totalAmounts := make(map[int]map[string]decimal.Decimal)
+	for _, ar := range ancestors {
+		rtr, ok := own[ar.DescendantID]
+		if !ok {
+			continue
+		}
+		i, ok := indexes[ar.TaskID]
+		if !ok {
+			i = len(totals)
+			indexes[ar.TaskID] = i
+			totals = append(totals, ar.reportTaskRow)
+			totalAmounts[ar.TaskID] = make(map[string]decimal.Decimal)
+		}
+		totals[i].Duration += rtr.Duration
+		totals[i].BillableDuration += rtr.BillableDuration
+		for _, a := range amounts[ar.DescendantID] {
+			totalAmounts[ar.TaskID][a.Currency] = totalAmounts[ar.TaskID][a.Currency].Add(a.Value)
+		}
+	}
+
+	slices.SortFunc(totals, func(a, b reportTaskRow) int {
+		return cmp.Or(cmp.Compare(b.Duration, a.Duration), cmp.Compare(a.TaskID, b.TaskID))
+	})
+	rolledUpAmounts := make(map[int][]Amount, len(totalAmounts))
+	for taskID, byCurrency := range totalAmounts {
+		for c, v := range byCurrency {
+			rolledUpAmounts[taskID] = append(rolledUpAmounts[taskID], Amount{Value: v, Currency: c})
+		}
+		slices.SortFunc(rolledUpAmounts[taskID], func(a, b Amount) int { return cmp.Compare(a.Currency, b.Currency) })
+	}
+	return totals, rolledUpAmounts
+}
+
+// queryTaskAncestors returns the ancestors of each of the tasks, including the
+// tasks themselves.
+func (s *ServiceImpl) queryTaskAncestors(ctx context.Context, taskIDs []int) ([]taskAncestorRow, error) {
+	q := `
+		WITH RECURSIVE ancestors (descendant_id, ancestor_id) AS (
+			SELECT id, id FROM unnest($1::integer[]) AS id
+			UNION
+			SELECT ancestors.descendant_id, tasks.parent_id
+			FROM ancestors
+			JOIN tasks ON tasks.id = ancestors.ancestor_id
+			WHERE tasks.parent_id IS NOT NULL
+		)
+		SELECT ancestors.descendant_id,
+			   tasks.id AS task_id,
+			   tasks.description AS task_description,
+			   tasks.billable AS task_billable,
+			   ` + reportTaskProjectColumns + `
+		FROM ancestors
+		JOIN tasks ON ancestors.ancestor_id = tasks.id
+		JOIN projects ON tasks.project_id = projects.id
+		LEFT JOIN clients ON projects.client_id = clients.id
+		ORDER BY ancestors.descendant_id, tasks.id
+	`
+	rows, err := s.db.Query(ctx, q, taskIDs)
+	if err != nil {
+		return nil, errors.Join(errors.New("failed to select task ancestors"), err)
+	}
+	defer rows.Close()
+
+	ancestors, err := pgx.CollectRows(rows, pgx.RowToStructByNameLax[taskAncestorRow])
+	if err != nil {
+		return nil, errors.Join(errors.New("failed to collect task ancestors"), err)
+	}
+	return ancestors, nil
+}
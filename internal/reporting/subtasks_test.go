@@ -0,0 +1,68 @@
+package reporting
+
+import (
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+func TestIncludeSubtasks(t *testing.T) {
+	// Task 1 has the subtask 2, which has the subtask 3. Task 4 is on its own.
+	rows := []reportTaskRow{
+		{TaskID: 3, Duration: 30 * time.Minute, BillableDuration: 30 * time.Minute},
+		{TaskID: 4, Duration: 20 * time.Minute},
+		{TaskID: 2, Duration: 10 * time.Minute, BillableDuration: 10 * time.Minute},
+	}
+	amounts := map[int][]Amount{
+		2: {{Value: decimal.RequireFromString("5.00"), Currency: "USD"}},
+		3: {
+			{Value: decimal.RequireFromString("15.00"), Currency: "EUR"},
+			{Value: decimal.RequireFromString("1.00"), Currency: "USD"},
+		},
+	}
+	ancestors := []taskAncestorRow{
+		{DescendantID: 2, reportTaskRow: reportTaskRow{TaskID: 1}},
+		{DescendantID: 2, reportTaskRow: reportTaskRow{TaskID: 2}},
+		{DescendantID: 3, reportTaskRow: reportTaskRow{TaskID: 1}},
+		{DescendantID: 3, reportTaskRow: reportTaskRow{TaskID: 2}},
+		{DescendantID: 3, reportTaskRow: reportTaskRow{TaskID: 3}},
+		{DescendantID: 4, reportTaskRow: reportTaskRow{TaskID: 4}},
+	}
+
+	totals, totalAmounts := includeSubtasks(rows, amounts, ancestors)
+
+	expected := []struct {
+		taskID   int
+		duration time.Duration
+		amounts  string
+	}{
+		{1, 40 * time.Minute, "15.00 EUR, 6.00 USD"},
+		{2, 40 * time.Minute, "15.00 EUR, 6.00 USD"},
+		{3, 30 * time.Minute, "15.00 EUR, 1.00 USD"},
+		{4, 20 * time.Minute, ""},
+	}
+	if len(totals) != len(expected) {
+		t.Fatalf("expected %d tasks, got %d", len(expected), len(totals))
+	}
+	for i, e := range expected {
+		if totals[i].TaskID != e.taskID || totals[i].Duration != e.duration {
+			t.Errorf("expected task %d with %v at %d, got task %d with %v",
+				e.taskID, e.duration, i, totals[i].TaskID, totals[i].Duration)
+		}
+		if got := formatAmounts(totalAmounts[e.taskID]); got != e.amounts {
+			t.Errorf("expected amounts %q for task %d, got %q", e.amounts, e.taskID, got)
+		}
+	}
+}
+
+func formatAmounts(amounts []Amount) string {
+	s := ""
+	for i, a := range amounts {
+		if i > 0 {
+			s += ", "
+		}
+		s += a.Value.StringFixed(2) + " " + a.Currency
+	}
+	return s
+}
@@ -0,0 +1,163 @@
+package reporting
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/kirillgashkov/timetrack/internal/billing"
+	"github.com/kirillgashkov/timetrack/internal/tag"
+	"github.com/shopspring/decimal"
+)
+
+// GroupByTag groups the time by the tags of the works and of their tasks. A
+// work with several tags is reported under each of them.
+const GroupByTag GroupBy = "tag"
+
+// ReportTag is the time spent in works with a tag. Tag is nil for the time
+// spent in works without tags.
+type ReportTag struct {
+	Tag *tag.Tag
+	ReportEntry
+}
+
+type reportTagRow struct {
+	TagID            *int          `db:"tag_id"`
+	TagName          *string       `db:"tag_name"`
+	Duration         time.Duration `db:"duration"`
+	BillableDuration time.Duration `db:"billable_duration"`
+}
+
+type reportTagAmountRow struct {
+	TagID    *int            `db:"tag_id"`
+	Amount   decimal.Decimal `db:"amount"`
+	Currency string
+}
+
+// workTagsLateral joins the tags of the work referred to as "works" and of its
+// task as "work_tags_all", one row without a tag for works without tags.
+const workTagsLateral = `
+	LEFT JOIN LATERAL (
+		SELECT work_tags.tag_id FROM work_tags WHERE work_tags.work_id = works.id
+		UNION
+		SELECT task_tags.tag_id FROM task_tags WHERE task_tags.task_id = works.task_id
+	) AS work_tags_all ON true
+	LEFT JOIN tags ON tags.id = work_tags_all.tag_id
+`
+
+// reportTags reports the time spent in works with each tag. Tags overlap, so
+// the total is the total of the report by task rather than the sum of the
+// tags.
+func (s *ServiceImpl) reportTags(ctx context.Context, userID int, opts *ReportOptions) (*Report, error) {
+	reportTagRows, err := s.queryReportTags(ctx, userID, opts.From, opts.To)
+	if err != nil {
+		return nil, err
+	}
+
+	reportTagAmountRows, err := s.queryReportTagAmounts(ctx, userID, opts.From, opts.To)
+	if err != nil {
+		return nil, err
+	}
+	amounts := make(map[int][]Amount)
+	for _, rar := range reportTagAmountRows {
+		k := tagKey(rar.TagID)
+		amounts[k] = append(amounts[k], Amount{Value: rar.Amount, Currency: rar.Currency})
+	}
+
+	report := &Report{Tags: make([]ReportTag, 0, len(reportTagRows))}
+	for _, rtr := range reportTagRows {
+		rt := ReportTag{
+			ReportEntry: ReportEntry{
+				Duration:         opts.Rounding.roundGroup(rtr.Duration.Truncate(time.Millisecond)),
+				BillableDuration: opts.Rounding.roundGroup(rtr.BillableDuration.Truncate(time.Millisecond)),
+				Amounts:          amounts[tagKey(rtr.TagID)],
+			},
+		}
+		if rtr.TagID != nil {
+			rt.Tag = &tag.Tag{ID: *rtr.TagID, Name: *rtr.TagName}
+		}
+		report.Tags = append(report.Tags, rt)
+	}
+
+	tasks, err := s.reportTasks(ctx, userID, opts)
+	if err != nil {
+		return nil, err
+	}
+	report.Total = tasks.Total
+	return report, nil
+}
+
+// tagKey is the key of a tag in maps, 0 for works without tags.
+func tagKey(tagID *int) int {
+	if tagID == nil {
+		return 0
+	}
+	return *tagID
+}
+
+// queryReportTags returns the time spent in works with each tag in the period,
+// ordered by the time spent.
+func (s *ServiceImpl) queryReportTags(ctx context.Context, userID int, from, to time.Time) ([]reportTagRow, error) {
+	q := `
+		SELECT tags.id AS tag_id,
+			   tags.name AS tag_name,
+			   SUM(LEAST(works.stopped_at, $3) - GREATEST(works.started_at, $2)) AS duration,
+			   COALESCE(
+				   SUM(LEAST(works.stopped_at, $3) - GREATEST(works.started_at, $2)) FILTER (WHERE works.billable),
+				   '0'
+			   ) AS billable_duration
+		FROM works
+		` + workTagsLateral + `
+		WHERE works.user_id = $1 AND works.started_at <= $3 AND works.stopped_at >= $2
+		GROUP BY tags.id
+		ORDER BY duration DESC, tags.id NULLS LAST
+	`
+	rows, err := s.db.Query(ctx, q, userID, from, to)
+	if err != nil {
+		return nil, errors.Join(errors.New("failed to select report tags"), err)
+	}
+	defer rows.Close()
+
+	reportTags, err := pgx.CollectRows(rows, pgx.RowToStructByName[reportTagRow])
+	if err != nil {
+		return nil, errors.Join(errors.New("failed to collect report tags"), err)
+	}
+	return reportTags, nil
+}
+
+// queryReportTagAmounts returns the cost of billable works for each tag and
+// currency, the same way as queryReportAmounts does for tasks.
+func (s *ServiceImpl) queryReportTagAmounts(
+	ctx context.Context, userID int, from, to time.Time,
+) ([]reportTagAmountRow, error) {
+	q := `
+		SELECT tags.id AS tag_id,
+			   ROUND(
+				   SUM(
+					   rates.hourly_rate
+						   * EXTRACT(EPOCH FROM LEAST(works.stopped_at, $3) - GREATEST(works.started_at, $2))
+						   / 3600
+				   ),
+				   2
+			   ) AS amount,
+			   rates.currency AS currency
+		FROM works
+		` + workTagsLateral + `
+		JOIN LATERAL (` + billing.WorkRateSubquery + `) AS rates ON true
+		WHERE works.user_id = $1 AND works.billable AND works.started_at <= $3 AND works.stopped_at >= $2
+		GROUP BY tags.id, rates.currency
+		ORDER BY tags.id, rates.currency
+	`
+	rows, err := s.db.Query(ctx, q, userID, from, to)
+	if err != nil {
+		return nil, errors.Join(errors.New("failed to select report tag amounts"), err)
+	}
+	defer rows.Close()
+
+	reportTagAmounts, err := pgx.CollectRows(rows, pgx.RowToStructByName[reportTagAmountRow])
+	if err != nil {
+		return nil, errors.Join(errors.New("failed to collect report tag amounts"), err)
+	}
+	return reportTagAmounts, nil
+}
@@ -0,0 +1,123 @@
+package rollup
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/kirillgashkov/timetrack/internal/app/database"
+	"github.com/kirillgashkov/timetrack/internal/billing"
+)
+
+const day = 24 * time.Hour
+
+// rollupsQuery returns a query that selects the rollups of stopped works.
+// Works are split into UTC days, the condition filters the works and the days.
+func rollupsQuery(condition string) string {
+	return `
+		SELECT works.user_id AS user_id,
+			   works.task_id AS task_id,
+			   (days.started_at AT TIME ZONE 'UTC')::date AS day,
+			   SUM(
+				   LEAST(works.stopped_at, days.started_at + interval '24 hours')
+					   - GREATEST(works.started_at, days.started_at)
+			   ) AS duration,
+			   COALESCE(
+				   SUM(
+					   LEAST(works.stopped_at, days.started_at + interval '24 hours')
+						   - GREATEST(works.started_at, days.started_at)
+				   ) FILTER (WHERE works.billable),
+				   '0'
+			   ) AS billable_duration
+		FROM works
+		CROSS JOIN LATERAL generate_series(
+			date_trunc('day', works.started_at, 'UTC'), works.stopped_at, interval '24 hours'
+		) AS days (started_at)
+		WHERE works.stopped_at IS NOT NULL AND (` + condition + `)
+		GROUP BY works.user_id, works.task_id, days.started_at
+	`
+}
+
+// Refresh recomputes the rollups and the rate rollups of the user's task for
+// the UTC days that overlap [from, to]. It must be called in the transaction that changes the
+// works, after the change, with the bounds of the works before and after it.
+// Refreshes of the same user are serialized so that concurrent transactions
+// don't overwrite each other's rollups with stale sums.
+func Refresh(ctx context.Context, tx pgx.Tx, userID, taskID int, from, to time.Time) error {
+	if _, err := tx.Exec(ctx, `SELECT pg_advisory_xact_lock(hashtext('work_rollups'), $1)`, userID); err != nil {
+		return errors.Join(errors.New("failed to lock rollups"), err)
+	}
+
+	fromDay, toDay := startOfDay(from), startOfDay(to)
+	q := `DELETE FROM work_rollups WHERE user_id = $1 AND task_id = $2 AND day >= $3::date AND day <= $4::date`
+	if _, err := tx.Exec(ctx, q, userID, taskID, fromDay, toDay); err != nil {
+		return errors.Join(errors.New("failed to delete rollups"), err)
+	}
+
+	q = `INSERT INTO work_rollups (user_id, task_id, day, duration, billable_duration)` + rollupsQuery(`
+		works.user_id = $1
+		AND works.task_id = $2
+		AND works.started_at < $4::timestamptz + interval '24 hours'
+		AND works.stopped_at >= $3
+		AND days.started_at >= $3
+		AND days.started_at <= $4
+	`)
+	if _, err := tx.Exec(ctx, q, userID, taskID, fromDay, toDay); err != nil {
+		return errors.Join(errors.New("failed to insert rollups"), err)
+	}
+	return billing.RefreshRateRollups(ctx, tx, &userID, &taskID, fromDay, &toDay)
+}
+
+// Rebuild recomputes all rollups and rate rollups from the works and returns
+// the number of rollups. Tracking waits for the rebuild to finish, reports
+// don't.
+func Rebuild(ctx context.Context, db database.DB) (int64, error) {
+	tx, err := db.Begin(ctx)
+	if err != nil {
+		return 0, errors.Join(errors.New("failed to start transaction"), err)
+	}
+	defer func(tx pgx.Tx) {
+		if txErr := tx.Rollback(ctx); txErr != nil && !errors.Is(txErr, pgx.ErrTxClosed) {
+			slog.Error("failed to rollback transaction", "error", txErr)
+		}
+	}(tx)
+
+	if _, err = tx.Exec(ctx, `LOCK TABLE work_rollups, work_rate_rollups IN EXCLUSIVE MODE`); err != nil {
+		return 0, errors.Join(errors.New("failed to lock rollups"), err)
+	}
+	if _, err = tx.Exec(ctx, `DELETE FROM work_rollups`); err != nil {
+		return 0, errors.Join(errors.New("failed to delete rollups"), err)
+	}
+
+	q := `INSERT INTO work_rollups (user_id, task_id, day, duration, billable_duration)` + rollupsQuery(`true`)
+	tag, err := tx.Exec(ctx, q)
+	if err != nil {
+		return 0, errors.Join(errors.New("failed to insert rollups"), err)
+	}
+	if err = billing.RebuildRateRollups(ctx, tx); err != nil {
+		return 0, err
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return 0, errors.Join(errors.New("failed to commit transaction"), err)
+	}
+	return tag.RowsAffected(), nil
+}
+
+// FullDays returns the whole UTC days within [from, to) as [fromDay, toDay).
+// It reports false if there are none.
+func FullDays(from, to time.Time) (fromDay, toDay time.Time, ok bool) {
+	fromDay = startOfDay(from)
+	if fromDay.Before(from) {
+		fromDay = fromDay.Add(day)
+	}
+	toDay = startOfDay(to)
+	return fromDay, toDay, fromDay.Before(toDay)
+}
+
+func startOfDay(t time.Time) time.Time {
+	y, m, d := t.UTC().Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+}
@@ -0,0 +1,227 @@
+package schedule
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/kirillgashkov/timetrack/api/timetrackapi/v1"
+	"github.com/kirillgashkov/timetrack/internal/app/api/apiutil"
+	openapi_types "github.com/oapi-codegen/runtime/types"
+	"github.com/shopspring/decimal"
+)
+
+var maxDayHours = decimal.NewFromInt(24)
+
+type Handler struct {
+	service Service
+}
+
+func NewHandler(service Service) *Handler {
+	return &Handler{service: service}
+}
+
+// GetSchedules handles "GET /schedules/".
+func (h *Handler) GetSchedules(w http.ResponseWriter, r *http.Request, params timetrackapi.GetSchedulesParams) {
+	schedules, err := h.service.ListSchedules(r.Context(), params.UserId)
+	if err != nil {
+		apiutil.MustWriteInternalServerError(w, "failed to list schedules", err)
+		return
+	}
+
+	resp := make([]*timetrackapi.ScheduleResponse, 0, len(schedules))
+	for _, s := range schedules {
+		resp = append(resp, toScheduleResponse(&s))
+	}
+	apiutil.MustWriteJSON(w, resp, http.StatusOK)
+}
+
+// PostSchedules handles "POST /schedules/".
+func (h *Handler) PostSchedules(w http.ResponseWriter, r *http.Request) {
+	create, err := parseAndValidateCreateScheduleRequest(r)
+	if err != nil {
+		var ve apiutil.ValidationError
+		if errors.As(err, &ve) {
+			apiutil.MustWriteUnprocessableEntity(w, ve)
+			return
+		}
+		apiutil.MustWriteInternalServerError(w, "failed to parse and validate request", err)
+		return
+	}
+
+	schedule, err := h.service.CreateSchedule(r.Context(), create)
+	if err != nil {
+		if errors.Is(err, ErrScheduleAlreadyExists) {
+			apiutil.MustWriteError(w, "schedule with the same effective date already exists", http.StatusBadRequest)
+			return
+		}
+		if errors.Is(err, ErrUserNotFound) {
+			apiutil.MustWriteUnprocessableEntity(w, apiutil.ValidationError{"user not found"})
+			return
+		}
+		apiutil.MustWriteInternalServerError(w, "failed to create schedule", err)
+		return
+	}
+
+	apiutil.MustWriteJSON(w, toScheduleResponse(schedule), http.StatusOK)
+}
+
+func parseAndValidateCreateScheduleRequest(r *http.Request) (*CreateSchedule, error) {
+	var req *timetrackapi.CreateScheduleRequest
+	if err := apiutil.ReadJSON(r, &req); err != nil {
+		return nil, errors.Join(apiutil.ValidationError{"bad JSON"}, err)
+	}
+
+	e := make([]string, 0)
+
+	if req.EffectiveFrom.IsZero() {
+		e = append(e, "missing effectiveFrom")
+	}
+
+	var hours [7]decimal.Decimal
+	days := []struct {
+		weekday time.Weekday
+		value   string
+	}{
+		{time.Monday, req.Hours.Monday},
+		{time.Tuesday, req.Hours.Tuesday},
+		{time.Wednesday, req.Hours.Wednesday},
+		{time.Thursday, req.Hours.Thursday},
+		{time.Friday, req.Hours.Friday},
+		{time.Saturday, req.Hours.Saturday},
+		{time.Sunday, req.Hours.Sunday},
+	}
+	for _, d := range days {
+		h, err := decimal.NewFromString(d.value)
+		if err != nil || h.IsNegative() || h.GreaterThan(maxDayHours) || h.Exponent() < -2 {
+			e = append(e, "invalid hours."+strings.ToLower(d.weekday.String())+
+				", must be a decimal number between 0 and 24 with at most 2 decimal places")
+			continue
+		}
+		hours[d.weekday] = h
+	}
+
+	if len(e) > 0 {
+		return nil, apiutil.ValidationError(e)
+	}
+	return &CreateSchedule{
+		UserID:        req.UserId,
+		EffectiveFrom: req.EffectiveFrom.Time,
+		Hours:         hours,
+	}, nil
+}
+
+// DeleteSchedulesId handles "DELETE /schedules/{id}".
+//
+//nolint:revive
+func (h *Handler) DeleteSchedulesId(w http.ResponseWriter, r *http.Request, id int) {
+	schedule, err := h.service.DeleteSchedule(r.Context(), id)
+	if err != nil {
+		if errors.Is(err, ErrScheduleNotFound) {
+			apiutil.MustWriteError(w, "schedule not found", http.StatusNotFound)
+			return
+		}
+		apiutil.MustWriteInternalServerError(w, "failed to delete schedule", err)
+		return
+	}
+
+	apiutil.MustWriteJSON(w, toScheduleResponse(schedule), http.StatusOK)
+}
+
+// GetHolidays handles "GET /holidays/".
+func (h *Handler) GetHolidays(w http.ResponseWriter, r *http.Request, params timetrackapi.GetHolidaysParams) {
+	var from, to *time.Time
+	if params.From != nil {
+		from = &params.From.Time
+	}
+	if params.To != nil {
+		to = &params.To.Time
+	}
+
+	holidays, err := h.service.ListHolidays(r.Context(), from, to)
+	if err != nil {
+		apiutil.MustWriteInternalServerError(w, "failed to list holidays", err)
+		return
+	}
+
+	resp := make([]*timetrackapi.HolidayResponse, 0, len(holidays))
+	for _, holiday := range holidays {
+		resp = append(resp, toHolidayResponse(&holiday))
+	}
+	apiutil.MustWriteJSON(w, resp, http.StatusOK)
+}
+
+// PostHolidays handles "POST /holidays/".
+func (h *Handler) PostHolidays(w http.ResponseWriter, r *http.Request) {
+	var req *timetrackapi.CreateHolidayRequest
+	if err := apiutil.ReadJSON(r, &req); err != nil {
+		apiutil.MustWriteUnprocessableEntity(w, apiutil.ValidationError{"bad JSON"})
+		return
+	}
+	e := make([]string, 0)
+	if req.Date.IsZero() {
+		e = append(e, "missing date")
+	}
+	if strings.TrimSpace(req.Name) == "" {
+		e = append(e, "missing name")
+	}
+	if len(e) > 0 {
+		apiutil.MustWriteUnprocessableEntity(w, apiutil.ValidationError(e))
+		return
+	}
+
+	holiday, err := h.service.CreateHoliday(r.Context(), &CreateHoliday{Date: req.Date.Time, Name: req.Name})
+	if err != nil {
+		if errors.Is(err, ErrHolidayAlreadyExists) {
+			apiutil.MustWriteError(w, "holiday on the same date already exists", http.StatusBadRequest)
+			return
+		}
+		apiutil.MustWriteInternalServerError(w, "failed to create holiday", err)
+		return
+	}
+
+	apiutil.MustWriteJSON(w, toHolidayResponse(holiday), http.StatusOK)
+}
+
+// DeleteHolidaysId handles "DELETE /holidays/{id}".
+//
+//nolint:revive
+func (h *Handler) DeleteHolidaysId(w http.ResponseWriter, r *http.Request, id int) {
+	holiday, err := h.service.DeleteHoliday(r.Context(), id)
+	if err != nil {
+		if errors.Is(err, ErrHolidayNotFound) {
+			apiutil.MustWriteError(w, "holiday not found", http.StatusNotFound)
+			return
+		}
+		apiutil.MustWriteInternalServerError(w, "failed to delete holiday", err)
+		return
+	}
+
+	apiutil.MustWriteJSON(w, toHolidayResponse(holiday), http.StatusOK)
+}
+
+func toScheduleResponse(s *Schedule) *timetrackapi.ScheduleResponse {
+	return &timetrackapi.ScheduleResponse{
+		Id:            s.ID,
+		UserId:        s.UserID,
+		EffectiveFrom: openapi_types.Date{Time: s.EffectiveFrom},
+		Hours: timetrackapi.ScheduleHours{
+			Monday:    s.MondayHours.StringFixed(2),
+			Tuesday:   s.TuesdayHours.StringFixed(2),
+			Wednesday: s.WednesdayHours.StringFixed(2),
+			Thursday:  s.ThursdayHours.StringFixed(2),
+			Friday:    s.FridayHours.StringFixed(2),
+			Saturday:  s.SaturdayHours.StringFixed(2),
+			Sunday:    s.SundayHours.StringFixed(2),
+		},
+	}
+}
+
+func toHolidayResponse(h *Holiday) *timetrackapi.HolidayResponse {
+	return &timetrackapi.HolidayResponse{
+		Id:   h.ID,
+		Date: openapi_types.Date{Time: h.Date},
+		Name: h.Name,
+	}
+}
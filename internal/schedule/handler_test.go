@@ -0,0 +1,110 @@
+package schedule
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/kirillgashkov/timetrack/api/timetrackapi/v1"
+)
+
+type ServiceMock struct {
+	CreateScheduleFunc func(ctx context.Context, create *CreateSchedule) (*Schedule, error)
+	ListSchedulesFunc  func(ctx context.Context, userID *int) ([]Schedule, error)
+	DeleteScheduleFunc func(ctx context.Context, id int) (*Schedule, error)
+	CreateHolidayFunc  func(ctx context.Context, create *CreateHoliday) (*Holiday, error)
+	ListHolidaysFunc   func(ctx context.Context, from, to *time.Time) ([]Holiday, error)
+	DeleteHolidayFunc  func(ctx context.Context, id int) (*Holiday, error)
+}
+
+func (s *ServiceMock) CreateSchedule(ctx context.Context, create *CreateSchedule) (*Schedule, error) {
+	return s.CreateScheduleFunc(ctx, create)
+}
+
+func (s *ServiceMock) ListSchedules(ctx context.Context, userID *int) ([]Schedule, error) {
+	return s.ListSchedulesFunc(ctx, userID)
+}
+
+func (s *ServiceMock) DeleteSchedule(ctx context.Context, id int) (*Schedule, error) {
+	return s.DeleteScheduleFunc(ctx, id)
+}
+
+func (s *ServiceMock) CreateHoliday(ctx context.Context, create *CreateHoliday) (*Holiday, error) {
+	return s.CreateHolidayFunc(ctx, create)
+}
+
+func (s *ServiceMock) ListHolidays(ctx context.Context, from, to *time.Time) ([]Holiday, error) {
+	return s.ListHolidaysFunc(ctx, from, to)
+}
+
+func (s *ServiceMock) DeleteHoliday(ctx context.Context, id int) (*Holiday, error) {
+	return s.DeleteHolidayFunc(ctx, id)
+}
+
+func TestPostSchedules(t *testing.T) {
+	body := func(monday string) string {
+		return `{"userId":1,"effectiveFrom":"2024-07-01","hours":{"monday":"` + monday + `","tuesday":"8",` +
+			`"wednesday":"8","thursday":"8","friday":"7.5","saturday":"0","sunday":"0"}}`
+	}
+
+	tests := []struct {
+		name               string
+		body               string
+		createErr          error
+		expectedStatusCode int
+	}{
+		{"full time", body("8"), nil, http.StatusOK},
+		{"too many hours", body("24.5"), nil, http.StatusUnprocessableEntity},
+		{"negative hours", body("-1"), nil, http.StatusUnprocessableEntity},
+		{"too precise", body("7.125"), nil, http.StatusUnprocessableEntity},
+		{"missing hours", `{"userId":1,"effectiveFrom":"2024-07-01"}`, nil, http.StatusUnprocessableEntity},
+		{"already exists", body("8"), ErrScheduleAlreadyExists, http.StatusBadRequest},
+		{"user not found", body("8"), ErrUserNotFound, http.StatusUnprocessableEntity},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var created *CreateSchedule
+			handler := NewHandler(&ServiceMock{
+				CreateScheduleFunc: func(_ context.Context, create *CreateSchedule) (*Schedule, error) {
+					if tt.createErr != nil {
+						return nil, tt.createErr
+					}
+					created = create
+					return &Schedule{
+						ID:            1,
+						UserID:        create.UserID,
+						EffectiveFrom: create.EffectiveFrom,
+						MondayHours:   create.Hours[time.Monday],
+						FridayHours:   create.Hours[time.Friday],
+					}, nil
+				},
+			})
+
+			req := httptest.NewRequest(http.MethodPost, "/schedules/", bytes.NewBufferString(tt.body))
+			w := httptest.NewRecorder()
+			handler.PostSchedules(w, req)
+
+			if w.Code != tt.expectedStatusCode {
+				t.Fatalf("expected status code %d, got %d: %s", tt.expectedStatusCode, w.Code, w.Body.String())
+			}
+			if w.Code != http.StatusOK {
+				return
+			}
+			if created.Hours[time.Friday].String() != "7.5" || !created.Hours[time.Sunday].IsZero() {
+				t.Errorf("expected hours by weekday, got %v", created.Hours)
+			}
+			var resp timetrackapi.ScheduleResponse
+			if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+				t.Fatal(err)
+			}
+			if resp.Hours.Monday != "8.00" || resp.Hours.Friday != "7.50" {
+				t.Errorf("expected hours with 2 decimal places, got %+v", resp.Hours)
+			}
+		})
+	}
+}
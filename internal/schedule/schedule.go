@@ -0,0 +1,26 @@
+package schedule
+
+// DayHoursSubquery selects the hours a user is expected to work on a day as
+// "hours". It refers to the user as "users" and to the day as "days.date" and
+// is meant to be used in a lateral join, e.g. "LEFT JOIN LATERAL (" +
+// DayHoursSubquery + ") AS expected ON true". The schedule with the latest
+// effective_from not after the day applies, holidays are not expected to be
+// worked. Days before the first schedule of the user have no row.
+const DayHoursSubquery = `
+	SELECT CASE
+			   WHEN EXISTS (SELECT 1 FROM holidays WHERE holidays.date = days.date) THEN 0
+			   ELSE CASE extract(isodow FROM days.date)
+						WHEN 1 THEN work_schedules.monday_hours
+						WHEN 2 THEN work_schedules.tuesday_hours
+						WHEN 3 THEN work_schedules.wednesday_hours
+						WHEN 4 THEN work_schedules.thursday_hours
+						WHEN 5 THEN work_schedules.friday_hours
+						WHEN 6 THEN work_schedules.saturday_hours
+						ELSE work_schedules.sunday_hours
+					END
+		   END AS hours
+	FROM work_schedules
+	WHERE work_schedules.user_id = users.id AND work_schedules.effective_from <= days.date
+	ORDER BY work_schedules.effective_from DESC
+	LIMIT 1
+`
@@ -0,0 +1,201 @@
+package schedule
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/jackc/pgerrcode"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/kirillgashkov/timetrack/internal/app/database"
+	"github.com/shopspring/decimal"
+)
+
+var (
+	ErrScheduleNotFound      = errors.New("schedule not found")
+	ErrScheduleAlreadyExists = errors.New("schedule already exists")
+	ErrUserNotFound          = errors.New("user not found")
+	ErrHolidayNotFound       = errors.New("holiday not found")
+	ErrHolidayAlreadyExists  = errors.New("holiday already exists")
+)
+
+// Schedule is the number of hours a user is contracted to work on each
+// weekday from EffectiveFrom until the next schedule of the user.
+type Schedule struct {
+	ID             int
+	UserID         int             `db:"user_id"`
+	EffectiveFrom  time.Time       `db:"effective_from"`
+	MondayHours    decimal.Decimal `db:"monday_hours"`
+	TuesdayHours   decimal.Decimal `db:"tuesday_hours"`
+	WednesdayHours decimal.Decimal `db:"wednesday_hours"`
+	ThursdayHours  decimal.Decimal `db:"thursday_hours"`
+	FridayHours    decimal.Decimal `db:"friday_hours"`
+	SaturdayHours  decimal.Decimal `db:"saturday_hours"`
+	SundayHours    decimal.Decimal `db:"sunday_hours"`
+}
+
+type CreateSchedule struct {
+	UserID        int
+	EffectiveFrom time.Time
+	// Hours are the hours for each weekday indexed by time.Weekday, i.e.
+	// starting with Sunday.
+	Hours [7]decimal.Decimal
+}
+
+// Holiday is a day nobody is expected to work.
+type Holiday struct {
+	ID   int
+	Date time.Time
+	Name string
+}
+
+type CreateHoliday struct {
+	Date time.Time
+	Name string
+}
+
+type Service interface {
+	CreateSchedule(ctx context.Context, create *CreateSchedule) (*Schedule, error)
+	ListSchedules(ctx context.Context, userID *int) ([]Schedule, error)
+	DeleteSchedule(ctx context.Context, id int) (*Schedule, error)
+	CreateHoliday(ctx context.Context, create *CreateHoliday) (*Holiday, error)
+	ListHolidays(ctx context.Context, from, to *time.Time) ([]Holiday, error)
+	DeleteHoliday(ctx context.Context, id int) (*Holiday, error)
+}
+
+type ServiceImpl struct {
+	db database.DB
+}
+
+func NewServiceImpl(db database.DB) *ServiceImpl {
+	return &ServiceImpl{db: db}
+}
+
+const scheduleColumns = `
+	id, user_id, effective_from,
+	monday_hours, tuesday_hours, wednesday_hours, thursday_hours, friday_hours, saturday_hours, sunday_hours
+`
+
+func (s *ServiceImpl) CreateSchedule(ctx context.Context, create *CreateSchedule) (*Schedule, error) {
+	q := `
+		INSERT INTO work_schedules (
+			user_id, effective_from,
+			monday_hours, tuesday_hours, wednesday_hours, thursday_hours, friday_hours, saturday_hours, sunday_hours
+		)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		RETURNING ` + scheduleColumns
+	args := []any{
+		create.UserID,
+		create.EffectiveFrom,
+		create.Hours[time.Monday],
+		create.Hours[time.Tuesday],
+		create.Hours[time.Wednesday],
+		create.Hours[time.Thursday],
+		create.Hours[time.Friday],
+		create.Hours[time.Saturday],
+		create.Hours[time.Sunday],
+	}
+	return s.queryOneSchedule(ctx, q, args...)
+}
+
+func (s *ServiceImpl) ListSchedules(ctx context.Context, userID *int) ([]Schedule, error) {
+	q := `
+		SELECT ` + scheduleColumns + `
+		FROM work_schedules
+		WHERE $1::integer IS NULL OR user_id = $1
+		ORDER BY user_id, effective_from DESC
+	`
+	rows, err := s.db.Query(ctx, q, userID)
+	if err != nil {
+		return nil, errors.Join(errors.New("failed to select schedules"), err)
+	}
+	defer rows.Close()
+
+	schedules, err := pgx.CollectRows(rows, pgx.RowToStructByName[Schedule])
+	if err != nil {
+		return nil, errors.Join(errors.New("failed to collect schedules"), err)
+	}
+	return schedules, nil
+}
+
+func (s *ServiceImpl) DeleteSchedule(ctx context.Context, id int) (*Schedule, error) {
+	q := `DELETE FROM work_schedules WHERE id = $1 RETURNING ` + scheduleColumns
+	return s.queryOneSchedule(ctx, q, id)
+}
+
+func (s *ServiceImpl) CreateHoliday(ctx context.Context, create *CreateHoliday) (*Holiday, error) {
+	q := `INSERT INTO holidays (date, name) VALUES ($1, $2) RETURNING id, date, name`
+	return s.queryOneHoliday(ctx, q, create.Date, create.Name)
+}
+
+// ListHolidays lists holidays in [from, to). Both bounds are optional.
+func (s *ServiceImpl) ListHolidays(ctx context.Context, from, to *time.Time) ([]Holiday, error) {
+	q := `
+		SELECT id, date, name
+		FROM holidays
+		WHERE ($1::date IS NULL OR date >= $1) AND ($2::date IS NULL OR date < $2)
+		ORDER BY date
+	`
+	rows, err := s.db.Query(ctx, q, from, to)
+	if err != nil {
+		return nil, errors.Join(errors.New("failed to select holidays"), err)
+	}
+	defer rows.Close()
+
+	holidays, err := pgx.CollectRows(rows, pgx.RowToStructByName[Holiday])
+	if err != nil {
+		return nil, errors.Join(errors.New("failed to collect holidays"), err)
+	}
+	return holidays, nil
+}
+
+func (s *ServiceImpl) DeleteHoliday(ctx context.Context, id int) (*Holiday, error) {
+	q := `DELETE FROM holidays WHERE id = $1 RETURNING id, date, name`
+	return s.queryOneHoliday(ctx, q, id)
+}
+
+func (s *ServiceImpl) queryOneSchedule(ctx context.Context, query string, args ...any) (*Schedule, error) {
+	rows, err := s.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, errors.Join(errors.New("failed to select schedule"), err)
+	}
+	defer rows.Close()
+
+	schedule, err := pgx.CollectExactlyOneRow(rows, pgx.RowToStructByName[Schedule])
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == pgerrcode.UniqueViolation {
+			return nil, errors.Join(ErrScheduleAlreadyExists, err)
+		}
+		if errors.As(err, &pgErr) && pgErr.Code == pgerrcode.ForeignKeyViolation {
+			return nil, errors.Join(ErrUserNotFound, err)
+		}
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrScheduleNotFound
+		}
+		return nil, errors.Join(errors.New("failed to collect schedule"), err)
+	}
+	return &schedule, nil
+}
+
+func (s *ServiceImpl) queryOneHoliday(ctx context.Context, query string, args ...any) (*Holiday, error) {
+	rows, err := s.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, errors.Join(errors.New("failed to select holiday"), err)
+	}
+	defer rows.Close()
+
+	holiday, err := pgx.CollectExactlyOneRow(rows, pgx.RowToStructByName[Holiday])
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == pgerrcode.UniqueViolation {
+			return nil, errors.Join(ErrHolidayAlreadyExists, err)
+		}
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrHolidayNotFound
+		}
+		return nil, errors.Join(errors.New("failed to collect holiday"), err)
+	}
+	return &holiday, nil
+}
@@ -0,0 +1,150 @@
+package subscription
+
+import (
+	"errors"
+	"net/http"
+	netmail "net/mail"
+
+	"github.com/kirillgashkov/timetrack/api/timetrackapi/v1"
+	"github.com/kirillgashkov/timetrack/db/timetrackdb"
+	"github.com/kirillgashkov/timetrack/internal/app/api/apiutil"
+	"github.com/kirillgashkov/timetrack/internal/auth"
+	openapi_types "github.com/oapi-codegen/runtime/types"
+)
+
+type Handler struct {
+	service Service
+}
+
+func NewHandler(service Service) *Handler {
+	return &Handler{service: service}
+}
+
+// GetSubscriptions handles "GET /subscriptions/".
+func (h *Handler) GetSubscriptions(w http.ResponseWriter, r *http.Request) {
+	u := auth.MustUserFromContext(r.Context())
+	subs, err := h.service.List(r.Context(), u.ID)
+	if err != nil {
+		apiutil.MustWriteInternalServerError(w, "failed to list subscriptions", err)
+		return
+	}
+
+	resp := make([]*timetrackapi.ReportSubscriptionResponse, 0, len(subs))
+	for _, sub := range subs {
+		resp = append(resp, toReportSubscriptionResponse(&sub))
+	}
+	apiutil.MustWriteJSON(w, resp, http.StatusOK)
+}
+
+// PostSubscriptions handles "POST /subscriptions/".
+func (h *Handler) PostSubscriptions(w http.ResponseWriter, r *http.Request) {
+	var req *timetrackapi.CreateReportSubscriptionRequest
+	if err := apiutil.ReadJSON(r, &req); err != nil {
+		apiutil.MustWriteUnprocessableEntity(w, apiutil.ValidationError{"bad JSON"})
+		return
+	}
+	create, err := validateCreateSubscription(req)
+	if err != nil {
+		apiutil.MustWriteUnprocessableEntity(w, err)
+		return
+	}
+
+	u := auth.MustUserFromContext(r.Context())
+	sub, createErr := h.service.Create(r.Context(), u.ID, create)
+	if createErr != nil {
+		if errors.Is(createErr, ErrUserNotManaged) {
+			apiutil.MustWriteForbidden(w)
+			return
+		}
+		apiutil.MustWriteInternalServerError(w, "failed to create subscription", createErr)
+		return
+	}
+
+	apiutil.MustWriteJSON(w, toReportSubscriptionResponse(sub), http.StatusOK)
+}
+
+func validateCreateSubscription(
+	req *timetrackapi.CreateReportSubscriptionRequest,
+) (*CreateSubscription, apiutil.ValidationError) {
+	e := make([]string, 0)
+
+	addr, err := netmail.ParseAddress(string(req.Recipient))
+	if err != nil {
+		e = append(e, "invalid recipient, must be an email address")
+	}
+
+	var userID *int
+	switch req.Scope {
+	case timetrackdb.SubscriptionScopeTeam:
+		if req.UserId != nil {
+			e = append(e, "invalid userId, must be omitted for the team scope")
+		}
+	case timetrackdb.SubscriptionScopeUser:
+		if req.UserId == nil {
+			e = append(e, "missing userId, required for the user scope")
+		}
+		userID = req.UserId
+	default:
+		e = append(e, "invalid scope, must be one of: team, user")
+	}
+
+	switch req.Period {
+	case timetrackdb.SubscriptionPeriodDay, timetrackdb.SubscriptionPeriodWeek, timetrackdb.SubscriptionPeriodMonth:
+	default:
+		e = append(e, "invalid period, must be one of: day, week, month")
+	}
+
+	switch req.Format {
+	case timetrackdb.SubscriptionFormatText, timetrackdb.SubscriptionFormatCSV:
+	default:
+		e = append(e, "invalid format, must be one of: text, csv")
+	}
+
+	if _, err = ParseSchedule(req.Schedule); err != nil {
+		e = append(e, "invalid schedule, must be a cron expression such as \"0 8 * * 1\"")
+	}
+
+	if len(e) > 0 {
+		return nil, apiutil.ValidationError(e)
+	}
+	return &CreateSubscription{
+		Recipient: addr.Address,
+		Scope:     string(req.Scope),
+		UserID:    userID,
+		Period:    string(req.Period),
+		Schedule:  req.Schedule,
+		Format:    string(req.Format),
+	}, nil
+}
+
+// DeleteSubscriptionsId handles "DELETE /subscriptions/{id}".
+//
+//nolint:revive
+func (h *Handler) DeleteSubscriptionsId(w http.ResponseWriter, r *http.Request, id int) {
+	u := auth.MustUserFromContext(r.Context())
+	sub, err := h.service.Delete(r.Context(), id, u.ID)
+	if err != nil {
+		if errors.Is(err, ErrNotFound) {
+			apiutil.MustWriteError(w, "subscription not found", http.StatusNotFound)
+			return
+		}
+		apiutil.MustWriteInternalServerError(w, "failed to delete subscription", err)
+		return
+	}
+
+	apiutil.MustWriteJSON(w, toReportSubscriptionResponse(sub), http.StatusOK)
+}
+
+func toReportSubscriptionResponse(sub *Subscription) *timetrackapi.ReportSubscriptionResponse {
+	return &timetrackapi.ReportSubscriptionResponse{
+		Id:        sub.ID,
+		Recipient: openapi_types.Email(sub.Recipient),
+		Scope:     timetrackapi.ReportSubscriptionScope(sub.Scope),
+		UserId:    sub.UserID,
+		Period:    timetrackapi.ReportSubscriptionPeriod(sub.Period),
+		Schedule:  sub.Schedule,
+		Format:    timetrackapi.ReportSubscriptionFormat(sub.Format),
+		NextRunAt: sub.NextRunAt,
+		LastRunAt: sub.LastRunAt,
+	}
+}
@@ -0,0 +1,112 @@
+package subscription
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/kirillgashkov/timetrack/internal/auth"
+)
+
+type ServiceMock struct {
+	CreateFunc func(ctx context.Context, ownerID int, create *CreateSubscription) (*Subscription, error)
+	ListFunc   func(ctx context.Context, ownerID int) ([]Subscription, error)
+	DeleteFunc func(ctx context.Context, id int, ownerID int) (*Subscription, error)
+}
+
+func (s *ServiceMock) Create(ctx context.Context, ownerID int, create *CreateSubscription) (*Subscription, error) {
+	return s.CreateFunc(ctx, ownerID, create)
+}
+
+func (s *ServiceMock) List(ctx context.Context, ownerID int) ([]Subscription, error) {
+	return s.ListFunc(ctx, ownerID)
+}
+
+func (s *ServiceMock) Delete(ctx context.Context, id int, ownerID int) (*Subscription, error) {
+	return s.DeleteFunc(ctx, id, ownerID)
+}
+
+func TestPostSubscriptions(t *testing.T) {
+	tests := []struct {
+		name               string
+		body               string
+		createErr          error
+		expectedStatusCode int
+	}{
+		{
+			"team",
+			`{"recipient":"m@example.com","scope":"team","period":"week","schedule":"0 8 * * 1","format":"text"}`,
+			nil,
+			http.StatusOK,
+		},
+		{
+			"user",
+			`{"recipient":"m@example.com","scope":"user","userId":2,"period":"month","schedule":"@monthly","format":"csv"}`,
+			nil,
+			http.StatusOK,
+		},
+		{
+			"user not managed",
+			`{"recipient":"m@example.com","scope":"user","userId":3,"period":"day","schedule":"@daily","format":"csv"}`,
+			ErrUserNotManaged,
+			http.StatusForbidden,
+		},
+		{
+			"missing user",
+			`{"recipient":"m@example.com","scope":"user","period":"week","schedule":"0 8 * * 1","format":"text"}`,
+			nil,
+			http.StatusUnprocessableEntity,
+		},
+		{
+			"invalid schedule",
+			`{"recipient":"m@example.com","scope":"team","period":"week","schedule":"mondays","format":"text"}`,
+			nil,
+			http.StatusUnprocessableEntity,
+		},
+		{
+			"invalid format",
+			`{"recipient":"m@example.com","scope":"team","period":"week","schedule":"0 8 * * 1","format":"pdf"}`,
+			nil,
+			http.StatusUnprocessableEntity,
+		},
+		{
+			"invalid recipient",
+			`{"recipient":"manager","scope":"team","period":"week","schedule":"0 8 * * 1","format":"text"}`,
+			nil,
+			http.StatusUnprocessableEntity,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := NewHandler(&ServiceMock{
+				CreateFunc: func(_ context.Context, ownerID int, create *CreateSubscription) (*Subscription, error) {
+					if tt.createErr != nil {
+						return nil, tt.createErr
+					}
+					return &Subscription{
+						ID:        1,
+						OwnerID:   ownerID,
+						Recipient: create.Recipient,
+						Scope:     create.Scope,
+						UserID:    create.UserID,
+						Period:    create.Period,
+						Schedule:  create.Schedule,
+						Format:    create.Format,
+					}, nil
+				},
+			})
+
+			req := httptest.NewRequest(http.MethodPost, "/subscriptions/", bytes.NewBufferString(tt.body))
+			req = req.WithContext(auth.ContextWithUser(req.Context(), &auth.User{ID: 1}))
+			w := httptest.NewRecorder()
+			handler.PostSubscriptions(w, req)
+
+			if w.Code != tt.expectedStatusCode {
+				t.Errorf("expected status code %d, got %d: %s", tt.expectedStatusCode, w.Code, w.Body.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,110 @@
+package subscription
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/kirillgashkov/timetrack/db/timetrackdb"
+	"github.com/kirillgashkov/timetrack/internal/app/api/apiutil"
+	"github.com/kirillgashkov/timetrack/internal/app/mail"
+)
+
+// Summary is the time a person spent on tasks in the period of a delivery.
+type Summary struct {
+	Person           Person
+	Duration         time.Duration
+	BillableDuration time.Duration
+}
+
+type Person struct {
+	ID         int
+	Surname    string  `db:"surname"`
+	Name       string  `db:"name"`
+	Patronymic *string `db:"patronymic"`
+}
+
+func (p *Person) FullName() string {
+	parts := []string{p.Surname, p.Name}
+	if p.Patronymic != nil && *p.Patronymic != "" {
+		parts = append(parts, *p.Patronymic)
+	}
+	return strings.Join(parts, " ")
+}
+
+// render renders the summaries of the period [from, to) as a message to the
+// recipient of the subscription. Text reports are in the body, CSV reports
+// are attached.
+func render(sub *Subscription, from, to time.Time, summaries []Summary) (*mail.Message, error) {
+	period := fmt.Sprintf("%s – %s", from.Format(time.DateOnly), to.AddDate(0, 0, -1).Format(time.DateOnly))
+	msg := &mail.Message{
+		To:      []string{sub.Recipient},
+		Subject: "Timetrack: hours for " + period,
+	}
+
+	if sub.Format == timetrackdb.SubscriptionFormatCSV {
+		content, err := renderCSV(summaries)
+		if err != nil {
+			return nil, err
+		}
+		msg.Body = fmt.Sprintf("Hours per person for %s are attached.\n", period)
+		msg.Attachments = []mail.Attachment{{
+			Filename:    fmt.Sprintf("hours-%s.csv", from.Format(time.DateOnly)),
+			ContentType: "text/csv; charset=utf-8",
+			Content:     content,
+		}}
+		return msg, nil
+	}
+
+	msg.Body = renderText(period, summaries)
+	return msg, nil
+}
+
+func renderText(period string, summaries []Summary) string {
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "Hours per person for %s\n\n", period)
+	if len(summaries) == 0 {
+		buf.WriteString("Nobody to report on.\n")
+		return buf.String()
+	}
+
+	tw := tabwriter.NewWriter(&buf, 0, 0, 2, ' ', tabwriter.AlignRight)
+	_, _ = fmt.Fprint(tw, "Person\tHours\tBillable hours\t\n")
+	var total, billable time.Duration
+	for _, s := range summaries {
+		_, _ = fmt.Fprintf(tw, "%s\t%s\t%s\t\n",
+			s.Person.FullName(), apiutil.FormatHours(s.Duration), apiutil.FormatHours(s.BillableDuration))
+		total += s.Duration
+		billable += s.BillableDuration
+	}
+	_, _ = fmt.Fprintf(tw, "Total\t%s\t%s\t\n", apiutil.FormatHours(total), apiutil.FormatHours(billable))
+	_ = tw.Flush()
+	return buf.String()
+}
+
+func renderCSV(summaries []Summary) ([]byte, error) {
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+	records := [][]string{{"user_id", "surname", "name", "patronymic", "hours", "billable_hours"}}
+	for _, s := range summaries {
+		patronymic := ""
+		if s.Person.Patronymic != nil {
+			patronymic = *s.Person.Patronymic
+		}
+		records = append(records, []string{
+			fmt.Sprint(s.Person.ID),
+			s.Person.Surname,
+			s.Person.Name,
+			patronymic,
+			apiutil.FormatHours(s.Duration),
+			apiutil.FormatHours(s.BillableDuration),
+		})
+	}
+	if err := w.WriteAll(records); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
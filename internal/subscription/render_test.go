@@ -0,0 +1,105 @@
+package subscription
+
+import (
+	"bytes"
+	"encoding/base64"
+	"io"
+	"mime"
+	"mime/multipart"
+	netmail "net/mail"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kirillgashkov/timetrack/internal/app/config"
+	"github.com/kirillgashkov/timetrack/internal/app/mail"
+	"github.com/kirillgashkov/timetrack/internal/app/testutil"
+)
+
+func TestRenderText(t *testing.T) {
+	patronymic := "Сергеевич"
+	summaries := []Summary{
+		{Person{1, "Иванов", "Иван", &patronymic}, 40 * time.Hour, 32*time.Hour + 30*time.Minute},
+		{Person{2, "Smith", "John", nil}, 12*time.Hour + 15*time.Minute, 0},
+	}
+
+	got := renderText("2024-07-01 – 2024-07-07", summaries)
+
+	for _, want := range []string{
+		"Hours per person for 2024-07-01 – 2024-07-07",
+		"Иванов Иван Сергеевич  40.00           32.50",
+		"Smith John  12.25            0.00",
+		"Total  52.25           32.50",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("expected text to contain %q, got:\n%s", want, got)
+		}
+	}
+}
+
+func TestRenderDelivery(t *testing.T) {
+	srv, err := testutil.NewSMTPServer()
+	if err != nil {
+		t.Fatalf("failed to start SMTP server: %v", err)
+	}
+	defer func() { _ = srv.Close() }()
+
+	host, port := srv.Addr()
+	mailer := mail.NewSMTPMailer(&config.SMTPConfig{Host: host, Port: port, From: "timetrack@example.com"})
+
+	sub := &Subscription{Recipient: "manager@example.com", Format: "csv"}
+	from := time.Date(2024, 7, 1, 0, 0, 0, 0, time.UTC)
+	summaries := []Summary{{Person{1, "Smith", "John", nil}, 7*time.Hour + 30*time.Minute, time.Hour}}
+
+	msg, err := render(sub, from, from.AddDate(0, 0, 7), summaries)
+	if err != nil {
+		t.Fatalf("failed to render: %v", err)
+	}
+	if err = mailer.Send(msg); err != nil {
+		t.Fatalf("failed to send: %v", err)
+	}
+
+	messages := srv.Messages()
+	if len(messages) != 1 {
+		t.Fatalf("expected 1 message, got %d", len(messages))
+	}
+	if len(messages[0].To) != 1 || messages[0].To[0] != "manager@example.com" {
+		t.Errorf("expected recipient manager@example.com, got %v", messages[0].To)
+	}
+
+	m, err := netmail.ReadMessage(bytes.NewReader(messages[0].Data))
+	if err != nil {
+		t.Fatalf("failed to read message: %v", err)
+	}
+	subject, err := new(mime.WordDecoder).DecodeHeader(m.Header.Get("Subject"))
+	if err != nil {
+		t.Fatalf("failed to decode subject: %v", err)
+	}
+	if want := "Timetrack: hours for 2024-07-01 – 2024-07-07"; subject != want {
+		t.Errorf("expected subject %q, got %q", want, subject)
+	}
+
+	_, params, err := mime.ParseMediaType(m.Header.Get("Content-Type"))
+	if err != nil {
+		t.Fatalf("failed to parse content type: %v", err)
+	}
+	mr := multipart.NewReader(m.Body, params["boundary"])
+	if _, err = mr.NextPart(); err != nil {
+		t.Fatalf("failed to read body part: %v", err)
+	}
+	attachment, err := mr.NextPart()
+	if err != nil {
+		t.Fatalf("failed to read attachment part: %v", err)
+	}
+	if attachment.FileName() != "hours-2024-07-01.csv" {
+		t.Errorf("expected attachment hours-2024-07-01.csv, got %q", attachment.FileName())
+	}
+	content, err := io.ReadAll(base64.NewDecoder(base64.StdEncoding, attachment))
+	if err != nil {
+		t.Fatalf("failed to read attachment: %v", err)
+	}
+	want := "user_id,surname,name,patronymic,hours,billable_hours\n1,Smith,John,,7.50,1.00\n"
+	if string(content) != want {
+		t.Errorf("expected attachment %q, got %q", want, content)
+	}
+}
@@ -0,0 +1,42 @@
+package subscription
+
+import (
+	"errors"
+	"time"
+
+	"github.com/kirillgashkov/timetrack/db/timetrackdb"
+	"github.com/robfig/cron/v3"
+)
+
+var scheduleParser = cron.NewParser(cron.Minute | cron.Hour | cron.Dom | cron.Month | cron.Dow | cron.Descriptor)
+
+// ParseSchedule parses a standard five-field cron expression or a descriptor
+// such as "@weekly". Schedules are evaluated in UTC.
+func ParseSchedule(s string) (cron.Schedule, error) {
+	if s == "" {
+		return nil, errors.New("empty schedule")
+	}
+	schedule, err := scheduleParser.Parse("TZ=UTC " + s)
+	if err != nil {
+		return nil, errors.Join(errors.New("failed to parse schedule"), err)
+	}
+	return schedule, nil
+}
+
+// ReportPeriod returns the last complete period in UTC before t, e.g. the
+// previous week from Monday to Monday for a delivery on Monday morning.
+func ReportPeriod(period string, t time.Time) (from, to time.Time) {
+	y, m, d := t.UTC().Date()
+	today := time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+
+	switch period {
+	case timetrackdb.SubscriptionPeriodDay:
+		return today.AddDate(0, 0, -1), today
+	case timetrackdb.SubscriptionPeriodMonth:
+		thisMonth := time.Date(y, m, 1, 0, 0, 0, 0, time.UTC)
+		return thisMonth.AddDate(0, -1, 0), thisMonth
+	default:
+		thisWeek := today.AddDate(0, 0, -(int(today.Weekday())+6)%7)
+		return thisWeek.AddDate(0, 0, -7), thisWeek
+	}
+}
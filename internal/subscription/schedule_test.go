@@ -0,0 +1,79 @@
+package subscription
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseSchedule(t *testing.T) {
+	after := time.Date(2024, 7, 3, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		schedule string
+		wantNext time.Time
+		wantErr  bool
+	}{
+		{"monday morning", "0 8 * * 1", time.Date(2024, 7, 8, 8, 0, 0, 0, time.UTC), false},
+		{"descriptor", "@daily", time.Date(2024, 7, 4, 0, 0, 0, 0, time.UTC), false},
+		{"first of month", "30 6 1 * *", time.Date(2024, 8, 1, 6, 30, 0, 0, time.UTC), false},
+		{"seconds field", "0 0 8 * * 1", time.Time{}, true},
+		{"time zone", "TZ=Europe/Moscow 0 8 * * 1", time.Time{}, true},
+		{"garbage", "every monday", time.Time{}, true},
+		{"empty", "", time.Time{}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			schedule, err := ParseSchedule(tt.schedule)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("expected error, got nil")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("expected no error, got: %v", err)
+			}
+			if got := schedule.Next(after); !got.Equal(tt.wantNext) {
+				t.Errorf("expected next run at %v, got %v", tt.wantNext, got)
+			}
+		})
+	}
+}
+
+func TestReportPeriod(t *testing.T) {
+	date := func(month time.Month, day int) time.Time {
+		return time.Date(2024, month, day, 0, 0, 0, 0, time.UTC)
+	}
+	mondayMorning := time.Date(2024, 7, 8, 8, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		period   string
+		t        time.Time
+		wantFrom time.Time
+		wantTo   time.Time
+	}{
+		{"day", "day", mondayMorning, date(7, 7), date(7, 8)},
+		{"week on monday", "week", mondayMorning, date(7, 1), date(7, 8)},
+		{"week on sunday", "week", date(7, 14).Add(-time.Hour), date(7, 1), date(7, 8)},
+		{"month", "month", mondayMorning, date(6, 1), date(7, 1)},
+		{
+			"month in january",
+			"month",
+			time.Date(2024, 1, 1, 8, 0, 0, 0, time.UTC),
+			time.Date(2023, 12, 1, 0, 0, 0, 0, time.UTC),
+			date(1, 1),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			from, to := ReportPeriod(tt.period, tt.t)
+			if !from.Equal(tt.wantFrom) || !to.Equal(tt.wantTo) {
+				t.Errorf("expected [%v, %v), got [%v, %v)", tt.wantFrom, tt.wantTo, from, to)
+			}
+		})
+	}
+}
@@ -0,0 +1,165 @@
+package subscription
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/kirillgashkov/timetrack/db/timetrackdb"
+	"github.com/kirillgashkov/timetrack/internal/app/database"
+	"github.com/kirillgashkov/timetrack/internal/app/mail"
+	"github.com/kirillgashkov/timetrack/internal/reporting"
+)
+
+// Scheduler delivers the reports of the subscriptions that are due.
+type Scheduler struct {
+	db        database.DB
+	reporting reporting.Service
+	mailer    mail.Mailer
+}
+
+func NewScheduler(db database.DB, reportingService reporting.Service, mailer mail.Mailer) *Scheduler {
+	return &Scheduler{db: db, reporting: reportingService, mailer: mailer}
+}
+
+// RunDue delivers the reports of the subscriptions with next_run_at at or
+// before now and returns the number of delivered reports. Each subscription is
+// locked while its report is delivered so that several workers can run at the
+// same time. A subscription that fails to deliver is logged and retried on
+// the next run.
+func (s *Scheduler) RunDue(ctx context.Context, now time.Time) (int, error) {
+	failed := make([]int, 0)
+	delivered := 0
+	for {
+		ok, id, err := s.runNext(ctx, now, failed)
+		if err != nil {
+			if id == 0 {
+				return delivered, err
+			}
+			slog.Error("failed to deliver report", "subscription_id", id, "error", err)
+			failed = append(failed, id)
+			continue
+		}
+		if !ok {
+			return delivered, nil
+		}
+		delivered++
+	}
+}
+
+// runNext delivers the report of one due subscription. It reports false if
+// there are no due subscriptions left and the ID of the subscription if it
+// failed to deliver.
+func (s *Scheduler) runNext(ctx context.Context, now time.Time, skip []int) (bool, int, error) {
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return false, 0, errors.Join(errors.New("failed to start transaction"), err)
+	}
+	defer rollback(ctx, tx)
+
+	q := `
+		SELECT ` + subscriptionColumns + `
+		FROM report_subscriptions
+		WHERE next_run_at <= $1 AND id <> ALL($2)
+		ORDER BY next_run_at
+		LIMIT 1
+		FOR UPDATE SKIP LOCKED
+	`
+	rows, err := tx.Query(ctx, q, now, skip)
+	if err != nil {
+		return false, 0, errors.Join(errors.New("failed to select due subscription"), err)
+	}
+	sub, err := pgx.CollectExactlyOneRow(rows, pgx.RowToStructByName[Subscription])
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return false, 0, nil
+		}
+		return false, 0, errors.Join(errors.New("failed to collect due subscription"), err)
+	}
+
+	if err = s.deliver(ctx, tx, &sub, now); err != nil {
+		return false, sub.ID, err
+	}
+
+	schedule, err := ParseSchedule(sub.Schedule)
+	if err != nil {
+		return false, sub.ID, err
+	}
+	q = `UPDATE report_subscriptions SET last_run_at = $2, next_run_at = $3 WHERE id = $1`
+	if _, err = tx.Exec(ctx, q, sub.ID, now, schedule.Next(now)); err != nil {
+		return false, sub.ID, errors.Join(errors.New("failed to update subscription"), err)
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return false, sub.ID, errors.Join(errors.New("failed to commit transaction"), err)
+	}
+	return true, sub.ID, nil
+}
+
+func (s *Scheduler) deliver(ctx context.Context, db database.DB, sub *Subscription, now time.Time) error {
+	from, to := ReportPeriod(sub.Period, now)
+
+	people, err := s.people(ctx, db, sub)
+	if err != nil {
+		return err
+	}
+
+	summaries := make([]Summary, 0, len(people))
+	for _, p := range people {
+		r, reportErr := s.reporting.Report(ctx, p.ID, &reporting.ReportOptions{
+			From:    from,
+			To:      to,
+			GroupBy: reporting.GroupByTask,
+		})
+		if reportErr != nil {
+			return errors.Join(errors.New("failed to report"), reportErr)
+		}
+		summaries = append(summaries, Summary{
+			Person:           p,
+			Duration:         r.Total.Duration,
+			BillableDuration: r.Total.BillableDuration,
+		})
+	}
+
+	msg, err := render(sub, from, to, summaries)
+	if err != nil {
+		return errors.Join(errors.New("failed to render report"), err)
+	}
+	return s.mailer.Send(msg)
+}
+
+// people returns the people a report of the subscription covers sorted by
+// name.
+func (s *Scheduler) people(ctx context.Context, db database.DB, sub *Subscription) ([]Person, error) {
+	var rows pgx.Rows
+	var err error
+	if sub.Scope == timetrackdb.SubscriptionScopeUser {
+		q := `SELECT id, surname, name, patronymic FROM users WHERE id = $1`
+		rows, err = db.Query(ctx, q, *sub.UserID)
+	} else {
+		q := `
+			SELECT id, surname, name, patronymic
+			FROM users
+			WHERE manager_id = $1 AND deleted_at IS NULL
+			ORDER BY surname, name, patronymic, id
+		`
+		rows, err = db.Query(ctx, q, sub.OwnerID)
+	}
+	if err != nil {
+		return nil, errors.Join(errors.New("failed to select users"), err)
+	}
+
+	people, err := pgx.CollectRows(rows, pgx.RowToStructByName[Person])
+	if err != nil {
+		return nil, errors.Join(errors.New("failed to collect users"), err)
+	}
+	return people, nil
+}
+
+func rollback(ctx context.Context, tx pgx.Tx) {
+	if txErr := tx.Rollback(ctx); txErr != nil && !errors.Is(txErr, pgx.ErrTxClosed) {
+		slog.Error("failed to rollback transaction", "error", txErr)
+	}
+}
@@ -0,0 +1,145 @@
+package subscription
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/jackc/pgerrcode"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/kirillgashkov/timetrack/db/timetrackdb"
+	"github.com/kirillgashkov/timetrack/internal/app/database"
+)
+
+var (
+	ErrNotFound       = errors.New("subscription not found")
+	ErrUserNotManaged = errors.New("user is neither the owner nor managed by the owner")
+)
+
+// Subscription delivers a summary of hours to the recipient on a schedule.
+// With the team scope the summary covers the users managed by the owner, with
+// the user scope it covers the user.
+type Subscription struct {
+	ID        int
+	OwnerID   int        `db:"owner_id"`
+	Recipient string     `db:"recipient"`
+	Scope     string     `db:"scope"`
+	UserID    *int       `db:"user_id"`
+	Period    string     `db:"period"`
+	Schedule  string     `db:"schedule"`
+	Format    string     `db:"format"`
+	NextRunAt time.Time  `db:"next_run_at"`
+	LastRunAt *time.Time `db:"last_run_at"`
+}
+
+type CreateSubscription struct {
+	Recipient string
+	Scope     string
+	UserID    *int
+	Period    string
+	Schedule  string
+	Format    string
+}
+
+type Service interface {
+	Create(ctx context.Context, ownerID int, create *CreateSubscription) (*Subscription, error)
+	List(ctx context.Context, ownerID int) ([]Subscription, error)
+	Delete(ctx context.Context, id int, ownerID int) (*Subscription, error)
+}
+
+type ServiceImpl struct {
+	db database.DB
+}
+
+func NewServiceImpl(db database.DB) *ServiceImpl {
+	return &ServiceImpl{db: db}
+}
+
+const subscriptionColumns = `
+	id, owner_id, recipient, scope, user_id, period, schedule, format, next_run_at, last_run_at
+`
+
+// Create creates a subscription of the owner. The schedule must be valid, see
+// ParseSchedule. A subscription for a user is allowed only for the owner
+// themselves and for the users they manage.
+func (s *ServiceImpl) Create(ctx context.Context, ownerID int, create *CreateSubscription) (*Subscription, error) {
+	schedule, err := ParseSchedule(create.Schedule)
+	if err != nil {
+		return nil, err
+	}
+
+	if create.Scope == timetrackdb.SubscriptionScopeUser && *create.UserID != ownerID {
+		var managed bool
+		q := `SELECT EXISTS (SELECT 1 FROM users WHERE id = $1 AND manager_id = $2)`
+		if err = s.db.QueryRow(ctx, q, *create.UserID, ownerID).Scan(&managed); err != nil {
+			return nil, errors.Join(errors.New("failed to select user"), err)
+		}
+		if !managed {
+			return nil, ErrUserNotManaged
+		}
+	}
+
+	q := `
+		INSERT INTO report_subscriptions (owner_id, recipient, scope, user_id, period, schedule, format, next_run_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		RETURNING ` + subscriptionColumns
+	args := []any{
+		ownerID,
+		create.Recipient,
+		create.Scope,
+		create.UserID,
+		create.Period,
+		create.Schedule,
+		create.Format,
+		schedule.Next(time.Now()),
+	}
+	rows, err := s.db.Query(ctx, q, args...)
+	if err != nil {
+		return nil, errors.Join(errors.New("failed to insert subscription"), err)
+	}
+	defer rows.Close()
+
+	sub, err := pgx.CollectExactlyOneRow(rows, pgx.RowToStructByName[Subscription])
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == pgerrcode.ForeignKeyViolation {
+			return nil, errors.Join(ErrUserNotManaged, err)
+		}
+		return nil, errors.Join(errors.New("failed to collect subscription"), err)
+	}
+	return &sub, nil
+}
+
+func (s *ServiceImpl) List(ctx context.Context, ownerID int) ([]Subscription, error) {
+	q := `SELECT ` + subscriptionColumns + ` FROM report_subscriptions WHERE owner_id = $1 ORDER BY id`
+	rows, err := s.db.Query(ctx, q, ownerID)
+	if err != nil {
+		return nil, errors.Join(errors.New("failed to select subscriptions"), err)
+	}
+	defer rows.Close()
+
+	subs, err := pgx.CollectRows(rows, pgx.RowToStructByName[Subscription])
+	if err != nil {
+		return nil, errors.Join(errors.New("failed to collect subscriptions"), err)
+	}
+	return subs, nil
+}
+
+func (s *ServiceImpl) Delete(ctx context.Context, id int, ownerID int) (*Subscription, error) {
+	q := `DELETE FROM report_subscriptions WHERE id = $1 AND owner_id = $2 RETURNING ` + subscriptionColumns
+	rows, err := s.db.Query(ctx, q, id, ownerID)
+	if err != nil {
+		return nil, errors.Join(errors.New("failed to delete subscription"), err)
+	}
+	defer rows.Close()
+
+	sub, err := pgx.CollectExactlyOneRow(rows, pgx.RowToStructByName[Subscription])
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, errors.Join(errors.New("failed to collect subscription"), err)
+	}
+	return &sub, nil
+}
@@ -0,0 +1,139 @@
+package tag
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/kirillgashkov/timetrack/api/timetrackapi/v1"
+	"github.com/kirillgashkov/timetrack/internal/app/api/apiutil"
+)
+
+type Handler struct {
+	service Service
+}
+
+func NewHandler(service Service) *Handler {
+	return &Handler{service: service}
+}
+
+// PostTags handles "POST /tags/".
+func (h *Handler) PostTags(w http.ResponseWriter, r *http.Request) {
+	var req *timetrackapi.CreateTagRequest
+	if err := apiutil.ReadJSON(r, &req); err != nil {
+		apiutil.MustWriteUnprocessableEntity(w, apiutil.ValidationError{"bad JSON"})
+		return
+	}
+	if strings.TrimSpace(req.Name) == "" {
+		apiutil.MustWriteUnprocessableEntity(w, apiutil.ValidationError{"missing name"})
+		return
+	}
+
+	t, err := h.service.Create(r.Context(), &CreateTag{Name: strings.TrimSpace(req.Name)})
+	if err != nil {
+		if errors.Is(err, ErrAlreadyExists) {
+			apiutil.MustWriteError(w, "tag already exists", http.StatusBadRequest)
+			return
+		}
+		apiutil.MustWriteInternalServerError(w, "failed to create tag", err)
+		return
+	}
+
+	apiutil.MustWriteJSON(w, toTagResponse(t), http.StatusOK)
+}
+
+// GetTags handles "GET /tags/".
+func (h *Handler) GetTags(w http.ResponseWriter, r *http.Request, params timetrackapi.GetTagsParams) {
+	page, ve := apiutil.ParsePage(r, params.Cursor, params.Offset, params.Limit)
+	if ve != nil {
+		apiutil.MustWriteUnprocessableEntity(w, ve)
+		return
+	}
+
+	tags, more, err := h.service.List(r.Context(), page)
+	if err != nil {
+		apiutil.MustWriteInternalServerError(w, "failed to list tags", err)
+		return
+	}
+
+	resp := make([]*timetrackapi.TagResponse, 0, len(tags))
+	for _, t := range tags {
+		resp = append(resp, toTagResponse(&t))
+	}
+	apiutil.SetPageLinks(w, r, page, tags, more, func(t *Tag) []any { return []any{t.Name, t.ID} })
+	apiutil.MustWriteJSON(w, resp, http.StatusOK)
+}
+
+// GetTagsId handles "GET /tags/{id}".
+//
+//nolint:revive
+func (h *Handler) GetTagsId(w http.ResponseWriter, r *http.Request, id int) {
+	t, err := h.service.Get(r.Context(), id)
+	if err != nil {
+		if errors.Is(err, ErrNotFound) {
+			apiutil.MustWriteError(w, "tag not found", http.StatusNotFound)
+			return
+		}
+		apiutil.MustWriteInternalServerError(w, "failed to get tag", err)
+		return
+	}
+
+	apiutil.MustWriteJSON(w, toTagResponse(t), http.StatusOK)
+}
+
+// PatchTagsId handles "PATCH /tags/{id}".
+//
+//nolint:revive
+func (h *Handler) PatchTagsId(w http.ResponseWriter, r *http.Request, id int) {
+	var req *timetrackapi.UpdateTagRequest
+	if err := apiutil.ReadJSON(r, &req); err != nil {
+		apiutil.MustWriteUnprocessableEntity(w, apiutil.ValidationError{"bad JSON"})
+		return
+	}
+	update := &UpdateTag{}
+	if req.Name != nil {
+		name := strings.TrimSpace(*req.Name)
+		if name == "" {
+			apiutil.MustWriteUnprocessableEntity(w, apiutil.ValidationError{"invalid name, must not be empty"})
+			return
+		}
+		update.Name = &name
+	}
+
+	t, err := h.service.Update(r.Context(), id, update)
+	if err != nil {
+		if errors.Is(err, ErrNotFound) {
+			apiutil.MustWriteError(w, "tag not found", http.StatusNotFound)
+			return
+		}
+		if errors.Is(err, ErrAlreadyExists) {
+			apiutil.MustWriteError(w, "tag already exists", http.StatusBadRequest)
+			return
+		}
+		apiutil.MustWriteInternalServerError(w, "failed to update tag", err)
+		return
+	}
+
+	apiutil.MustWriteJSON(w, toTagResponse(t), http.StatusOK)
+}
+
+// DeleteTagsId handles "DELETE /tags/{id}".
+//
+//nolint:revive
+func (h *Handler) DeleteTagsId(w http.ResponseWriter, r *http.Request, id int) {
+	t, err := h.service.Delete(r.Context(), id)
+	if err != nil {
+		if errors.Is(err, ErrNotFound) {
+			apiutil.MustWriteError(w, "tag not found", http.StatusNotFound)
+			return
+		}
+		apiutil.MustWriteInternalServerError(w, "failed to delete tag", err)
+		return
+	}
+
+	apiutil.MustWriteJSON(w, toTagResponse(t), http.StatusOK)
+}
+
+func toTagResponse(t *Tag) *timetrackapi.TagResponse {
+	return &timetrackapi.TagResponse{Id: t.ID, Name: t.Name}
+}
@@ -0,0 +1,78 @@
+package tag
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/kirillgashkov/timetrack/internal/app/database"
+)
+
+type ServiceMock struct {
+	CreateFunc func(ctx context.Context, create *CreateTag) (*Tag, error)
+	GetFunc    func(ctx context.Context, id int) (*Tag, error)
+	ListFunc   func(ctx context.Context, page *database.Page) ([]Tag, bool, error)
+	UpdateFunc func(ctx context.Context, id int, update *UpdateTag) (*Tag, error)
+	DeleteFunc func(ctx context.Context, id int) (*Tag, error)
+}
+
+func (s *ServiceMock) Create(ctx context.Context, create *CreateTag) (*Tag, error) {
+	return s.CreateFunc(ctx, create)
+}
+
+func (s *ServiceMock) Get(ctx context.Context, id int) (*Tag, error) {
+	return s.GetFunc(ctx, id)
+}
+
+func (s *ServiceMock) List(ctx context.Context, page *database.Page) ([]Tag, bool, error) {
+	return s.ListFunc(ctx, page)
+}
+
+func (s *ServiceMock) Update(ctx context.Context, id int, update *UpdateTag) (*Tag, error) {
+	return s.UpdateFunc(ctx, id, update)
+}
+
+func (s *ServiceMock) Delete(ctx context.Context, id int) (*Tag, error) {
+	return s.DeleteFunc(ctx, id)
+}
+
+func TestPostTags(t *testing.T) {
+	tests := []struct {
+		name               string
+		body               string
+		createErr          error
+		expectedName       string
+		expectedStatusCode int
+	}{
+		{"create", `{"name":"urgent"}`, nil, "urgent", http.StatusOK},
+		{"trimmed name", `{"name":" urgent "}`, nil, "urgent", http.StatusOK},
+		{"empty name", `{"name":" "}`, nil, "", http.StatusUnprocessableEntity},
+		{"already exists", `{"name":"Urgent"}`, ErrAlreadyExists, "Urgent", http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := NewHandler(&ServiceMock{
+				CreateFunc: func(_ context.Context, create *CreateTag) (*Tag, error) {
+					if create.Name != tt.expectedName {
+						t.Errorf("expected name %q, got %q", tt.expectedName, create.Name)
+					}
+					if tt.createErr != nil {
+						return nil, tt.createErr
+					}
+					return &Tag{ID: 1, Name: create.Name}, nil
+				},
+			})
+
+			req := httptest.NewRequest(http.MethodPost, "/tags/", bytes.NewBufferString(tt.body))
+			w := httptest.NewRecorder()
+			handler.PostTags(w, req)
+
+			if w.Code != tt.expectedStatusCode {
+				t.Errorf("expected status code %d, got %d: %s", tt.expectedStatusCode, w.Code, w.Body.String())
+			}
+		})
+	}
+}
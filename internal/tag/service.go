@@ -0,0 +1,108 @@
+package tag
+
+import (
+	"context"
+	"errors"
+
+	"github.com/jackc/pgerrcode"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/kirillgashkov/timetrack/internal/app/database"
+)
+
+var (
+	ErrNotFound      = errors.New("tag not found")
+	ErrAlreadyExists = errors.New("tag already exists")
+)
+
+// Tag categorizes tasks and works across projects, e.g. meetings or reviews.
+// Tag names are unique regardless of case.
+type Tag struct {
+	ID   int
+	Name string
+}
+
+type CreateTag struct {
+	Name string
+}
+
+type UpdateTag struct {
+	Name *string
+}
+
+type Service interface {
+	Create(ctx context.Context, create *CreateTag) (*Tag, error)
+	Get(ctx context.Context, id int) (*Tag, error)
+	List(ctx context.Context, page *database.Page) ([]Tag, bool, error)
+	Update(ctx context.Context, id int, update *UpdateTag) (*Tag, error)
+	Delete(ctx context.Context, id int) (*Tag, error)
+}
+
+type ServiceImpl struct {
+	db database.DB
+}
+
+func NewServiceImpl(db database.DB) *ServiceImpl {
+	return &ServiceImpl{db: db}
+}
+
+func (s *ServiceImpl) Create(ctx context.Context, create *CreateTag) (*Tag, error) {
+	q := `INSERT INTO tags (name) VALUES ($1) RETURNING id, name`
+	return s.queryOne(ctx, q, create.Name)
+}
+
+func (s *ServiceImpl) Get(ctx context.Context, id int) (*Tag, error) {
+	q := `SELECT id, name FROM tags WHERE id = $1`
+	return s.queryOne(ctx, q, id)
+}
+
+// List lists the tags sorted by name case-insensitively and reports whether
+// there are more tags past the page.
+func (s *ServiceImpl) List(ctx context.Context, page *database.Page) ([]Tag, bool, error) {
+	pageCondition, pageClauses, pageArgs := page.Keyset(1, "lower(name)", "id")
+	q := `SELECT id, name FROM tags WHERE ` + pageCondition + ` ` + pageClauses
+	rows, err := s.db.Query(ctx, q, pageArgs...)
+	if err != nil {
+		return nil, false, errors.Join(errors.New("failed to select tags"), err)
+	}
+	defer rows.Close()
+
+	tags, err := pgx.CollectRows(rows, pgx.RowToStructByName[Tag])
+	if err != nil {
+		return nil, false, errors.Join(errors.New("failed to collect tags"), err)
+	}
+	tags, more := database.PageItems(page, tags)
+	return tags, more, nil
+}
+
+func (s *ServiceImpl) Update(ctx context.Context, id int, update *UpdateTag) (*Tag, error) {
+	q := `UPDATE tags SET name = coalesce($1, name) WHERE id = $2 RETURNING id, name`
+	return s.queryOne(ctx, q, update.Name, id)
+}
+
+// Delete deletes the tag and removes it from the tasks and the works it is on.
+func (s *ServiceImpl) Delete(ctx context.Context, id int) (*Tag, error) {
+	q := `DELETE FROM tags WHERE id = $1 RETURNING id, name`
+	return s.queryOne(ctx, q, id)
+}
+
+func (s *ServiceImpl) queryOne(ctx context.Context, query string, args ...any) (*Tag, error) {
+	rows, err := s.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, errors.Join(errors.New("failed to select tag"), err)
+	}
+	defer rows.Close()
+
+	tag, err := pgx.CollectExactlyOneRow(rows, pgx.RowToStructByName[Tag])
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == pgerrcode.UniqueViolation {
+			return nil, errors.Join(ErrAlreadyExists, err)
+		}
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, errors.Join(errors.New("failed to collect tag"), err)
+	}
+	return &tag, nil
+}
@@ -0,0 +1,126 @@
+package task
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/kirillgashkov/timetrack/internal/app/database"
+)
+
+// Visibility is who can see a task and track time on it besides its creator,
+// its assignees, and administrators. Project members are the creators and the
+// assignees of the tasks of the project.
+type Visibility string
+
+const (
+	VisibilityPrivate  Visibility = "private"
+	VisibilityProject  Visibility = "project"
+	VisibilityEveryone Visibility = "everyone"
+)
+
+// The conditions refer to the task as "tasks" and to the user the task is
+// accessed by as "$user", which is replaced with a query parameter.
+const (
+	adminCondition = `EXISTS (
+		SELECT 1 FROM users WHERE users.id = $user AND users.is_admin AND users.deleted_at IS NULL
+	)`
+
+	creatorCondition = `(tasks.created_by IS NOT NULL AND tasks.created_by = $user)`
+
+	assigneeCondition = `EXISTS (
+		SELECT 1 FROM task_assignees WHERE task_assignees.task_id = tasks.id AND task_assignees.user_id = $user
+	)`
+
+	// The projects of the user don't depend on the task, so they are selected
+	// once per query rather than once per task.
+	memberCondition = `tasks.project_id IN (
+		SELECT project_tasks.project_id FROM tasks AS project_tasks WHERE project_tasks.created_by = $user
+		UNION
+		SELECT project_tasks.project_id
+		FROM task_assignees
+		JOIN tasks AS project_tasks ON project_tasks.id = task_assignees.task_id
+		WHERE task_assignees.user_id = $user
+	)`
+
+	visibleCondition = `(tasks.deleted_at IS NULL AND (
+		tasks.visibility = 'everyone'
+		OR tasks.visibility = 'project' AND ` + memberCondition + `
+		OR ` + creatorCondition + `
+		OR ` + assigneeCondition + `
+		OR ` + adminCondition + `
+	))`
+
+	editableCondition = `(` + creatorCondition + ` OR ` + assigneeCondition + ` OR ` + adminCondition + `)`
+
+	deletableCondition = `(` + creatorCondition + ` OR ` + adminCondition + `)`
+)
+
+// VisibleTo returns an SQL condition that the task referred to as "tasks" is
+// visible to the user whose ID is the query parameter param, e.g. "$2".
+// Deleted tasks aren't visible to anyone.
+func VisibleTo(param string) string {
+	return strings.ReplaceAll(visibleCondition, "$user", param)
+}
+
+func editableBy(param string) string {
+	return strings.ReplaceAll(editableCondition, "$user", param)
+}
+
+func deletableBy(param string) string {
+	return strings.ReplaceAll(deletableCondition, "$user", param)
+}
+
+// access is what a user can do with a task in its current status. Tasks are
+// updated by their creators, their assignees, and administrators, and deleted
+// by their creators and administrators. Admin reports whether the user is an
+// administrator.
+type access struct {
+	Visible   bool   `db:"visible"`
+	Editable  bool   `db:"editable"`
+	Deletable bool   `db:"deletable"`
+	Admin     bool   `db:"admin"`
+	Status    Status `db:"status"`
+	Version   int    `db:"version"`
+}
+
+// queryAccess locks the task and returns what the user can do with it. Tasks
+// that aren't visible to the user are not found.
+func queryAccess(ctx context.Context, db database.DB, id, userID int) (*access, error) {
+	q := `
+		SELECT ` + VisibleTo("$2") + ` AS visible, ` + editableBy("$2") + ` AS editable,
+			` + deletableBy("$2") + ` AS deletable, ` + strings.ReplaceAll(adminCondition, "$user", "$2") + ` AS admin,
+			status, version
+		FROM tasks
+		WHERE id = $1
+		FOR UPDATE
+	`
+	rows, err := db.Query(ctx, q, id, userID)
+	if err != nil {
+		return nil, errors.Join(errors.New("failed to select task access"), err)
+	}
+	defer rows.Close()
+
+	a, err := pgx.CollectExactlyOneRow(rows, pgx.RowToStructByName[access])
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, errors.Join(errors.New("failed to collect task access"), err)
+	}
+	if !a.Visible {
+		return nil, ErrNotFound
+	}
+	return &a, nil
+}
+
+// checkVersion checks that the task locked by queryAccess is still at the
+// version the change is based on, if any. The task stays locked until the
+// change is committed, so the check and the change are a compare-and-swap.
+func checkVersion(a *access, version *int) error {
+	if version != nil && *version != a.Version {
+		return ErrVersionMismatch
+	}
+	return nil
+}
@@ -0,0 +1,97 @@
+package task
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/kirillgashkov/timetrack/internal/app/database"
+)
+
+// ActivityKind is what happened to a task.
+type ActivityKind string
+
+const (
+	ActivityDescriptionChanged ActivityKind = "description_changed"
+	ActivityStatusChanged      ActivityKind = "status_changed"
+	ActivityWorkStarted        ActivityKind = "work_started"
+	ActivityWorkStopped        ActivityKind = "work_stopped"
+)
+
+// Activity records what a user did to a task. OldValue and NewValue are the
+// description or the status before and after a change, WorkID is the work
+// that was started or stopped. UserID and WorkID are nil once the user or the
+// work is deleted.
+type Activity struct {
+	ID        int
+	TaskID    int          `db:"task_id"`
+	UserID    *int         `db:"user_id"`
+	Kind      ActivityKind `db:"kind"`
+	OldValue  *string      `db:"old_value"`
+	NewValue  *string      `db:"new_value"`
+	WorkID    *int         `db:"work_id"`
+	CreatedAt time.Time    `db:"created_at"`
+}
+
+// RecordWorkActivity records that the user started or stopped the work on the
+// task. It is meant to be called in the transaction that starts or stops the
+// work.
+func RecordWorkActivity(ctx context.Context, db database.DB, kind ActivityKind, taskID, userID, workID int) error {
+	q := `INSERT INTO task_activities (task_id, user_id, kind, work_id) VALUES ($1, $2, $3, $4)`
+	if _, err := db.Exec(ctx, q, taskID, userID, kind, workID); err != nil {
+		return errors.Join(errors.New("failed to insert task activity"), err)
+	}
+	return nil
+}
+
+// recordChanges records the changes of the description and the status of the
+// tasks that an update is about to make. Nil values are not changed.
+func recordChanges(
+	ctx context.Context, db database.DB, ids []int, userID int, description *string, status *Status,
+) error {
+	q := `
+		INSERT INTO task_activities (task_id, user_id, kind, old_value, new_value)
+		SELECT id, $2, 'description_changed', description, $3
+		FROM tasks
+		WHERE id = ANY ($1) AND $3::text IS NOT NULL AND description <> $3
+		UNION ALL
+		SELECT id, $2, 'status_changed', status, $4
+		FROM tasks
+		WHERE id = ANY ($1) AND $4::text IS NOT NULL AND status <> $4
+		ORDER BY 1
+	`
+	if _, err := db.Exec(ctx, q, ids, userID, description, status); err != nil {
+		return errors.Join(errors.New("failed to insert task activities"), err)
+	}
+	return nil
+}
+
+// ListActivities lists the activities of the task visible to the user, latest
+// first, and reports whether there are more activities past the page.
+func (s *ServiceImpl) ListActivities(
+	ctx context.Context, taskID, userID int, page *database.Page,
+) ([]Activity, bool, error) {
+	if _, err := s.Get(ctx, taskID, userID); err != nil {
+		return nil, false, err
+	}
+
+	pageCondition, pageClauses, pageArgs := page.Keyset(2, "id DESC")
+	q := `
+		SELECT id, task_id, user_id, kind, old_value, new_value, work_id, created_at
+		FROM task_activities
+		WHERE task_id = $1 AND ` + pageCondition + `
+		` + pageClauses
+	rows, err := s.db.Query(ctx, q, append([]any{taskID}, pageArgs...)...)
+	if err != nil {
+		return nil, false, errors.Join(errors.New("failed to select task activities"), err)
+	}
+	defer rows.Close()
+
+	activities, err := pgx.CollectRows(rows, pgx.RowToStructByName[Activity])
+	if err != nil {
+		return nil, false, errors.Join(errors.New("failed to collect task activities"), err)
+	}
+	activities, more := database.PageItems(page, activities)
+	return activities, more, nil
+}
@@ -0,0 +1,167 @@
+package task
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/kirillgashkov/timetrack/internal/app/database"
+)
+
+// BatchKind is what an operation of a batch does with a task.
+type BatchKind string
+
+const (
+	BatchCreate  BatchKind = "create"
+	BatchUpdate  BatchKind = "update"
+	BatchArchive BatchKind = "archive"
+	BatchDelete  BatchKind = "delete"
+)
+
+// BatchOperation is an operation of a batch. Create is set for create
+// operations, ID is the task of the other operations, Update is set for update
+// operations, Children is the children mode of archive and delete operations,
+// and Force forces delete operations of tasks with works. If Version is set,
+// archive and delete operations fail unless the task is still at the version,
+// update operations have it in Update.
+type BatchOperation struct {
+	Kind     BatchKind
+	ID       int
+	Create   *CreateTask
+	Update   *UpdateTask
+	Children ChildrenMode
+	Force    bool
+	Version  *int
+}
+
+// BatchResult is the task an operation of a partial batch created, updated,
+// archived, or deleted, or the error the operation failed with.
+type BatchResult struct {
+	Task *Task
+	Err  error
+}
+
+// BatchError is the error of the operation that failed a batch.
+type BatchError struct {
+	Index int
+	Err   error
+}
+
+func (e *BatchError) Error() string {
+	return fmt.Sprintf("operation %d failed: %s", e.Index, e.Err.Error())
+}
+
+func (e *BatchError) Unwrap() error {
+	return e.Err
+}
+
+// Batch runs the operations in order in a single transaction on behalf of the
+// user and returns their tasks. Each operation behaves like the single
+// operation. If an operation fails, none of the operations are applied and the
+// error is a *BatchError.
+func (s *ServiceImpl) Batch(ctx context.Context, userID int, ops []BatchOperation) ([]Task, error) {
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return nil, errors.Join(errors.New("failed to start transaction"), err)
+	}
+	defer func(tx pgx.Tx) {
+		if txErr := tx.Rollback(ctx); txErr != nil && !errors.Is(txErr, pgx.ErrTxClosed) {
+			slog.Error("failed to rollback transaction", "error", txErr)
+		}
+	}(tx)
+
+	if err = lockBatch(ctx, tx, ops); err != nil {
+		return nil, err
+	}
+
+	txs := &ServiceImpl{db: tx}
+	tasks := make([]Task, 0, len(ops))
+	for i := range ops {
+		t, opErr := txs.runBatchOperation(ctx, userID, &ops[i])
+		if opErr != nil {
+			return nil, &BatchError{Index: i, Err: opErr}
+		}
+		tasks = append(tasks, *t)
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return nil, errors.Join(errors.New("failed to commit transaction"), err)
+	}
+	return tasks, nil
+}
+
+// BatchPartial runs the operations in order in a single transaction on behalf
+// of the user like Batch, but an operation that fails is rolled back alone and
+// the others are applied. The result of each operation is returned, the error
+// is returned only if the transaction fails.
+func (s *ServiceImpl) BatchPartial(ctx context.Context, userID int, ops []BatchOperation) ([]BatchResult, error) {
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return nil, errors.Join(errors.New("failed to start transaction"), err)
+	}
+	defer func(tx pgx.Tx) {
+		if txErr := tx.Rollback(ctx); txErr != nil && !errors.Is(txErr, pgx.ErrTxClosed) {
+			slog.Error("failed to rollback transaction", "error", txErr)
+		}
+	}(tx)
+
+	if err = lockBatch(ctx, tx, ops); err != nil {
+		return nil, err
+	}
+
+	// Every operation starts a nested transaction, i.e. a savepoint, so a
+	// failed operation leaves the transaction usable.
+	txs := &ServiceImpl{db: tx}
+	results := make([]BatchResult, 0, len(ops))
+	for i := range ops {
+		t, opErr := txs.runBatchOperation(ctx, userID, &ops[i])
+		results = append(results, BatchResult{Task: t, Err: opErr})
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return nil, errors.Join(errors.New("failed to commit transaction"), err)
+	}
+	return results, nil
+}
+
+func (s *ServiceImpl) runBatchOperation(ctx context.Context, userID int, op *BatchOperation) (*Task, error) {
+	switch op.Kind {
+	case BatchCreate:
+		return s.Create(ctx, userID, op.Create)
+	case BatchUpdate:
+		return s.Update(ctx, op.ID, userID, op.Update)
+	case BatchArchive:
+		return s.Archive(ctx, op.ID, userID, op.Children, op.Version)
+	case BatchDelete:
+		return s.Delete(ctx, op.ID, userID, op.Children, op.Force, op.Version)
+	default:
+		return nil, fmt.Errorf("unknown batch operation %q", op.Kind)
+	}
+}
+
+// lockBatch locks the existing tasks of the operations in the order of their
+// IDs, so that concurrent batches touching the same tasks can't deadlock.
+func lockBatch(ctx context.Context, db database.DB, ops []BatchOperation) error {
+	ids := make([]int, 0, len(ops))
+	for _, op := range ops {
+		if op.Kind != BatchCreate {
+			ids = append(ids, op.ID)
+		}
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+
+	q := `SELECT id FROM tasks WHERE id = ANY($1) ORDER BY id FOR UPDATE`
+	rows, err := db.Query(ctx, q, ids)
+	if err != nil {
+		return errors.Join(errors.New("failed to lock tasks"), err)
+	}
+	rows.Close()
+	if err = rows.Err(); err != nil {
+		return errors.Join(errors.New("failed to lock tasks"), err)
+	}
+	return nil
+}
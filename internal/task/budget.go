@@ -0,0 +1,71 @@
+package task
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/kirillgashkov/timetrack/internal/app/database"
+)
+
+// BudgetThresholds are the percentages of a budget that are recorded as budget
+// events when the tracked time reaches them.
+var BudgetThresholds = []int{80, 100}
+
+// BudgetEvent records that the tracked time of a task reached a threshold in
+// percent of its budget. Each threshold is recorded once per budget, so
+// changing the budget of a task records the thresholds of the new budget again.
+type BudgetEvent struct {
+	ID        int
+	TaskID    int           `db:"task_id"`
+	Threshold int           `db:"threshold"`
+	Budget    time.Duration `db:"budget"`
+	Tracked   time.Duration `db:"tracked"`
+	CreatedAt time.Time     `db:"created_at"`
+}
+
+// RecordBudgetEvents records the thresholds of the budget of the task that the
+// tracked time reached and that are not recorded yet. It is meant to be called
+// in the transaction that changes the tracked time or the budget.
+func RecordBudgetEvents(ctx context.Context, db database.DB, taskID int) error {
+	q := `
+		INSERT INTO task_budget_events (task_id, threshold, budget, tracked)
+		SELECT tasks.id, thresholds.threshold, tasks.budget, totals.tracked
+		FROM tasks
+		CROSS JOIN LATERAL (` + trackedSubquery + `) AS totals (tracked)
+		CROSS JOIN unnest($2::integer[]) AS thresholds (threshold)
+		WHERE tasks.id = $1 AND tasks.budget IS NOT NULL AND totals.tracked >= tasks.budget * thresholds.threshold / 100
+		ORDER BY thresholds.threshold
+		ON CONFLICT (task_id, threshold, budget) DO NOTHING
+	`
+	if _, err := db.Exec(ctx, q, taskID, BudgetThresholds); err != nil {
+		return errors.Join(errors.New("failed to insert budget events"), err)
+	}
+	return nil
+}
+
+// ListBudgetEvents lists budget events of tasks visible to the user with IDs
+// greater than afterID in the order of their IDs, so that integrations can poll
+// for new events with the ID of the last event they have seen.
+func (s *ServiceImpl) ListBudgetEvents(ctx context.Context, userID, afterID, limit int) ([]BudgetEvent, error) {
+	q := `
+		SELECT task_budget_events.id, task_id, threshold, task_budget_events.budget, tracked, created_at
+		FROM task_budget_events
+		JOIN tasks ON tasks.id = task_budget_events.task_id
+		WHERE task_budget_events.id > $1 AND ` + VisibleTo("$3") + `
+		ORDER BY task_budget_events.id
+		LIMIT $2
+	`
+	rows, err := s.db.Query(ctx, q, afterID, limit, userID)
+	if err != nil {
+		return nil, errors.Join(errors.New("failed to select budget events"), err)
+	}
+	defer rows.Close()
+
+	events, err := pgx.CollectRows(rows, pgx.RowToStructByName[BudgetEvent])
+	if err != nil {
+		return nil, errors.Join(errors.New("failed to collect budget events"), err)
+	}
+	return events, nil
+}
@@ -0,0 +1,144 @@
+package task
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/kirillgashkov/timetrack/internal/app/database"
+)
+
+var ErrCommentNotFound = errors.New("comment not found")
+
+// Comment is a message of a user on a task. UserID is nil once the user is
+// deleted, UpdatedAt is nil unless the comment was edited.
+type Comment struct {
+	ID        int
+	TaskID    int        `db:"task_id"`
+	UserID    *int       `db:"user_id"`
+	Body      string     `db:"body"`
+	CreatedAt time.Time  `db:"created_at"`
+	UpdatedAt *time.Time `db:"updated_at"`
+}
+
+const commentColumns = `id, task_id, user_id, body, created_at, updated_at`
+
+// ListComments lists the comments on the task visible to the user, oldest
+// first, and reports whether there are more comments past the page.
+func (s *ServiceImpl) ListComments(
+	ctx context.Context, taskID, userID int, page *database.Page,
+) ([]Comment, bool, error) {
+	if _, err := s.Get(ctx, taskID, userID); err != nil {
+		return nil, false, err
+	}
+
+	pageCondition, pageClauses, pageArgs := page.Keyset(2, "id")
+	q := `
+		SELECT ` + commentColumns + `
+		FROM task_comments
+		WHERE task_id = $1 AND ` + pageCondition + `
+		` + pageClauses
+	rows, err := s.db.Query(ctx, q, append([]any{taskID}, pageArgs...)...)
+	if err != nil {
+		return nil, false, errors.Join(errors.New("failed to select comments"), err)
+	}
+	defer rows.Close()
+
+	comments, err := pgx.CollectRows(rows, pgx.RowToStructByName[Comment])
+	if err != nil {
+		return nil, false, errors.Join(errors.New("failed to collect comments"), err)
+	}
+	comments, more := database.PageItems(page, comments)
+	return comments, more, nil
+}
+
+// CreateComment comments on the task visible to the user.
+func (s *ServiceImpl) CreateComment(ctx context.Context, taskID, userID int, body string) (*Comment, error) {
+	q := `
+		INSERT INTO task_comments (task_id, user_id, body)
+		SELECT id, $2, $3 FROM tasks WHERE id = $1 AND ` + VisibleTo("$2") + `
+		RETURNING ` + commentColumns
+	c, err := queryOneComment(ctx, s.db, q, taskID, userID, body)
+	if err != nil {
+		if errors.Is(err, ErrCommentNotFound) {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+	return c, nil
+}
+
+// UpdateComment edits a comment of the user.
+func (s *ServiceImpl) UpdateComment(ctx context.Context, id, userID int, body string) (*Comment, error) {
+	q := `UPDATE task_comments SET body = $2, updated_at = now() WHERE id = $1 RETURNING ` + commentColumns
+	return s.modifyComment(ctx, id, userID, q, id, body)
+}
+
+// DeleteComment deletes a comment of the user.
+func (s *ServiceImpl) DeleteComment(ctx context.Context, id, userID int) (*Comment, error) {
+	q := `DELETE FROM task_comments WHERE id = $1 RETURNING ` + commentColumns
+	return s.modifyComment(ctx, id, userID, q, id)
+}
+
+// modifyComment locks a comment on a task visible to the user, checks that the
+// user wrote it, and runs the query that modifies it and returns it.
+func (s *ServiceImpl) modifyComment(
+	ctx context.Context, id, userID int, query string, args ...any,
+) (*Comment, error) {
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return nil, errors.Join(errors.New("failed to start transaction"), err)
+	}
+	defer func(tx pgx.Tx) {
+		if txErr := tx.Rollback(ctx); txErr != nil && !errors.Is(txErr, pgx.ErrTxClosed) {
+			slog.Error("failed to rollback transaction", "error", txErr)
+		}
+	}(tx)
+
+	q := `
+		SELECT task_comments.user_id IS NOT NULL AND task_comments.user_id = $2
+		FROM task_comments
+		JOIN tasks ON tasks.id = task_comments.task_id
+		WHERE task_comments.id = $1 AND ` + VisibleTo("$2") + `
+		FOR UPDATE OF task_comments
+	`
+	var own bool
+	if err = tx.QueryRow(ctx, q, id, userID).Scan(&own); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrCommentNotFound
+		}
+		return nil, errors.Join(errors.New("failed to select comment"), err)
+	}
+	if !own {
+		return nil, ErrForbidden
+	}
+
+	c, err := queryOneComment(ctx, tx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return nil, errors.Join(errors.New("failed to commit transaction"), err)
+	}
+	return c, nil
+}
+
+func queryOneComment(ctx context.Context, db database.DB, query string, args ...any) (*Comment, error) {
+	rows, err := db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, errors.Join(errors.New("failed to select comment"), err)
+	}
+	defer rows.Close()
+
+	c, err := pgx.CollectExactlyOneRow(rows, pgx.RowToStructByName[Comment])
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrCommentNotFound
+		}
+		return nil, errors.Join(errors.New("failed to collect comment"), err)
+	}
+	return &c, nil
+}
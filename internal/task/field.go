@@ -0,0 +1,122 @@
+package task
+
+import (
+	"context"
+	"errors"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/kirillgashkov/timetrack/internal/app/database"
+	"github.com/kirillgashkov/timetrack/internal/field"
+)
+
+// FieldValue is the value of a custom field of a task. A nil Value removes the
+// value when the task is updated.
+type FieldValue struct {
+	FieldID int
+	Value   *string
+}
+
+// fieldValuesColumn selects the values of the custom fields of the task
+// referred to as "tasks" as a JSON object keyed by field ID.
+const fieldValuesColumn = `
+	coalesce(
+		(
+			SELECT jsonb_object_agg(task_field_values.field_id, task_field_values.value)
+			FROM task_field_values
+			WHERE task_field_values.task_id = tasks.id
+		),
+		'{}'
+	) AS field_values
+`
+
+// queryFields returns the custom fields by ID. The fields are locked against
+// updates, so that enum options can't be removed while values are set.
+func queryFields(ctx context.Context, db database.DB, ids []int) (map[int]*field.Field, error) {
+	q := `SELECT id, name, type, options FROM custom_fields WHERE id = ANY ($1) FOR SHARE`
+	rows, err := db.Query(ctx, q, ids)
+	if err != nil {
+		return nil, errors.Join(errors.New("failed to select custom fields"), err)
+	}
+	defer rows.Close()
+
+	fields, err := pgx.CollectRows(rows, pgx.RowToStructByName[field.Field])
+	if err != nil {
+		return nil, errors.Join(errors.New("failed to collect custom fields"), err)
+	}
+	byID := make(map[int]*field.Field, len(fields))
+	for i := range fields {
+		byID[fields[i].ID] = &fields[i]
+	}
+	return byID, nil
+}
+
+// setFieldValues sets or removes the values of the custom fields of the task.
+// Values are normalized according to the types of the fields, a value that
+// doesn't match its type is returned as a *field.ValueError. Field IDs must
+// not repeat.
+func setFieldValues(ctx context.Context, db database.DB, id int, values []FieldValue) error {
+	ids := make([]int, 0, len(values))
+	for _, v := range values {
+		ids = append(ids, v.FieldID)
+	}
+	fields, err := queryFields(ctx, db, ids)
+	if err != nil {
+		return err
+	}
+
+	removedIDs := make([]int, 0)
+	setIDs, setValues := make([]int, 0, len(values)), make([]string, 0, len(values))
+	for _, v := range values {
+		f, ok := fields[v.FieldID]
+		if !ok {
+			return ErrFieldNotFound
+		}
+		if v.Value == nil {
+			removedIDs = append(removedIDs, v.FieldID)
+			continue
+		}
+		normalized, err := f.Normalize(*v.Value)
+		if err != nil {
+			return err
+		}
+		setIDs, setValues = append(setIDs, v.FieldID), append(setValues, normalized)
+	}
+
+	q := `DELETE FROM task_field_values WHERE task_id = $1 AND field_id = ANY ($2)`
+	if _, err = db.Exec(ctx, q, id, removedIDs); err != nil {
+		return errors.Join(errors.New("failed to delete task field values"), err)
+	}
+	q = `
+		INSERT INTO task_field_values (task_id, field_id, value)
+		SELECT $1, unnest($2::integer[]), unnest($3::text[])
+		ON CONFLICT (task_id, field_id) DO UPDATE SET value = excluded.value
+	`
+	if _, err = db.Exec(ctx, q, id, setIDs, setValues); err != nil {
+		return errors.Join(errors.New("failed to insert task field values"), err)
+	}
+	return nil
+}
+
+// normalizeFilter returns a copy of the filter with the custom field value
+// normalized according to the type of the field, so that it matches the stored
+// values.
+func (s *ServiceImpl) normalizeFilter(ctx context.Context, filter *FilterTask) (*FilterTask, error) {
+	if filter.FieldID == nil {
+		return filter, nil
+	}
+	fields, err := queryFields(ctx, s.db, []int{*filter.FieldID})
+	if err != nil {
+		return nil, err
+	}
+	f, ok := fields[*filter.FieldID]
+	if !ok {
+		return nil, ErrFieldNotFound
+	}
+	value, err := f.Normalize(filter.FieldValue)
+	if err != nil {
+		return nil, err
+	}
+	normalized := *filter
+	normalized.FieldValue = value
+	return &normalized, nil
+}
@@ -1,60 +1,145 @@
 package task
 
 import (
+	"cmp"
+	"database/sql"
 	"errors"
+	"fmt"
+	"log/slog"
 	"net/http"
+	"slices"
+	"strings"
+	"time"
 
 	"github.com/kirillgashkov/timetrack/api/timetrackapi/v1"
 	"github.com/kirillgashkov/timetrack/internal/app/api/apiutil"
+	"github.com/kirillgashkov/timetrack/internal/app/database"
+	"github.com/kirillgashkov/timetrack/internal/auth"
+	"github.com/kirillgashkov/timetrack/internal/field"
+	"github.com/shopspring/decimal"
 )
 
+// Handler handles the task endpoints. If requireIfMatch is true, batch
+// operations that change existing tasks must have a version, as the single
+// endpoints must have an If-Match header.
 type Handler struct {
-	service Service
+	service        Service
+	requireIfMatch bool
 }
 
-func NewHandler(service Service) *Handler {
-	return &Handler{service: service}
+func NewHandler(service Service, requireIfMatch bool) *Handler {
+	return &Handler{service: service, requireIfMatch: requireIfMatch}
 }
 
 // PostTasks handles "POST /tasks/".
-//
-// For simplicity, we don't use user in the task domain, but we do use them in
-// other domains.
 func (h *Handler) PostTasks(w http.ResponseWriter, r *http.Request) {
+	currentUser := auth.MustUserFromContext(r.Context())
+
 	var req *timetrackapi.CreateTaskRequest
 	if err := apiutil.ReadJSON(r, &req); err != nil {
 		apiutil.MustWriteUnprocessableEntity(w, apiutil.ValidationError{"bad JSON"})
 		return
 	}
 
-	create := &CreateTask{Description: req.Description}
-	t, err := h.service.Create(r.Context(), create)
+	create, ve := createTaskFromRequest(req)
+	if ve != nil {
+		apiutil.MustWriteUnprocessableEntity(w, ve)
+		return
+	}
+	t, err := h.service.Create(r.Context(), currentUser.ID, create)
 	if err != nil {
+		if code, msg, ok := taskError(err); ok {
+			apiutil.MustWriteError(w, msg, code)
+			return
+		}
 		apiutil.MustWriteInternalServerError(w, "failed to create task", err)
 		return
 	}
 
-	resp := toTaskResponse(t)
-	apiutil.MustWriteJSON(w, resp, http.StatusOK)
+	mustWriteTask(w, t)
+}
+
+// createTaskFromRequest creates a billable task visible to everyone unless the
+// request says otherwise.
+func createTaskFromRequest(req *timetrackapi.CreateTaskRequest) (*CreateTask, apiutil.ValidationError) {
+	create := &CreateTask{
+		Description: req.Description,
+		Billable:    true,
+		ProjectID:   req.ProjectId,
+		Visibility:  VisibilityEveryone,
+		ParentID:    req.ParentId,
+	}
+	if req.Billable != nil {
+		create.Billable = *req.Billable
+	}
+	if req.BudgetHours != nil {
+		budget, ok := parseBudgetHours(*req.BudgetHours)
+		if !ok {
+			return nil, apiutil.ValidationError{invalidBudgetHours}
+		}
+		create.Budget = &budget
+	}
+	if req.Visibility != nil {
+		v, ok := parseVisibility(*req.Visibility)
+		if !ok {
+			return nil, apiutil.ValidationError{invalidVisibility}
+		}
+		create.Visibility = v
+	}
+	if req.AssigneeIds != nil {
+		create.AssigneeIDs = *req.AssigneeIds
+	}
+	if req.TagIds != nil {
+		create.TagIDs = *req.TagIds
+	}
+	if req.CustomFields != nil {
+		values, ok := fieldValuesFromRequest(*req.CustomFields)
+		if !ok {
+			return nil, apiutil.ValidationError{invalidCustomFields}
+		}
+		create.FieldValues = values
+	}
+	return create, nil
 }
 
 // GetTasks handles "GET /tasks/".
-//
-// For simplicity, we don't use user in the task domain, but we do use them in
-// other domains.
 func (h *Handler) GetTasks(w http.ResponseWriter, r *http.Request, params timetrackapi.GetTasksParams) {
-	if err := validateAndNormalizeListTasksRequest(&params); err != nil {
-		var ve apiutil.ValidationError
-		if errors.As(err, &ve) {
-			apiutil.MustWriteUnprocessableEntity(w, ve)
-			return
-		}
-		apiutil.MustWriteInternalServerError(w, "failed to validate and normalize request", err)
+	currentUser := auth.MustUserFromContext(r.Context())
+
+	page, ve := parseListTasksRequest(r, &params)
+	if ve != nil {
+		apiutil.MustWriteUnprocessableEntity(w, ve)
 		return
 	}
 
-	tasks, err := h.service.List(r.Context(), *params.Offset, *params.Limit)
+	filter := &FilterTask{
+		ProjectID:  params.ProjectId,
+		AssigneeID: params.AssigneeId,
+		TagID:      params.TagId,
+		ParentID:   params.ParentId,
+		Statuses:   toStatuses(params.Status),
+		FieldID:    params.FieldId,
+	}
+	if params.FieldValue != nil {
+		filter.FieldValue = *params.FieldValue
+	}
+	if params.Q != nil {
+		h.searchTasks(w, r, currentUser.ID, *params.Q, filter, page)
+		return
+	}
+
+	h.listTasks(w, r, currentUser.ID, filter, page)
+}
+
+func (h *Handler) listTasks(
+	w http.ResponseWriter, r *http.Request, userID int, filter *FilterTask, page *database.Page,
+) {
+	tasks, more, err := h.service.List(r.Context(), userID, filter, page)
 	if err != nil {
+		if ve, ok := fieldValidationError(err); ok {
+			apiutil.MustWriteUnprocessableEntity(w, ve)
+			return
+		}
 		apiutil.MustWriteInternalServerError(w, "failed to list tasks", err)
 		return
 	}
@@ -63,118 +148,902 @@ func (h *Handler) GetTasks(w http.ResponseWriter, r *http.Request, params timetr
 	for _, t := range tasks {
 		resp = append(resp, toTaskResponse(&t))
 	}
+	apiutil.SetPageLinks(w, r, page, tasks, more, func(t *Task) []any { return []any{t.ID} })
 	apiutil.MustWriteJSON(w, resp, http.StatusOK)
 }
 
-func validateAndNormalizeListTasksRequest(params *timetrackapi.GetTasksParams) error {
-	if err := validateListTasksRequest(*params); err != nil {
-		return err
+func (h *Handler) searchTasks(
+	w http.ResponseWriter, r *http.Request, userID int, query string, filter *FilterTask, page *database.Page,
+) {
+	results, more, err := h.service.Search(r.Context(), userID, query, filter, page)
+	if err != nil {
+		if ve, ok := fieldValidationError(err); ok {
+			apiutil.MustWriteUnprocessableEntity(w, ve)
+			return
+		}
+		apiutil.MustWriteInternalServerError(w, "failed to search tasks", err)
+		return
+	}
+
+	resp := make([]*timetrackapi.TaskResponse, 0, len(results))
+	for _, sr := range results {
+		tr := toTaskResponse(&sr.Task)
+		tr.Match = &timetrackapi.TaskMatchResponse{
+			Score:      sr.Score,
+			Highlights: make([]timetrackapi.TaskHighlightResponse, 0, len(sr.Highlights)),
+		}
+		for _, hl := range sr.Highlights {
+			tr.Match.Highlights = append(tr.Match.Highlights, timetrackapi.TaskHighlightResponse{
+				Start: hl.Start,
+				End:   hl.End,
+			})
+		}
+		resp = append(resp, tr)
 	}
-	normalizeListTasksRequest(params)
-	return nil
+	apiutil.SetPageLinks(w, r, page, results, more, func(sr *SearchResult) []any { return []any{sr.Score, sr.ID} })
+	apiutil.MustWriteJSON(w, resp, http.StatusOK)
 }
 
-func validateListTasksRequest(params timetrackapi.GetTasksParams) error {
-	e := make([]string, 0)
+// parseListTasksRequest validates the parameters of a request that lists tasks
+// and returns its page.
+func parseListTasksRequest(
+	r *http.Request, params *timetrackapi.GetTasksParams,
+) (*database.Page, apiutil.ValidationError) {
+	page, e := apiutil.ParsePage(r, params.Cursor, params.Offset, params.Limit)
 
-	if params.Offset != nil && *params.Offset < 0 {
-		e = append(e, "invalid offset, must be greater than or equal to 0")
+	if params.Q != nil && strings.TrimSpace(*params.Q) == "" {
+		e = append(e, "invalid q, must not be empty")
+	}
+	if (params.FieldId == nil) != (params.FieldValue == nil) {
+		e = append(e, "invalid fieldId and fieldValue, must be used together")
 	}
-	if params.Limit != nil && *params.Limit < 1 || *params.Limit > 100 {
-		e = append(e, "invalid limit, must be between 1 and 100")
+	if params.Status != nil {
+		for _, st := range *params.Status {
+			if _, ok := parseStatus(st); !ok {
+				e = append(e, invalidStatus)
+				break
+			}
+		}
 	}
 
 	if len(e) > 0 {
-		return apiutil.ValidationError(e)
+		return nil, e
 	}
-	return nil
+	return page, nil
 }
 
-func normalizeListTasksRequest(params *timetrackapi.GetTasksParams) {
-	if params.Offset == nil {
-		params.Offset = intPtr(0)
+// GetTasksId handles "GET /tasks/{id}".
+//
+//nolint:revive
+func (h *Handler) GetTasksId(w http.ResponseWriter, r *http.Request, id int) {
+	currentUser := auth.MustUserFromContext(r.Context())
+
+	t, err := h.service.Get(r.Context(), id, currentUser.ID)
+	if err != nil {
+		if errors.Is(err, ErrNotFound) {
+			apiutil.MustWriteError(w, "task not found", http.StatusNotFound)
+			return
+		}
+		apiutil.MustWriteInternalServerError(w, "failed to get task", err)
+		return
 	}
-	if params.Limit == nil {
-		params.Limit = intPtr(50)
+
+	mustWriteTask(w, t)
+}
+
+// GetTasksIdChildren handles "GET /tasks/{id}/children".
+//
+//nolint:revive
+func (h *Handler) GetTasksIdChildren(
+	w http.ResponseWriter, r *http.Request, id int, params timetrackapi.GetTasksIdChildrenParams,
+) {
+	currentUser := auth.MustUserFromContext(r.Context())
+
+	listParams := timetrackapi.GetTasksParams{
+		Status: params.Status, Cursor: params.Cursor, Offset: params.Offset, Limit: params.Limit,
 	}
+	page, ve := parseListTasksRequest(r, &listParams)
+	if ve != nil {
+		apiutil.MustWriteUnprocessableEntity(w, ve)
+		return
+	}
+
+	if _, err := h.service.Get(r.Context(), id, currentUser.ID); err != nil {
+		if errors.Is(err, ErrNotFound) {
+			apiutil.MustWriteError(w, "task not found", http.StatusNotFound)
+			return
+		}
+		apiutil.MustWriteInternalServerError(w, "failed to get task", err)
+		return
+	}
+
+	filter := &FilterTask{ParentID: &id, Statuses: toStatuses(listParams.Status)}
+	h.listTasks(w, r, currentUser.ID, filter, page)
 }
 
-// GetTasksId handles "GET /tasks/{id}".
+// PatchTasksId handles "PATCH /tasks/{id}".
 //
-// For simplicity, we don't use user in the task domain, but we do use them in
-// other domains.
+//nolint:revive
+func (h *Handler) PatchTasksId(
+	w http.ResponseWriter, r *http.Request, id int, params timetrackapi.PatchTasksIdParams,
+) {
+	currentUser := auth.MustUserFromContext(r.Context())
+
+	version, ve := apiutil.IfMatchVersion(params.IfMatch)
+	if ve != nil {
+		apiutil.MustWriteUnprocessableEntity(w, ve)
+		return
+	}
+
+	var req *timetrackapi.UpdateTaskRequest
+	if err := apiutil.ReadJSON(r, &req); err != nil {
+		apiutil.MustWriteUnprocessableEntity(w, apiutil.ValidationError{"bad JSON"})
+		return
+	}
+
+	update, ve := updateTaskFromRequest(id, req)
+	if ve != nil {
+		apiutil.MustWriteUnprocessableEntity(w, ve)
+		return
+	}
+	update.Version = version
+	t, err := h.service.Update(r.Context(), id, currentUser.ID, update)
+	if err != nil {
+		if code, msg, ok := taskError(err); ok {
+			apiutil.MustWriteError(w, msg, code)
+			return
+		}
+		apiutil.MustWriteInternalServerError(w, "failed to update task", err)
+		return
+	}
+
+	mustWriteTask(w, t)
+}
+
+func updateTaskFromRequest(id int, req *timetrackapi.UpdateTaskRequest) (*UpdateTask, apiutil.ValidationError) {
+	var budget *sql.Null[time.Duration]
+	if req.BudgetHours != nil {
+		v, ok := parseBudgetHours(*req.BudgetHours)
+		if !ok {
+			return nil, apiutil.ValidationError{invalidBudgetHours}
+		}
+		budget = &sql.Null[time.Duration]{V: v, Valid: true}
+	}
+	if req.BudgetHoursNull != nil {
+		if budget == nil {
+			budget = &sql.Null[time.Duration]{}
+		}
+		budget.Valid = !*req.BudgetHoursNull
+	}
+
+	var parentID *sql.Null[int]
+	if req.ParentId != nil {
+		if *req.ParentId == id {
+			return nil, apiutil.ValidationError{invalidParentID}
+		}
+		parentID = &sql.Null[int]{V: *req.ParentId, Valid: true}
+	}
+	if req.ParentIdNull != nil {
+		if parentID == nil {
+			parentID = &sql.Null[int]{}
+		}
+		parentID.Valid = !*req.ParentIdNull
+	}
+
+	var visibility *Visibility
+	if req.Visibility != nil {
+		v, ok := parseVisibility(*req.Visibility)
+		if !ok {
+			return nil, apiutil.ValidationError{invalidVisibility}
+		}
+		visibility = &v
+	}
+
+	var status *Status
+	if req.Status != nil {
+		st, ok := parseStatus(*req.Status)
+		if !ok {
+			return nil, apiutil.ValidationError{invalidStatus}
+		}
+		if st == StatusArchived {
+			return nil, apiutil.ValidationError{archivedStatus}
+		}
+		status = &st
+	}
+
+	var assigneeIDs []int
+	if req.AssigneeIds != nil {
+		assigneeIDs = *req.AssigneeIds
+	}
+	var tagIDs []int
+	if req.TagIds != nil {
+		tagIDs = *req.TagIds
+	}
+	var fieldValues []FieldValue
+	if req.CustomFields != nil {
+		values, ok := fieldValuesFromRequest(*req.CustomFields)
+		if !ok {
+			return nil, apiutil.ValidationError{invalidCustomFields}
+		}
+		fieldValues = values
+	}
+
+	return &UpdateTask{
+		Description: req.Description,
+		Billable:    req.Billable,
+		ProjectID:   req.ProjectId,
+		Budget:      budget,
+		Visibility:  visibility,
+		AssigneeIDs: assigneeIDs,
+		TagIDs:      tagIDs,
+		Status:      status,
+		ParentID:    parentID,
+		FieldValues: fieldValues,
+	}, nil
+}
+
+// DeleteTasksId handles "DELETE /tasks/{id}". Tasks are archived unless the
+// deletion is permanent, then they are moved to the trash, their children are
+// handled according to the children mode.
 //
 //nolint:revive
-func (h *Handler) GetTasksId(w http.ResponseWriter, r *http.Request, id int) {
-	t, err := h.service.Get(r.Context(), id)
+func (h *Handler) DeleteTasksId(
+	w http.ResponseWriter, r *http.Request, id int, params timetrackapi.DeleteTasksIdParams,
+) {
+	currentUser := auth.MustUserFromContext(r.Context())
+
+	children := ChildrenRestrict
+	if params.Children != nil {
+		var ok bool
+		if children, ok = parseChildrenMode(*params.Children); !ok {
+			apiutil.MustWriteUnprocessableEntity(w, apiutil.ValidationError{invalidChildrenMode})
+			return
+		}
+	}
+
+	version, ve := apiutil.IfMatchVersion(params.IfMatch)
+	if ve != nil {
+		apiutil.MustWriteUnprocessableEntity(w, ve)
+		return
+	}
+
+	permanent := params.Permanent != nil && *params.Permanent
+	force := params.Force != nil && *params.Force
+	if params.Force != nil && !permanent {
+		apiutil.MustWriteUnprocessableEntity(w, apiutil.ValidationError{"invalid force, allowed with permanent=true only"})
+		return
+	}
+
+	var t *Task
+	var err error
+	if permanent {
+		t, err = h.service.Delete(r.Context(), id, currentUser.ID, children, force, version)
+	} else {
+		t, err = h.service.Archive(r.Context(), id, currentUser.ID, children, version)
+	}
+	if err != nil {
+		if code, msg, ok := taskError(err); ok {
+			apiutil.MustWriteError(w, msg, code)
+			return
+		}
+		apiutil.MustWriteInternalServerError(w, "failed to delete task", err)
+		return
+	}
+
+	mustWriteTask(w, t)
+}
+
+// versionMismatchMessage is the error message of changing a task that was
+// changed since the version in If-Match.
+const versionMismatchMessage = "task was changed by someone else, get it again and retry"
+
+// hasWorksMessage is the error message of deleting a task with works.
+const hasWorksMessage = "task has works, only an administrator can delete it with force=true"
+
+// PostTasksIdRestore handles "POST /tasks/{id}/restore".
+//
+//nolint:revive
+func (h *Handler) PostTasksIdRestore(w http.ResponseWriter, r *http.Request, id int) {
+	currentUser := auth.MustUserFromContext(r.Context())
+
+	t, err := h.service.Restore(r.Context(), id, currentUser.ID)
+	if err != nil {
+		if errors.Is(err, ErrNotFound) {
+			apiutil.MustWriteError(w, "task not found in the trash", http.StatusNotFound)
+			return
+		}
+		if errors.Is(err, ErrParentDeleted) {
+			apiutil.MustWriteError(w, "parent task is in the trash", http.StatusConflict)
+			return
+		}
+		apiutil.MustWriteInternalServerError(w, "failed to restore task", err)
+		return
+	}
+
+	mustWriteTask(w, t)
+}
+
+// maxBatchOperations is the maximum number of operations in a batch.
+const maxBatchOperations = 100
+
+// PostTasksBatch handles "POST /tasks/batch". Invalid operations fail the whole
+// batch unless it is partial, then they are reported with the failed ones.
+func (h *Handler) PostTasksBatch(w http.ResponseWriter, r *http.Request) {
+	currentUser := auth.MustUserFromContext(r.Context())
+
+	var req *timetrackapi.BatchTasksRequest
+	if err := apiutil.ReadJSON(r, &req); err != nil {
+		apiutil.MustWriteUnprocessableEntity(w, apiutil.ValidationError{"bad JSON"})
+		return
+	}
+	if len(req.Operations) == 0 || len(req.Operations) > maxBatchOperations {
+		msg := fmt.Sprintf("invalid operations, must have between 1 and %d items", maxBatchOperations)
+		apiutil.MustWriteUnprocessableEntity(w, apiutil.ValidationError{msg})
+		return
+	}
+	partial := req.Partial != nil && *req.Partial
+
+	results := make([]timetrackapi.BatchTaskResult, len(req.Operations))
+	ops := make([]BatchOperation, 0, len(req.Operations))
+	indexes := make([]int, 0, len(req.Operations))
+	invalid := make(apiutil.ValidationError, 0)
+	for i := range req.Operations {
+		op, ve := batchOperationFromRequest(&req.Operations[i])
+		if ve != nil {
+			for _, m := range ve {
+				invalid = append(invalid, fmt.Sprintf("operations[%d]: %s", i, m))
+			}
+			results[i] = timetrackapi.BatchTaskResult{
+				Status: http.StatusUnprocessableEntity,
+				Error:  stringPtr(ve.Error()),
+			}
+			continue
+		}
+		if h.requireIfMatch && op.Kind != BatchCreate && op.Version == nil {
+			msg := fmt.Sprintf("operations[%d]: missing version", i)
+			if !partial {
+				apiutil.MustWriteError(w, msg, http.StatusPreconditionRequired)
+				return
+			}
+			results[i] = timetrackapi.BatchTaskResult{Status: http.StatusPreconditionRequired, Error: stringPtr(msg)}
+			continue
+		}
+		ops = append(ops, *op)
+		indexes = append(indexes, i)
+	}
+
+	if !partial {
+		if len(invalid) > 0 {
+			apiutil.MustWriteUnprocessableEntity(w, invalid)
+			return
+		}
+		tasks, err := h.service.Batch(r.Context(), currentUser.ID, ops)
+		if err != nil {
+			var be *BatchError
+			if errors.As(err, &be) {
+				if code, msg, ok := taskError(be.Err); ok {
+					apiutil.MustWriteError(w, fmt.Sprintf("operations[%d]: %s", be.Index, msg), code)
+					return
+				}
+			}
+			apiutil.MustWriteInternalServerError(w, "failed to run batch", err)
+			return
+		}
+		for i := range tasks {
+			results[i] = timetrackapi.BatchTaskResult{Status: http.StatusOK, Task: toTaskResponse(&tasks[i])}
+		}
+		apiutil.MustWriteJSON(w, &timetrackapi.BatchTasksResponse{Results: results}, http.StatusOK)
+		return
+	}
+
+	batchResults, err := h.service.BatchPartial(r.Context(), currentUser.ID, ops)
+	if err != nil {
+		apiutil.MustWriteInternalServerError(w, "failed to run batch", err)
+		return
+	}
+	for j, res := range batchResults {
+		i := indexes[j]
+		if res.Err != nil {
+			code, msg, ok := taskError(res.Err)
+			if !ok {
+				slog.Error("failed to run batch operation", "index", i, "error", res.Err)
+				code, msg = http.StatusInternalServerError, "internal server error"
+			}
+			results[i] = timetrackapi.BatchTaskResult{Status: code, Error: &msg}
+			continue
+		}
+		results[i] = timetrackapi.BatchTaskResult{Status: http.StatusOK, Task: toTaskResponse(res.Task)}
+	}
+	apiutil.MustWriteJSON(w, &timetrackapi.BatchTasksResponse{Results: results}, http.StatusOK)
+}
+
+func batchOperationFromRequest(req *timetrackapi.BatchTaskOperation) (*BatchOperation, apiutil.ValidationError) {
+	op := &BatchOperation{Kind: BatchKind(req.Op), Children: ChildrenRestrict}
+	switch op.Kind {
+	case BatchCreate:
+		if req.Id != nil || req.Version != nil {
+			return nil, apiutil.ValidationError{"invalid id and version, must be omitted for create operations"}
+		}
+		if req.Create == nil {
+			return nil, apiutil.ValidationError{"missing create, required for create operations"}
+		}
+		create, ve := createTaskFromRequest(req.Create)
+		if ve != nil {
+			return nil, ve
+		}
+		op.Create = create
+		return op, nil
+	case BatchUpdate, BatchArchive, BatchDelete:
+	default:
+		return nil, apiutil.ValidationError{"invalid op, must be one of: create, update, archive, delete"}
+	}
+
+	if req.Id == nil {
+		return nil, apiutil.ValidationError{"missing id, required for update, archive, and delete operations"}
+	}
+	op.ID = *req.Id
+	op.Version = req.Version
+
+	if op.Kind == BatchUpdate {
+		if req.Update == nil {
+			return nil, apiutil.ValidationError{"missing update, required for update operations"}
+		}
+		update, ve := updateTaskFromRequest(op.ID, req.Update)
+		if ve != nil {
+			return nil, ve
+		}
+		update.Version = req.Version
+		op.Update = update
+		return op, nil
+	}
+
+	if req.Children != nil {
+		children, ok := parseChildrenMode(*req.Children)
+		if !ok {
+			return nil, apiutil.ValidationError{invalidChildrenMode}
+		}
+		op.Children = children
+	}
+	if req.Force != nil {
+		if op.Kind != BatchDelete {
+			return nil, apiutil.ValidationError{"invalid force, allowed for delete operations only"}
+		}
+		op.Force = *req.Force
+	}
+	return op, nil
+}
+
+// taskError returns the status code and the message the single task endpoints
+// respond with to the error. It reports false for unexpected errors.
+func taskError(err error) (int, string, bool) {
+	if ve, ok := fieldValidationError(err); ok {
+		return http.StatusUnprocessableEntity, ve.Error(), true
+	}
+	switch {
+	case errors.Is(err, ErrNotFound):
+		return http.StatusNotFound, "task not found", true
+	case errors.Is(err, ErrForbidden):
+		return http.StatusForbidden, "forbidden", true
+	case errors.Is(err, ErrProjectNotFound):
+		return http.StatusUnprocessableEntity, "project not found", true
+	case errors.Is(err, ErrAssigneeNotFound):
+		return http.StatusUnprocessableEntity, "assignee not found", true
+	case errors.Is(err, ErrTagNotFound):
+		return http.StatusUnprocessableEntity, "tag not found", true
+	case errors.Is(err, ErrParentNotFound):
+		return http.StatusUnprocessableEntity, "parent not found", true
+	case errors.Is(err, ErrParentCycle):
+		return http.StatusUnprocessableEntity, invalidParentID, true
+	case errors.Is(err, ErrInvalidStatus):
+		return http.StatusConflict, "task can't move to this status", true
+	case errors.Is(err, ErrHasInvoicedWorks):
+		return http.StatusConflict, "task has invoiced works", true
+	case errors.Is(err, ErrVersionMismatch):
+		return http.StatusPreconditionFailed, versionMismatchMessage, true
+	case errors.Is(err, ErrHasWorks):
+		return http.StatusConflict, hasWorksMessage, true
+	case errors.Is(err, ErrHasChildren):
+		return http.StatusConflict, "task has children", true
+	default:
+		return 0, "", false
+	}
+}
+
+// GetTasksIdProgress handles "GET /tasks/{id}/progress".
+//
+//nolint:revive
+func (h *Handler) GetTasksIdProgress(w http.ResponseWriter, r *http.Request, id int) {
+	currentUser := auth.MustUserFromContext(r.Context())
+
+	p, err := h.service.Progress(r.Context(), id, currentUser.ID)
 	if err != nil {
 		if errors.Is(err, ErrNotFound) {
 			apiutil.MustWriteError(w, "task not found", http.StatusNotFound)
 			return
 		}
-		apiutil.MustWriteInternalServerError(w, "failed to get task", err)
+		apiutil.MustWriteInternalServerError(w, "failed to get task progress", err)
 		return
 	}
 
-	resp := toTaskResponse(t)
-	apiutil.MustWriteJSON(w, resp, http.StatusOK)
+	apiutil.MustWriteJSON(w, toTaskProgressResponse(p), http.StatusOK)
 }
 
-// PatchTasksId handles "PATCH /tasks/{id}".
+// GetTasksIdComments handles "GET /tasks/{id}/comments".
 //
-// For simplicity, we don't use user in the task domain, but we do use them in
-// other domains.
+//nolint:revive
+func (h *Handler) GetTasksIdComments(
+	w http.ResponseWriter, r *http.Request, id int, params timetrackapi.GetTasksIdCommentsParams,
+) {
+	currentUser := auth.MustUserFromContext(r.Context())
+
+	page, ve := apiutil.ParsePage(r, params.Cursor, params.Offset, params.Limit)
+	if ve != nil {
+		apiutil.MustWriteUnprocessableEntity(w, ve)
+		return
+	}
+
+	comments, more, err := h.service.ListComments(r.Context(), id, currentUser.ID, page)
+	if err != nil {
+		if errors.Is(err, ErrNotFound) {
+			apiutil.MustWriteError(w, "task not found", http.StatusNotFound)
+			return
+		}
+		apiutil.MustWriteInternalServerError(w, "failed to list comments", err)
+		return
+	}
+
+	resp := make([]*timetrackapi.CommentResponse, 0, len(comments))
+	for _, c := range comments {
+		resp = append(resp, toCommentResponse(&c))
+	}
+	apiutil.SetPageLinks(w, r, page, comments, more, func(c *Comment) []any { return []any{c.ID} })
+	apiutil.MustWriteJSON(w, resp, http.StatusOK)
+}
+
+// PostTasksIdComments handles "POST /tasks/{id}/comments".
 //
 //nolint:revive
-func (h *Handler) PatchTasksId(w http.ResponseWriter, r *http.Request, id int) {
-	var req *timetrackapi.UpdateTaskRequest
+func (h *Handler) PostTasksIdComments(w http.ResponseWriter, r *http.Request, id int) {
+	currentUser := auth.MustUserFromContext(r.Context())
+
+	var req *timetrackapi.CreateCommentRequest
 	if err := apiutil.ReadJSON(r, &req); err != nil {
 		apiutil.MustWriteUnprocessableEntity(w, apiutil.ValidationError{"bad JSON"})
 		return
 	}
+	if strings.TrimSpace(req.Body) == "" {
+		apiutil.MustWriteUnprocessableEntity(w, apiutil.ValidationError{"missing body"})
+		return
+	}
 
-	update := &UpdateTask{Description: req.Description}
-	t, err := h.service.Update(r.Context(), id, update)
+	c, err := h.service.CreateComment(r.Context(), id, currentUser.ID, req.Body)
 	if err != nil {
 		if errors.Is(err, ErrNotFound) {
 			apiutil.MustWriteError(w, "task not found", http.StatusNotFound)
 			return
 		}
-		apiutil.MustWriteInternalServerError(w, "failed to update task", err)
+		apiutil.MustWriteInternalServerError(w, "failed to create comment", err)
 		return
 	}
 
-	apiutil.MustWriteJSON(w, toTaskResponse(t), http.StatusOK)
+	apiutil.MustWriteJSON(w, toCommentResponse(c), http.StatusOK)
 }
 
-// DeleteTasksId handles "DELETE /tasks/{id}".
+// PatchCommentsId handles "PATCH /comments/{id}".
 //
-// For simplicity, we don't use user in the task domain, but we do use them in
-// other domains.
+//nolint:revive
+func (h *Handler) PatchCommentsId(w http.ResponseWriter, r *http.Request, id int) {
+	currentUser := auth.MustUserFromContext(r.Context())
+
+	var req *timetrackapi.UpdateCommentRequest
+	if err := apiutil.ReadJSON(r, &req); err != nil {
+		apiutil.MustWriteUnprocessableEntity(w, apiutil.ValidationError{"bad JSON"})
+		return
+	}
+	if strings.TrimSpace(req.Body) == "" {
+		apiutil.MustWriteUnprocessableEntity(w, apiutil.ValidationError{"missing body"})
+		return
+	}
+
+	c, err := h.service.UpdateComment(r.Context(), id, currentUser.ID, req.Body)
+	if err != nil {
+		h.writeCommentError(w, "failed to update comment", err)
+		return
+	}
+
+	apiutil.MustWriteJSON(w, toCommentResponse(c), http.StatusOK)
+}
+
+// DeleteCommentsId handles "DELETE /comments/{id}".
 //
 //nolint:revive
-func (h *Handler) DeleteTasksId(w http.ResponseWriter, r *http.Request, id int) {
-	t, err := h.service.Delete(r.Context(), id)
+func (h *Handler) DeleteCommentsId(w http.ResponseWriter, r *http.Request, id int) {
+	currentUser := auth.MustUserFromContext(r.Context())
+
+	c, err := h.service.DeleteComment(r.Context(), id, currentUser.ID)
+	if err != nil {
+		h.writeCommentError(w, "failed to delete comment", err)
+		return
+	}
+
+	apiutil.MustWriteJSON(w, toCommentResponse(c), http.StatusOK)
+}
+
+func (h *Handler) writeCommentError(w http.ResponseWriter, msg string, err error) {
+	if errors.Is(err, ErrCommentNotFound) {
+		apiutil.MustWriteError(w, "comment not found", http.StatusNotFound)
+		return
+	}
+	if errors.Is(err, ErrForbidden) {
+		apiutil.MustWriteForbidden(w)
+		return
+	}
+	apiutil.MustWriteInternalServerError(w, msg, err)
+}
+
+// GetTasksIdActivity handles "GET /tasks/{id}/activity".
+//
+//nolint:revive
+func (h *Handler) GetTasksIdActivity(
+	w http.ResponseWriter, r *http.Request, id int, params timetrackapi.GetTasksIdActivityParams,
+) {
+	currentUser := auth.MustUserFromContext(r.Context())
+
+	page, ve := apiutil.ParsePage(r, params.Cursor, params.Offset, params.Limit)
+	if ve != nil {
+		apiutil.MustWriteUnprocessableEntity(w, ve)
+		return
+	}
+
+	activities, more, err := h.service.ListActivities(r.Context(), id, currentUser.ID, page)
 	if err != nil {
 		if errors.Is(err, ErrNotFound) {
 			apiutil.MustWriteError(w, "task not found", http.StatusNotFound)
 			return
 		}
-		apiutil.MustWriteInternalServerError(w, "failed to delete task", err)
+		apiutil.MustWriteInternalServerError(w, "failed to list task activity", err)
 		return
 	}
 
+	resp := make([]*timetrackapi.TaskActivityResponse, 0, len(activities))
+	for _, a := range activities {
+		resp = append(resp, &timetrackapi.TaskActivityResponse{
+			Id:        a.ID,
+			TaskId:    a.TaskID,
+			UserId:    a.UserID,
+			Kind:      timetrackapi.TaskActivityKind(a.Kind),
+			OldValue:  a.OldValue,
+			NewValue:  a.NewValue,
+			WorkId:    a.WorkID,
+			CreatedAt: a.CreatedAt,
+		})
+	}
+	apiutil.SetPageLinks(w, r, page, activities, more, func(a *Activity) []any { return []any{a.ID} })
+	apiutil.MustWriteJSON(w, resp, http.StatusOK)
+}
+
+// GetBudgetEvents handles "GET /budget-events/".
+func (h *Handler) GetBudgetEvents(w http.ResponseWriter, r *http.Request, params timetrackapi.GetBudgetEventsParams) {
+	currentUser := auth.MustUserFromContext(r.Context())
+
+	afterID, limit := 0, 50
+	if params.AfterId != nil {
+		afterID = *params.AfterId
+	}
+	if params.Limit != nil {
+		limit = *params.Limit
+	}
+	if limit < 1 || limit > 100 {
+		apiutil.MustWriteUnprocessableEntity(w, apiutil.ValidationError{"invalid limit, must be between 1 and 100"})
+		return
+	}
+
+	events, err := h.service.ListBudgetEvents(r.Context(), currentUser.ID, afterID, limit)
+	if err != nil {
+		apiutil.MustWriteInternalServerError(w, "failed to list budget events", err)
+		return
+	}
+
+	resp := make([]*timetrackapi.BudgetEventResponse, 0, len(events))
+	for _, e := range events {
+		resp = append(resp, &timetrackapi.BudgetEventResponse{
+			Id:           e.ID,
+			TaskId:       e.TaskID,
+			Threshold:    e.Threshold,
+			BudgetHours:  apiutil.FormatHours(e.Budget),
+			TrackedHours: apiutil.FormatHours(e.Tracked),
+			CreatedAt:    e.CreatedAt,
+		})
+	}
+	apiutil.MustWriteJSON(w, resp, http.StatusOK)
+}
+
+// mustWriteTask writes the task with its version as the ETag. The version
+// doesn't change with the works on the task or the client of its project, so
+// the fields computed from them are left out.
+func mustWriteTask(w http.ResponseWriter, t *Task) {
 	resp := toTaskResponse(t)
+	resp.ClientId, resp.TrackedHours, resp.OverBudget = nil, nil, nil
+	w.Header().Set("ETag", apiutil.VersionETag(t.Version))
 	apiutil.MustWriteJSON(w, resp, http.StatusOK)
 }
 
 func toTaskResponse(t *Task) *timetrackapi.TaskResponse {
-	return &timetrackapi.TaskResponse{
-		Id:          t.ID,
-		Description: t.Description,
+	assigneeIDs, tagIDs := t.AssigneeIDs, t.TagIDs
+	if assigneeIDs == nil {
+		assigneeIDs = make([]int, 0)
+	}
+	if tagIDs == nil {
+		tagIDs = make([]int, 0)
+	}
+	resp := &timetrackapi.TaskResponse{
+		Id:           t.ID,
+		Description:  t.Description,
+		Billable:     t.Billable,
+		ProjectId:    intPtr(t.ProjectID),
+		ClientId:     t.ClientID,
+		TrackedHours: stringPtr(apiutil.FormatHours(t.Tracked)),
+		OverBudget:   boolPtr(t.OverBudget()),
+		CreatedBy:    t.CreatedBy,
+		AssigneeIds:  &assigneeIDs,
+		Visibility:   (*timetrackapi.TaskVisibility)(stringPtr(string(t.Visibility))),
+		Status:       (*timetrackapi.TaskStatus)(stringPtr(string(t.Status))),
+		TagIds:       &tagIDs,
+		ParentId:     t.ParentID,
+		CustomFields: toFieldValueResponses(t.FieldValues),
+		DeletedAt:    t.DeletedAt,
+		Version:      &t.Version,
+	}
+	if t.Budget != nil {
+		resp.BudgetHours = stringPtr(apiutil.FormatHours(*t.Budget))
+	}
+	return resp
+}
+
+// toFieldValueResponses orders the values of the custom fields by field ID.
+func toFieldValueResponses(values map[int]string) *[]timetrackapi.TaskFieldValueResponse {
+	resp := make([]timetrackapi.TaskFieldValueResponse, 0, len(values))
+	for id, v := range values {
+		resp = append(resp, timetrackapi.TaskFieldValueResponse{FieldId: id, Value: v})
+	}
+	slices.SortFunc(resp, func(a, b timetrackapi.TaskFieldValueResponse) int { return cmp.Compare(a.FieldId, b.FieldId) })
+	return &resp
+}
+
+func toTaskProgressResponse(p *Progress) *timetrackapi.TaskProgressResponse {
+	resp := &timetrackapi.TaskProgressResponse{
+		Task:         *toTaskResponse(&p.Task),
+		TrackedHours: apiutil.FormatHours(p.Task.Tracked),
+		OverBudget:   p.Task.OverBudget(),
+		Users:        make([]timetrackapi.TaskUserProgressResponse, 0, len(p.Users)),
+	}
+	if b := p.Task.Budget; b != nil {
+		percent := decimal.NewFromInt(p.Task.Tracked.Milliseconds()).
+			Mul(decimal.NewFromInt(100)).
+			Div(decimal.NewFromInt(b.Milliseconds()))
+		resp.BudgetHours = stringPtr(apiutil.FormatHours(*b))
+		resp.RemainingHours = stringPtr(apiutil.FormatHours(*b - p.Task.Tracked))
+		resp.Percent = stringPtr(percent.StringFixed(2))
+	}
+	for _, u := range p.Users {
+		resp.Users = append(resp.Users, timetrackapi.TaskUserProgressResponse{
+			UserId:       u.UserID,
+			TrackedHours: apiutil.FormatHours(u.Tracked),
+		})
+	}
+	return resp
+}
+
+const invalidBudgetHours = "invalid budgetHours, must be a positive decimal number with at most 2 decimal places"
+
+// parseBudgetHours parses a positive decimal number of hours with at most 2
+// decimal places, which is a whole number of seconds.
+func parseBudgetHours(s string) (time.Duration, bool) {
+	hours, err := decimal.NewFromString(s)
+	if err != nil || !hours.IsPositive() || hours.Exponent() < -2 {
+		return 0, false
+	}
+	return time.Duration(hours.Mul(decimal.NewFromInt(3600)).IntPart()) * time.Second, true
+}
+
+const invalidVisibility = "invalid visibility, must be one of: private, project, everyone"
+
+func parseVisibility(v timetrackapi.TaskVisibility) (Visibility, bool) {
+	switch Visibility(v) {
+	case VisibilityPrivate, VisibilityProject, VisibilityEveryone:
+		return Visibility(v), true
+	default:
+		return "", false
+	}
+}
+
+const invalidStatus = "invalid status, must be one of: open, in_progress, done, archived"
+
+// archivedStatus is the error message of archiving a task by updating its
+// status, which must be done by archiving it, so that its children are handled.
+const archivedStatus = "invalid status, archive the task with DELETE /tasks/{id} or the archive operation instead"
+
+func parseStatus(st timetrackapi.TaskStatus) (Status, bool) {
+	switch Status(st) {
+	case StatusOpen, StatusInProgress, StatusDone, StatusArchived:
+		return Status(st), true
+	default:
+		return "", false
+	}
+}
+
+func toCommentResponse(c *Comment) *timetrackapi.CommentResponse {
+	return &timetrackapi.CommentResponse{
+		Id:        c.ID,
+		TaskId:    c.TaskID,
+		UserId:    c.UserID,
+		Body:      c.Body,
+		CreatedAt: c.CreatedAt,
+		UpdatedAt: c.UpdatedAt,
+	}
+}
+
+func toStatuses(sts *[]timetrackapi.TaskStatus) []Status {
+	if sts == nil {
+		return nil
+	}
+	statuses := make([]Status, 0, len(*sts))
+	for _, st := range *sts {
+		statuses = append(statuses, Status(st))
+	}
+	return statuses
+}
+
+const invalidCustomFields = "invalid customFields, must not repeat a field"
+
+// fieldValuesFromRequest returns false if a custom field repeats.
+func fieldValuesFromRequest(req []timetrackapi.TaskFieldValueRequest) ([]FieldValue, bool) {
+	values := make([]FieldValue, 0, len(req))
+	seen := make(map[int]bool, len(req))
+	for _, v := range req {
+		if seen[v.FieldId] {
+			return nil, false
+		}
+		seen[v.FieldId] = true
+		values = append(values, FieldValue{FieldID: v.FieldId, Value: v.Value})
+	}
+	return values, true
+}
+
+// fieldValidationError describes an unknown custom field or a value that
+// doesn't match the type of its field.
+func fieldValidationError(err error) (apiutil.ValidationError, bool) {
+	if errors.Is(err, ErrFieldNotFound) {
+		return apiutil.ValidationError{"custom field not found"}, true
+	}
+	var ve *field.ValueError
+	if errors.As(err, &ve) {
+		return apiutil.ValidationError{ve.Error()}, true
+	}
+	return nil, false
+}
+
+const invalidParentID = "invalid parentId, must not be the task or one of its descendants"
+
+const invalidChildrenMode = "invalid children, must be one of: restrict, cascade, detach"
+
+func parseChildrenMode(m timetrackapi.TaskChildrenMode) (ChildrenMode, bool) {
+	switch ChildrenMode(m) {
+	case ChildrenRestrict, ChildrenCascade, ChildrenDetach:
+		return ChildrenMode(m), true
+	default:
+		return "", false
 	}
 }
 
 func intPtr(i int) *int {
 	return &i
 }
+
+func stringPtr(s string) *string {
+	return &s
+}
+
+func boolPtr(b bool) *bool {
+	return &b
+}
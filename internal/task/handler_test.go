@@ -0,0 +1,938 @@
+package task
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kirillgashkov/timetrack/api/timetrackapi/v1"
+	"github.com/kirillgashkov/timetrack/internal/app/database"
+	"github.com/kirillgashkov/timetrack/internal/auth"
+	"github.com/kirillgashkov/timetrack/internal/field"
+)
+
+type ServiceMock struct {
+	CreateFunc  func(ctx context.Context, userID int, create *CreateTask) (*Task, error)
+	UpdateFunc  func(ctx context.Context, id, userID int, update *UpdateTask) (*Task, error)
+	ArchiveFunc func(ctx context.Context, id, userID int, children ChildrenMode, version *int) (*Task, error)
+	DeleteFunc  func(ctx context.Context, id, userID int, children ChildrenMode, force bool, version *int) (*Task, error)
+	RestoreFunc func(ctx context.Context, id, userID int) (*Task, error)
+	ListFunc    func(ctx context.Context, userID int, filter *FilterTask, page *database.Page) ([]Task, bool, error)
+
+	BatchFunc        func(ctx context.Context, userID int, ops []BatchOperation) ([]Task, error)
+	BatchPartialFunc func(ctx context.Context, userID int, ops []BatchOperation) ([]BatchResult, error)
+
+	UpdateCommentFunc func(ctx context.Context, id, userID int, body string) (*Comment, error)
+	DeleteCommentFunc func(ctx context.Context, id, userID int) (*Comment, error)
+}
+
+func (s *ServiceMock) Create(ctx context.Context, userID int, create *CreateTask) (*Task, error) {
+	return s.CreateFunc(ctx, userID, create)
+}
+
+func (s *ServiceMock) Get(context.Context, int, int) (*Task, error) {
+	panic("not implemented")
+}
+
+func (s *ServiceMock) List(
+	ctx context.Context, userID int, filter *FilterTask, page *database.Page,
+) ([]Task, bool, error) {
+	return s.ListFunc(ctx, userID, filter, page)
+}
+
+func (s *ServiceMock) Search(context.Context, int, string, *FilterTask, *database.Page) ([]SearchResult, bool, error) {
+	panic("not implemented")
+}
+
+func (s *ServiceMock) Update(ctx context.Context, id, userID int, update *UpdateTask) (*Task, error) {
+	return s.UpdateFunc(ctx, id, userID, update)
+}
+
+func (s *ServiceMock) Archive(ctx context.Context, id, userID int, children ChildrenMode, version *int) (*Task, error) {
+	return s.ArchiveFunc(ctx, id, userID, children, version)
+}
+
+func (s *ServiceMock) Delete(
+	ctx context.Context, id, userID int, children ChildrenMode, force bool, version *int,
+) (*Task, error) {
+	return s.DeleteFunc(ctx, id, userID, children, force, version)
+}
+
+func (s *ServiceMock) Restore(ctx context.Context, id, userID int) (*Task, error) {
+	return s.RestoreFunc(ctx, id, userID)
+}
+
+func (s *ServiceMock) Batch(ctx context.Context, userID int, ops []BatchOperation) ([]Task, error) {
+	return s.BatchFunc(ctx, userID, ops)
+}
+
+func (s *ServiceMock) BatchPartial(ctx context.Context, userID int, ops []BatchOperation) ([]BatchResult, error) {
+	return s.BatchPartialFunc(ctx, userID, ops)
+}
+
+func (s *ServiceMock) Progress(context.Context, int, int) (*Progress, error) {
+	panic("not implemented")
+}
+
+func (s *ServiceMock) ListBudgetEvents(context.Context, int, int, int) ([]BudgetEvent, error) {
+	panic("not implemented")
+}
+
+func (s *ServiceMock) ListComments(context.Context, int, int, *database.Page) ([]Comment, bool, error) {
+	panic("not implemented")
+}
+
+func (s *ServiceMock) CreateComment(context.Context, int, int, string) (*Comment, error) {
+	panic("not implemented")
+}
+
+func (s *ServiceMock) UpdateComment(ctx context.Context, id, userID int, body string) (*Comment, error) {
+	return s.UpdateCommentFunc(ctx, id, userID, body)
+}
+
+func (s *ServiceMock) DeleteComment(ctx context.Context, id, userID int) (*Comment, error) {
+	return s.DeleteCommentFunc(ctx, id, userID)
+}
+
+func (s *ServiceMock) ListActivities(context.Context, int, int, *database.Page) ([]Activity, bool, error) {
+	panic("not implemented")
+}
+
+func newRequest(method, target, body string) *http.Request {
+	req := httptest.NewRequest(method, target, bytes.NewBufferString(body))
+	return req.WithContext(auth.ContextWithUser(req.Context(), &auth.User{ID: 1}))
+}
+
+func newTestHandler(service Service) *Handler {
+	return NewHandler(service, false)
+}
+
+func TestParseBudgetHours(t *testing.T) {
+	tests := []struct {
+		s      string
+		want   time.Duration
+		wantOK bool
+	}{
+		{"40", 40 * time.Hour, true},
+		{"0.01", 36 * time.Second, true},
+		{"7.5", 7*time.Hour + 30*time.Minute, true},
+		{"0", 0, false},
+		{"-1", 0, false},
+		{"1.005", 0, false},
+		{"forty", 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.s, func(t *testing.T) {
+			got, ok := parseBudgetHours(tt.s)
+			if got != tt.want || ok != tt.wantOK {
+				t.Errorf("expected %v, %t, got %v, %t", tt.want, tt.wantOK, got, ok)
+			}
+		})
+	}
+}
+
+func TestToTaskProgressResponse(t *testing.T) {
+	budget := 10 * time.Hour
+	p := &Progress{
+		Task: Task{ID: 1, Description: "Write docs", Budget: &budget, Tracked: 10*time.Hour + 45*time.Minute},
+		Users: []UserProgress{
+			{UserID: 1, Tracked: 8 * time.Hour},
+			{UserID: 2, Tracked: 2*time.Hour + 45*time.Minute},
+		},
+	}
+
+	resp := toTaskProgressResponse(p)
+	if !resp.OverBudget || !*resp.Task.OverBudget {
+		t.Error("expected over budget")
+	}
+	if *resp.BudgetHours != "10.00" || resp.TrackedHours != "10.75" {
+		t.Errorf("expected 10.75 of 10.00 hours, got %s of %s", resp.TrackedHours, *resp.BudgetHours)
+	}
+	if *resp.RemainingHours != "-0.75" || *resp.Percent != "107.50" {
+		t.Errorf("expected -0.75 hours remaining and 107.50%%, got %s and %s", *resp.RemainingHours, *resp.Percent)
+	}
+	if len(resp.Users) != 2 || resp.Users[1].TrackedHours != "2.75" {
+		t.Errorf("unexpected users %+v", resp.Users)
+	}
+
+	p.Task.Budget = nil
+	resp = toTaskProgressResponse(p)
+	if resp.OverBudget || resp.BudgetHours != nil || resp.Percent != nil {
+		t.Errorf("expected no budget, got %+v", resp)
+	}
+}
+
+func TestPostTasks(t *testing.T) {
+	tests := []struct {
+		name               string
+		body               string
+		expectedVisibility Visibility
+		expectedStatusCode int
+	}{
+		{"defaults", `{"description":"Write docs"}`, VisibilityEveryone, http.StatusOK},
+		{"private", `{"description":"Write docs","visibility":"private"}`, VisibilityPrivate, http.StatusOK},
+		{"invalid visibility", `{"description":"Write docs","visibility":"team"}`, "", http.StatusUnprocessableEntity},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := newTestHandler(&ServiceMock{
+				CreateFunc: func(_ context.Context, userID int, create *CreateTask) (*Task, error) {
+					if create.Visibility != tt.expectedVisibility {
+						t.Errorf("expected visibility %q, got %q", tt.expectedVisibility, create.Visibility)
+					}
+					return &Task{ID: 1, Description: create.Description, CreatedBy: &userID}, nil
+				},
+			})
+
+			w := httptest.NewRecorder()
+			handler.PostTasks(w, newRequest(http.MethodPost, "/tasks/", tt.body))
+
+			if w.Code != tt.expectedStatusCode {
+				t.Errorf("expected status code %d, got %d: %s", tt.expectedStatusCode, w.Code, w.Body.String())
+			}
+		})
+	}
+}
+
+func TestTaskAccess(t *testing.T) {
+	tests := []struct {
+		name               string
+		err                error
+		expectedStatusCode int
+	}{
+		{"allowed", nil, http.StatusOK},
+		{"forbidden", ErrForbidden, http.StatusForbidden},
+		{"not visible", ErrNotFound, http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := newTestHandler(&ServiceMock{
+				UpdateFunc: func(_ context.Context, id, _ int, _ *UpdateTask) (*Task, error) {
+					if tt.err != nil {
+						return nil, tt.err
+					}
+					return &Task{ID: id}, nil
+				},
+				DeleteFunc: func(_ context.Context, id, _ int, _ ChildrenMode, _ bool, _ *int) (*Task, error) {
+					if tt.err != nil {
+						return nil, tt.err
+					}
+					return &Task{ID: id}, nil
+				},
+			})
+			permanent := true
+
+			w := httptest.NewRecorder()
+			req := newRequest(http.MethodPatch, "/tasks/2", `{"assigneeIds":[]}`)
+			handler.PatchTasksId(w, req, 2, timetrackapi.PatchTasksIdParams{})
+			if w.Code != tt.expectedStatusCode {
+				t.Errorf("expected PATCH status code %d, got %d", tt.expectedStatusCode, w.Code)
+			}
+
+			w = httptest.NewRecorder()
+			req = newRequest(http.MethodDelete, "/tasks/2?permanent=true", "")
+			handler.DeleteTasksId(w, req, 2, timetrackapi.DeleteTasksIdParams{Permanent: &permanent})
+			if w.Code != tt.expectedStatusCode {
+				t.Errorf("expected DELETE status code %d, got %d", tt.expectedStatusCode, w.Code)
+			}
+		})
+	}
+}
+
+func TestDeleteTasksIdArchives(t *testing.T) {
+	var archived, deleted bool
+	handler := newTestHandler(&ServiceMock{
+		ArchiveFunc: func(_ context.Context, id, _ int, _ ChildrenMode, _ *int) (*Task, error) {
+			archived = true
+			return &Task{ID: id, Status: StatusArchived}, nil
+		},
+		DeleteFunc: func(_ context.Context, id, _ int, _ ChildrenMode, _ bool, _ *int) (*Task, error) {
+			deleted = true
+			return &Task{ID: id}, nil
+		},
+	})
+
+	w := httptest.NewRecorder()
+	handler.DeleteTasksId(w, newRequest(http.MethodDelete, "/tasks/2", ""), 2, timetrackapi.DeleteTasksIdParams{})
+
+	if w.Code != http.StatusOK || !archived || deleted {
+		t.Errorf("expected task to be archived, got status code %d, archived %t, deleted %t", w.Code, archived, deleted)
+	}
+}
+
+func TestDeleteTasksIdForceWithoutPermanent(t *testing.T) {
+	handler := newTestHandler(&ServiceMock{})
+
+	w := httptest.NewRecorder()
+	req := newRequest(http.MethodDelete, "/tasks/2?force=true", "")
+	handler.DeleteTasksId(w, req, 2, timetrackapi.DeleteTasksIdParams{Force: boolPtr(true)})
+
+	if w.Code != http.StatusUnprocessableEntity {
+		t.Errorf("expected status code %d, got %d: %s", http.StatusUnprocessableEntity, w.Code, w.Body.String())
+	}
+}
+
+func TestPatchTasksIdIfMatch(t *testing.T) {
+	tests := []struct {
+		name               string
+		ifMatch            *string
+		expectedVersion    *int
+		expectedStatusCode int
+		expectedETag       string
+	}{
+		{"no header", nil, nil, http.StatusOK, `"4"`},
+		{"any version", stringPtr("*"), nil, http.StatusOK, `"4"`},
+		{"current version", stringPtr(`"3"`), intPtr(3), http.StatusOK, `"4"`},
+		{"old version", stringPtr(`"2"`), intPtr(2), http.StatusPreconditionFailed, ""},
+		{"weak ETag", stringPtr(`W/"3"`), nil, http.StatusUnprocessableEntity, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := newTestHandler(&ServiceMock{
+				UpdateFunc: func(_ context.Context, id, _ int, update *UpdateTask) (*Task, error) {
+					if !reflect.DeepEqual(update.Version, tt.expectedVersion) {
+						t.Errorf("expected version %v, got %v", tt.expectedVersion, update.Version)
+					}
+					if update.Version != nil && *update.Version != 3 {
+						return nil, ErrVersionMismatch
+					}
+					return &Task{ID: id, Description: *update.Description, Version: 4}, nil
+				},
+			})
+
+			w := httptest.NewRecorder()
+			req := newRequest(http.MethodPatch, "/tasks/2", `{"description":"Write docs"}`)
+			handler.PatchTasksId(w, req, 2, timetrackapi.PatchTasksIdParams{IfMatch: tt.ifMatch})
+
+			if w.Code != tt.expectedStatusCode {
+				t.Errorf("expected status code %d, got %d: %s", tt.expectedStatusCode, w.Code, w.Body.String())
+			}
+			if etag := w.Header().Get("ETag"); etag != tt.expectedETag {
+				t.Errorf("expected ETag %q, got %q", tt.expectedETag, etag)
+			}
+		})
+	}
+}
+
+func TestPatchTasksIdErrors(t *testing.T) {
+	tests := []struct {
+		err                error
+		expectedStatusCode int
+	}{
+		{ErrNotFound, http.StatusNotFound},
+		{ErrForbidden, http.StatusForbidden},
+		{ErrProjectNotFound, http.StatusUnprocessableEntity},
+		{ErrParentCycle, http.StatusUnprocessableEntity},
+		{ErrInvalidStatus, http.StatusConflict},
+		{ErrHasChildren, http.StatusConflict},
+		{ErrVersionMismatch, http.StatusPreconditionFailed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.err.Error(), func(t *testing.T) {
+			handler := newTestHandler(&ServiceMock{
+				UpdateFunc: func(context.Context, int, int, *UpdateTask) (*Task, error) {
+					return nil, tt.err
+				},
+			})
+
+			w := httptest.NewRecorder()
+			req := newRequest(http.MethodPatch, "/tasks/2", `{"description":"Write docs"}`)
+			handler.PatchTasksId(w, req, 2, timetrackapi.PatchTasksIdParams{})
+
+			if w.Code != tt.expectedStatusCode {
+				t.Errorf("expected status code %d, got %d: %s", tt.expectedStatusCode, w.Code, w.Body.String())
+			}
+		})
+	}
+}
+
+func TestPatchTasksIdComputedFields(t *testing.T) {
+	budget := 10 * time.Hour
+	handler := newTestHandler(&ServiceMock{
+		UpdateFunc: func(_ context.Context, id, _ int, update *UpdateTask) (*Task, error) {
+			clientID := 5
+			return &Task{
+				ID: id, Description: *update.Description, ClientID: &clientID, Budget: &budget, Tracked: 11 * time.Hour,
+				Version: 4,
+			}, nil
+		},
+	})
+
+	w := httptest.NewRecorder()
+	req := newRequest(http.MethodPatch, "/tasks/2", `{"description":"Write docs"}`)
+	handler.PatchTasksId(w, req, 2, timetrackapi.PatchTasksIdParams{})
+
+	var resp map[string]any
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("failed to unmarshal response: %v", err)
+	}
+	for _, key := range []string{"clientId", "trackedHours", "overBudget"} {
+		if _, ok := resp[key]; ok {
+			t.Errorf("expected no %s in a response with an ETag, got %v", key, resp[key])
+		}
+	}
+	if resp["budgetHours"] != "10.00" {
+		t.Errorf("expected budgetHours 10.00, got %v", resp["budgetHours"])
+	}
+}
+
+func TestDeleteTasksIdWorks(t *testing.T) {
+	tests := []struct {
+		name               string
+		force              *bool
+		err                error
+		expectedForce      bool
+		expectedStatusCode int
+	}{
+		{"no works", nil, nil, false, http.StatusOK},
+		{"works", nil, ErrHasWorks, false, http.StatusConflict},
+		{"forced", boolPtr(true), nil, true, http.StatusOK},
+		{"forced by user", boolPtr(true), ErrForbidden, true, http.StatusForbidden},
+		{"invoiced works", boolPtr(true), ErrHasInvoicedWorks, true, http.StatusConflict},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := newTestHandler(&ServiceMock{
+				DeleteFunc: func(_ context.Context, id, _ int, _ ChildrenMode, force bool, _ *int) (*Task, error) {
+					if force != tt.expectedForce {
+						t.Errorf("expected force %t, got %t", tt.expectedForce, force)
+					}
+					if tt.err != nil {
+						return nil, tt.err
+					}
+					deletedAt := time.Now()
+					return &Task{ID: id, DeletedAt: &deletedAt}, nil
+				},
+			})
+
+			w := httptest.NewRecorder()
+			req := newRequest(http.MethodDelete, "/tasks/2?permanent=true", "")
+			params := timetrackapi.DeleteTasksIdParams{Permanent: boolPtr(true), Force: tt.force}
+			handler.DeleteTasksId(w, req, 2, params)
+
+			if w.Code != tt.expectedStatusCode {
+				t.Errorf("expected status code %d, got %d: %s", tt.expectedStatusCode, w.Code, w.Body.String())
+			}
+		})
+	}
+}
+
+func TestPostTasksIdRestore(t *testing.T) {
+	tests := []struct {
+		name               string
+		err                error
+		expectedStatusCode int
+	}{
+		{"restored", nil, http.StatusOK},
+		{"not in trash", ErrNotFound, http.StatusNotFound},
+		{"parent in trash", ErrParentDeleted, http.StatusConflict},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := newTestHandler(&ServiceMock{
+				RestoreFunc: func(_ context.Context, id, _ int) (*Task, error) {
+					if tt.err != nil {
+						return nil, tt.err
+					}
+					return &Task{ID: id}, nil
+				},
+			})
+
+			w := httptest.NewRecorder()
+			handler.PostTasksIdRestore(w, newRequest(http.MethodPost, "/tasks/2/restore", ""), 2)
+
+			if w.Code != tt.expectedStatusCode {
+				t.Errorf("expected status code %d, got %d: %s", tt.expectedStatusCode, w.Code, w.Body.String())
+			}
+		})
+	}
+}
+
+func TestGetTasksCursor(t *testing.T) {
+	handler := newTestHandler(&ServiceMock{
+		ListFunc: func(_ context.Context, _ int, _ *FilterTask, page *database.Page) ([]Task, bool, error) {
+			// Tasks 1 to 5 selected like database.Page.Keyset does.
+			var key int
+			if len(page.Key) > 0 {
+				key, _ = page.Key[0].(int)
+			}
+			tasks := make([]Task, 0)
+			for id := 1; id <= 5; id++ {
+				if len(page.Key) == 0 || (!page.Backward && id > key) || (page.Backward && id < key) {
+					tasks = append(tasks, Task{ID: id})
+				}
+			}
+			if page.Backward {
+				slices.Reverse(tasks)
+			}
+			tasks = tasks[min(page.Offset, len(tasks)):min(page.Offset+page.Limit+1, len(tasks))]
+			tasks, more := database.PageItems(page, tasks)
+			return tasks, more, nil
+		},
+	})
+
+	get := func(target string) (*httptest.ResponseRecorder, []int) {
+		req := newRequest(http.MethodGet, target, "")
+		params := timetrackapi.GetTasksParams{}
+		q := req.URL.Query()
+		if q.Has("cursor") {
+			params.Cursor = stringPtr(q.Get("cursor"))
+		}
+		if q.Has("offset") {
+			params.Offset = intPtr(2)
+		}
+		params.Limit = intPtr(2)
+
+		w := httptest.NewRecorder()
+		handler.GetTasks(w, req, params)
+
+		var resp []timetrackapi.TaskResponse
+		_ = json.Unmarshal(w.Body.Bytes(), &resp)
+		ids := make([]int, 0, len(resp))
+		for _, tr := range resp {
+			ids = append(ids, tr.Id)
+		}
+		return w, ids
+	}
+	link := func(w *httptest.ResponseRecorder, rel string) string {
+		for _, l := range strings.Split(w.Header().Get("Link"), ", ") {
+			if target, ok := strings.CutSuffix(l, `>; rel="`+rel+`"`); ok {
+				return strings.TrimPrefix(target, "<")
+			}
+		}
+		return ""
+	}
+
+	w, ids := get("/tasks/?limit=2")
+	if !slices.Equal(ids, []int{1, 2}) || link(w, "prev") != "" {
+		t.Fatalf("expected first page [1 2] without prev, got %v, Link %q", ids, w.Header().Get("Link"))
+	}
+	w, ids = get(link(w, "next"))
+	if !slices.Equal(ids, []int{3, 4}) {
+		t.Fatalf("expected second page [3 4], got %v", ids)
+	}
+	next := link(w, "next")
+	w, ids = get(link(w, "prev"))
+	if !slices.Equal(ids, []int{1, 2}) || link(w, "prev") != "" {
+		t.Fatalf("expected prev page [1 2] without prev, got %v, Link %q", ids, w.Header().Get("Link"))
+	}
+	w, ids = get(next)
+	if !slices.Equal(ids, []int{5}) || link(w, "next") != "" {
+		t.Fatalf("expected last page [5] without next, got %v, Link %q", ids, w.Header().Get("Link"))
+	}
+	w, ids = get("/tasks/?offset=2&limit=2")
+	if !slices.Equal(ids, []int{3, 4}) || link(w, "prev") == "" || link(w, "next") == "" {
+		t.Fatalf("expected offset page [3 4] with links, got %v, Link %q", ids, w.Header().Get("Link"))
+	}
+
+	cursor := strings.TrimPrefix(next, "/tasks/?cursor=")
+	cursor = strings.TrimSuffix(cursor, "&limit=2")
+	invalid := []string{
+		"/tasks/?cursor=" + cursor + "&offset=2",
+		"/tasks/?cursor=" + cursor + "&status=done",
+		"/tasks/?cursor=" + cursor[:len(cursor)-1],
+		"/tasks/?cursor=invalid",
+	}
+	for _, target := range invalid {
+		if w, _ = get(target); w.Code != http.StatusUnprocessableEntity {
+			t.Errorf("%s: expected status code %d, got %d", target, http.StatusUnprocessableEntity, w.Code)
+		}
+	}
+}
+
+func TestPatchTasksIdStatus(t *testing.T) {
+	tests := []struct {
+		name               string
+		body               string
+		err                error
+		expectedStatusCode int
+	}{
+		{"done", `{"status":"done"}`, nil, http.StatusOK},
+		{"invalid transition", `{"status":"done"}`, ErrInvalidStatus, http.StatusConflict},
+		{"unknown status", `{"status":"closed"}`, nil, http.StatusUnprocessableEntity},
+		{"archived", `{"status":"archived"}`, nil, http.StatusUnprocessableEntity},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := newTestHandler(&ServiceMock{
+				UpdateFunc: func(_ context.Context, id, _ int, update *UpdateTask) (*Task, error) {
+					if tt.err != nil {
+						return nil, tt.err
+					}
+					return &Task{ID: id, Status: *update.Status}, nil
+				},
+			})
+
+			w := httptest.NewRecorder()
+			handler.PatchTasksId(w, newRequest(http.MethodPatch, "/tasks/2", tt.body), 2, timetrackapi.PatchTasksIdParams{})
+
+			if w.Code != tt.expectedStatusCode {
+				t.Errorf("expected status code %d, got %d: %s", tt.expectedStatusCode, w.Code, w.Body.String())
+			}
+		})
+	}
+}
+
+func TestPatchTasksIdParent(t *testing.T) {
+	tests := []struct {
+		name               string
+		body               string
+		err                error
+		expectedParentID   *int
+		expectedStatusCode int
+	}{
+		{"set parent", `{"parentId":3}`, nil, intPtr(3), http.StatusOK},
+		{"remove parent", `{"parentIdNull":true}`, nil, nil, http.StatusOK},
+		{"itself", `{"parentId":2}`, nil, nil, http.StatusUnprocessableEntity},
+		{"descendant", `{"parentId":4}`, ErrParentCycle, intPtr(4), http.StatusUnprocessableEntity},
+		{"parent not found", `{"parentId":9}`, ErrParentNotFound, intPtr(9), http.StatusUnprocessableEntity},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := newTestHandler(&ServiceMock{
+				UpdateFunc: func(_ context.Context, id, _ int, update *UpdateTask) (*Task, error) {
+					if update.ParentID == nil {
+						t.Fatal("expected parent to be updated")
+					}
+					if update.ParentID.Valid != (tt.expectedParentID != nil) ||
+						update.ParentID.Valid && update.ParentID.V != *tt.expectedParentID {
+						t.Errorf("expected parent %v, got %+v", tt.expectedParentID, *update.ParentID)
+					}
+					if tt.err != nil {
+						return nil, tt.err
+					}
+					return &Task{ID: id}, nil
+				},
+			})
+
+			w := httptest.NewRecorder()
+			handler.PatchTasksId(w, newRequest(http.MethodPatch, "/tasks/2", tt.body), 2, timetrackapi.PatchTasksIdParams{})
+
+			if w.Code != tt.expectedStatusCode {
+				t.Errorf("expected status code %d, got %d: %s", tt.expectedStatusCode, w.Code, w.Body.String())
+			}
+		})
+	}
+}
+
+func TestDeleteTasksIdChildren(t *testing.T) {
+	cascade := timetrackapi.Cascade
+	unknown := timetrackapi.TaskChildrenMode("orphan")
+
+	tests := []struct {
+		name               string
+		children           *timetrackapi.TaskChildrenMode
+		err                error
+		expectedChildren   ChildrenMode
+		expectedStatusCode int
+	}{
+		{"restrict by default", nil, nil, ChildrenRestrict, http.StatusOK},
+		{"has children", nil, ErrHasChildren, ChildrenRestrict, http.StatusConflict},
+		{"cascade", &cascade, nil, ChildrenCascade, http.StatusOK},
+		{"cascade forbidden", &cascade, ErrForbidden, ChildrenCascade, http.StatusForbidden},
+		{"unknown mode", &unknown, nil, "", http.StatusUnprocessableEntity},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := newTestHandler(&ServiceMock{
+				ArchiveFunc: func(_ context.Context, id, _ int, children ChildrenMode, _ *int) (*Task, error) {
+					if children != tt.expectedChildren {
+						t.Errorf("expected children mode %q, got %q", tt.expectedChildren, children)
+					}
+					if tt.err != nil {
+						return nil, tt.err
+					}
+					return &Task{ID: id, Status: StatusArchived}, nil
+				},
+			})
+
+			w := httptest.NewRecorder()
+			params := timetrackapi.DeleteTasksIdParams{Children: tt.children}
+			handler.DeleteTasksId(w, newRequest(http.MethodDelete, "/tasks/2", ""), 2, params)
+
+			if w.Code != tt.expectedStatusCode {
+				t.Errorf("expected status code %d, got %d: %s", tt.expectedStatusCode, w.Code, w.Body.String())
+			}
+		})
+	}
+}
+
+func TestCommentAccess(t *testing.T) {
+	tests := []struct {
+		name               string
+		body               string
+		err                error
+		expectedStatusCode int
+	}{
+		{"own", `{"body":"Done"}`, nil, http.StatusOK},
+		{"someone else's", `{"body":"Done"}`, ErrForbidden, http.StatusForbidden},
+		{"not visible", `{"body":"Done"}`, ErrCommentNotFound, http.StatusNotFound},
+		{"blank body", `{"body":"  "}`, nil, http.StatusUnprocessableEntity},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := newTestHandler(&ServiceMock{
+				UpdateCommentFunc: func(_ context.Context, id, userID int, body string) (*Comment, error) {
+					if tt.err != nil {
+						return nil, tt.err
+					}
+					return &Comment{ID: id, UserID: &userID, Body: body}, nil
+				},
+				DeleteCommentFunc: func(_ context.Context, id, userID int) (*Comment, error) {
+					if tt.err != nil {
+						return nil, tt.err
+					}
+					return &Comment{ID: id, UserID: &userID}, nil
+				},
+			})
+
+			w := httptest.NewRecorder()
+			handler.PatchCommentsId(w, newRequest(http.MethodPatch, "/comments/3", tt.body), 3)
+			if w.Code != tt.expectedStatusCode {
+				t.Errorf("expected PATCH status code %d, got %d", tt.expectedStatusCode, w.Code)
+			}
+
+			if tt.expectedStatusCode == http.StatusUnprocessableEntity {
+				return
+			}
+			w = httptest.NewRecorder()
+			handler.DeleteCommentsId(w, newRequest(http.MethodDelete, "/comments/3", ""), 3)
+			if w.Code != tt.expectedStatusCode {
+				t.Errorf("expected DELETE status code %d, got %d", tt.expectedStatusCode, w.Code)
+			}
+		})
+	}
+}
+
+func TestPatchTasksIdCustomFields(t *testing.T) {
+	tests := []struct {
+		name               string
+		body               string
+		err                error
+		expectedValues     int
+		expectedStatusCode int
+	}{
+		{"set and remove", `{"customFields":[{"fieldId":1,"value":"high"},{"fieldId":2}]}`, nil, 2, http.StatusOK},
+		{"repeated field", `{"customFields":[{"fieldId":1,"value":"high"},{"fieldId":1}]}`, nil, 0,
+			http.StatusUnprocessableEntity},
+		{"unknown field", `{"customFields":[{"fieldId":3,"value":"high"}]}`, ErrFieldNotFound, 1,
+			http.StatusUnprocessableEntity},
+		{"invalid value", `{"customFields":[{"fieldId":1,"value":"urgent"}]}`,
+			&field.ValueError{Field: "Priority", Reason: "must be one of high, low"}, 1, http.StatusUnprocessableEntity},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := newTestHandler(&ServiceMock{
+				UpdateFunc: func(_ context.Context, id, _ int, update *UpdateTask) (*Task, error) {
+					if len(update.FieldValues) != tt.expectedValues {
+						t.Errorf("expected %d values, got %+v", tt.expectedValues, update.FieldValues)
+					}
+					if tt.err != nil {
+						return nil, tt.err
+					}
+					return &Task{ID: id, FieldValues: map[int]string{1: "high"}}, nil
+				},
+			})
+
+			w := httptest.NewRecorder()
+			handler.PatchTasksId(w, newRequest(http.MethodPatch, "/tasks/2", tt.body), 2, timetrackapi.PatchTasksIdParams{})
+
+			if w.Code != tt.expectedStatusCode {
+				t.Errorf("expected status code %d, got %d: %s", tt.expectedStatusCode, w.Code, w.Body.String())
+			}
+		})
+	}
+}
+
+func TestPostTasksBatch(t *testing.T) {
+	tests := []struct {
+		name               string
+		body               string
+		failAt             int
+		failErr            error
+		expectedStatusCode int
+		expectedStatuses   []int
+	}{
+		{
+			"all",
+			`{"operations":[{"op":"create","create":{"description":"New"}},{"op":"update","id":2,` +
+				`"update":{"projectId":3}},{"op":"archive","id":4,"children":"cascade"},{"op":"delete","id":5}]}`,
+			-1,
+			nil,
+			http.StatusOK,
+			[]int{200, 200, 200, 200},
+		},
+		{
+			"all fail",
+			`{"operations":[{"op":"update","id":2,"update":{"projectId":3}},{"op":"delete","id":5}]}`,
+			1,
+			ErrForbidden,
+			http.StatusForbidden,
+			nil,
+		},
+		{
+			"all invalid",
+			`{"operations":[{"op":"update","id":2,"update":{"projectId":3}},{"op":"delete"}]}`,
+			-1,
+			nil,
+			http.StatusUnprocessableEntity,
+			nil,
+		},
+		{
+			"partial",
+			`{"partial":true,"operations":[{"op":"update","id":2,"update":{"status":"done"}},{"op":"move","id":3},` +
+				`{"op":"archive","id":4},{"op":"create","create":{"description":"New"}}]}`,
+			1,
+			ErrHasChildren,
+			http.StatusOK,
+			[]int{200, 422, 409, 200},
+		},
+		{
+			"empty",
+			`{"operations":[]}`,
+			-1,
+			nil,
+			http.StatusUnprocessableEntity,
+			nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := newTestHandler(&ServiceMock{
+				BatchFunc: func(_ context.Context, _ int, ops []BatchOperation) ([]Task, error) {
+					if tt.failAt >= 0 {
+						return nil, &BatchError{Index: tt.failAt, Err: tt.failErr}
+					}
+					tasks := make([]Task, 0, len(ops))
+					for i := range ops {
+						tasks = append(tasks, Task{ID: i + 1})
+					}
+					return tasks, nil
+				},
+				BatchPartialFunc: func(_ context.Context, _ int, ops []BatchOperation) ([]BatchResult, error) {
+					results := make([]BatchResult, 0, len(ops))
+					for i := range ops {
+						if i == tt.failAt {
+							results = append(results, BatchResult{Err: tt.failErr})
+							continue
+						}
+						results = append(results, BatchResult{Task: &Task{ID: i + 1}})
+					}
+					return results, nil
+				},
+			})
+
+			w := httptest.NewRecorder()
+			handler.PostTasksBatch(w, newRequest(http.MethodPost, "/tasks/batch", tt.body))
+
+			if w.Code != tt.expectedStatusCode {
+				t.Fatalf("expected status code %d, got %d: %s", tt.expectedStatusCode, w.Code, w.Body.String())
+			}
+			if tt.expectedStatuses == nil {
+				return
+			}
+			var resp timetrackapi.BatchTasksResponse
+			if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+				t.Fatalf("failed to unmarshal response: %v", err)
+			}
+			statuses := make([]int, 0, len(resp.Results))
+			for _, res := range resp.Results {
+				statuses = append(statuses, res.Status)
+			}
+			if !slices.Equal(statuses, tt.expectedStatuses) {
+				t.Errorf("expected statuses %v, got %v", tt.expectedStatuses, statuses)
+			}
+		})
+	}
+}
+
+func TestPostTasksBatchRequireIfMatch(t *testing.T) {
+	tests := []struct {
+		name               string
+		body               string
+		expectedStatusCode int
+		expectedStatuses   []int
+	}{
+		{
+			"versions",
+			`{"operations":[{"op":"create","create":{"description":"New"}},{"op":"delete","id":5,"version":2}]}`,
+			http.StatusOK,
+			[]int{200, 200},
+		},
+		{
+			"missing version",
+			`{"operations":[{"op":"create","create":{"description":"New"}},{"op":"archive","id":4}]}`,
+			http.StatusPreconditionRequired,
+			nil,
+		},
+		{
+			"partial missing version",
+			`{"partial":true,"operations":[{"op":"update","id":2,"update":{"projectId":3}},` +
+				`{"op":"update","id":3,"version":1,"update":{"projectId":3}}]}`,
+			http.StatusOK,
+			[]int{428, 200},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := NewHandler(&ServiceMock{
+				BatchFunc: func(_ context.Context, _ int, ops []BatchOperation) ([]Task, error) {
+					tasks := make([]Task, 0, len(ops))
+					for i := range ops {
+						tasks = append(tasks, Task{ID: i + 1})
+					}
+					return tasks, nil
+				},
+				BatchPartialFunc: func(_ context.Context, _ int, ops []BatchOperation) ([]BatchResult, error) {
+					results := make([]BatchResult, 0, len(ops))
+					for i := range ops {
+						results = append(results, BatchResult{Task: &Task{ID: i + 1}})
+					}
+					return results, nil
+				},
+			}, true)
+
+			w := httptest.NewRecorder()
+			handler.PostTasksBatch(w, newRequest(http.MethodPost, "/tasks/batch", tt.body))
+
+			if w.Code != tt.expectedStatusCode {
+				t.Fatalf("expected status code %d, got %d: %s", tt.expectedStatusCode, w.Code, w.Body.String())
+			}
+			if tt.expectedStatuses == nil {
+				return
+			}
+			var resp timetrackapi.BatchTasksResponse
+			if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+				t.Fatalf("failed to unmarshal response: %v", err)
+			}
+			statuses := make([]int, 0, len(resp.Results))
+			for _, res := range resp.Results {
+				statuses = append(statuses, res.Status)
+			}
+			if !slices.Equal(statuses, tt.expectedStatuses) {
+				t.Errorf("expected statuses %v, got %v", tt.expectedStatuses, statuses)
+			}
+		})
+	}
+}
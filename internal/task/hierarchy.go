@@ -0,0 +1,152 @@
+package task
+
+import (
+	"context"
+	"errors"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// ChildrenMode is what happens to the children of a task when the task is
+// archived or deleted. Restrict refuses to archive a task with children that
+// aren't archived, or to delete a task with any children. Cascade archives or
+// deletes all the descendants with the task, the user must be able to delete
+// each of them. Detach moves the children to the parent of the task. Children
+// in the trash are left as is.
+type ChildrenMode string
+
+const (
+	ChildrenRestrict ChildrenMode = "restrict"
+	ChildrenCascade  ChildrenMode = "cascade"
+	ChildrenDetach   ChildrenMode = "detach"
+)
+
+// descendantsCTE selects the IDs of the descendants of the task $1 as
+// "descendants".
+const descendantsCTE = `
+	WITH RECURSIVE descendants (id) AS (
+		SELECT id FROM tasks WHERE parent_id = $1
+		UNION
+		SELECT tasks.id FROM descendants JOIN tasks ON tasks.parent_id = descendants.id
+	)
+`
+
+// checkParent checks that the parent is visible to the user and that it isn't
+// the task itself or one of its descendants, so that tasks don't form cycles.
+// New tasks have the ID 0 and can't form cycles. Parents of existing tasks are
+// changed one transaction at a time, so that concurrent changes can't form a
+// cycle together.
+func checkParent(ctx context.Context, tx pgx.Tx, id, parentID, userID int) error {
+	if id != 0 {
+		if _, err := tx.Exec(ctx, `SELECT pg_advisory_xact_lock(hashtext('task_parents'))`); err != nil {
+			return errors.Join(errors.New("failed to lock task parents"), err)
+		}
+	}
+
+	q := `
+		WITH RECURSIVE ancestors (id) AS (
+			SELECT $1::integer
+			UNION
+			SELECT tasks.parent_id
+			FROM ancestors
+			JOIN tasks ON tasks.id = ancestors.id
+			WHERE tasks.parent_id IS NOT NULL
+		)
+		SELECT EXISTS (SELECT 1 FROM tasks WHERE id = $1 AND ` + VisibleTo("$2") + `),
+			   EXISTS (SELECT 1 FROM ancestors WHERE id = $3)
+	`
+	var visible, cycle bool
+	if err := tx.QueryRow(ctx, q, parentID, userID, id).Scan(&visible, &cycle); err != nil {
+		return errors.Join(errors.New("failed to select task parent"), err)
+	}
+	if !visible {
+		return ErrParentNotFound
+	}
+	if cycle {
+		return ErrParentCycle
+	}
+	return nil
+}
+
+// handleChildren handles the children of a task that is about to be archived,
+// or deleted if del is true, according to the mode.
+func handleChildren(ctx context.Context, tx pgx.Tx, id, userID int, mode ChildrenMode, del bool) error {
+	switch mode {
+	case ChildrenDetach:
+		q := `
+			UPDATE tasks
+			SET parent_id = (SELECT parent.parent_id FROM tasks AS parent WHERE parent.id = $1),
+				version = version + 1
+			WHERE parent_id = $1 AND deleted_at IS NULL
+		`
+		if _, err := tx.Exec(ctx, q, id); err != nil {
+			return errors.Join(errors.New("failed to detach task children"), err)
+		}
+		return nil
+	case ChildrenCascade:
+		return cascadeToDescendants(ctx, tx, id, userID, del)
+	default:
+		q := `SELECT EXISTS (
+			SELECT 1 FROM tasks WHERE parent_id = $1 AND deleted_at IS NULL AND ($2 OR status <> 'archived')
+		)`
+		var exists bool
+		if err := tx.QueryRow(ctx, q, id, del).Scan(&exists); err != nil {
+			return errors.Join(errors.New("failed to select task children"), err)
+		}
+		if exists {
+			return ErrHasChildren
+		}
+		return nil
+	}
+}
+
+// cascadeToDescendants locks the live descendants of a task and archives them,
+// or moves them to the trash if del is true. Descendants that are already
+// archived are left as is when archiving, others get a status change activity.
+func cascadeToDescendants(ctx context.Context, tx pgx.Tx, id, userID int, del bool) error {
+	q := descendantsCTE + `
+		SELECT id, ` + deletableBy("$2") + ` AS deletable
+		FROM tasks
+		WHERE id IN (SELECT id FROM descendants) AND deleted_at IS NULL
+		FOR UPDATE
+	`
+	rows, err := tx.Query(ctx, q, id, userID)
+	if err != nil {
+		return errors.Join(errors.New("failed to select task descendants"), err)
+	}
+	type descendant struct {
+		ID        int
+		Deletable bool `db:"deletable"`
+	}
+	descendants, err := pgx.CollectRows(rows, pgx.RowToStructByName[descendant])
+	if err != nil {
+		return errors.Join(errors.New("failed to collect task descendants"), err)
+	}
+	ids := make([]int, 0, len(descendants))
+	for _, d := range descendants {
+		if !d.Deletable {
+			return ErrForbidden
+		}
+		ids = append(ids, d.ID)
+	}
+
+	if del {
+		// now() is the start of the transaction, so the descendants are
+		// deleted at the same time as the task and are restored with it.
+		q = `UPDATE tasks SET deleted_at = now(), version = version + 1 WHERE id = ANY ($1)`
+		if _, err = tx.Exec(ctx, q, ids); err != nil {
+			return errors.Join(errors.New("failed to delete task descendants"), err)
+		}
+		return nil
+	}
+
+	archived := StatusArchived
+	if err = recordChanges(ctx, tx, ids, userID, nil, &archived); err != nil {
+		return err
+	}
+	q = `UPDATE tasks SET status = 'archived', version = version + 1 WHERE id = ANY ($1) AND status <> 'archived'`
+	if _, err = tx.Exec(ctx, q, ids); err != nil {
+		return errors.Join(errors.New("failed to archive task descendants"), err)
+	}
+	return nil
+}
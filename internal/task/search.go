@@ -0,0 +1,163 @@
+package task
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"unicode"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/kirillgashkov/timetrack/internal/app/database"
+)
+
+// minHighlightSimilarity is the trigram similarity of a word of a description
+// to a word of a query above which the word is highlighted as a fuzzy match.
+const minHighlightSimilarity = 0.5
+
+// SearchResult is a task that matches a search query. Score is the word
+// similarity of the query to the description from 0 to 1, 1 for exact matches.
+// Highlights are the matched parts of the description.
+type SearchResult struct {
+	Task
+	Score      float64     `db:"score"`
+	Highlights []Highlight `db:"-"`
+}
+
+// Highlight is a matched part of a description from Start inclusive to End
+// exclusive, in Unicode code points.
+type Highlight struct {
+	Start int
+	End   int
+}
+
+// Search lists the tasks visible to the user that match the query and the
+// filter, ranked by score. Descriptions match if they contain the query, or a
+// part of them is similar to the query by trigrams, so that typos are
+// forgiven.
+func (s *ServiceImpl) Search(
+	ctx context.Context, userID int, query string, filter *FilterTask, page *database.Page,
+) ([]SearchResult, bool, error) {
+	filter, err := s.normalizeFilter(ctx, filter)
+	if err != nil {
+		return nil, false, err
+	}
+
+	// The results are selected from a subquery, so that the page can be
+	// selected by score.
+	pageCondition, pageClauses, pageArgs := page.Keyset(10, "score DESC", "id")
+	q := `
+		SELECT *
+		FROM (
+			SELECT ` + taskColumns + `, word_similarity($9, description) AS score
+			FROM tasks
+			WHERE ` + listCondition + `
+				AND ($9 <% description OR description ILIKE '%' || $9 || '%')
+		) AS results
+		WHERE ` + pageCondition + `
+		` + pageClauses
+	args := append(append(listArgs(userID, filter), query), pageArgs...)
+	rows, err := s.db.Query(ctx, q, args...)
+	if err != nil {
+		return nil, false, errors.Join(errors.New("failed to select tasks"), err)
+	}
+	defer rows.Close()
+
+	results, err := pgx.CollectRows(rows, pgx.RowToStructByName[SearchResult])
+	if err != nil {
+		return nil, false, errors.Join(errors.New("failed to collect tasks"), err)
+	}
+	results, more := database.PageItems(page, results)
+	for i := range results {
+		results[i].Highlights = highlight(results[i].Description, query)
+	}
+	return results, more, nil
+}
+
+// highlight finds the parts of the description that match the words of the
+// query. Parts that contain a word of the query are highlighted as is, words
+// that are similar to a word of the query by trigrams are highlighted whole.
+func highlight(description, query string) []Highlight {
+	// Runes are lowered one by one, so that offsets stay the same.
+	text := []rune(strings.Map(unicode.ToLower, description))
+	marked := make([]bool, len(text))
+
+	queryWords := strings.FieldsFunc(strings.Map(unicode.ToLower, query), isNotWordRune)
+	for _, qw := range queryWords {
+		w := []rune(qw)
+		for i := 0; i+len(w) <= len(text); i++ {
+			if string(text[i:i+len(w)]) == qw {
+				for j := i; j < i+len(w); j++ {
+					marked[j] = true
+				}
+			}
+		}
+	}
+
+	for start := 0; start < len(text); {
+		if isNotWordRune(text[start]) {
+			start++
+			continue
+		}
+		end := start
+		for end < len(text) && !isNotWordRune(text[end]) {
+			end++
+		}
+		word := string(text[start:end])
+		for _, qw := range queryWords {
+			if trigramSimilarity(word, qw) >= minHighlightSimilarity {
+				for j := start; j < end; j++ {
+					marked[j] = true
+				}
+				break
+			}
+		}
+		start = end
+	}
+
+	highlights := make([]Highlight, 0)
+	for i := 0; i < len(marked); i++ {
+		if !marked[i] {
+			continue
+		}
+		h := Highlight{Start: i}
+		for i < len(marked) && marked[i] {
+			i++
+		}
+		h.End = i
+		highlights = append(highlights, h)
+	}
+	return highlights
+}
+
+// trigramSimilarity is the similarity of two lowercase words by trigrams like
+// pg_trgm computes it, the number of shared trigrams divided by the number of
+// distinct trigrams of both words.
+func trigramSimilarity(a, b string) float64 {
+	ta, tb := trigrams(a), trigrams(b)
+	shared := 0
+	for t := range ta {
+		if _, ok := tb[t]; ok {
+			shared++
+		}
+	}
+	union := len(ta) + len(tb) - shared
+	if union == 0 {
+		return 0
+	}
+	return float64(shared) / float64(union)
+}
+
+// trigrams returns the trigrams of a word padded with two spaces in front and
+// one space behind like pg_trgm pads them.
+func trigrams(word string) map[string]struct{} {
+	r := []rune("  " + word + " ")
+	t := make(map[string]struct{}, len(r))
+	for i := 0; i+3 <= len(r); i++ {
+		t[string(r[i:i+3])] = struct{}{}
+	}
+	return t
+}
+
+func isNotWordRune(r rune) bool {
+	return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+}
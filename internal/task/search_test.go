@@ -0,0 +1,45 @@
+package task
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestHighlight(t *testing.T) {
+	tests := []struct {
+		name        string
+		description string
+		query       string
+		want        []Highlight
+	}{
+		{"substring", "Weekly sync", "sync", []Highlight{{7, 11}}},
+		{"case insensitive", "Weekly Sync", "SYNC", []Highlight{{7, 11}}},
+		{"prefix", "Monthly close", "mon", []Highlight{{0, 3}}},
+		{"several words", "Review pull requests", "pull review", []Highlight{{0, 6}, {7, 11}}},
+		{"typo", "Prepare presentation", "presentaton", []Highlight{{8, 20}}},
+		{"multibyte", "Ünïcode review", "review", []Highlight{{8, 14}}},
+		{"no match", "Weekly sync", "invoice", []Highlight{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := highlight(tt.description, tt.query); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestTrigramSimilarity(t *testing.T) {
+	if got := trigramSimilarity("word", "word"); got != 1 {
+		t.Errorf("expected 1, got %v", got)
+	}
+	// "word" and "two" share no trigrams.
+	if got := trigramSimilarity("word", "two"); got != 0 {
+		t.Errorf("expected 0, got %v", got)
+	}
+	// "word" and "words" share "  w", " wo", "wor" and "ord" of 7 distinct trigrams.
+	if got := trigramSimilarity("word", "words"); got != 4.0/7 {
+		t.Errorf("expected 4/7, got %v", got)
+	}
+}
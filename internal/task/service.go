@@ -2,35 +2,144 @@ package task
 
 import (
 	"context"
+	"database/sql"
 	"errors"
+	"fmt"
+	"log/slog"
+	"time"
 
+	"github.com/jackc/pgerrcode"
 	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/kirillgashkov/timetrack/db/timetrackdb"
 	"github.com/kirillgashkov/timetrack/internal/app/database"
 )
 
 var (
-	ErrNotFound = errors.New("task not found")
+	ErrNotFound         = errors.New("task not found")
+	ErrProjectNotFound  = errors.New("project not found")
+	ErrAssigneeNotFound = errors.New("assignee not found")
+	ErrTagNotFound      = errors.New("tag not found")
+	ErrFieldNotFound    = errors.New("custom field not found")
+	ErrParentNotFound   = errors.New("parent task not found")
+	ErrParentCycle      = errors.New("parent task is the task or its descendant")
+	ErrHasChildren      = errors.New("task has children")
+	ErrForbidden        = errors.New("task access forbidden")
+	ErrInvalidStatus    = errors.New("invalid task status transition")
+	ErrHasInvoicedWorks = errors.New("task has invoiced works")
+	ErrHasWorks         = errors.New("task has works")
+	ErrParentDeleted    = errors.New("parent task is deleted")
+	ErrVersionMismatch  = errors.New("task version mismatch")
 )
 
+// Task is a unit of work in a project. ClientID is the client of the project.
+// Tracked is the time in stopped works on the task by all users, it is compared
+// with the optional budget. CreatedBy is nil for tasks created before tasks had
+// creators. ParentID is the task the task is a subtask of. FieldValues are the
+// values of the custom fields of the task by field ID. DeletedAt is set for
+// tasks in the trash. Version is incremented on every update of the task.
 type Task struct {
 	ID          int
 	Description string
+	Billable    bool
+	ProjectID   int            `db:"project_id"`
+	ClientID    *int           `db:"client_id"`
+	Budget      *time.Duration `db:"budget"`
+	Tracked     time.Duration  `db:"tracked"`
+	CreatedBy   *int           `db:"created_by"`
+	Visibility  Visibility     `db:"visibility"`
+	AssigneeIDs []int          `db:"assignee_ids"`
+	Status      Status         `db:"status"`
+	TagIDs      []int          `db:"tag_ids"`
+	ParentID    *int           `db:"parent_id"`
+	FieldValues map[int]string `db:"field_values"`
+	DeletedAt   *time.Time     `db:"deleted_at"`
+	Version     int            `db:"version"`
 }
 
+// OverBudget reports whether more time than budgeted was tracked on the task.
+func (t *Task) OverBudget() bool {
+	return t.Budget != nil && t.Tracked > *t.Budget
+}
+
+// CreateTask creates a task of the user in the project, or in the default
+// project if ProjectID is nil.
 type CreateTask struct {
 	Description string
+	Billable    bool
+	ProjectID   *int
+	Budget      *time.Duration
+	Visibility  Visibility
+	AssigneeIDs []int
+	TagIDs      []int
+	ParentID    *int
+	FieldValues []FieldValue
 }
 
+// UpdateTask updates a task, AssigneeIDs and TagIDs replace the assignees and
+// the tags if not nil. FieldValues set or remove the values of the given custom
+// fields and keep the others. If Version is set, the task is updated only if it
+// is still at the version.
 type UpdateTask struct {
 	Description *string
+	Billable    *bool
+	ProjectID   *int
+	Budget      *sql.Null[time.Duration]
+	Visibility  *Visibility
+	AssigneeIDs []int
+	TagIDs      []int
+	Status      *Status
+	ParentID    *sql.Null[int]
+	FieldValues []FieldValue
+	Version     *int
+}
+
+// FilterTask filters tasks. Tasks in any status but archived are listed if
+// Statuses is empty. If FieldID is set, tasks must have the FieldValue of the
+// custom field.
+type FilterTask struct {
+	ProjectID  *int
+	AssigneeID *int
+	TagID      *int
+	ParentID   *int
+	Statuses   []Status
+	FieldID    *int
+	FieldValue string
+}
+
+// Progress is the time tracked on a task by each user, ordered by user ID.
+type Progress struct {
+	Task  Task
+	Users []UserProgress
+}
+
+type UserProgress struct {
+	UserID  int           `db:"user_id"`
+	Tracked time.Duration `db:"tracked"`
 }
 
+// Service manages tasks on behalf of a user. Tasks that aren't visible to the
+// user are not found.
 type Service interface {
-	Create(ctx context.Context, create *CreateTask) (*Task, error)
-	Get(ctx context.Context, id int) (*Task, error)
-	List(ctx context.Context, offset, limit int) ([]Task, error)
-	Update(ctx context.Context, id int, update *UpdateTask) (*Task, error)
-	Delete(ctx context.Context, id int) (*Task, error)
+	Create(ctx context.Context, userID int, create *CreateTask) (*Task, error)
+	Get(ctx context.Context, id, userID int) (*Task, error)
+	List(ctx context.Context, userID int, filter *FilterTask, page *database.Page) ([]Task, bool, error)
+	Search(
+		ctx context.Context, userID int, query string, filter *FilterTask, page *database.Page,
+	) ([]SearchResult, bool, error)
+	Update(ctx context.Context, id, userID int, update *UpdateTask) (*Task, error)
+	Archive(ctx context.Context, id, userID int, children ChildrenMode, version *int) (*Task, error)
+	Delete(ctx context.Context, id, userID int, children ChildrenMode, force bool, version *int) (*Task, error)
+	Restore(ctx context.Context, id, userID int) (*Task, error)
+	Batch(ctx context.Context, userID int, ops []BatchOperation) ([]Task, error)
+	BatchPartial(ctx context.Context, userID int, ops []BatchOperation) ([]BatchResult, error)
+	Progress(ctx context.Context, id, userID int) (*Progress, error)
+	ListBudgetEvents(ctx context.Context, userID, afterID, limit int) ([]BudgetEvent, error)
+	ListComments(ctx context.Context, taskID, userID int, page *database.Page) ([]Comment, bool, error)
+	CreateComment(ctx context.Context, taskID, userID int, body string) (*Comment, error)
+	UpdateComment(ctx context.Context, id, userID int, body string) (*Comment, error)
+	DeleteComment(ctx context.Context, id, userID int) (*Comment, error)
+	ListActivities(ctx context.Context, taskID, userID int, page *database.Page) ([]Activity, bool, error)
 }
 
 type ServiceImpl struct {
@@ -41,29 +150,527 @@ func NewServiceImpl(db database.DB) *ServiceImpl {
 	return &ServiceImpl{db: db}
 }
 
-func (s *ServiceImpl) Create(ctx context.Context, create *CreateTask) (*Task, error) {
-	q := `INSERT INTO tasks (description) VALUES ($1) RETURNING id, description`
-	return s.queryOne(ctx, q, create.Description)
+// trackedSubquery selects the time in stopped works on the task referred to as
+// "tasks" from the rollups of the works, which tracking keeps up to date in the
+// transactions that change the works.
+const trackedSubquery = `
+	SELECT COALESCE(SUM(work_rollups.duration), '0')
+	FROM work_rollups
+	WHERE work_rollups.task_id = tasks.id
+`
+
+const taskColumns = `
+	id, description, billable, project_id,
+	(SELECT projects.client_id FROM projects WHERE projects.id = tasks.project_id) AS client_id,
+	budget, (` + trackedSubquery + `) AS tracked, created_by, visibility, status,
+	ARRAY(
+		SELECT task_assignees.user_id FROM task_assignees WHERE task_assignees.task_id = tasks.id ORDER BY 1
+	) AS assignee_ids,
+	ARRAY(SELECT task_tags.tag_id FROM task_tags WHERE task_tags.task_id = tasks.id ORDER BY 1) AS tag_ids,
+	parent_id, deleted_at, version, ` + fieldValuesColumn
+
+func (s *ServiceImpl) Create(ctx context.Context, userID int, create *CreateTask) (*Task, error) {
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return nil, errors.Join(errors.New("failed to start transaction"), err)
+	}
+	defer func(tx pgx.Tx) {
+		if txErr := tx.Rollback(ctx); txErr != nil && !errors.Is(txErr, pgx.ErrTxClosed) {
+			slog.Error("failed to rollback transaction", "error", txErr)
+		}
+	}(tx)
+
+	if create.ParentID != nil {
+		if err = checkParent(ctx, tx, 0, *create.ParentID, userID); err != nil {
+			return nil, err
+		}
+	}
+
+	q := `
+		INSERT INTO tasks (description, billable, project_id, budget, created_by, visibility, parent_id)
+		VALUES ($1, $2, coalesce($3, default_project_id()), $4, $5, $6, $7)
+		RETURNING ` + taskColumns
+	args := []any{
+		create.Description,
+		create.Billable,
+		create.ProjectID,
+		create.Budget,
+		userID,
+		create.Visibility,
+		create.ParentID,
+	}
+	t, err := queryOne(ctx, tx, q, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(create.AssigneeIDs) > 0 || len(create.TagIDs) > 0 || len(create.FieldValues) > 0 {
+		if err = replaceLinks(ctx, tx, assigneeLinks, t.ID, create.AssigneeIDs); err != nil {
+			return nil, err
+		}
+		if err = replaceLinks(ctx, tx, tagLinks, t.ID, create.TagIDs); err != nil {
+			return nil, err
+		}
+		if err = setFieldValues(ctx, tx, t.ID, create.FieldValues); err != nil {
+			return nil, err
+		}
+		if t, err = queryOne(ctx, tx, `SELECT `+taskColumns+` FROM tasks WHERE id = $1`, t.ID); err != nil {
+			return nil, err
+		}
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return nil, errors.Join(errors.New("failed to commit transaction"), err)
+	}
+	return t, nil
+}
+
+func (s *ServiceImpl) Get(ctx context.Context, id, userID int) (*Task, error) {
+	q := `SELECT ` + taskColumns + ` FROM tasks WHERE id = $1 AND ` + VisibleTo("$2")
+	return queryOne(ctx, s.db, q, id, userID)
+}
+
+// listCondition selects the tasks visible to the user $1 that match the filter
+// in the parameters $2 to $8 built by listArgs.
+var listCondition = VisibleTo("$1") + `
+	AND ($2::integer IS NULL OR project_id = $2)
+	AND ($3::integer IS NULL OR EXISTS (
+		SELECT 1 FROM task_assignees WHERE task_assignees.task_id = tasks.id AND task_assignees.user_id = $3
+	))
+	AND (cardinality($4::text[]) = 0 AND status <> 'archived' OR status = ANY ($4))
+	AND ($5::integer IS NULL OR EXISTS (
+		SELECT 1 FROM task_tags WHERE task_tags.task_id = tasks.id AND task_tags.tag_id = $5
+	))
+	AND ($6::integer IS NULL OR parent_id = $6)
+	AND ($7::integer IS NULL OR EXISTS (
+		SELECT 1
+		FROM task_field_values
+		WHERE task_field_values.task_id = tasks.id AND task_field_values.field_id = $7 AND task_field_values.value = $8
+	))
+`
+
+func listArgs(userID int, filter *FilterTask) []any {
+	statuses := make([]string, 0, len(filter.Statuses))
+	for _, st := range filter.Statuses {
+		statuses = append(statuses, string(st))
+	}
+	return []any{
+		userID,
+		filter.ProjectID,
+		filter.AssigneeID,
+		statuses,
+		filter.TagID,
+		filter.ParentID,
+		filter.FieldID,
+		filter.FieldValue,
+	}
+}
+
+// List lists the tasks visible to the user that match the filter sorted by ID
+// and reports whether there are more tasks past the page. A custom field value
+// that doesn't match the type of the field is returned as a *field.ValueError.
+func (s *ServiceImpl) List(
+	ctx context.Context, userID int, filter *FilterTask, page *database.Page,
+) ([]Task, bool, error) {
+	filter, err := s.normalizeFilter(ctx, filter)
+	if err != nil {
+		return nil, false, err
+	}
+
+	pageCondition, pageClauses, pageArgs := page.Keyset(9, "id")
+	q := `
+		SELECT ` + taskColumns + `
+		FROM tasks
+		WHERE ` + listCondition + ` AND ` + pageCondition + `
+		` + pageClauses
+	tasks, err := s.queryAll(ctx, q, append(listArgs(userID, filter), pageArgs...)...)
+	if err != nil {
+		return nil, false, err
+	}
+	tasks, more := database.PageItems(page, tasks)
+	return tasks, more, nil
+}
+
+// Update updates the task if the user can edit it. The status can only change
+// along the allowed transitions and not to archived, tasks are archived with
+// Archive, so that their children are handled. The parent can't be the task or
+// one of its descendants. Changes of the description and the status are
+// recorded as activities. A changed budget may be reached already, so budget
+// events are recorded in the same transaction.
+func (s *ServiceImpl) Update(ctx context.Context, id, userID int, update *UpdateTask) (*Task, error) {
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return nil, errors.Join(errors.New("failed to start transaction"), err)
+	}
+	defer func(tx pgx.Tx) {
+		if txErr := tx.Rollback(ctx); txErr != nil && !errors.Is(txErr, pgx.ErrTxClosed) {
+			slog.Error("failed to rollback transaction", "error", txErr)
+		}
+	}(tx)
+
+	a, err := queryAccess(ctx, tx, id, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !a.Editable {
+		return nil, ErrForbidden
+	}
+	if err = checkVersion(a, update.Version); err != nil {
+		return nil, err
+	}
+	if update.Status != nil && (!canTransition(a.Status, *update.Status) ||
+		(*update.Status == StatusArchived && a.Status != StatusArchived)) {
+		return nil, ErrInvalidStatus
+	}
+	if update.ParentID != nil && update.ParentID.Valid {
+		if err = checkParent(ctx, tx, id, update.ParentID.V, userID); err != nil {
+			return nil, err
+		}
+	}
+	if err = recordChanges(ctx, tx, []int{id}, userID, update.Description, update.Status); err != nil {
+		return nil, err
+	}
+
+	q := `
+		UPDATE tasks
+		SET description = coalesce($1, description),
+			billable = coalesce($2, billable),
+			project_id = coalesce($3, project_id),
+			budget = CASE WHEN $5 THEN $4 ELSE budget END,
+			visibility = coalesce($6, visibility),
+			status = coalesce($7, status),
+			parent_id = CASE WHEN $9 THEN $8 ELSE parent_id END,
+			version = version + 1
+		WHERE id = $10
+		RETURNING ` + taskColumns
+	args := []any{
+		update.Description,
+		update.Billable,
+		update.ProjectID,
+		update.Budget,
+		update.Budget != nil,
+		update.Visibility,
+		update.Status,
+		update.ParentID,
+		update.ParentID != nil,
+		id,
+	}
+	t, err := queryOne(ctx, tx, q, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	if update.AssigneeIDs != nil || update.TagIDs != nil || update.FieldValues != nil {
+		if update.AssigneeIDs != nil {
+			if err = replaceLinks(ctx, tx, assigneeLinks, id, update.AssigneeIDs); err != nil {
+				return nil, err
+			}
+		}
+		if update.TagIDs != nil {
+			if err = replaceLinks(ctx, tx, tagLinks, id, update.TagIDs); err != nil {
+				return nil, err
+			}
+		}
+		if update.FieldValues != nil {
+			if err = setFieldValues(ctx, tx, id, update.FieldValues); err != nil {
+				return nil, err
+			}
+		}
+		if t, err = queryOne(ctx, tx, `SELECT `+taskColumns+` FROM tasks WHERE id = $1`, id); err != nil {
+			return nil, err
+		}
+	}
+
+	if update.Budget != nil {
+		if err = RecordBudgetEvents(ctx, tx, id); err != nil {
+			return nil, err
+		}
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return nil, errors.Join(errors.New("failed to commit transaction"), err)
+	}
+	return t, nil
+}
+
+// Archive archives the task if the user can delete it, handling its children
+// according to the mode. Tasks in any status can be archived, their works are
+// kept. If version is set, the task is archived only if it is still at the
+// version.
+func (s *ServiceImpl) Archive(
+	ctx context.Context, id, userID int, children ChildrenMode, version *int,
+) (*Task, error) {
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return nil, errors.Join(errors.New("failed to start transaction"), err)
+	}
+	defer func(tx pgx.Tx) {
+		if txErr := tx.Rollback(ctx); txErr != nil && !errors.Is(txErr, pgx.ErrTxClosed) {
+			slog.Error("failed to rollback transaction", "error", txErr)
+		}
+	}(tx)
+
+	a, err := queryAccess(ctx, tx, id, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !a.Deletable {
+		return nil, ErrForbidden
+	}
+	if err = checkVersion(a, version); err != nil {
+		return nil, err
+	}
+	if err = handleChildren(ctx, tx, id, userID, children, false); err != nil {
+		return nil, err
+	}
+	archived := StatusArchived
+	if err = recordChanges(ctx, tx, []int{id}, userID, nil, &archived); err != nil {
+		return nil, err
+	}
+
+	q := `UPDATE tasks SET status = $1, version = version + 1 WHERE id = $2 RETURNING ` + taskColumns
+	t, err := queryOne(ctx, tx, q, StatusArchived, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return nil, errors.Join(errors.New("failed to commit transaction"), err)
+	}
+	return t, nil
+}
+
+// Delete moves the task to the trash if the user can delete it, handling its
+// children according to the mode. Tasks with works, or with descendants with
+// works if the children are cascaded to, are deleted only if force is true and
+// the user is an administrator, so that tracked time isn't lost by accident.
+// Tasks with invoiced works are never deleted. Deleted tasks keep their works
+// until they are purged. If version is set, the task is deleted only if it is
+// still at the version.
+func (s *ServiceImpl) Delete(
+	ctx context.Context, id, userID int, children ChildrenMode, force bool, version *int,
+) (*Task, error) {
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return nil, errors.Join(errors.New("failed to start transaction"), err)
+	}
+	defer func(tx pgx.Tx) {
+		if txErr := tx.Rollback(ctx); txErr != nil && !errors.Is(txErr, pgx.ErrTxClosed) {
+			slog.Error("failed to rollback transaction", "error", txErr)
+		}
+	}(tx)
+
+	a, err := queryAccess(ctx, tx, id, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !a.Deletable {
+		return nil, ErrForbidden
+	}
+	if force && !a.Admin {
+		return nil, ErrForbidden
+	}
+	if err = checkVersion(a, version); err != nil {
+		return nil, err
+	}
+	if err = checkWorks(ctx, tx, id, children == ChildrenCascade, force); err != nil {
+		return nil, err
+	}
+	if err = handleChildren(ctx, tx, id, userID, children, true); err != nil {
+		return nil, err
+	}
+
+	q := `UPDATE tasks SET deleted_at = now(), version = version + 1 WHERE id = $1 RETURNING ` + taskColumns
+	t, err := queryOne(ctx, tx, q, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return nil, errors.Join(errors.New("failed to commit transaction"), err)
+	}
+	return t, nil
 }
 
-func (s *ServiceImpl) Get(ctx context.Context, id int) (*Task, error) {
-	q := `SELECT id, description FROM tasks WHERE id = $1`
-	return s.queryOne(ctx, q, id)
+// checkWorks checks that the task, and its live descendants if descendants is
+// true, have no invoiced works, and no works at all unless force is true.
+func checkWorks(ctx context.Context, tx pgx.Tx, id int, descendants, force bool) error {
+	q := descendantsCTE + `, task_works (id) AS (
+			SELECT works.id
+			FROM works
+			JOIN tasks ON works.task_id = tasks.id
+			WHERE tasks.id = $1 OR $2 AND tasks.id IN (SELECT id FROM descendants) AND tasks.deleted_at IS NULL
+		)
+		SELECT EXISTS (SELECT 1 FROM task_works) AS has_works,
+			   EXISTS (
+				   SELECT 1 FROM task_works JOIN invoice_works ON invoice_works.work_id = task_works.id
+			   ) AS has_invoiced_works
+	`
+	var hasWorks, hasInvoicedWorks bool
+	if err := tx.QueryRow(ctx, q, id, descendants).Scan(&hasWorks, &hasInvoicedWorks); err != nil {
+		return errors.Join(errors.New("failed to select task works"), err)
+	}
+	if hasInvoicedWorks {
+		return ErrHasInvoicedWorks
+	}
+	if hasWorks && !force {
+		return ErrHasWorks
+	}
+	return nil
 }
 
-func (s *ServiceImpl) List(ctx context.Context, offset, limit int) ([]Task, error) {
-	q := `SELECT id, description FROM tasks ORDER BY id OFFSET $1 LIMIT $2`
-	return s.queryAll(ctx, q, offset, limit)
+// Restore restores the task from the trash with the descendants that were
+// deleted with it if the user can delete it. Tasks that aren't in the trash are
+// not found. A task can't be restored while its parent is in the trash.
+func (s *ServiceImpl) Restore(ctx context.Context, id, userID int) (*Task, error) {
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return nil, errors.Join(errors.New("failed to start transaction"), err)
+	}
+	defer func(tx pgx.Tx) {
+		if txErr := tx.Rollback(ctx); txErr != nil && !errors.Is(txErr, pgx.ErrTxClosed) {
+			slog.Error("failed to rollback transaction", "error", txErr)
+		}
+	}(tx)
+
+	q := `
+		SELECT ` + deletableBy("$2") + ` AS deletable,
+			   EXISTS (
+				   SELECT 1 FROM tasks AS parent WHERE parent.id = tasks.parent_id AND parent.deleted_at IS NOT NULL
+			   ) AS parent_deleted
+		FROM tasks
+		WHERE id = $1 AND deleted_at IS NOT NULL
+		FOR UPDATE
+	`
+	var deletable, parentDeleted bool
+	if err = tx.QueryRow(ctx, q, id, userID).Scan(&deletable, &parentDeleted); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, errors.Join(errors.New("failed to select deleted task"), err)
+	}
+	if !deletable {
+		return nil, ErrNotFound
+	}
+	if parentDeleted {
+		return nil, ErrParentDeleted
+	}
+
+	q = descendantsCTE + `
+		UPDATE tasks
+		SET deleted_at = NULL, version = version + 1
+		WHERE id IN (SELECT id FROM descendants)
+			AND deleted_at = (SELECT deleted_at FROM tasks WHERE id = $1)
+	`
+	if _, err = tx.Exec(ctx, q, id); err != nil {
+		return nil, errors.Join(errors.New("failed to restore task descendants"), err)
+	}
+	q = `UPDATE tasks SET deleted_at = NULL, version = version + 1 WHERE id = $1 RETURNING ` + taskColumns
+	t, err := queryOne(ctx, tx, q, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return nil, errors.Join(errors.New("failed to commit transaction"), err)
+	}
+	return t, nil
 }
 
-func (s *ServiceImpl) Update(ctx context.Context, id int, update *UpdateTask) (*Task, error) {
-	q := `UPDATE tasks SET description = coalesce($1, description) WHERE id = $2 RETURNING id, description`
-	return s.queryOne(ctx, q, update.Description, id)
+// purgeableCondition is true for a task in the trash since before $1 that has
+// no subtasks and no works on an invoice or in a timesheet with the status $2
+// or $3.
+const purgeableCondition = `
+	tasks.deleted_at < $1
+	AND NOT EXISTS (SELECT 1 FROM tasks AS subtasks WHERE subtasks.parent_id = tasks.id)
+	AND NOT EXISTS (
+		SELECT 1
+		FROM works
+		WHERE works.task_id = tasks.id
+		  AND (
+			  EXISTS (SELECT 1 FROM invoice_works WHERE invoice_works.work_id = works.id)
+			  OR EXISTS (
+				  SELECT 1
+				  FROM timesheets
+				  WHERE timesheets.user_id = works.user_id
+					AND works.started_at >= timesheets.period_from
+					AND works.started_at < timesheets.period_to
+					AND timesheets.status IN ($2, $3)
+			  )
+		  )
+	)
+`
+
+// Purge permanently deletes the tasks deleted before the time with their works
+// one by one, subtasks before their parents, and returns the number of deleted
+// tasks and the IDs of the tasks kept in the trash. Tasks with works on an
+// invoice or in a submitted or approved timesheet are kept with their
+// ancestors, and a task that fails to delete doesn't stop the others.
+func (s *ServiceImpl) Purge(ctx context.Context, before time.Time) (int, []int, error) {
+	args := []any{before, timetrackdb.TimesheetStatusSubmitted, timetrackdb.TimesheetStatusApproved}
+	purged := 0
+	failed := map[int]bool{}
+	var errs []error
+	for {
+		ids, err := queryIDs(ctx, s.db, `SELECT id FROM tasks WHERE `+purgeableCondition+` ORDER BY id`, args...)
+		if err != nil {
+			return purged, nil, err
+		}
+
+		deleted := 0
+		for _, id := range ids {
+			if failed[id] {
+				continue
+			}
+			if err = ctx.Err(); err != nil {
+				return purged, nil, err
+			}
+			q := `DELETE FROM tasks WHERE id = $4 AND ` + purgeableCondition
+			tag, err := s.db.Exec(ctx, q, append(args, id)...)
+			if err != nil {
+				failed[id] = true
+				errs = append(errs, errors.Join(fmt.Errorf("failed to purge task %d", id), err))
+				continue
+			}
+			deleted += int(tag.RowsAffected())
+		}
+		purged += deleted
+		if deleted == 0 {
+			break
+		}
+	}
+
+	kept, err := queryIDs(ctx, s.db, `SELECT id FROM tasks WHERE deleted_at < $1 ORDER BY id`, before)
+	if err != nil {
+		return purged, nil, err
+	}
+	return purged, kept, errors.Join(errs...)
 }
 
-func (s *ServiceImpl) Delete(ctx context.Context, id int) (*Task, error) {
-	q := `DELETE FROM tasks WHERE id = $1 RETURNING id, description`
-	return s.queryOne(ctx, q, id)
+func (s *ServiceImpl) Progress(ctx context.Context, id, userID int) (*Progress, error) {
+	t, err := s.Get(ctx, id, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	q := `
+		SELECT user_id, SUM(duration) AS tracked
+		FROM work_rollups
+		WHERE task_id = $1
+		GROUP BY user_id
+		ORDER BY user_id
+	`
+	rows, err := s.db.Query(ctx, q, id)
+	if err != nil {
+		return nil, errors.Join(errors.New("failed to select progress"), err)
+	}
+	defer rows.Close()
+
+	users, err := pgx.CollectRows(rows, pgx.RowToStructByName[UserProgress])
+	if err != nil {
+		return nil, errors.Join(errors.New("failed to collect progress"), err)
+	}
+	return &Progress{Task: *t, Users: users}, nil
 }
 
 func (s *ServiceImpl) queryAll(ctx context.Context, query string, args ...any) ([]Task, error) {
@@ -80,8 +687,20 @@ func (s *ServiceImpl) queryAll(ctx context.Context, query string, args ...any) (
 	return tasks, nil
 }
 
-func (s *ServiceImpl) queryOne(ctx context.Context, query string, args ...any) (*Task, error) {
-	rows, err := s.db.Query(ctx, query, args...)
+func queryIDs(ctx context.Context, db database.DB, query string, args ...any) ([]int, error) {
+	rows, err := db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, errors.Join(errors.New("failed to select task IDs"), err)
+	}
+	ids, err := pgx.CollectRows(rows, pgx.RowTo[int])
+	if err != nil {
+		return nil, errors.Join(errors.New("failed to collect task IDs"), err)
+	}
+	return ids, nil
+}
+
+func queryOne(ctx context.Context, db database.DB, query string, args ...any) (*Task, error) {
+	rows, err := db.Query(ctx, query, args...)
 	if err != nil {
 		return nil, errors.Join(errors.New("failed to select task"), err)
 	}
@@ -89,6 +708,18 @@ func (s *ServiceImpl) queryOne(ctx context.Context, query string, args ...any) (
 
 	task, err := pgx.CollectExactlyOneRow(rows, pgx.RowToStructByName[Task])
 	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == pgerrcode.ForeignKeyViolation {
+			// Tasks reference projects and parents and are referenced by
+			// invoiced works through cascading deletion of their works.
+			if pgErr.TableName == "invoice_works" {
+				return nil, errors.Join(ErrHasInvoicedWorks, err)
+			}
+			if pgErr.ConstraintName == "tasks_parent_id_fkey" {
+				return nil, errors.Join(ErrParentNotFound, err)
+			}
+			return nil, errors.Join(ErrProjectNotFound, err)
+		}
 		if errors.Is(err, pgx.ErrNoRows) {
 			return nil, errors.Join(ErrNotFound, ErrNotFound)
 		}
@@ -96,3 +727,35 @@ func (s *ServiceImpl) queryOne(ctx context.Context, query string, args ...any) (
 	}
 	return &task, nil
 }
+
+// links are the users or the tags linked to tasks in a link table.
+type links struct {
+	table    string
+	column   string
+	notFound error
+}
+
+var (
+	assigneeLinks = links{table: "task_assignees", column: "user_id", notFound: ErrAssigneeNotFound}
+	tagLinks      = links{table: "task_tags", column: "tag_id", notFound: ErrTagNotFound}
+)
+
+// replaceLinks replaces the users or the tags linked to the task.
+func replaceLinks(ctx context.Context, db database.DB, l links, id int, ids []int) error {
+	if _, err := db.Exec(ctx, `DELETE FROM `+l.table+` WHERE task_id = $1`, id); err != nil {
+		return errors.Join(errors.New("failed to delete from "+l.table), err)
+	}
+	q := `
+		INSERT INTO ` + l.table + ` (task_id, ` + l.column + `)
+		SELECT $1, unnest($2::integer[])
+		ON CONFLICT DO NOTHING
+	`
+	if _, err := db.Exec(ctx, q, id, ids); err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == pgerrcode.ForeignKeyViolation {
+			return errors.Join(l.notFound, err)
+		}
+		return errors.Join(errors.New("failed to insert into "+l.table), err)
+	}
+	return nil
+}
@@ -0,0 +1,223 @@
+package task
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/kirillgashkov/timetrack/internal/app/testutil"
+)
+
+// TestPurge purges the trash with an invoiced work in it. It needs a test
+// database, the data is created in a transaction that is rolled back.
+func TestPurge(t *testing.T) {
+	if os.Getenv("TEST_APP_DATABASE_DSN") == "" {
+		t.Skip("TEST_APP_DATABASE_DSN is not set")
+	}
+
+	ctx := context.Background()
+	db := testutil.NewTestPool()
+	defer db.Close()
+
+	tx, err := db.Begin(ctx)
+	if err != nil {
+		t.Fatalf("failed to start transaction: %v", err)
+	}
+	defer func(tx pgx.Tx) {
+		if txErr := tx.Rollback(ctx); txErr != nil && !errors.Is(txErr, pgx.ErrTxClosed) {
+			slog.Error("failed to rollback transaction", "error", txErr)
+		}
+	}(tx)
+
+	passportNumber := fmt.Sprintf("purge %d", time.Now().UnixNano())
+	q := `
+		INSERT INTO users (passport_number, surname, name, address)
+		VALUES ($1, 'Surname', 'Name', 'Address')
+		RETURNING id
+	`
+	var userID int
+	if err = tx.QueryRow(ctx, q, passportNumber).Scan(&userID); err != nil {
+		t.Fatalf("failed to insert user: %v", err)
+	}
+
+	insertTask := func(description string, parentID *int) int {
+		q := `
+			INSERT INTO tasks (description, parent_id, deleted_at)
+			VALUES ($1, $2, now() - interval '1 day')
+			RETURNING id
+		`
+		var id int
+		if err := tx.QueryRow(ctx, q, description, parentID).Scan(&id); err != nil {
+			t.Fatalf("failed to insert task: %v", err)
+		}
+		return id
+	}
+	insertWork := func(taskID int) int {
+		q := `
+			INSERT INTO works (started_at, stopped_at, task_id, user_id, status)
+			VALUES (now() - interval '2 days', now() - interval '47 hours', $1, $2, 'stopped')
+			RETURNING id
+		`
+		var id int
+		if err := tx.QueryRow(ctx, q, taskID, userID).Scan(&id); err != nil {
+			t.Fatalf("failed to insert work: %v", err)
+		}
+		return id
+	}
+
+	parentID := insertTask("Parent", nil)
+	childID := insertTask("Child", &parentID)
+	insertWork(childID)
+	invoicedParentID := insertTask("Invoiced parent", nil)
+	invoicedID := insertTask("Invoiced", &invoicedParentID)
+	invoicedWorkID := insertWork(invoicedID)
+
+	q = `INSERT INTO clients (name) VALUES ($1) RETURNING id`
+	var clientID int
+	if err = tx.QueryRow(ctx, q, passportNumber).Scan(&clientID); err != nil {
+		t.Fatalf("failed to insert client: %v", err)
+	}
+	q = `
+		INSERT INTO invoices (client_id, period_from, period_to, currency, status)
+		VALUES ($1, now() - interval '1 week', now(), 'USD', 'draft')
+		RETURNING id
+	`
+	var invoiceID int
+	if err = tx.QueryRow(ctx, q, clientID).Scan(&invoiceID); err != nil {
+		t.Fatalf("failed to insert invoice: %v", err)
+	}
+	q = `
+		INSERT INTO invoice_works (invoice_id, work_id, duration, hourly_rate, amount)
+		VALUES ($1, $2, interval '1 hour', 10, 10)
+	`
+	if _, err = tx.Exec(ctx, q, invoiceID, invoicedWorkID); err != nil {
+		t.Fatalf("failed to insert invoice work: %v", err)
+	}
+
+	s := NewServiceImpl(tx)
+	purged, kept, err := s.Purge(ctx, time.Now())
+	if err != nil {
+		t.Fatalf("failed to purge: %v", err)
+	}
+	if purged < 2 {
+		t.Errorf("got %d purged, want at least 2", purged)
+	}
+
+	tests := []struct {
+		name     string
+		id       int
+		wantKept bool
+	}{
+		{"parent", parentID, false},
+		{"child", childID, false},
+		{"invoiced parent", invoicedParentID, true},
+		{"invoiced", invoicedID, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var exists bool
+			q := `SELECT EXISTS (SELECT 1 FROM tasks WHERE id = $1)`
+			if err := tx.QueryRow(ctx, q, tt.id).Scan(&exists); err != nil {
+				t.Fatalf("failed to select task: %v", err)
+			}
+			if exists != tt.wantKept {
+				t.Errorf("got exists %t, want %t", exists, tt.wantKept)
+			}
+			if got := slices.Contains(kept, tt.id); got != tt.wantKept {
+				t.Errorf("got kept %t, want %t", got, tt.wantKept)
+			}
+		})
+	}
+}
+
+// TestVisibleToProjectMembers checks that tasks visible to the project are
+// visible to the creators and the assignees of the tasks of the project only.
+// It needs a test database, the data is created in a transaction that is
+// rolled back.
+func TestVisibleToProjectMembers(t *testing.T) {
+	if os.Getenv("TEST_APP_DATABASE_DSN") == "" {
+		t.Skip("TEST_APP_DATABASE_DSN is not set")
+	}
+
+	ctx := context.Background()
+	db := testutil.NewTestPool()
+	defer db.Close()
+
+	tx, err := db.Begin(ctx)
+	if err != nil {
+		t.Fatalf("failed to start transaction: %v", err)
+	}
+	defer func(tx pgx.Tx) {
+		if txErr := tx.Rollback(ctx); txErr != nil && !errors.Is(txErr, pgx.ErrTxClosed) {
+			slog.Error("failed to rollback transaction", "error", txErr)
+		}
+	}(tx)
+
+	suffix := time.Now().UnixNano()
+	insertUser := func(name string) int {
+		q := `
+			INSERT INTO users (passport_number, surname, name, address)
+			VALUES ($1, 'Surname', $2, 'Address')
+			RETURNING id
+		`
+		var id int
+		if err := tx.QueryRow(ctx, q, fmt.Sprintf("%s %d", name, suffix), name).Scan(&id); err != nil {
+			t.Fatalf("failed to insert user: %v", err)
+		}
+		return id
+	}
+	creatorID := insertUser("Creator")
+	assigneeID := insertUser("Assignee")
+	outsiderID := insertUser("Outsider")
+
+	q := `INSERT INTO projects (name) VALUES ($1) RETURNING id`
+	var projectID int
+	if err = tx.QueryRow(ctx, q, fmt.Sprintf("members %d", suffix)).Scan(&projectID); err != nil {
+		t.Fatalf("failed to insert project: %v", err)
+	}
+	insertTask := func(description string, visibility Visibility) int {
+		q := `
+			INSERT INTO tasks (description, project_id, created_by, visibility)
+			VALUES ($1, $2, $3, $4)
+			RETURNING id
+		`
+		var id int
+		if err := tx.QueryRow(ctx, q, description, projectID, creatorID, visibility).Scan(&id); err != nil {
+			t.Fatalf("failed to insert task: %v", err)
+		}
+		return id
+	}
+	sharedID := insertTask("Shared", VisibilityProject)
+	assignedID := insertTask("Assigned", VisibilityPrivate)
+	q = `INSERT INTO task_assignees (task_id, user_id) VALUES ($1, $2)`
+	if _, err = tx.Exec(ctx, q, assignedID, assigneeID); err != nil {
+		t.Fatalf("failed to insert assignee: %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		userID  int
+		wantErr error
+	}{
+		{"creator", creatorID, nil},
+		{"assignee of another task", assigneeID, nil},
+		{"outsider", outsiderID, ErrNotFound},
+	}
+
+	s := NewServiceImpl(tx)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := s.Get(ctx, sharedID, tt.userID)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("got error %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,35 @@
+package task
+
+import "slices"
+
+// Status is the stage of the lifecycle of a task. Archived tasks are hidden
+// from task lists, and time can't be tracked on done or archived tasks.
+type Status string
+
+const (
+	StatusOpen       Status = "open"
+	StatusInProgress Status = "in_progress"
+	StatusDone       Status = "done"
+	StatusArchived   Status = "archived"
+)
+
+// transitions are the statuses a task can move to from each status. Open and in
+// progress tasks can move to any status, done tasks can be reopened or
+// archived, and archived tasks can be reopened.
+var transitions = map[Status][]Status{
+	StatusOpen:       {StatusInProgress, StatusDone, StatusArchived},
+	StatusInProgress: {StatusOpen, StatusDone, StatusArchived},
+	StatusDone:       {StatusOpen, StatusInProgress, StatusArchived},
+	StatusArchived:   {StatusOpen},
+}
+
+// canTransition reports whether a task can move from one status to another.
+// Staying in the same status is always allowed.
+func canTransition(from, to Status) bool {
+	return from == to || slices.Contains(transitions[from], to)
+}
+
+// Trackable reports whether time can be tracked on tasks in the status.
+func (s Status) Trackable() bool {
+	return s == StatusOpen || s == StatusInProgress
+}
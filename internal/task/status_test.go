@@ -0,0 +1,27 @@
+package task
+
+import "testing"
+
+func TestCanTransition(t *testing.T) {
+	tests := []struct {
+		from, to Status
+		want     bool
+	}{
+		{StatusOpen, StatusInProgress, true},
+		{StatusOpen, StatusArchived, true},
+		{StatusInProgress, StatusDone, true},
+		{StatusDone, StatusOpen, true},
+		{StatusDone, StatusDone, true},
+		{StatusArchived, StatusOpen, true},
+		{StatusArchived, StatusDone, false},
+		{StatusArchived, StatusInProgress, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.from)+"_to_"+string(tt.to), func(t *testing.T) {
+			if got := canTransition(tt.from, tt.to); got != tt.want {
+				t.Errorf("expected %t, got %t", tt.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,217 @@
+package timesheet
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/kirillgashkov/timetrack/api/timetrackapi/v1"
+	"github.com/kirillgashkov/timetrack/internal/app/api/apiutil"
+	"github.com/kirillgashkov/timetrack/internal/auth"
+)
+
+type Handler struct {
+	service Service
+}
+
+func NewHandler(service Service) *Handler {
+	return &Handler{service: service}
+}
+
+// GetTimesheets handles "GET /timesheets/".
+func (h *Handler) GetTimesheets(w http.ResponseWriter, r *http.Request, params timetrackapi.GetTimesheetsParams) {
+	page, ve := apiutil.ParsePage(r, params.Cursor, params.Offset, params.Limit)
+	if ve != nil {
+		apiutil.MustWriteUnprocessableEntity(w, ve)
+		return
+	}
+
+	u := auth.MustUserFromContext(r.Context())
+	timesheets, more, listErr := h.service.List(r.Context(), u.ID, page)
+	if listErr != nil {
+		apiutil.MustWriteInternalServerError(w, "failed to list timesheets", listErr)
+		return
+	}
+
+	apiutil.SetPageLinks(w, r, page, timesheets, more, func(ts *Timesheet) []any { return []any{ts.From} })
+	apiutil.MustWriteJSON(w, toTimesheetResponses(timesheets), http.StatusOK)
+}
+
+// GetTimesheetsQueue handles "GET /timesheets/queue".
+func (h *Handler) GetTimesheetsQueue(
+	w http.ResponseWriter, r *http.Request, params timetrackapi.GetTimesheetsQueueParams,
+) {
+	page, ve := apiutil.ParsePage(r, params.Cursor, params.Offset, params.Limit)
+	if ve != nil {
+		apiutil.MustWriteUnprocessableEntity(w, ve)
+		return
+	}
+
+	u := auth.MustUserFromContext(r.Context())
+	timesheets, more, queueErr := h.service.Queue(r.Context(), u.ID, page)
+	if queueErr != nil {
+		apiutil.MustWriteInternalServerError(w, "failed to list submitted timesheets", queueErr)
+		return
+	}
+
+	// Submitted timesheets always have a submission time.
+	apiutil.SetPageLinks(w, r, page, timesheets, more, func(ts *Timesheet) []any { return []any{*ts.SubmittedAt, ts.ID} })
+	apiutil.MustWriteJSON(w, toTimesheetResponses(timesheets), http.StatusOK)
+}
+
+// PostTimesheets handles "POST /timesheets/".
+func (h *Handler) PostTimesheets(w http.ResponseWriter, r *http.Request) {
+	var req *timetrackapi.CreateTimesheetRequest
+	if err := apiutil.ReadJSON(r, &req); err != nil {
+		apiutil.MustWriteUnprocessableEntity(w, apiutil.ValidationError{"bad JSON"})
+		return
+	}
+	weekStart := req.Week.Time
+	if weekStart.IsZero() {
+		apiutil.MustWriteUnprocessableEntity(w, apiutil.ValidationError{"missing week"})
+		return
+	}
+	if weekStart.Weekday() != time.Monday {
+		apiutil.MustWriteUnprocessableEntity(w, apiutil.ValidationError{"invalid week, must be a Monday"})
+		return
+	}
+
+	u := auth.MustUserFromContext(r.Context())
+	ts, err := h.service.Create(r.Context(), u.ID, WeekStart(weekStart))
+	if err != nil {
+		if errors.Is(err, ErrAlreadyExists) {
+			apiutil.MustWriteError(w, "timesheet for the week already exists", http.StatusConflict)
+			return
+		}
+		apiutil.MustWriteInternalServerError(w, "failed to create timesheet", err)
+		return
+	}
+
+	apiutil.MustWriteJSON(w, toTimesheetResponse(ts), http.StatusOK)
+}
+
+// GetTimesheetsId handles "GET /timesheets/{id}".
+//
+//nolint:revive
+func (h *Handler) GetTimesheetsId(w http.ResponseWriter, r *http.Request, id int) {
+	u := auth.MustUserFromContext(r.Context())
+	ts, err := h.service.Get(r.Context(), id, u.ID)
+	if err != nil {
+		writeError(w, err, "failed to get timesheet")
+		return
+	}
+
+	apiutil.MustWriteJSON(w, toTimesheetResponse(ts), http.StatusOK)
+}
+
+// PostTimesheetsIdSubmit handles "POST /timesheets/{id}/submit".
+//
+//nolint:revive
+func (h *Handler) PostTimesheetsIdSubmit(w http.ResponseWriter, r *http.Request, id int) {
+	u := auth.MustUserFromContext(r.Context())
+	ts, err := h.service.Submit(r.Context(), id, u.ID)
+	if err != nil {
+		writeError(w, err, "failed to submit timesheet")
+		return
+	}
+
+	apiutil.MustWriteJSON(w, toTimesheetResponse(ts), http.StatusOK)
+}
+
+// PostTimesheetsIdApprove handles "POST /timesheets/{id}/approve".
+//
+//nolint:revive
+func (h *Handler) PostTimesheetsIdApprove(w http.ResponseWriter, r *http.Request, id int) {
+	var req *timetrackapi.DecideTimesheetRequest
+	if err := apiutil.ReadJSON(r, &req); err != nil {
+		apiutil.MustWriteUnprocessableEntity(w, apiutil.ValidationError{"bad JSON"})
+		return
+	}
+
+	u := auth.MustUserFromContext(r.Context())
+	ts, err := h.service.Approve(r.Context(), id, u.ID, req.Comment)
+	if err != nil {
+		writeError(w, err, "failed to approve timesheet")
+		return
+	}
+
+	apiutil.MustWriteJSON(w, toTimesheetResponse(ts), http.StatusOK)
+}
+
+// PostTimesheetsIdReject handles "POST /timesheets/{id}/reject".
+//
+//nolint:revive
+func (h *Handler) PostTimesheetsIdReject(w http.ResponseWriter, r *http.Request, id int) {
+	var req *timetrackapi.DecideTimesheetRequest
+	if err := apiutil.ReadJSON(r, &req); err != nil {
+		apiutil.MustWriteUnprocessableEntity(w, apiutil.ValidationError{"bad JSON"})
+		return
+	}
+	if req.Comment == nil || strings.TrimSpace(*req.Comment) == "" {
+		apiutil.MustWriteUnprocessableEntity(w, apiutil.ValidationError{"missing comment"})
+		return
+	}
+
+	u := auth.MustUserFromContext(r.Context())
+	ts, err := h.service.Reject(r.Context(), id, u.ID, *req.Comment)
+	if err != nil {
+		writeError(w, err, "failed to reject timesheet")
+		return
+	}
+
+	apiutil.MustWriteJSON(w, toTimesheetResponse(ts), http.StatusOK)
+}
+
+func writeError(w http.ResponseWriter, err error, message string) {
+	switch {
+	case errors.Is(err, ErrNotFound):
+		apiutil.MustWriteError(w, "timesheet not found", http.StatusNotFound)
+	case errors.Is(err, ErrNotManager):
+		apiutil.MustWriteError(w, "only the manager can decide on a timesheet", http.StatusForbidden)
+	case errors.Is(err, ErrInvalidTransition):
+		apiutil.MustWriteError(w, "timesheet status doesn't allow this action", http.StatusConflict)
+	case errors.Is(err, ErrPeriodNotOver):
+		apiutil.MustWriteError(w, "week is not over yet", http.StatusConflict)
+	case errors.Is(err, ErrWorksRunning):
+		apiutil.MustWriteError(w, "week has running works, stop them first", http.StatusConflict)
+	default:
+		apiutil.MustWriteInternalServerError(w, message, err)
+	}
+}
+
+func toTimesheetResponses(timesheets []Timesheet) []*timetrackapi.TimesheetResponse {
+	resp := make([]*timetrackapi.TimesheetResponse, 0, len(timesheets))
+	for _, ts := range timesheets {
+		resp = append(resp, toTimesheetResponse(&ts))
+	}
+	return resp
+}
+
+func toTimesheetResponse(ts *Timesheet) *timetrackapi.TimesheetResponse {
+	tasks := make([]timetrackapi.TimesheetTaskResponse, 0, len(ts.Tasks))
+	for _, t := range ts.Tasks {
+		tasks = append(tasks, timetrackapi.TimesheetTaskResponse{
+			Task: timetrackapi.TaskResponse{
+				Id:          t.Task.ID,
+				Description: t.Task.Description,
+				Billable:    t.Task.Billable,
+			},
+			Hours: apiutil.FormatHours(t.Duration),
+		})
+	}
+
+	return &timetrackapi.TimesheetResponse{
+		Id:          ts.ID,
+		UserId:      ts.UserID,
+		From:        ts.From,
+		To:          ts.To,
+		Status:      timetrackapi.TimesheetResponseStatus(ts.Status),
+		Comment:     ts.Comment,
+		SubmittedAt: ts.SubmittedAt,
+		DecidedAt:   ts.DecidedAt,
+		DecidedBy:   ts.DecidedBy,
+		Tasks:       tasks,
+		Hours:       apiutil.FormatHours(ts.Total()),
+	}
+}
@@ -0,0 +1,130 @@
+package timesheet
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kirillgashkov/timetrack/internal/app/database"
+	"github.com/kirillgashkov/timetrack/internal/auth"
+)
+
+type ServiceMock struct {
+	CreateFunc  func(ctx context.Context, userID int, weekStart time.Time) (*Timesheet, error)
+	GetFunc     func(ctx context.Context, id int, viewerID int) (*Timesheet, error)
+	ListFunc    func(ctx context.Context, userID int, page *database.Page) ([]Timesheet, bool, error)
+	QueueFunc   func(ctx context.Context, managerID int, page *database.Page) ([]Timesheet, bool, error)
+	SubmitFunc  func(ctx context.Context, id int, userID int) (*Timesheet, error)
+	ApproveFunc func(ctx context.Context, id int, managerID int, comment *string) (*Timesheet, error)
+	RejectFunc  func(ctx context.Context, id int, managerID int, comment string) (*Timesheet, error)
+}
+
+func (s *ServiceMock) Create(ctx context.Context, userID int, weekStart time.Time) (*Timesheet, error) {
+	return s.CreateFunc(ctx, userID, weekStart)
+}
+
+func (s *ServiceMock) Get(ctx context.Context, id int, viewerID int) (*Timesheet, error) {
+	return s.GetFunc(ctx, id, viewerID)
+}
+
+func (s *ServiceMock) List(ctx context.Context, userID int, page *database.Page) ([]Timesheet, bool, error) {
+	return s.ListFunc(ctx, userID, page)
+}
+
+func (s *ServiceMock) Queue(ctx context.Context, managerID int, page *database.Page) ([]Timesheet, bool, error) {
+	return s.QueueFunc(ctx, managerID, page)
+}
+
+func (s *ServiceMock) Submit(ctx context.Context, id int, userID int) (*Timesheet, error) {
+	return s.SubmitFunc(ctx, id, userID)
+}
+
+func (s *ServiceMock) Approve(ctx context.Context, id int, managerID int, comment *string) (*Timesheet, error) {
+	return s.ApproveFunc(ctx, id, managerID, comment)
+}
+
+func (s *ServiceMock) Reject(ctx context.Context, id int, managerID int, comment string) (*Timesheet, error) {
+	return s.RejectFunc(ctx, id, managerID, comment)
+}
+
+func newRequest(method, target, body string) *http.Request {
+	req := httptest.NewRequest(method, target, bytes.NewBufferString(body))
+	return req.WithContext(auth.ContextWithUser(req.Context(), &auth.User{ID: 1}))
+}
+
+func TestPostTimesheets(t *testing.T) {
+	createFunc := func(_ context.Context, userID int, weekStart time.Time) (*Timesheet, error) {
+		if weekStart.Equal(time.Date(2024, 7, 8, 0, 0, 0, 0, time.UTC)) {
+			return nil, ErrAlreadyExists
+		}
+		return &Timesheet{ID: 1, UserID: userID, From: weekStart, To: weekStart.Add(week), Status: "draft"}, nil
+	}
+
+	tests := []struct {
+		name               string
+		body               string
+		expectedStatusCode int
+		expectedBody       string
+	}{
+		{"ok", `{"week":"2024-07-01"}`, http.StatusOK, `"to":"2024-07-08T00:00:00Z"`},
+		{"not monday", `{"week":"2024-07-03"}`, http.StatusUnprocessableEntity, `must be a Monday`},
+		{"missing week", `{}`, http.StatusUnprocessableEntity, `missing week`},
+		{"existing", `{"week":"2024-07-08"}`, http.StatusConflict, `already exists`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := NewHandler(&ServiceMock{CreateFunc: createFunc})
+
+			w := httptest.NewRecorder()
+			handler.PostTimesheets(w, newRequest(http.MethodPost, "/timesheets/", tt.body))
+
+			if w.Code != tt.expectedStatusCode {
+				t.Errorf("expected status code %d, got %d", tt.expectedStatusCode, w.Code)
+			}
+			if body := w.Body.String(); !strings.Contains(body, tt.expectedBody) {
+				t.Errorf("expected body to contain %s, got %s", tt.expectedBody, body)
+			}
+		})
+	}
+}
+
+func TestPostTimesheetsIdReject(t *testing.T) {
+	tests := []struct {
+		name               string
+		body               string
+		rejectErr          error
+		expectedStatusCode int
+	}{
+		{"ok", `{"comment":"Missing Friday"}`, nil, http.StatusOK},
+		{"missing comment", `{}`, nil, http.StatusUnprocessableEntity},
+		{"blank comment", `{"comment":" "}`, nil, http.StatusUnprocessableEntity},
+		{"not submitted", `{"comment":"Missing Friday"}`, ErrInvalidTransition, http.StatusConflict},
+		{"own timesheet", `{"comment":"Missing Friday"}`, ErrNotManager, http.StatusForbidden},
+		{"not found", `{"comment":"Missing Friday"}`, ErrNotFound, http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := NewHandler(&ServiceMock{
+				RejectFunc: func(_ context.Context, id int, _ int, comment string) (*Timesheet, error) {
+					if tt.rejectErr != nil {
+						return nil, tt.rejectErr
+					}
+					return &Timesheet{ID: id, Status: "rejected", Comment: &comment}, nil
+				},
+			})
+
+			w := httptest.NewRecorder()
+			handler.PostTimesheetsIdReject(w, newRequest(http.MethodPost, "/timesheets/1/reject", tt.body), 1)
+
+			if w.Code != tt.expectedStatusCode {
+				t.Errorf("expected status code %d, got %d", tt.expectedStatusCode, w.Code)
+			}
+		})
+	}
+}
@@ -0,0 +1,395 @@
+package timesheet
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/jackc/pgerrcode"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/kirillgashkov/timetrack/db/timetrackdb"
+	"github.com/kirillgashkov/timetrack/internal/app/database"
+	"github.com/kirillgashkov/timetrack/internal/task"
+)
+
+var (
+	ErrNotFound          = errors.New("timesheet not found")
+	ErrAlreadyExists     = errors.New("timesheet already exists")
+	ErrNotManager        = errors.New("user is not the manager of the timesheet's user")
+	ErrInvalidTransition = errors.New("timesheet can't transition from its status")
+	ErrPeriodNotOver     = errors.New("timesheet period is not over")
+	ErrWorksRunning      = errors.New("timesheet period has running works")
+)
+
+const week = 7 * 24 * time.Hour
+
+// Timesheet is a user's time for a week that the user submits and their
+// manager approves or rejects. Tasks are built from stopped works started in
+// the week.
+type Timesheet struct {
+	ID          int
+	UserID      int
+	From        time.Time
+	To          time.Time
+	Status      string
+	Comment     *string
+	SubmittedAt *time.Time
+	DecidedAt   *time.Time
+	DecidedBy   *int
+	Tasks       []TimesheetTask
+}
+
+type TimesheetTask struct {
+	Task     task.Task
+	Duration time.Duration
+}
+
+func (t *Timesheet) Total() time.Duration {
+	var total time.Duration
+	for _, tt := range t.Tasks {
+		total += tt.Duration
+	}
+	return total
+}
+
+type timesheetRow struct {
+	ID          int
+	UserID      int        `db:"user_id"`
+	PeriodFrom  time.Time  `db:"period_from"`
+	PeriodTo    time.Time  `db:"period_to"`
+	Status      string     `db:"status"`
+	Comment     *string    `db:"comment"`
+	SubmittedAt *time.Time `db:"submitted_at"`
+	DecidedAt   *time.Time `db:"decided_at"`
+	DecidedBy   *int       `db:"decided_by"`
+}
+
+type timesheetTaskRow struct {
+	TimesheetID     int           `db:"timesheet_id"`
+	TaskID          int           `db:"task_id"`
+	TaskDescription string        `db:"task_description"`
+	TaskBillable    bool          `db:"task_billable"`
+	Duration        time.Duration `db:"duration"`
+}
+
+type Service interface {
+	Create(ctx context.Context, userID int, weekStart time.Time) (*Timesheet, error)
+	Get(ctx context.Context, id int, viewerID int) (*Timesheet, error)
+	List(ctx context.Context, userID int, page *database.Page) ([]Timesheet, bool, error)
+	Queue(ctx context.Context, managerID int, page *database.Page) ([]Timesheet, bool, error)
+	Submit(ctx context.Context, id int, userID int) (*Timesheet, error)
+	Approve(ctx context.Context, id int, managerID int, comment *string) (*Timesheet, error)
+	Reject(ctx context.Context, id int, managerID int, comment string) (*Timesheet, error)
+}
+
+type ServiceImpl struct {
+	db database.DB
+}
+
+func NewServiceImpl(db database.DB) *ServiceImpl {
+	return &ServiceImpl{db: db}
+}
+
+// WeekStart returns the start of the week containing t, Monday 00:00 UTC.
+func WeekStart(t time.Time) time.Time {
+	y, m, d := t.UTC().Date()
+	day := time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+	return day.AddDate(0, 0, -(int(day.Weekday())+6)%7)
+}
+
+// Create creates a draft timesheet of the user for the week starting at
+// weekStart which must be a start of a week.
+func (s *ServiceImpl) Create(ctx context.Context, userID int, weekStart time.Time) (*Timesheet, error) {
+	q := `
+		INSERT INTO timesheets (user_id, period_from, period_to, status)
+		VALUES ($1, $2, $3, $4)
+		RETURNING id
+	`
+	var id int
+	err := s.db.QueryRow(ctx, q, userID, weekStart, weekStart.Add(week), timetrackdb.TimesheetStatusDraft).Scan(&id)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == pgerrcode.UniqueViolation {
+			return nil, errors.Join(ErrAlreadyExists, err)
+		}
+		return nil, errors.Join(errors.New("failed to insert timesheet"), err)
+	}
+	return getTimesheet(ctx, s.db, id)
+}
+
+// Get returns the timesheet if the viewer is its user or their manager.
+func (s *ServiceImpl) Get(ctx context.Context, id int, viewerID int) (*Timesheet, error) {
+	q := timesheetSelect + `
+		JOIN users ON timesheets.user_id = users.id
+		WHERE timesheets.id = $1 AND (timesheets.user_id = $2 OR users.manager_id = $2)
+	`
+	timesheets, err := queryTimesheets(ctx, s.db, q, id, viewerID)
+	if err != nil {
+		return nil, err
+	}
+	if len(timesheets) == 0 {
+		return nil, ErrNotFound
+	}
+	return &timesheets[0], nil
+}
+
+func (s *ServiceImpl) List(ctx context.Context, userID int, page *database.Page) ([]Timesheet, bool, error) {
+	pageCondition, pageClauses, pageArgs := page.Keyset(2, "timesheets.period_from DESC")
+	q := timesheetSelect + `
+		WHERE timesheets.user_id = $1 AND ` + pageCondition + `
+		` + pageClauses
+	timesheets, err := queryTimesheets(ctx, s.db, q, append([]any{userID}, pageArgs...)...)
+	if err != nil {
+		return nil, false, err
+	}
+	timesheets, more := database.PageItems(page, timesheets)
+	return timesheets, more, nil
+}
+
+// Queue returns the submitted timesheets of the users managed by the manager,
+// oldest submissions first.
+func (s *ServiceImpl) Queue(ctx context.Context, managerID int, page *database.Page) ([]Timesheet, bool, error) {
+	pageCondition, pageClauses, pageArgs := page.Keyset(3, "timesheets.submitted_at", "timesheets.id")
+	q := timesheetSelect + `
+		JOIN users ON timesheets.user_id = users.id
+		WHERE users.manager_id = $1 AND timesheets.status = $2 AND ` + pageCondition + `
+		` + pageClauses
+	args := append([]any{managerID, timetrackdb.TimesheetStatusSubmitted}, pageArgs...)
+	timesheets, err := queryTimesheets(ctx, s.db, q, args...)
+	if err != nil {
+		return nil, false, err
+	}
+	timesheets, more := database.PageItems(page, timesheets)
+	return timesheets, more, nil
+}
+
+// Submit submits a draft or rejected timesheet of the user. The week must be
+// over and all works started in it must be stopped. The works are locked while
+// the decision is pending, so the manager decides on the time that was
+// submitted.
+func (s *ServiceImpl) Submit(ctx context.Context, id int, userID int) (*Timesheet, error) {
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return nil, errors.Join(errors.New("failed to start transaction"), err)
+	}
+	defer rollback(ctx, tx)
+
+	ts, err := lockTimesheet(ctx, tx, id)
+	if err != nil {
+		return nil, err
+	}
+	if ts.UserID != userID {
+		return nil, ErrNotFound
+	}
+	if ts.Status != timetrackdb.TimesheetStatusDraft && ts.Status != timetrackdb.TimesheetStatusRejected {
+		return nil, ErrInvalidTransition
+	}
+	if ts.PeriodTo.After(time.Now()) {
+		return nil, ErrPeriodNotOver
+	}
+
+	// Locking the works makes concurrent changes to them wait for the
+	// submission and then fail the lock check in tracking.
+	q := `SELECT status FROM works WHERE user_id = $1 AND started_at >= $2 AND started_at < $3 FOR UPDATE`
+	rows, err := tx.Query(ctx, q, ts.UserID, ts.PeriodFrom, ts.PeriodTo)
+	if err != nil {
+		return nil, errors.Join(errors.New("failed to select works"), err)
+	}
+	statuses, err := pgx.CollectRows(rows, pgx.RowTo[string])
+	if err != nil {
+		return nil, errors.Join(errors.New("failed to collect works"), err)
+	}
+	for _, status := range statuses {
+		if status == timetrackdb.WorkStatusStarted {
+			return nil, ErrWorksRunning
+		}
+	}
+
+	q = `
+		UPDATE timesheets
+		SET status = $2, submitted_at = now(), comment = NULL, decided_at = NULL, decided_by = NULL
+		WHERE id = $1
+	`
+	if _, err = tx.Exec(ctx, q, id, timetrackdb.TimesheetStatusSubmitted); err != nil {
+		return nil, errors.Join(errors.New("failed to update timesheet"), err)
+	}
+	return commitAndGet(ctx, tx, id)
+}
+
+// Approve approves a submitted timesheet of a user managed by the manager.
+func (s *ServiceImpl) Approve(ctx context.Context, id int, managerID int, comment *string) (*Timesheet, error) {
+	return s.decide(ctx, id, managerID, timetrackdb.TimesheetStatusApproved, comment)
+}
+
+// Reject rejects a submitted timesheet of a user managed by the manager. The
+// works are unlocked, so the user can fix them and submit the timesheet again.
+func (s *ServiceImpl) Reject(ctx context.Context, id int, managerID int, comment string) (*Timesheet, error) {
+	return s.decide(ctx, id, managerID, timetrackdb.TimesheetStatusRejected, &comment)
+}
+
+func (s *ServiceImpl) decide(
+	ctx context.Context, id int, managerID int, status string, comment *string,
+) (*Timesheet, error) {
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return nil, errors.Join(errors.New("failed to start transaction"), err)
+	}
+	defer rollback(ctx, tx)
+
+	ts, err := lockTimesheet(ctx, tx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	var isManager bool
+	q := `SELECT EXISTS (SELECT 1 FROM users WHERE id = $1 AND manager_id = $2)`
+	if err = tx.QueryRow(ctx, q, ts.UserID, managerID).Scan(&isManager); err != nil {
+		return nil, errors.Join(errors.New("failed to select manager"), err)
+	}
+	if !isManager {
+		if ts.UserID == managerID {
+			return nil, ErrNotManager
+		}
+		return nil, ErrNotFound
+	}
+	if ts.Status != timetrackdb.TimesheetStatusSubmitted {
+		return nil, ErrInvalidTransition
+	}
+
+	q = `UPDATE timesheets SET status = $2, comment = $3, decided_at = now(), decided_by = $4 WHERE id = $1`
+	if _, err = tx.Exec(ctx, q, id, status, comment, managerID); err != nil {
+		return nil, errors.Join(errors.New("failed to update timesheet"), err)
+	}
+	return commitAndGet(ctx, tx, id)
+}
+
+func lockTimesheet(ctx context.Context, tx pgx.Tx, id int) (*timesheetRow, error) {
+	rows, err := tx.Query(ctx, timesheetSelect+` WHERE timesheets.id = $1 FOR UPDATE`, id)
+	if err != nil {
+		return nil, errors.Join(errors.New("failed to select timesheet"), err)
+	}
+	defer rows.Close()
+
+	ts, err := pgx.CollectExactlyOneRow(rows, pgx.RowToStructByName[timesheetRow])
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, errors.Join(errors.New("failed to collect timesheet"), err)
+	}
+	return &ts, nil
+}
+
+func commitAndGet(ctx context.Context, tx pgx.Tx, id int) (*Timesheet, error) {
+	ts, err := getTimesheet(ctx, tx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err = tx.Commit(ctx); err != nil {
+		return nil, errors.Join(errors.New("failed to commit transaction"), err)
+	}
+	return ts, nil
+}
+
+const timesheetSelect = `
+	SELECT timesheets.id, timesheets.user_id, timesheets.period_from, timesheets.period_to, timesheets.status,
+		   timesheets.comment, timesheets.submitted_at, timesheets.decided_at, timesheets.decided_by
+	FROM timesheets
+`
+
+func getTimesheet(ctx context.Context, db database.DB, id int) (*Timesheet, error) {
+	timesheets, err := queryTimesheets(ctx, db, timesheetSelect+` WHERE timesheets.id = $1`, id)
+	if err != nil {
+		return nil, err
+	}
+	if len(timesheets) == 0 {
+		return nil, ErrNotFound
+	}
+	return &timesheets[0], nil
+}
+
+// queryTimesheets runs a query that selects timesheetRow and loads tasks of
+// the selected timesheets.
+func queryTimesheets(ctx context.Context, db database.DB, query string, args ...any) ([]Timesheet, error) {
+	rows, err := db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, errors.Join(errors.New("failed to select timesheets"), err)
+	}
+	defer rows.Close()
+
+	timesheetRows, err := pgx.CollectRows(rows, pgx.RowToStructByName[timesheetRow])
+	if err != nil {
+		return nil, errors.Join(errors.New("failed to collect timesheets"), err)
+	}
+
+	ids := make([]int, 0, len(timesheetRows))
+	for _, tr := range timesheetRows {
+		ids = append(ids, tr.ID)
+	}
+	taskRows, err := queryTimesheetTasks(ctx, db, ids)
+	if err != nil {
+		return nil, err
+	}
+	tasks := make(map[int][]TimesheetTask)
+	for _, tr := range taskRows {
+		tasks[tr.TimesheetID] = append(tasks[tr.TimesheetID], TimesheetTask{
+			Task:     task.Task{ID: tr.TaskID, Description: tr.TaskDescription, Billable: tr.TaskBillable},
+			Duration: tr.Duration,
+		})
+	}
+
+	timesheets := make([]Timesheet, 0, len(timesheetRows))
+	for _, tr := range timesheetRows {
+		timesheets = append(timesheets, Timesheet{
+			ID:          tr.ID,
+			UserID:      tr.UserID,
+			From:        tr.PeriodFrom,
+			To:          tr.PeriodTo,
+			Status:      tr.Status,
+			Comment:     tr.Comment,
+			SubmittedAt: tr.SubmittedAt,
+			DecidedAt:   tr.DecidedAt,
+			DecidedBy:   tr.DecidedBy,
+			Tasks:       tasks[tr.ID],
+		})
+	}
+	return timesheets, nil
+}
+
+func queryTimesheetTasks(ctx context.Context, db database.DB, timesheetIDs []int) ([]timesheetTaskRow, error) {
+	q := `
+		SELECT timesheets.id AS timesheet_id,
+			   tasks.id AS task_id,
+			   tasks.description AS task_description,
+			   tasks.billable AS task_billable,
+			   SUM(works.stopped_at - works.started_at) AS duration
+		FROM timesheets
+		JOIN works ON works.user_id = timesheets.user_id
+				  AND works.started_at >= timesheets.period_from
+				  AND works.started_at < timesheets.period_to
+				  AND works.status = $2
+		JOIN tasks ON works.task_id = tasks.id
+		WHERE timesheets.id = ANY($1)
+		GROUP BY timesheets.id, tasks.id
+		ORDER BY timesheets.id, duration DESC, tasks.id
+	`
+	rows, err := db.Query(ctx, q, timesheetIDs, timetrackdb.WorkStatusStopped)
+	if err != nil {
+		return nil, errors.Join(errors.New("failed to select timesheet tasks"), err)
+	}
+	defer rows.Close()
+
+	taskRows, err := pgx.CollectRows(rows, pgx.RowToStructByName[timesheetTaskRow])
+	if err != nil {
+		return nil, errors.Join(errors.New("failed to collect timesheet tasks"), err)
+	}
+	return taskRows, nil
+}
+
+func rollback(ctx context.Context, tx pgx.Tx) {
+	if txErr := tx.Rollback(ctx); txErr != nil && !errors.Is(txErr, pgx.ErrTxClosed) {
+		slog.Error("failed to rollback transaction", "error", txErr)
+	}
+}
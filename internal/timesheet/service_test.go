@@ -0,0 +1,30 @@
+package timesheet
+
+import (
+	"testing"
+	"time"
+)
+
+func TestWeekStart(t *testing.T) {
+	monday := time.Date(2024, 7, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name string
+		t    time.Time
+		want time.Time
+	}{
+		{"monday midnight", monday, monday},
+		{"wednesday", time.Date(2024, 7, 3, 15, 30, 0, 0, time.UTC), monday},
+		{"sunday before midnight", time.Date(2024, 7, 7, 23, 59, 59, 0, time.UTC), monday},
+		{"next monday", time.Date(2024, 7, 8, 0, 0, 0, 0, time.UTC), monday.AddDate(0, 0, 7)},
+		{"other location", time.Date(2024, 7, 1, 2, 0, 0, 0, time.FixedZone("MSK", 3*60*60)), monday.AddDate(0, 0, -7)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := WeekStart(tt.t); !got.Equal(tt.want) {
+				t.Errorf("expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
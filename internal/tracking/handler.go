@@ -6,6 +6,7 @@ import (
 
 	"github.com/kirillgashkov/timetrack/internal/auth"
 
+	"github.com/kirillgashkov/timetrack/api/timetrackapi/v1"
 	"github.com/kirillgashkov/timetrack/internal/app/api/apiutil"
 )
 
@@ -29,6 +30,14 @@ func (h *Handler) PostTasksIdStart(w http.ResponseWriter, r *http.Request, id in
 			apiutil.MustWriteError(w, "task already started or not found", http.StatusBadRequest)
 			return
 		}
+		if errors.Is(err, ErrTaskNotTrackable) {
+			apiutil.MustWriteError(w, "task is done or archived", http.StatusConflict)
+			return
+		}
+		if errors.Is(err, ErrWorkLocked) {
+			apiutil.MustWriteError(w, "work is locked", http.StatusConflict)
+			return
+		}
 		apiutil.MustWriteInternalServerError(w, "failed to start task", err)
 		return
 	}
@@ -48,9 +57,121 @@ func (h *Handler) PostTasksIdStop(w http.ResponseWriter, r *http.Request, id int
 			apiutil.MustWriteError(w, "task not started or not found", http.StatusBadRequest)
 			return
 		}
+		if errors.Is(err, ErrWorkLocked) {
+			apiutil.MustWriteError(w, "work is locked", http.StatusConflict)
+			return
+		}
 		apiutil.MustWriteInternalServerError(w, "failed to stop task", err)
 		return
 	}
 
 	apiutil.MustWriteNoContent(w)
 }
+
+// GetWorks handles "GET /works/".
+func (h *Handler) GetWorks(w http.ResponseWriter, r *http.Request, params timetrackapi.GetWorksParams) {
+	currentUser := auth.MustUserFromContext(r.Context())
+
+	page, ve := apiutil.ParsePage(r, params.Cursor, params.Offset, params.Limit)
+	if params.From != nil && params.To != nil && params.From.After(*params.To) {
+		ve = append(ve, "invalid from, must be before to")
+	}
+	if len(ve) > 0 {
+		apiutil.MustWriteUnprocessableEntity(w, ve)
+		return
+	}
+
+	filter := &FilterWork{From: params.From, To: params.To, TagID: params.TagId}
+	if params.TaskId != nil {
+		taskID := TaskID(*params.TaskId)
+		filter.TaskID = &taskID
+	}
+	works, more, err := h.service.ListWorks(r.Context(), UserID(currentUser.ID), filter, page)
+	if err != nil {
+		apiutil.MustWriteInternalServerError(w, "failed to list works", err)
+		return
+	}
+
+	resp := make([]*timetrackapi.WorkResponse, 0, len(works))
+	for _, work := range works {
+		resp = append(resp, toWorkResponse(&work))
+	}
+	apiutil.SetPageLinks(w, r, page, works, more, func(work *Work) []any { return []any{work.StartedAt, int(work.ID)} })
+	apiutil.MustWriteJSON(w, resp, http.StatusOK)
+}
+
+// PatchWorksId handles "PATCH /works/{id}".
+//
+//nolint:revive
+func (h *Handler) PatchWorksId(w http.ResponseWriter, r *http.Request, id int) {
+	currentUser := auth.MustUserFromContext(r.Context())
+
+	var req *timetrackapi.UpdateWorkRequest
+	if err := apiutil.ReadJSON(r, &req); err != nil {
+		apiutil.MustWriteUnprocessableEntity(w, apiutil.ValidationError{"bad JSON"})
+		return
+	}
+
+	update := &UpdateWork{Billable: req.Billable}
+	if req.TagIds != nil {
+		update.TagIDs = *req.TagIds
+	}
+	work, err := h.service.UpdateWork(r.Context(), WorkID(id), UserID(currentUser.ID), update)
+	if err != nil {
+		if errors.Is(err, ErrWorkNotFound) {
+			apiutil.MustWriteError(w, "work not found", http.StatusNotFound)
+			return
+		}
+		if errors.Is(err, ErrWorkLocked) {
+			apiutil.MustWriteError(w, "work is locked", http.StatusConflict)
+			return
+		}
+		if errors.Is(err, ErrTagNotFound) {
+			apiutil.MustWriteUnprocessableEntity(w, apiutil.ValidationError{"tag not found"})
+			return
+		}
+		apiutil.MustWriteInternalServerError(w, "failed to update work", err)
+		return
+	}
+
+	apiutil.MustWriteJSON(w, toWorkResponse(work), http.StatusOK)
+}
+
+// DeleteWorksId handles "DELETE /works/{id}".
+//
+//nolint:revive
+func (h *Handler) DeleteWorksId(w http.ResponseWriter, r *http.Request, id int) {
+	currentUser := auth.MustUserFromContext(r.Context())
+
+	work, err := h.service.DeleteWork(r.Context(), WorkID(id), UserID(currentUser.ID))
+	if err != nil {
+		if errors.Is(err, ErrWorkNotFound) {
+			apiutil.MustWriteError(w, "work not found", http.StatusNotFound)
+			return
+		}
+		if errors.Is(err, ErrWorkLocked) {
+			apiutil.MustWriteError(w, "work is locked", http.StatusConflict)
+			return
+		}
+		apiutil.MustWriteInternalServerError(w, "failed to delete work", err)
+		return
+	}
+
+	apiutil.MustWriteJSON(w, toWorkResponse(work), http.StatusOK)
+}
+
+func toWorkResponse(w *Work) *timetrackapi.WorkResponse {
+	tagIDs := w.TagIDs
+	if tagIDs == nil {
+		tagIDs = make([]int, 0)
+	}
+	return &timetrackapi.WorkResponse{
+		Id:        int(w.ID),
+		TaskId:    int(w.TaskID),
+		UserId:    int(w.UserID),
+		StartedAt: w.StartedAt,
+		StoppedAt: w.StoppedAt,
+		Billable:  w.Billable,
+		TagIds:    &tagIDs,
+	}
+}
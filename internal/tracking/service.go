@@ -5,26 +5,66 @@ import (
 	"errors"
 	"fmt"
 	"log/slog"
+	"time"
 
 	"github.com/jackc/pgerrcode"
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/kirillgashkov/timetrack/db/timetrackdb"
 	"github.com/kirillgashkov/timetrack/internal/app/database"
+	"github.com/kirillgashkov/timetrack/internal/rollup"
+	"github.com/kirillgashkov/timetrack/internal/task"
 )
 
 var (
 	ErrAlreadyStartedOrNotFound = errors.New("task already started or not found")
 	ErrNotStartedOrNotFound     = errors.New("task not started or not found")
+	ErrWorkNotFound             = errors.New("work not found")
+	ErrWorkLocked               = errors.New("work is locked")
+	ErrTaskNotTrackable         = errors.New("task is done or archived")
+	ErrTagNotFound              = errors.New("tag not found")
 )
 
 type UserID int
 
 type TaskID int
 
+type WorkID int
+
+// Work is a period of time a user spent on a task. Billable is copied from the
+// task when the work is started and can be changed afterward. TagIDs are the
+// tags of the work itself, the work is also tagged with the tags of its task.
+type Work struct {
+	ID        WorkID
+	TaskID    TaskID     `db:"task_id"`
+	UserID    UserID     `db:"user_id"`
+	StartedAt time.Time  `db:"started_at"`
+	StoppedAt *time.Time `db:"stopped_at"`
+	Billable  bool
+	TagIDs    []int `db:"tag_ids"`
+}
+
+// UpdateWork updates a work, TagIDs replace the tags of the work if not nil.
+type UpdateWork struct {
+	Billable *bool
+	TagIDs   []int
+}
+
+// FilterWork filters works that overlap the period from From to To. Works
+// match TagID if the work or its task has the tag.
+type FilterWork struct {
+	From   *time.Time
+	To     *time.Time
+	TaskID *TaskID
+	TagID  *int
+}
+
 type Service interface {
 	StartTask(ctx context.Context, taskID TaskID, userID UserID) error
 	StopTask(ctx context.Context, taskID TaskID, userID UserID) error
+	ListWorks(ctx context.Context, userID UserID, filter *FilterWork, page *database.Page) ([]Work, bool, error)
+	UpdateWork(ctx context.Context, id WorkID, userID UserID, update *UpdateWork) (*Work, error)
+	DeleteWork(ctx context.Context, id WorkID, userID UserID) (*Work, error)
 }
 
 type ServiceImpl struct {
@@ -35,23 +75,80 @@ func NewServiceImpl(db database.DB) *ServiceImpl {
 	return &ServiceImpl{db: db}
 }
 
+const workColumns = `
+	id, task_id, user_id, started_at, stopped_at, billable,
+	ARRAY(SELECT work_tags.tag_id FROM work_tags WHERE work_tags.work_id = works.id ORDER BY 1) AS tag_ids
+`
+
+// StartTask starts a work of the user on the task. Users can only track time on
+// open and in progress tasks visible to them, tasks that aren't visible are not
+// found. Started works can be stopped even if the task is no longer visible or
+// trackable, but not in a week with a submitted or approved timesheet. Starts
+// and stops are recorded as activities of the task.
 func (s *ServiceImpl) StartTask(ctx context.Context, taskID TaskID, userID UserID) error {
-	q := `INSERT INTO works (started_at, task_id, user_id, status) VALUES (now(), $1, $2, $3)`
-	_, err := s.db.Exec(
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return errors.Join(errors.New("failed to start transaction"), err)
+	}
+	defer func(tx pgx.Tx) {
+		if txErr := tx.Rollback(ctx); txErr != nil && !errors.Is(txErr, pgx.ErrTxClosed) {
+			slog.Error("failed to rollback transaction", "error", txErr)
+		}
+	}(tx)
+
+	// The task is locked, so that it can't be closed while the work starts.
+	q := `SELECT status FROM tasks WHERE id = $1 AND ` + task.VisibleTo("$2") + ` FOR UPDATE`
+	rows, err := tx.Query(ctx, q, taskID, userID)
+	if err != nil {
+		return errors.Join(errors.New("failed to select task"), err)
+	}
+	status, err := pgx.CollectExactlyOneRow(rows, pgx.RowTo[task.Status])
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return ErrAlreadyStartedOrNotFound
+		}
+		return errors.Join(errors.New("failed to collect task"), err)
+	}
+	if !status.Trackable() {
+		return ErrTaskNotTrackable
+	}
+
+	q = `
+		INSERT INTO works (started_at, task_id, user_id, status, billable)
+		SELECT now(), id, $2, $3, billable
+		FROM tasks
+		WHERE id = $1
+		RETURNING id, started_at
+	`
+	var workID WorkID
+	var startedAt time.Time
+	err = tx.QueryRow(
 		ctx,
 		q,
 		taskID,
 		userID,
 		timetrackdb.WorkStatusStarted,
-	)
+	).Scan(&workID, &startedAt)
 	if err != nil {
 		var pgErr *pgconn.PgError
 		if errors.As(err, &pgErr) && pgerrcode.IsIntegrityConstraintViolation(pgErr.Code) {
 			return ErrAlreadyStartedOrNotFound
 		}
+		if errors.Is(err, pgx.ErrNoRows) {
+			return ErrAlreadyStartedOrNotFound
+		}
 		return errors.Join(errors.New("failed to insert work"), err)
 	}
-	return nil
+	if err = checkTimesheetsUnlocked(ctx, tx, userID, startedAt); err != nil {
+		return err
+	}
+
+	err = task.RecordWorkActivity(ctx, tx, task.ActivityWorkStarted, int(taskID), int(userID), int(workID))
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
 }
 
 func (s *ServiceImpl) StopTask(ctx context.Context, taskID TaskID, userID UserID) error {
@@ -65,8 +162,12 @@ func (s *ServiceImpl) StopTask(ctx context.Context, taskID TaskID, userID UserID
 		}
 	}(tx)
 
-	q := `UPDATE works SET stopped_at = now(), status = $1 WHERE task_id = $2 AND user_id = $3 AND status = $4`
-	tag, err := s.db.Exec(
+	q := `
+		UPDATE works
+		SET stopped_at = now(), status = $1, updated_at = now()
+		WHERE task_id = $2 AND user_id = $3 AND status = $4
+		RETURNING ` + workColumns
+	rows, err := tx.Query(
 		ctx,
 		q,
 		timetrackdb.WorkStatusStopped,
@@ -77,12 +178,231 @@ func (s *ServiceImpl) StopTask(ctx context.Context, taskID TaskID, userID UserID
 	if err != nil {
 		return errors.Join(errors.New("failed to update work"), err)
 	}
-	if tag.RowsAffected() != 1 {
-		if tag.RowsAffected() == 0 {
+	works, err := pgx.CollectRows(rows, pgx.RowToStructByName[Work])
+	if err != nil {
+		return errors.Join(errors.New("failed to collect work"), err)
+	}
+	if len(works) != 1 {
+		if len(works) == 0 {
 			return ErrNotStartedOrNotFound
 		}
-		return fmt.Errorf("update work affected unexpected number of rows: %d", tag.RowsAffected())
+		return fmt.Errorf("update work affected unexpected number of rows: %d", len(works))
+	}
+
+	w := works[0]
+	if err = checkTimesheetsUnlocked(ctx, tx, userID, w.StartedAt, *w.StoppedAt); err != nil {
+		return err
+	}
+	if err = rollup.Refresh(ctx, tx, int(w.UserID), int(w.TaskID), w.StartedAt, *w.StoppedAt); err != nil {
+		return err
+	}
+	if err = task.RecordBudgetEvents(ctx, tx, int(w.TaskID)); err != nil {
+		return err
+	}
+	err = task.RecordWorkActivity(ctx, tx, task.ActivityWorkStopped, int(w.TaskID), int(w.UserID), int(w.ID))
+	if err != nil {
+		return err
 	}
 
 	return tx.Commit(ctx)
 }
+
+// ListWorks lists the works of the user, latest first, and reports whether
+// there are more works past the page.
+func (s *ServiceImpl) ListWorks(
+	ctx context.Context, userID UserID, filter *FilterWork, page *database.Page,
+) ([]Work, bool, error) {
+	pageCondition, pageClauses, pageArgs := page.Keyset(6, "started_at DESC", "id DESC")
+	q := `
+		SELECT ` + workColumns + `
+		FROM works
+		WHERE user_id = $1
+			AND ($2::timestamptz IS NULL OR works.stopped_at IS NULL OR works.stopped_at >= $2)
+			AND ($3::timestamptz IS NULL OR works.started_at <= $3)
+			AND ($4::integer IS NULL OR works.task_id = $4)
+			AND ($5::integer IS NULL OR EXISTS (
+				SELECT 1 FROM work_tags WHERE work_tags.work_id = works.id AND work_tags.tag_id = $5
+			) OR EXISTS (
+				SELECT 1 FROM task_tags WHERE task_tags.task_id = works.task_id AND task_tags.tag_id = $5
+			))
+			AND ` + pageCondition + `
+		` + pageClauses
+	args := append([]any{userID, filter.From, filter.To, filter.TaskID, filter.TagID}, pageArgs...)
+	rows, err := s.db.Query(ctx, q, args...)
+	if err != nil {
+		return nil, false, errors.Join(errors.New("failed to select works"), err)
+	}
+	defer rows.Close()
+
+	works, err := pgx.CollectRows(rows, pgx.RowToStructByName[Work])
+	if err != nil {
+		return nil, false, errors.Join(errors.New("failed to collect works"), err)
+	}
+	works, more := database.PageItems(page, works)
+	return works, more, nil
+}
+
+// UpdateWork updates a work of the user. Works on an invoice are locked.
+func (s *ServiceImpl) UpdateWork(ctx context.Context, id WorkID, userID UserID, update *UpdateWork) (*Work, error) {
+	q := `
+		UPDATE works
+		SET billable = coalesce($2, billable), updated_at = now()
+		WHERE id = $1
+		RETURNING ` + workColumns
+	replaceTags := func(tx pgx.Tx) error {
+		if update.TagIDs == nil {
+			return nil
+		}
+		return replaceWorkTags(ctx, tx, id, update.TagIDs)
+	}
+	return s.modifyWork(ctx, id, userID, replaceTags, q, id, update.Billable)
+}
+
+// DeleteWork deletes a work of the user. Works on an invoice are locked.
+func (s *ServiceImpl) DeleteWork(ctx context.Context, id WorkID, userID UserID) (*Work, error) {
+	q := `DELETE FROM works WHERE id = $1 RETURNING ` + workColumns
+	return s.modifyWork(ctx, id, userID, nil, q, id)
+}
+
+// modifyWork locks a work of the user, checks that it can be modified, runs
+// prepare if not nil and the query that modifies the work and returns it, and
+// refreshes the rollups of the work.
+func (s *ServiceImpl) modifyWork(
+	ctx context.Context, id WorkID, userID UserID, prepare func(tx pgx.Tx) error, query string, args ...any,
+) (*Work, error) {
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return nil, errors.Join(errors.New("failed to start transaction"), err)
+	}
+	defer func(tx pgx.Tx) {
+		if txErr := tx.Rollback(ctx); txErr != nil && !errors.Is(txErr, pgx.ErrTxClosed) {
+			slog.Error("failed to rollback transaction", "error", txErr)
+		}
+	}(tx)
+
+	if err = checkWorkUnlocked(ctx, tx, id, userID); err != nil {
+		return nil, err
+	}
+	if prepare != nil {
+		if err = prepare(tx); err != nil {
+			return nil, err
+		}
+	}
+
+	rows, err := tx.Query(ctx, query, args...)
+	if err != nil {
+		return nil, errors.Join(errors.New("failed to modify work"), err)
+	}
+	defer rows.Close()
+
+	w, err := pgx.CollectExactlyOneRow(rows, pgx.RowToStructByName[Work])
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrWorkNotFound
+		}
+		return nil, errors.Join(errors.New("failed to collect work"), err)
+	}
+
+	if w.StoppedAt != nil {
+		if err = rollup.Refresh(ctx, tx, int(w.UserID), int(w.TaskID), w.StartedAt, *w.StoppedAt); err != nil {
+			return nil, err
+		}
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return nil, errors.Join(errors.New("failed to commit transaction"), err)
+	}
+	return &w, nil
+}
+
+// checkWorkUnlocked locks the work of the user for update and checks that it
+// is neither on an invoice nor in a submitted or approved timesheet.
+func checkWorkUnlocked(ctx context.Context, tx pgx.Tx, id WorkID, userID UserID) error {
+	q := `
+		SELECT EXISTS (SELECT 1 FROM invoice_works WHERE invoice_works.work_id = works.id) AS invoiced,
+			   EXISTS (
+				   SELECT 1
+				   FROM timesheets
+				   WHERE timesheets.user_id = works.user_id
+					 AND works.started_at >= timesheets.period_from
+					 AND works.started_at < timesheets.period_to
+					 AND timesheets.status IN ($3, $4)
+			   ) AS timesheet_locked
+		FROM works
+		WHERE id = $1 AND user_id = $2
+		FOR UPDATE
+	`
+	args := []any{id, userID, timetrackdb.TimesheetStatusSubmitted, timetrackdb.TimesheetStatusApproved}
+	rows, err := tx.Query(ctx, q, args...)
+	if err != nil {
+		return errors.Join(errors.New("failed to select work"), err)
+	}
+	defer rows.Close()
+
+	lock, err := pgx.CollectExactlyOneRow(rows, pgx.RowToStructByName[workLock])
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return ErrWorkNotFound
+		}
+		return errors.Join(errors.New("failed to collect work"), err)
+	}
+	if lock.Invoiced {
+		return errors.Join(ErrWorkLocked, errors.New("work is on an invoice"))
+	}
+	if lock.TimesheetLocked {
+		return errors.Join(ErrWorkLocked, errors.New("work is in a submitted or approved timesheet"))
+	}
+	return nil
+}
+
+// checkTimesheetsUnlocked checks that none of the times is in a submitted or
+// approved timesheet of the user. The timesheets are locked for share, so that
+// they can't be submitted until the transaction ends.
+func checkTimesheetsUnlocked(ctx context.Context, tx pgx.Tx, userID UserID, times ...time.Time) error {
+	q := `
+		SELECT status
+		FROM timesheets
+		WHERE user_id = $1
+		  AND EXISTS (SELECT 1 FROM unnest($2::timestamptz[]) AS t WHERE t >= period_from AND t < period_to)
+		FOR SHARE
+	`
+	rows, err := tx.Query(ctx, q, userID, times)
+	if err != nil {
+		return errors.Join(errors.New("failed to select timesheets"), err)
+	}
+	statuses, err := pgx.CollectRows(rows, pgx.RowTo[string])
+	if err != nil {
+		return errors.Join(errors.New("failed to collect timesheets"), err)
+	}
+	for _, status := range statuses {
+		if status == timetrackdb.TimesheetStatusSubmitted || status == timetrackdb.TimesheetStatusApproved {
+			return errors.Join(ErrWorkLocked, errors.New("work is in a submitted or approved timesheet"))
+		}
+	}
+	return nil
+}
+
+type workLock struct {
+	Invoiced        bool `db:"invoiced"`
+	TimesheetLocked bool `db:"timesheet_locked"`
+}
+
+// replaceWorkTags replaces the tags of the work.
+func replaceWorkTags(ctx context.Context, tx pgx.Tx, id WorkID, tagIDs []int) error {
+	if _, err := tx.Exec(ctx, `DELETE FROM work_tags WHERE work_id = $1`, id); err != nil {
+		return errors.Join(errors.New("failed to delete work tags"), err)
+	}
+	q := `
+		INSERT INTO work_tags (work_id, tag_id)
+		SELECT $1, unnest($2::integer[])
+		ON CONFLICT DO NOTHING
+	`
+	if _, err := tx.Exec(ctx, q, id, tagIDs); err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == pgerrcode.ForeignKeyViolation {
+			return errors.Join(ErrTagNotFound, err)
+		}
+		return errors.Join(errors.New("failed to insert work tags"), err)
+	}
+	return nil
+}
@@ -60,8 +60,7 @@ func (h *Handler) PostUsers(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	resp := toUserResponse(u)
-	apiutil.MustWriteJSON(w, resp, http.StatusOK)
+	mustWriteUser(w, u)
 }
 
 func parseAndValidateCreateUserRequest(r *http.Request) (*timetrackapi.CreateUserRequest, error) {
@@ -84,7 +83,7 @@ func validateCreateUserRequest(req *timetrackapi.CreateUserRequest) error {
 
 // GetUsers handles "GET /users".
 func (h *Handler) GetUsers(w http.ResponseWriter, r *http.Request, params timetrackapi.GetUsersParams) {
-	filter, err := parseListUsersRequestFilter(&params)
+	filter, sort, err := parseListUsersRequest(&params)
 	if err != nil {
 		var ve apiutil.ValidationError
 		if errors.As(err, &ve) {
@@ -95,17 +94,13 @@ func (h *Handler) GetUsers(w http.ResponseWriter, r *http.Request, params timetr
 		return
 	}
 
-	if err = validateAndNormalizeListUsersRequest(&params); err != nil {
-		var ve apiutil.ValidationError
-		if errors.As(err, &ve) {
-			apiutil.MustWriteUnprocessableEntity(w, ve)
-			return
-		}
-		apiutil.MustWriteInternalServerError(w, "failed validate and normalize request", err)
+	page, ve := apiutil.ParsePage(r, params.Cursor, params.Offset, params.Limit)
+	if ve != nil {
+		apiutil.MustWriteUnprocessableEntity(w, ve)
 		return
 	}
 
-	users, err := h.service.List(r.Context(), filter, *params.Offset, *params.Limit)
+	users, more, err := h.service.List(r.Context(), filter, sort, page)
 	if err != nil {
 		apiutil.MustWriteInternalServerError(w, "failed to list users", err)
 		return
@@ -113,93 +108,104 @@ func (h *Handler) GetUsers(w http.ResponseWriter, r *http.Request, params timetr
 
 	resp := make([]*timetrackapi.UserResponse, 0, len(users))
 	for _, u := range users {
-		resp = append(resp, toUserResponse(&u))
+		resp = append(resp, toUserResponse(&u.User))
 	}
+	order := listOrder(filter, sort)
+	apiutil.SetPageLinks(w, r, page, users, more, func(u *ListedUser) []any { return sortKey(u, order) })
 	apiutil.MustWriteJSON(w, resp, http.StatusOK)
 }
 
-func parseListUsersRequestFilter(params *timetrackapi.GetUsersParams) (*FilterUser, error) {
-	if params.Filter == nil {
-		return &FilterUser{}, nil
-	}
-
+// parseListUsersRequest parses the filters and the sort of a list request.
+// Each filter is a group of alternatives separated by |, each alternative is
+// field=value or field:mode=value, or patronymic_null=true or false.
+func parseListUsersRequest(params *timetrackapi.GetUsersParams) (*FilterUser, []Sort, error) {
 	e := make([]string, 0)
 
 	filter := &FilterUser{}
-	for _, f := range *params.Filter {
-		parts := strings.SplitN(f, "=", 2)
-		if len(parts) != 2 {
-			e = append(e, fmt.Sprintf("invalid filter %q, must be in the form of key=value", f))
-		}
-		k, v := parts[0], parts[1]
-
-		switch k {
-		case "passport_number":
-			filter.PassportNumber = &v
-		case "surname":
-			filter.Surname = &v
-		case "name":
-			filter.Name = &v
-		case "patronymic":
-			filter.Patronymic = &sql.NullString{String: v, Valid: true}
-		case "patronymic_null":
-			patronymic := filter.Patronymic
-			if patronymic == nil {
-				patronymic = &sql.NullString{}
+	if params.Filter != nil {
+		for _, f := range *params.Filter {
+			group := make([]Condition, 0)
+			for _, alt := range strings.Split(f, "|") {
+				c, ok := parseCondition(alt)
+				if !ok {
+					e = append(e, fmt.Sprintf(
+						"invalid filter %q, must be in the form of field=value or field:mode=value "+
+							"with a field of passport_number, surname, name, patronymic, and address "+
+							"and a mode of contains, exact, and fuzzy, or patronymic_null=true or false",
+						alt,
+					))
+					continue
+				}
+				group = append(group, c)
 			}
+			filter.Groups = append(filter.Groups, group)
+		}
+	}
 
-			switch v {
-			case "true":
-				patronymic.Valid = false
-			case "false":
-				patronymic.Valid = true
+	sort := make([]Sort, 0)
+	if params.Sort != nil {
+		seen := make(map[SortField]bool)
+		for _, f := range strings.Split(*params.Sort, ",") {
+			name, desc := strings.CutPrefix(f, "-")
+			field := SortField(name)
+			switch field {
+			case SortID, SortSurname, SortName, SortCreatedAt:
 			default:
-				e = append(e, fmt.Sprintf("invalid filter %q, value must be 'true' or 'false'", f))
+				e = append(e, fmt.Sprintf("invalid sort field %q, must be id, surname, name, or created_at", f))
 				continue
 			}
-			filter.Patronymic = patronymic
-		case "address":
-			filter.Address = &v
+			if seen[field] {
+				e = append(e, fmt.Sprintf("duplicate sort field %q", name))
+				continue
+			}
+			seen[field] = true
+			sort = append(sort, Sort{Field: field, Desc: desc})
 		}
 	}
 
 	if len(e) > 0 {
-		return nil, apiutil.ValidationError(e)
+		return nil, nil, apiutil.ValidationError(e)
 	}
-	return filter, nil
+	return filter, sort, nil
 }
 
-func validateAndNormalizeListUsersRequest(params *timetrackapi.GetUsersParams) error {
-	if err := validateListUsersRequest(params); err != nil {
-		return err
+func parseCondition(s string) (Condition, bool) {
+	k, v, ok := strings.Cut(s, "=")
+	if !ok {
+		return Condition{}, false
 	}
-	normalizeListUsersRequest(params)
-	return nil
-}
 
-func validateListUsersRequest(params *timetrackapi.GetUsersParams) error {
-	e := make([]string, 0)
-
-	if params.Offset != nil && *params.Offset < 0 {
-		e = append(e, "invalid offset, must be greater than or equal to 0")
-	}
-	if params.Limit != nil && (*params.Limit < 1 || *params.Limit > 100) {
-		e = append(e, "invalid limit, must be between 1 and 100")
+	if k == "patronymic_null" {
+		var isNull bool
+		switch v {
+		case "true":
+			isNull = true
+		case "false":
+			isNull = false
+		default:
+			return Condition{}, false
+		}
+		return Condition{Field: FieldPatronymic, Null: &isNull}, true
 	}
 
-	if len(e) > 0 {
-		return apiutil.ValidationError(e)
+	k, m, hasMode := strings.Cut(k, ":")
+	mode := MatchContains
+	if hasMode {
+		mode = MatchMode(m)
 	}
-	return nil
-}
-
-func normalizeListUsersRequest(params *timetrackapi.GetUsersParams) {
-	if params.Offset == nil {
-		params.Offset = intPtr(0)
+	switch mode {
+	case MatchContains, MatchExact, MatchFuzzy:
+	default:
+		return Condition{}, false
 	}
-	if params.Limit == nil {
-		params.Limit = intPtr(50)
+
+	field := Field(k)
+	switch field {
+	case FieldPassportNumber, FieldSurname, FieldName, FieldPatronymic, FieldAddress:
+	default:
+		return Condition{}, false
 	}
+	return Condition{Field: field, Mode: mode, Value: v}, true
 }
 
 // GetUsersCurrent handles "GET /users/current".
@@ -216,8 +222,7 @@ func (h *Handler) GetUsersCurrent(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	resp := toUserResponse(u)
-	apiutil.MustWriteJSON(w, resp, http.StatusOK)
+	mustWriteUser(w, u)
 }
 
 // GetUsersId handles "GET /users/{id}".
@@ -234,19 +239,23 @@ func (h *Handler) GetUsersId(w http.ResponseWriter, r *http.Request, id int) {
 		return
 	}
 
-	resp := toUserResponse(u)
-	apiutil.MustWriteJSON(w, resp, http.StatusOK)
+	mustWriteUser(w, u)
 }
 
 // PatchUsersId handles "PATCH /users/{id}".
 //
 //nolint:revive
-func (h *Handler) PatchUsersId(w http.ResponseWriter, r *http.Request, id int) {
+func (h *Handler) PatchUsersId(w http.ResponseWriter, r *http.Request, id int, params timetrackapi.PatchUsersIdParams) {
 	currentUser := auth.MustUserFromContext(r.Context())
 	if currentUser.ID != id {
 		apiutil.MustWriteForbidden(w)
 		return
 	}
+	version, ve := apiutil.IfMatchVersion(params.IfMatch)
+	if ve != nil {
+		apiutil.MustWriteUnprocessableEntity(w, ve)
+		return
+	}
 
 	var req *timetrackapi.UpdateUserRequest
 	if err := apiutil.ReadJSON(r, &req); err != nil {
@@ -255,12 +264,17 @@ func (h *Handler) PatchUsersId(w http.ResponseWriter, r *http.Request, id int) {
 	}
 
 	update := updateUserFromRequest(req)
+	update.Version = version
 	u, err := h.service.Update(r.Context(), id, update)
 	if err != nil {
 		if errors.Is(err, ErrNotFound) {
 			apiutil.MustWriteError(w, "user not found", http.StatusNotFound)
 			return
 		}
+		if errors.Is(err, ErrVersionMismatch) {
+			apiutil.MustWritePreconditionFailed(w, versionMismatchMessage)
+			return
+		}
 		if errors.Is(err, ErrAlreadyExists) {
 			apiutil.MustWriteError(w, "user already exists, perhaps a passport number conflict", http.StatusBadRequest)
 			return
@@ -269,8 +283,7 @@ func (h *Handler) PatchUsersId(w http.ResponseWriter, r *http.Request, id int) {
 		return
 	}
 
-	resp := toUserResponse(u)
-	apiutil.MustWriteJSON(w, resp, http.StatusOK)
+	mustWriteUser(w, u)
 }
 
 func updateUserFromRequest(req *timetrackapi.UpdateUserRequest) *UpdateUser {
@@ -294,28 +307,77 @@ func updateUserFromRequest(req *timetrackapi.UpdateUserRequest) *UpdateUser {
 	}
 }
 
-// DeleteUsersId handles "DELETE /users/{id}".
+// versionMismatchMessage is the error message of changing a user that was
+// changed since the version in If-Match.
+const versionMismatchMessage = "user was changed by someone else, get it again and retry"
+
+// DeleteUsersId handles "DELETE /users/{id}". Users delete themselves,
+// administrators delete anyone.
 //
 //nolint:revive
-func (h *Handler) DeleteUsersId(w http.ResponseWriter, r *http.Request, id int) {
+func (h *Handler) DeleteUsersId(
+	w http.ResponseWriter, r *http.Request, id int, params timetrackapi.DeleteUsersIdParams,
+) {
 	currentUser := auth.MustUserFromContext(r.Context())
-	if currentUser.ID != id {
-		apiutil.MustWriteForbidden(w)
+
+	version, ve := apiutil.IfMatchVersion(params.IfMatch)
+	if ve != nil {
+		apiutil.MustWriteUnprocessableEntity(w, ve)
 		return
 	}
 
-	u, err := h.service.Delete(r.Context(), id)
+	force := params.Force != nil && *params.Force
+	u, err := h.service.Delete(r.Context(), id, currentUser.ID, force, version)
 	if err != nil {
 		if errors.Is(err, ErrNotFound) {
 			apiutil.MustWriteError(w, "user not found", http.StatusNotFound)
 			return
 		}
+		if errors.Is(err, ErrVersionMismatch) {
+			apiutil.MustWritePreconditionFailed(w, versionMismatchMessage)
+			return
+		}
+		if errors.Is(err, ErrForbidden) {
+			apiutil.MustWriteForbidden(w)
+			return
+		}
+		if errors.Is(err, ErrHasInvoicedWorks) {
+			apiutil.MustWriteError(w, "user has invoiced works", http.StatusConflict)
+			return
+		}
+		if errors.Is(err, ErrHasWorks) {
+			msg := "user has works, only an administrator can delete them with force=true"
+			apiutil.MustWriteError(w, msg, http.StatusConflict)
+			return
+		}
 		apiutil.MustWriteInternalServerError(w, "failed to delete user", err)
 		return
 	}
 
-	resp := toUserResponse(u)
-	apiutil.MustWriteJSON(w, resp, http.StatusOK)
+	mustWriteUser(w, u)
+}
+
+// PostUsersIdRestore handles "POST /users/{id}/restore".
+//
+//nolint:revive
+func (h *Handler) PostUsersIdRestore(w http.ResponseWriter, r *http.Request, id int) {
+	u, err := h.service.Restore(r.Context(), id)
+	if err != nil {
+		if errors.Is(err, ErrNotFound) {
+			apiutil.MustWriteError(w, "user not found in the trash", http.StatusNotFound)
+			return
+		}
+		apiutil.MustWriteInternalServerError(w, "failed to restore user", err)
+		return
+	}
+
+	mustWriteUser(w, u)
+}
+
+// mustWriteUser writes the user with its version as the ETag.
+func mustWriteUser(w http.ResponseWriter, u *User) {
+	w.Header().Set("ETag", apiutil.VersionETag(u.Version))
+	apiutil.MustWriteJSON(w, toUserResponse(u), http.StatusOK)
 }
 
 // toUserResponse converts User to timetrackapi.UserResponse.
@@ -332,9 +394,7 @@ func toUserResponse(u *User) *timetrackapi.UserResponse {
 		Name:           u.Name,
 		Patronymic:     u.Patronymic,
 		Address:        u.Address,
+		CreatedAt:      &u.CreatedAt,
+		Version:        &u.Version,
 	}
 }
-
-func intPtr(i int) *int {
-	return &i
-}
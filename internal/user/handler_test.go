@@ -0,0 +1,44 @@
+package user
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/kirillgashkov/timetrack/api/timetrackapi/v1"
+)
+
+func TestParseListUsersRequest(t *testing.T) {
+	filters := []string{"surname:fuzzy=Ivonov|name:exact=Ivan", "patronymic_null=true"}
+	sort := "surname,-created_at"
+	filter, got, err := parseListUsersRequest(&timetrackapi.GetUsersParams{Filter: &filters, Sort: &sort})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	isNull := true
+	wantFilter := &FilterUser{Groups: [][]Condition{
+		{{Field: FieldSurname, Mode: MatchFuzzy, Value: "Ivonov"}, {Field: FieldName, Mode: MatchExact, Value: "Ivan"}},
+		{{Field: FieldPatronymic, Null: &isNull}},
+	}}
+	if !reflect.DeepEqual(filter, wantFilter) {
+		t.Errorf("got filter %+v, want %+v", filter, wantFilter)
+	}
+	wantSort := []Sort{{Field: SortSurname}, {Field: SortCreatedAt, Desc: true}}
+	if !reflect.DeepEqual(got, wantSort) {
+		t.Errorf("got sort %+v, want %+v", got, wantSort)
+	}
+	if order := listOrder(filter, got); len(order) != 3 || order[2].Field != SortID {
+		t.Errorf("got order %+v, want the sort followed by id", order)
+	}
+
+	for _, f := range []string{"surname", "surname:similar=Ivanov", "password=secret", "patronymic_null=yes"} {
+		if _, _, err = parseListUsersRequest(&timetrackapi.GetUsersParams{Filter: &[]string{f}}); err == nil {
+			t.Errorf("expected error for filter %q", f)
+		}
+	}
+	for _, s := range []string{"passport_number", "surname,-surname", ""} {
+		if _, _, err = parseListUsersRequest(&timetrackapi.GetUsersParams{Sort: &s}); err == nil {
+			t.Errorf("expected error for sort %q", s)
+		}
+	}
+}
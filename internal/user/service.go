@@ -4,12 +4,16 @@ import (
 	"context"
 	"database/sql"
 	"errors"
+	"fmt"
+	"log/slog"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/jackc/pgerrcode"
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/kirillgashkov/timetrack/db/timetrackdb"
 	"github.com/kirillgashkov/timetrack/internal/app/database"
 )
 
@@ -17,8 +21,14 @@ var (
 	ErrAlreadyExists         = errors.New("user already exists")
 	ErrNotFound              = errors.New("user not found")
 	ErrInvalidPassportNumber = errors.New("invalid passport number")
+	ErrHasInvoicedWorks      = errors.New("user has invoiced works")
+	ErrHasWorks              = errors.New("user has works")
+	ErrForbidden             = errors.New("user access forbidden")
+	ErrVersionMismatch       = errors.New("user version mismatch")
 )
 
+// User is a person who tracks time. Version is incremented on every update of
+// the user.
 type User struct {
 	ID             int
 	PassportNumber string `db:"passport_number"`
@@ -26,34 +36,103 @@ type User struct {
 	Name           string
 	Patronymic     *string
 	Address        string
+	CreatedAt      time.Time `db:"created_at"`
+	Version        int
+}
+
+const userColumns = `id, passport_number, surname, name, patronymic, address, created_at, version`
+
+// ListedUser is a user of a list. Score is the sum of the trigram similarities
+// of the fields of the user to the values of the fuzzy conditions of the
+// filter from 0 to 1 each, 0 if there are none.
+type ListedUser struct {
+	User
+	Score float64 `db:"score"`
 }
 
 type CreateUser struct {
 	PassportNumber string
 }
 
+// FilterUser matches the users that match all of its groups. A group matches
+// the users that match any of its conditions.
 type FilterUser struct {
-	PassportNumber *string
-	Surname        *string
-	Name           *string
-	Patronymic     *sql.NullString
-	Address        *string
+	Groups [][]Condition
 }
 
+// Field is a field of users that can be filtered by.
+type Field string
+
+const (
+	FieldPassportNumber Field = "passport_number"
+	FieldSurname        Field = "surname"
+	FieldName           Field = "name"
+	FieldPatronymic     Field = "patronymic"
+	FieldAddress        Field = "address"
+)
+
+// MatchMode is how a condition matches the value of a field.
+type MatchMode string
+
+const (
+	// MatchContains matches fields that contain the value, ignoring case.
+	MatchContains MatchMode = "contains"
+	// MatchExact matches fields that are equal to the value.
+	MatchExact MatchMode = "exact"
+	// MatchFuzzy matches fields that are similar to the value by trigrams, so
+	// that typos are forgiven, and ranks users by the similarity.
+	MatchFuzzy MatchMode = "fuzzy"
+)
+
+// Condition matches the users whose field matches the value in the mode. If
+// Null is set, it matches the users whose field is null, or is not null if Null
+// is false, instead.
+type Condition struct {
+	Field Field
+	Mode  MatchMode
+	Value string
+	Null  *bool
+}
+
+// SortField is a field of users that can be sorted by.
+type SortField string
+
+const (
+	SortID        SortField = "id"
+	SortSurname   SortField = "surname"
+	SortName      SortField = "name"
+	SortCreatedAt SortField = "created_at"
+
+	// sortScore sorts by the score of ListedUser.
+	sortScore SortField = "score"
+)
+
+// Sort is a key of the sort of a list of users.
+type Sort struct {
+	Field SortField
+	Desc  bool
+}
+
+// UpdateUser updates a user. If Version is set, the user is updated only if it
+// is still at the version.
 type UpdateUser struct {
 	PassportNumber *string
 	Surname        *string
 	Name           *string
 	Patronymic     *sql.NullString
 	Address        *string
+	Version        *int
 }
 
+// Service manages users. Deleted users are kept in the trash until they are
+// purged and are not found meanwhile, their passport numbers stay taken.
 type Service interface {
 	Create(ctx context.Context, passportNumber string) (*User, error)
 	Get(ctx context.Context, id int) (*User, error)
-	List(ctx context.Context, filter *FilterUser, offset, limit int) ([]User, error)
+	List(ctx context.Context, filter *FilterUser, sort []Sort, page *database.Page) ([]ListedUser, bool, error)
 	Update(ctx context.Context, id int, update *UpdateUser) (*User, error)
-	Delete(ctx context.Context, id int) (*User, error)
+	Delete(ctx context.Context, id, byID int, force bool, version *int) (*User, error)
+	Restore(ctx context.Context, id int) (*User, error)
 }
 
 type ServiceImpl struct {
@@ -79,7 +158,7 @@ func (s *ServiceImpl) Create(ctx context.Context, passportNumber string) (*User,
 	q := `
 		INSERT INTO users (passport_number, surname, name, patronymic, address)
 		VALUES ($1, $2, $3, $4, $5)
-		RETURNING id, passport_number, surname, name, patronymic, address
+		RETURNING ` + userColumns + `
 	`
 	args := []any{
 		passportNumber,
@@ -93,16 +172,28 @@ func (s *ServiceImpl) Create(ctx context.Context, passportNumber string) (*User,
 
 func (s *ServiceImpl) Get(ctx context.Context, id int) (*User, error) {
 	q := `
-		SELECT id, passport_number, surname, name, patronymic, address
+		SELECT ` + userColumns + `
 		FROM users
-		WHERE id = $1
+		WHERE id = $1 AND deleted_at IS NULL
 	`
 	return s.queryOne(ctx, q, id)
 }
 
-func (s *ServiceImpl) List(ctx context.Context, filter *FilterUser, offset, limit int) ([]User, error) {
-	q, args := buildSelectQuery(filter, limit, offset)
-	return s.queryAll(ctx, q, args...)
+// List lists the users that match the filter sorted as listOrder sorts them and
+// reports whether there are more users past the page.
+func (s *ServiceImpl) List(
+	ctx context.Context, filter *FilterUser, sort []Sort, page *database.Page,
+) ([]ListedUser, bool, error) {
+	q, args, err := buildSelectQuery(filter, listOrder(filter, sort), page)
+	if err != nil {
+		return nil, false, err
+	}
+	users, err := s.queryAll(ctx, q, args...)
+	if err != nil {
+		return nil, false, err
+	}
+	users, more := database.PageItems(page, users)
+	return users, more, nil
 }
 
 func (s *ServiceImpl) Update(ctx context.Context, id int, update *UpdateUser) (*User, error) {
@@ -112,9 +203,10 @@ func (s *ServiceImpl) Update(ctx context.Context, id int, update *UpdateUser) (*
 			surname = COALESCE($3, surname),
 			name = COALESCE($4, name),
 			patronymic = CASE WHEN $6 THEN $5 ELSE patronymic END,
-			address = COALESCE($7, address)
-		WHERE id = $1
-		RETURNING id, passport_number, surname, name, patronymic, address
+			address = COALESCE($7, address),
+			version = version + 1
+		WHERE id = $1 AND deleted_at IS NULL AND ($8::integer IS NULL OR version = $8)
+		RETURNING ` + userColumns + `
 	`
 	args := []any{
 		id,
@@ -124,27 +216,164 @@ func (s *ServiceImpl) Update(ctx context.Context, id int, update *UpdateUser) (*
 		update.Patronymic,
 		update.Patronymic != nil,
 		update.Address,
+		update.Version,
 	}
-	return s.queryOne(ctx, q, args...)
+	u, err := s.queryOne(ctx, q, args...)
+	if errors.Is(err, ErrNotFound) && update.Version != nil {
+		// The user is either not found or at another version.
+		q = `SELECT EXISTS (SELECT 1 FROM users WHERE id = $1 AND deleted_at IS NULL)`
+		var exists bool
+		if existsErr := s.db.QueryRow(ctx, q, id).Scan(&exists); existsErr != nil {
+			return nil, errors.Join(errors.New("failed to select user"), existsErr)
+		}
+		if exists {
+			return nil, ErrVersionMismatch
+		}
+	}
+	return u, err
 }
 
-func (s *ServiceImpl) Delete(ctx context.Context, id int) (*User, error) {
+// Delete moves the user to the trash on behalf of the user byID, who must be
+// the user or an administrator. Users with works are deleted only if force is
+// true and byID is an administrator, so that tracked time isn't lost by
+// accident. Users with invoiced works are never deleted. Deleted users keep
+// their works until they are purged. If version is set, the user is deleted
+// only if it is still at the version.
+func (s *ServiceImpl) Delete(ctx context.Context, id, byID int, force bool, version *int) (*User, error) {
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return nil, errors.Join(errors.New("failed to start transaction"), err)
+	}
+	defer func(tx pgx.Tx) {
+		if txErr := tx.Rollback(ctx); txErr != nil && !errors.Is(txErr, pgx.ErrTxClosed) {
+			slog.Error("failed to rollback transaction", "error", txErr)
+		}
+	}(tx)
+
 	q := `
-		DELETE FROM users
+		SELECT EXISTS (
+				   SELECT 1 FROM users AS admins WHERE admins.id = $2 AND admins.is_admin AND admins.deleted_at IS NULL
+			   ),
+			   EXISTS (SELECT 1 FROM works WHERE works.user_id = users.id),
+			   EXISTS (
+				   SELECT 1
+				   FROM works
+				   JOIN invoice_works ON invoice_works.work_id = works.id
+				   WHERE works.user_id = users.id
+			   ),
+			   version
+		FROM users
+		WHERE id = $1 AND deleted_at IS NULL
+		FOR UPDATE
+	`
+	var isAdmin, hasWorks, hasInvoicedWorks bool
+	var current int
+	if err = tx.QueryRow(ctx, q, id, byID).Scan(&isAdmin, &hasWorks, &hasInvoicedWorks, &current); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, errors.Join(errors.New("failed to select user works"), err)
+	}
+	if !isAdmin && (id != byID || force) {
+		return nil, ErrForbidden
+	}
+	if version != nil && *version != current {
+		return nil, ErrVersionMismatch
+	}
+	if hasInvoicedWorks {
+		return nil, ErrHasInvoicedWorks
+	}
+	if hasWorks && !force {
+		return nil, ErrHasWorks
+	}
+
+	q = `
+		UPDATE users
+		SET deleted_at = now(), version = version + 1
 		WHERE id = $1
-		RETURNING id, passport_number, surname, name, patronymic, address
+		RETURNING ` + userColumns + `
+	`
+	u, err := queryOne(ctx, tx, q, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return nil, errors.Join(errors.New("failed to commit transaction"), err)
+	}
+	return u, nil
+}
+
+// Restore restores the user from the trash. Users that aren't in the trash are
+// not found.
+func (s *ServiceImpl) Restore(ctx context.Context, id int) (*User, error) {
+	q := `
+		UPDATE users
+		SET deleted_at = NULL, version = version + 1
+		WHERE id = $1 AND deleted_at IS NOT NULL
+		RETURNING ` + userColumns + `
 	`
 	return s.queryOne(ctx, q, id)
 }
 
-func (s *ServiceImpl) queryAll(ctx context.Context, query string, args ...any) ([]User, error) {
+// purgeableCondition is true for a user in the trash since before $1 that has
+// no timesheets with the status $2 or $3 and no works on an invoice or in such
+// a timesheet.
+const purgeableCondition = `
+	users.deleted_at < $1
+	AND NOT EXISTS (
+		SELECT 1 FROM timesheets WHERE timesheets.user_id = users.id AND timesheets.status IN ($2, $3)
+	)
+	AND NOT EXISTS (
+		SELECT 1
+		FROM works
+		JOIN invoice_works ON invoice_works.work_id = works.id
+		WHERE works.user_id = users.id
+	)
+`
+
+// Purge permanently deletes the users deleted before the time with their works
+// one by one and returns the number of deleted users and the IDs of the users
+// kept in the trash. Users with works on an invoice or with submitted or
+// approved timesheets are kept, and a user that fails to delete doesn't stop
+// the others.
+func (s *ServiceImpl) Purge(ctx context.Context, before time.Time) (int, []int, error) {
+	args := []any{before, timetrackdb.TimesheetStatusSubmitted, timetrackdb.TimesheetStatusApproved}
+	ids, err := queryIDs(ctx, s.db, `SELECT id FROM users WHERE `+purgeableCondition+` ORDER BY id`, args...)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	purged := 0
+	var errs []error
+	for _, id := range ids {
+		if err = ctx.Err(); err != nil {
+			return purged, nil, err
+		}
+		q := `DELETE FROM users WHERE id = $4 AND ` + purgeableCondition
+		tag, err := s.db.Exec(ctx, q, append(args, id)...)
+		if err != nil {
+			errs = append(errs, errors.Join(fmt.Errorf("failed to purge user %d", id), err))
+			continue
+		}
+		purged += int(tag.RowsAffected())
+	}
+
+	kept, err := queryIDs(ctx, s.db, `SELECT id FROM users WHERE deleted_at < $1 ORDER BY id`, before)
+	if err != nil {
+		return purged, nil, err
+	}
+	return purged, kept, errors.Join(errs...)
+}
+
+func (s *ServiceImpl) queryAll(ctx context.Context, query string, args ...any) ([]ListedUser, error) {
 	rows, err := s.db.Query(ctx, query, args...)
 	if err != nil {
 		return nil, errors.Join(errors.New("failed to select users"), err)
 	}
 	defer rows.Close()
 
-	users, err := pgx.CollectRows(rows, pgx.RowToStructByName[User])
+	users, err := pgx.CollectRows(rows, pgx.RowToStructByName[ListedUser])
 	if err != nil {
 		return nil, errors.Join(errors.New("failed to collect users"), err)
 	}
@@ -152,7 +381,23 @@ func (s *ServiceImpl) queryAll(ctx context.Context, query string, args ...any) (
 }
 
 func (s *ServiceImpl) queryOne(ctx context.Context, query string, args ...any) (*User, error) {
-	rows, err := s.db.Query(ctx, query, args...)
+	return queryOne(ctx, s.db, query, args...)
+}
+
+func queryIDs(ctx context.Context, db database.DB, query string, args ...any) ([]int, error) {
+	rows, err := db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, errors.Join(errors.New("failed to select user IDs"), err)
+	}
+	ids, err := pgx.CollectRows(rows, pgx.RowTo[int])
+	if err != nil {
+		return nil, errors.Join(errors.New("failed to collect user IDs"), err)
+	}
+	return ids, nil
+}
+
+func queryOne(ctx context.Context, db database.DB, query string, args ...any) (*User, error) {
+	rows, err := db.Query(ctx, query, args...)
 	if err != nil {
 		return nil, errors.Join(errors.New("failed to select user"), err)
 	}
@@ -161,6 +406,9 @@ func (s *ServiceImpl) queryOne(ctx context.Context, query string, args ...any) (
 	user, err := pgx.CollectExactlyOneRow(rows, pgx.RowToStructByName[User])
 	if err != nil {
 		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == pgerrcode.ForeignKeyViolation && pgErr.TableName == "invoice_works" {
+			return nil, errors.Join(ErrHasInvoicedWorks, err)
+		}
 		if errors.As(err, &pgErr) && pgerrcode.IsIntegrityConstraintViolation(pgErr.Code) {
 			return nil, errors.Join(ErrAlreadyExists, err)
 		}
@@ -199,62 +447,121 @@ func parsePassportNumber(passportNumber string) (int, int, error) {
 	return series, number, nil
 }
 
-// buildSelectQuery builds a SELECT query with WHERE conditions based on the
-// provided filter. Filters utilize the similarity operator % for string
-// comparison (pg_trgm).
-func buildSelectQuery(filter *FilterUser, limit, offset int) (string, []any) {
-	baseQuery := `
-		SELECT id, passport_number, surname, name, patronymic, address
-		FROM users
-	`
-	whereConditions := make([]string, 0)
-	args := make([]any, 0)
-	argIndex := 1
-
-	if filter.PassportNumber != nil {
-		whereConditions = append(whereConditions, `passport_number ILIKE '%' || $`+itoa(argIndex)+` || '%'`)
-		args = append(args, *filter.PassportNumber)
-		argIndex++
+// listOrder returns the keys a list of users is sorted by: the keys of the
+// sort, or the score if the filter has fuzzy conditions, followed by the ID,
+// so that the keys are unique.
+func listOrder(filter *FilterUser, sort []Sort) []Sort {
+	order := make([]Sort, 0, len(sort)+1)
+	if len(sort) > 0 {
+		order = append(order, sort...)
+	} else if hasFuzzyConditions(filter) {
+		order = append(order, Sort{Field: sortScore, Desc: true})
 	}
-
-	if filter.Surname != nil {
-		whereConditions = append(whereConditions, `surname ILIKE '%' || $`+itoa(argIndex)+` || '%'`)
-		args = append(args, *filter.Surname)
-		argIndex++
+	for _, o := range order {
+		if o.Field == SortID {
+			return order
+		}
 	}
+	return append(order, Sort{Field: SortID})
+}
 
-	if filter.Name != nil {
-		whereConditions = append(whereConditions, `name ILIKE '%' || $`+itoa(argIndex)+` || '%'`)
-		args = append(args, *filter.Name)
-		argIndex++
+func hasFuzzyConditions(filter *FilterUser) bool {
+	for _, group := range filter.Groups {
+		for _, c := range group {
+			if c.Null == nil && c.Mode == MatchFuzzy {
+				return true
+			}
+		}
 	}
+	return false
+}
 
-	if filter.Patronymic != nil {
-		if filter.Patronymic.Valid {
-			whereConditions = append(whereConditions, `patronymic ILIKE '%' || $`+itoa(argIndex)+` || '%'`)
-			args = append(args, *filter.Patronymic)
-			argIndex++
-		} else {
-			whereConditions = append(whereConditions, `patronymic IS NULL`)
+// sortKey returns the key of the user in a list sorted by the keys of
+// listOrder.
+func sortKey(u *ListedUser, order []Sort) []any {
+	key := make([]any, len(order))
+	for i, o := range order {
+		switch o.Field {
+		case SortID:
+			key[i] = u.ID
+		case SortSurname:
+			key[i] = u.Surname
+		case SortName:
+			key[i] = u.Name
+		case SortCreatedAt:
+			key[i] = u.CreatedAt
+		case sortScore:
+			key[i] = u.Score
 		}
 	}
+	return key
+}
 
-	if filter.Address != nil {
-		whereConditions = append(whereConditions, `address ILIKE '%' || $`+itoa(argIndex)+` || '%'`)
-		args = append(args, *filter.Address)
-		argIndex++
+// buildSelectQuery builds a SELECT query of the page of the users that match
+// the filter sorted by the keys of order. Fuzzy conditions use the similarity
+// operator % of pg_trgm, which is served by the trigram indexes of the fields.
+func buildSelectQuery(filter *FilterUser, order []Sort, page *database.Page) (string, []any, error) {
+	q := &database.Query{}
+
+	groups := make([]string, 0, len(filter.Groups))
+	scores := make([]string, 0)
+	for _, group := range filter.Groups {
+		conds := make([]string, 0, len(group))
+		for _, c := range group {
+			switch c.Field {
+			case FieldPassportNumber, FieldSurname, FieldName, FieldPatronymic, FieldAddress:
+			default:
+				return "", nil, fmt.Errorf("unknown user field %q", c.Field)
+			}
+			column := string(c.Field)
+
+			if c.Null != nil {
+				if *c.Null {
+					conds = append(conds, column+` IS NULL`)
+				} else {
+					conds = append(conds, column+` IS NOT NULL`)
+				}
+				continue
+			}
+
+			switch c.Mode {
+			case MatchContains, "":
+				conds = append(conds, column+` ILIKE '%' || `+q.Arg(c.Value)+` || '%'`)
+			case MatchExact:
+				conds = append(conds, column+` = `+q.Arg(c.Value))
+			case MatchFuzzy:
+				arg := q.Arg(c.Value)
+				conds = append(conds, column+` % `+arg)
+				// Null fields have no similarity.
+				scores = append(scores, `coalesce(similarity(`+column+`, `+arg+`), 0)`)
+			default:
+				return "", nil, fmt.Errorf("unknown match mode %q", c.Mode)
+			}
+		}
+		groups = append(groups, database.Or(conds...))
 	}
 
-	if len(whereConditions) > 0 {
-		baseQuery += "WHERE " + strings.Join(whereConditions, " AND ") + " "
+	score := `0::real`
+	if len(scores) > 0 {
+		score = `(` + strings.Join(scores, ` + `) + `)`
 	}
 
-	baseQuery += "LIMIT $" + itoa(argIndex) + " OFFSET $" + itoa(argIndex+1)
-	args = append(args, limit, offset)
-
-	return baseQuery, args
-}
+	columns := make([]string, len(order))
+	for i, o := range order {
+		columns[i] = string(o.Field)
+		if o.Desc {
+			columns[i] += ` DESC`
+		}
+	}
 
-func itoa(i int) string {
-	return strconv.Itoa(i)
+	// The users are selected from a subquery, so that the page can be
+	// selected by score.
+	cond := database.And(append([]string{`deleted_at IS NULL`}, groups...)...)
+	sub := `SELECT ` + userColumns + `, ` + score + ` AS score FROM users WHERE ` + cond
+	pageCondition, pageClauses := q.Keyset(page, columns...)
+	return `
+		SELECT *
+		FROM (` + sub + `) AS users
+		WHERE ` + pageCondition + `
+		` + pageClauses, q.Args(), nil
 }